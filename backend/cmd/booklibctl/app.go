@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"booklib/internal/domain/transaction"
+	"booklib/internal/infra"
+	"booklib/internal/infra/config"
+	repobackup "booklib/internal/repo/backup"
+	repobook "booklib/internal/repo/book"
+	repoevent "booklib/internal/repo/event"
+	"booklib/internal/repo/sqltx"
+	"booklib/internal/usecase/backup"
+	"booklib/internal/usecase/book"
+)
+
+// app holds what the commands work with. Only the book and backup use cases
+// are needed: outbox and webhook delivery stay with the server, which picks up
+// the events recorded here like any other.
+type app struct {
+	res    *infra.Resources
+	book   book.UseCase
+	backup backup.UseCase
+	tx     transaction.Manager
+	out    printer
+}
+
+func newApp(ctx context.Context, conf *config.Config, out printer) (*app, error) {
+	if conf.Database.Driver == config.DriverMemory {
+		return nil, fmt.Errorf("the memory database driver only lives inside the server, booklibctl needs postgres or sqlite")
+	}
+
+	res, err := infra.NewResources(ctx, conf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build resources: %w", err)
+	}
+
+	tx := sqltx.NewManager(res.Database)
+	return &app{
+		res:    res,
+		book:   book.New(repobook.New(res.Database), repoevent.New(res.Database), tx),
+		backup: backup.New(repobackup.New(res.Database), tx),
+		tx:     tx,
+		out:    out,
+	}, nil
+}
+
+func (a *app) close() {
+	_ = a.res.Database.Close()
+}
+
+func runMigrate(ctx context.Context, a *app, args []string) error {
+	migrator, err := infra.NewMigrator(a.res)
+	if err != nil {
+		return err
+	}
+
+	return migrator.Run(ctx, args, a.out.out)
+}
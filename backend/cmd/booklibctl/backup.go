@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	domain "booklib/internal/domain/backup"
+	"booklib/internal/usecase/backup"
+)
+
+const (
+	archiveTimeLayout = "20060102T150405Z"
+)
+
+func runBackup(ctx context.Context, a *app, args []string) error {
+	if len(args) > 1 {
+		return errors.New("usage: booklibctl backup [file]")
+	}
+
+	file := fmt.Sprintf("booklib-%s.tar.gz", time.Now().UTC().Format(archiveTimeLayout))
+	if len(args) == 1 {
+		file = args[0]
+	}
+	if file == "-" {
+		_, err := a.backup.Backup(ctx, a.out.out)
+		return err
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	manifest, err := a.backup.Backup(ctx, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// never leave a partial archive behind
+		_ = os.Remove(file)
+		return err
+	}
+
+	records := 0
+	for _, ef := range manifest.Entities {
+		records += ef.Records
+	}
+	return a.out.message("backed up %d record(s) of schema %s to %s", records, manifest.SchemaVersion, file)
+}
+
+func runRestore(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("restore")
+	dryRun := fs.Bool("dry-run", false, "validate and restore the archive, then roll back")
+	files, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) != 1 {
+		return errors.New("usage: booklibctl restore [-dry-run] <file>")
+	}
+
+	var r io.Reader = os.Stdin
+	if files[0] != "-" {
+		f, err := os.Open(files[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	out, err := a.backup.Restore(ctx, r, backup.RestoreInput{DryRun: *dryRun})
+	if err != nil {
+		return err
+	}
+	return printRestoreReport(a.out, newRestoreReport(out))
+}
+
+type restoreReport struct {
+	ArchiveSchemaVersion string         `json:"archive_schema_version"`
+	SchemaVersion        string         `json:"schema_version"`
+	Upgraded             bool           `json:"upgraded"`
+	CreatedAt            time.Time      `json:"created_at"`
+	Restored             map[string]int `json:"restored"`
+	DryRun               bool           `json:"dry_run"`
+}
+
+func newRestoreReport(out *backup.RestoreOutput) *restoreReport {
+	return &restoreReport{
+		ArchiveSchemaVersion: out.Manifest.SchemaVersion,
+		SchemaVersion:        out.SchemaVersion,
+		Upgraded:             out.Upgraded,
+		CreatedAt:            out.Manifest.CreatedAt,
+		Restored:             out.Restored,
+		DryRun:               out.DryRun,
+	}
+}
+
+func printRestoreReport(p printer, report *restoreReport) error {
+	if p.format == formatJSON {
+		return p.json(report)
+	}
+
+	var rows [][]string
+	for _, name := range domain.Entities {
+		if n, ok := report.Restored[name]; ok {
+			rows = append(rows, []string{name, strconv.Itoa(n)})
+		}
+	}
+	if err := p.table([]string{"ENTITY", "RECORDS"}, rows); err != nil {
+		return err
+	}
+
+	summary := "restored the backup of " + report.CreatedAt.Local().Format(timeLayout)
+	if report.Upgraded {
+		summary += fmt.Sprintf(", upgraded from schema %s to %s", report.ArchiveSchemaVersion, report.SchemaVersion)
+	}
+	if report.DryRun {
+		summary += "; dry run, nothing was changed"
+	}
+	_, err := fmt.Fprintln(p.out, summary)
+	return err
+}
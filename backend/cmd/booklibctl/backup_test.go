@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/backup"
+	"booklib/internal/usecase/backup"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintRestoreReport(t *testing.T) {
+	out := &backup.RestoreOutput{
+		Manifest: domain.Manifest{
+			FormatVersion: domain.FormatVersion,
+			SchemaVersion: "20261019_02",
+			CreatedAt:     time.Date(2026, 10, 19, 10, 0, 0, 0, time.Local),
+		},
+		SchemaVersion: "20261019_04",
+		Upgraded:      true,
+		Restored:      map[string]int{domain.EntityEvents: 12, domain.EntityBooks: 3},
+		DryRun:        true,
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{
+			name:   "table",
+			format: formatTable,
+			expected: "ENTITY  RECORDS\n" +
+				"books   3\n" +
+				"events  12\n" +
+				"restored the backup of 2026-10-19 10:00:00, upgraded from schema 20261019_02 to 20261019_04; dry run, nothing was changed\n",
+		},
+		{
+			name:   "json",
+			format: formatJSON,
+			expected: `{
+  "archive_schema_version": "20261019_02",
+  "schema_version": "20261019_04",
+  "upgraded": true,
+  "created_at": "` + out.Manifest.CreatedAt.Format(time.RFC3339) + `",
+  "restored": {
+    "books": 3,
+    "events": 12
+  },
+  "dry_run": true
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := printRestoreReport(printer{out: &buf, format: tt.format}, newRestoreReport(out))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
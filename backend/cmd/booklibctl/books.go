@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	domain "booklib/internal/domain/book"
+	"booklib/internal/usecase/book"
+)
+
+var (
+	errYearEmpty = errors.New("year cannot be empty")
+)
+
+// parseArgs parses fs from args and returns the positional arguments. Unlike
+// fs.Parse it accepts flags after positional arguments, as in `update <id> -year 1999`.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet("booklibctl "+name, flag.ContinueOnError)
+}
+
+func runList(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("list")
+	updatedSince := fs.String("updated-since", "", "only books updated at or after this RFC 3339 timestamp")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	var in book.GetAllBooksInput
+	if *updatedSince != "" {
+		since, err := time.Parse(time.RFC3339Nano, *updatedSince)
+		if err != nil {
+			return fmt.Errorf("updated-since must be an RFC 3339 timestamp")
+		}
+		in.UpdatedSince = &since
+	}
+
+	books, err := a.book.GetAllBooks(ctx, in)
+	if err != nil {
+		return err
+	}
+	return a.out.books(books)
+}
+
+func runGet(ctx context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: booklibctl get <id>")
+	}
+
+	bk, err := a.book.GetBook(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	if bk == nil {
+		return domain.ErrNotFound
+	}
+	return a.out.book(bk)
+}
+
+func runAdd(ctx context.Context, a *app, args []string) error {
+	var (
+		fs = newFlagSet("add")
+		in book.AddBookInput
+	)
+	fs.StringVar(&in.Title, "title", "", "title of the book")
+	fs.StringVar(&in.Author, "author", "", "author of the book")
+	fs.IntVar(&in.Year, "year", 0, "publication year")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	// the API refuses a book without a year too
+	if in.Year == 0 {
+		return errYearEmpty
+	}
+
+	bk, err := a.book.AddBook(ctx, in)
+	if err != nil {
+		return err
+	}
+	return a.out.book(bk)
+}
+
+func runUpdate(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("update")
+	var (
+		title  = fs.String("title", "", "new title")
+		author = fs.String("author", "", "new author")
+		year   = fs.Int("year", 0, "new publication year")
+	)
+	ids, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(ids) != 1 {
+		return errors.New("usage: booklibctl update <id> [-title t] [-author a] [-year y]")
+	}
+
+	bk, err := a.book.GetBook(ctx, ids[0])
+	if err != nil {
+		return err
+	}
+	if bk == nil {
+		return domain.ErrNotFound
+	}
+
+	// only the given flags change, the other fields keep their value
+	in := book.UpdateBookInput{Title: bk.Title, Author: bk.Author, Year: bk.Year}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "title":
+			in.Title = *title
+		case "author":
+			in.Author = *author
+		case "year":
+			in.Year = *year
+		}
+	})
+	if in.Year == 0 {
+		return errYearEmpty
+	}
+
+	if bk, err = a.book.UpdateBook(ctx, ids[0], in); err != nil {
+		return err
+	}
+	return a.out.book(bk)
+}
+
+func runDelete(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: booklibctl delete <id>...")
+	}
+
+	for _, id := range args {
+		if err := a.book.DeleteBook(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", id, err)
+		}
+	}
+	return a.out.message("deleted %d book(s)", len(args))
+}
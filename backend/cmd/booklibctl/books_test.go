@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	domain "booklib/internal/domain/book"
+	"booklib/internal/usecase/book"
+	"booklib/internal/usecase/book/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name               string
+		args               []string
+		expectedPositional []string
+		expectedYear       int
+		expectedErr        string
+	}{
+		{name: "no arguments"},
+		{name: "flags first", args: []string{"-year", "1999", "id-1"}, expectedPositional: []string{"id-1"}, expectedYear: 1999},
+		{name: "flags after positional arguments", args: []string{"id-1", "-year", "1999", "id-2"}, expectedPositional: []string{"id-1", "id-2"}, expectedYear: 1999},
+		{name: "unknown flag", args: []string{"id-1", "-colour", "red"}, expectedErr: "flag provided but not defined: -colour"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newFlagSet("test")
+			fs.SetOutput(io.Discard)
+			year := fs.Int("year", 0, "")
+
+			positional, err := parseArgs(fs, tt.args)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPositional, positional)
+			assert.Equal(t, tt.expectedYear, *year)
+		})
+	}
+}
+
+func TestRunAdd(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		setupMocks  func(*mocks.UseCase)
+		expectedErr error
+	}{
+		{
+			name: "adds the book",
+			args: []string{"-title", "Dune", "-author", "Frank Herbert", "-year", "1965"},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("AddBook", mock.Anything, book.AddBookInput{Title: "Dune", Author: "Frank Herbert", Year: 1965}).
+					Return(&domain.Book{ID: "id-1", Title: "Dune", Author: "Frank Herbert", Year: 1965}, nil)
+			},
+		},
+		{
+			name:        "without year",
+			args:        []string{"-title", "Dune", "-author", "Frank Herbert"},
+			setupMocks:  func(uc *mocks.UseCase) {},
+			expectedErr: errYearEmpty,
+		},
+		{
+			name:        "year zero",
+			args:        []string{"-title", "Dune", "-author", "Frank Herbert", "-year", "0"},
+			setupMocks:  func(uc *mocks.UseCase) {},
+			expectedErr: errYearEmpty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := mocks.NewUseCase(t)
+			tt.setupMocks(uc)
+
+			var buf bytes.Buffer
+			err := runAdd(context.Background(), &app{book: uc, out: printer{out: &buf, format: formatJSON}}, tt.args)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, buf.String())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Contains(t, buf.String(), `"title": "Dune"`)
+		})
+	}
+}
+
+func TestRunUpdate(t *testing.T) {
+	t.Run("refuses year zero", func(t *testing.T) {
+		uc := mocks.NewUseCase(t)
+		uc.On("GetBook", mock.Anything, "id-1").Return(&domain.Book{ID: "id-1", Title: "Dune", Author: "Frank Herbert", Year: 1965}, nil)
+
+		err := runUpdate(context.Background(), &app{book: uc}, []string{"id-1", "-year", "0"})
+
+		assert.ErrorIs(t, err, errYearEmpty)
+	})
+
+	t.Run("keeps the year when it is not given", func(t *testing.T) {
+		uc := mocks.NewUseCase(t)
+		uc.On("GetBook", mock.Anything, "id-1").Return(&domain.Book{ID: "id-1", Title: "Dune", Author: "Frank Herbert", Year: 1965}, nil)
+		uc.On("UpdateBook", mock.Anything, "id-1", book.UpdateBookInput{Title: "Dune Messiah", Author: "Frank Herbert", Year: 1965}).
+			Return(&domain.Book{ID: "id-1", Title: "Dune Messiah", Author: "Frank Herbert", Year: 1965}, nil)
+
+		var buf bytes.Buffer
+		err := runUpdate(context.Background(), &app{book: uc, out: printer{out: &buf, format: formatJSON}}, []string{"id-1", "-title", "Dune Messiah"})
+
+		assert.NoError(t, err)
+	})
+}
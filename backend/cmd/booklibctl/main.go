@@ -0,0 +1,111 @@
+// Command booklibctl manages a booklib database from the command line. It
+// reads the server config and goes through the same use cases as the API, so
+// every change it makes is validated and recorded as an event.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"booklib/internal/infra/config"
+	"booklib/internal/infra/migration"
+)
+
+const (
+	appName = "booklib"
+
+	usage = `usage: booklibctl [-config file] [-o table|json] <command> [arguments]
+
+commands:
+  list [-updated-since time]                    list books
+  get <id>                                      show a book
+  add -title t -author a -year y                add a book
+  update <id> [-title t] [-author a] [-year y]  change the given fields of a book
+  delete <id>...                                delete books
+  import [-format f] [-best-effort] <file>      import books from a json, jsonl or csv file
+  export [-format f] [file]                     export every book, to stdout by default
+  seed                                          add a set of demo books
+  stats                                         print library statistics
+  backup [file]                                 write a backup archive, booklib-<time>.tar.gz by default
+  restore [-dry-run] <file>                     replace the whole library with a backup archive
+  migrate <command>                             run a migration command: ` + migration.Usage + `
+`
+)
+
+var (
+	errUsage = errors.New(usage)
+)
+
+type command func(ctx context.Context, a *app, args []string) error
+
+var commands = map[string]command{
+	"list":    runList,
+	"get":     runGet,
+	"add":     runAdd,
+	"update":  runUpdate,
+	"delete":  runDelete,
+	"import":  runImport,
+	"export":  runExport,
+	"seed":    runSeed,
+	"stats":   runStats,
+	"backup":  runBackup,
+	"restore": runRestore,
+	"migrate": runMigrate,
+}
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("booklibctl", flag.ContinueOnError)
+	fs.Usage = func() { _, _ = fmt.Fprint(fs.Output(), usage) }
+	var (
+		configFile = fs.String("config", "", "config file, files/etc/booklib/config.yaml by default")
+		format     = fs.String("o", formatTable, "output format, table or json")
+	)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errUsage
+	}
+
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		return fmt.Errorf("unknown command %q\n\n%s", fs.Arg(0), usage)
+	}
+	if *format != formatTable && *format != formatJSON {
+		return fmt.Errorf("unknown output format %q, expected table or json", *format)
+	}
+
+	conf, err := loadConfig(*configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	a, err := newApp(ctx, conf, printer{out: out, format: *format})
+	if err != nil {
+		return err
+	}
+	defer a.close()
+
+	return cmd(ctx, a, fs.Args()[1:])
+}
+
+func loadConfig(file string) (*config.Config, error) {
+	if file == "" {
+		return config.New(appName)
+	}
+	return config.NewFromFile(appName, file)
+}
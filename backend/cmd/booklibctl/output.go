@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	domain "booklib/internal/domain/book"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+
+	timeLayout = "2006-01-02 15:04:05"
+)
+
+// printer writes command results either as an aligned table for people or as
+// indented JSON for scripts.
+type printer struct {
+	out    io.Writer
+	format string
+}
+
+func (p printer) json(v any) error {
+	enc := json.NewEncoder(p.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// table writes rows under header; every row must have as many cells as header.
+func (p printer) table(header []string, rows [][]string) error {
+	w := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if i > 0 {
+				_, _ = fmt.Fprint(w, "\t")
+			}
+			_, _ = fmt.Fprint(w, cell)
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+	return w.Flush()
+}
+
+func (p printer) books(books []domain.Book) error {
+	if p.format == formatJSON {
+		if books == nil {
+			books = []domain.Book{}
+		}
+		return p.json(books)
+	}
+
+	rows := make([][]string, len(books))
+	for i, bk := range books {
+		rows[i] = []string{
+			bk.ID, bk.Title, bk.Author, strconv.Itoa(bk.Year),
+			bk.UpdatedAt.Local().Format(timeLayout),
+		}
+	}
+	return p.table([]string{"ID", "TITLE", "AUTHOR", "YEAR", "UPDATED AT"}, rows)
+}
+
+func (p printer) book(bk *domain.Book) error {
+	if p.format == formatJSON {
+		return p.json(bk)
+	}
+	return p.books([]domain.Book{*bk})
+}
+
+// message prints a short confirmation; JSON output gets it as {"message": ...}.
+func (p printer) message(format string, args ...any) error {
+	msg := fmt.Sprintf(format, args...)
+	if p.format == formatJSON {
+		return p.json(map[string]string{"message": msg})
+	}
+	_, err := fmt.Fprintln(p.out, msg)
+	return err
+}
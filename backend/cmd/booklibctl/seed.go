@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"booklib/internal/usecase/book"
+)
+
+// demoBooks is the library `booklibctl seed` starts from.
+var demoBooks = []book.BatchOperation{
+	{Title: "Pride and Prejudice", Author: "Jane Austen", Year: 1813},
+	{Title: "Emma", Author: "Jane Austen", Year: 1815},
+	{Title: "Frankenstein", Author: "Mary Shelley", Year: 1818},
+	{Title: "Moby-Dick", Author: "Herman Melville", Year: 1851},
+	{Title: "Great Expectations", Author: "Charles Dickens", Year: 1861},
+	{Title: "Crime and Punishment", Author: "Fyodor Dostoevsky", Year: 1866},
+	{Title: "Anna Karenina", Author: "Leo Tolstoy", Year: 1878},
+	{Title: "The Picture of Dorian Gray", Author: "Oscar Wilde", Year: 1890},
+	{Title: "The Great Gatsby", Author: "F. Scott Fitzgerald", Year: 1925},
+	{Title: "Mrs Dalloway", Author: "Virginia Woolf", Year: 1925},
+	{Title: "Nineteen Eighty-Four", Author: "George Orwell", Year: 1949},
+	{Title: "Animal Farm", Author: "George Orwell", Year: 1945},
+	{Title: "One Hundred Years of Solitude", Author: "Gabriel García Márquez", Year: 1967},
+	{Title: "Things Fall Apart", Author: "Chinua Achebe", Year: 1958},
+	{Title: "Bumi Manusia", Author: "Pramoedya Ananta Toer", Year: 1980},
+}
+
+// missingDemoBooks returns the demo books not in the library yet, matched on
+// title and author, so seeding twice does not add duplicates.
+func missingDemoBooks(ctx context.Context, a *app) ([]book.BatchOperation, error) {
+	books, err := a.book.GetAllBooks(ctx, book.GetAllBooksInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	key := func(title, author string) string {
+		return strings.ToLower(title) + "\x00" + strings.ToLower(author)
+	}
+	have := make(map[string]bool, len(books))
+	for _, bk := range books {
+		have[key(bk.Title, bk.Author)] = true
+	}
+
+	var missing []book.BatchOperation
+	for _, op := range demoBooks {
+		if !have[key(op.Title, op.Author)] {
+			op.Op = book.BatchOpCreate
+			missing = append(missing, op)
+		}
+	}
+	return missing, nil
+}
+
+func runSeed(ctx context.Context, a *app, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: booklibctl seed")
+	}
+
+	ops, err := missingDemoBooks(ctx, a)
+	if err != nil {
+		return err
+	}
+	if len(ops) == 0 {
+		return a.out.message("demo books are already there")
+	}
+
+	out, err := a.book.BatchBooks(ctx, book.BatchInput{Operations: ops})
+	if err != nil {
+		return err
+	}
+	for i, res := range out.Results {
+		if res.Status == book.BatchStatusError {
+			return fmt.Errorf("failed to seed %q: %s", ops[i].Title, res.Error)
+		}
+	}
+	return a.out.message("seeded %d book(s)", len(ops))
+}
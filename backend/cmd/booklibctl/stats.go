@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	domain "booklib/internal/domain/book"
+	"booklib/internal/usecase/book"
+)
+
+const (
+	topAuthors = 5
+)
+
+type authorCount struct {
+	Author string `json:"author"`
+	Books  int    `json:"books"`
+}
+
+type decadeCount struct {
+	Decade int `json:"decade"`
+	Books  int `json:"books"`
+}
+
+type stats struct {
+	Books   int `json:"books"`
+	Authors int `json:"authors"`
+	// OldestYear and NewestYear skip books without a year
+	OldestYear    int           `json:"oldest_year,omitempty"`
+	NewestYear    int           `json:"newest_year,omitempty"`
+	LastUpdatedAt *time.Time    `json:"last_updated_at,omitempty"`
+	TopAuthors    []authorCount `json:"top_authors"`
+	Decades       []decadeCount `json:"decades"`
+}
+
+func newStats(books []domain.Book) stats {
+	var (
+		st       = stats{Books: len(books), TopAuthors: []authorCount{}, Decades: []decadeCount{}}
+		byAuthor = map[string]int{}
+		byDecade = map[int]int{}
+	)
+
+	for i, bk := range books {
+		byAuthor[bk.Author]++
+		if st.LastUpdatedAt == nil || bk.UpdatedAt.After(*st.LastUpdatedAt) {
+			st.LastUpdatedAt = &books[i].UpdatedAt
+		}
+
+		if bk.Year == 0 {
+			continue
+		}
+		if st.OldestYear == 0 || bk.Year < st.OldestYear {
+			st.OldestYear = bk.Year
+		}
+		if bk.Year > st.NewestYear {
+			st.NewestYear = bk.Year
+		}
+		byDecade[bk.Year-bk.Year%10]++
+	}
+
+	st.Authors = len(byAuthor)
+	for author, n := range byAuthor {
+		st.TopAuthors = append(st.TopAuthors, authorCount{Author: author, Books: n})
+	}
+	sort.Slice(st.TopAuthors, func(i, j int) bool {
+		if st.TopAuthors[i].Books != st.TopAuthors[j].Books {
+			return st.TopAuthors[i].Books > st.TopAuthors[j].Books
+		}
+		return st.TopAuthors[i].Author < st.TopAuthors[j].Author
+	})
+	if len(st.TopAuthors) > topAuthors {
+		st.TopAuthors = st.TopAuthors[:topAuthors]
+	}
+
+	for decade, n := range byDecade {
+		st.Decades = append(st.Decades, decadeCount{Decade: decade, Books: n})
+	}
+	sort.Slice(st.Decades, func(i, j int) bool { return st.Decades[i].Decade < st.Decades[j].Decade })
+
+	return st
+}
+
+func runStats(ctx context.Context, a *app, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: booklibctl stats")
+	}
+
+	books, err := a.book.GetAllBooks(ctx, book.GetAllBooksInput{})
+	if err != nil {
+		return err
+	}
+	return printStats(a.out, newStats(books))
+}
+
+func printStats(p printer, st stats) error {
+	if p.format == formatJSON {
+		return p.json(st)
+	}
+
+	years, lastUpdated := "-", "-"
+	if st.OldestYear != 0 {
+		years = fmt.Sprintf("%d - %d", st.OldestYear, st.NewestYear)
+	}
+	if st.LastUpdatedAt != nil {
+		lastUpdated = st.LastUpdatedAt.Local().Format(timeLayout)
+	}
+	err := p.table([]string{"STAT", "VALUE"}, [][]string{
+		{"books", strconv.Itoa(st.Books)},
+		{"authors", strconv.Itoa(st.Authors)},
+		{"years", years},
+		{"last updated", lastUpdated},
+	})
+	if err != nil || st.Books == 0 {
+		return err
+	}
+
+	rows := make([][]string, len(st.TopAuthors))
+	for i, ac := range st.TopAuthors {
+		rows[i] = []string{ac.Author, strconv.Itoa(ac.Books)}
+	}
+	_, _ = fmt.Fprintln(p.out)
+	if err = p.table([]string{"TOP AUTHOR", "BOOKS"}, rows); err != nil {
+		return err
+	}
+
+	rows = make([][]string, len(st.Decades))
+	for i, dc := range st.Decades {
+		rows[i] = []string{fmt.Sprintf("%ds", dc.Decade), strconv.Itoa(dc.Books)}
+	}
+	_, _ = fmt.Fprintln(p.out)
+	return p.table([]string{"DECADE", "BOOKS"}, rows)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/book"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStats(t *testing.T) {
+	var (
+		older = time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+		newer = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	)
+
+	tests := []struct {
+		name     string
+		books    []domain.Book
+		expected stats
+	}{
+		{
+			name:     "empty library",
+			expected: stats{TopAuthors: []authorCount{}, Decades: []decadeCount{}},
+		},
+		{
+			name: "counts authors, years and decades",
+			books: []domain.Book{
+				{Author: "Jane Austen", Year: 1815, UpdatedAt: older},
+				{Author: "George Orwell", Year: 1949, UpdatedAt: newer},
+				{Author: "Jane Austen", Year: 1813, UpdatedAt: older},
+				{Author: "George Orwell", Year: 1945, UpdatedAt: older},
+				{Author: "Anonymous", UpdatedAt: older},
+			},
+			expected: stats{
+				Books:         5,
+				Authors:       3,
+				OldestYear:    1813,
+				NewestYear:    1949,
+				LastUpdatedAt: &newer,
+				TopAuthors: []authorCount{
+					{Author: "George Orwell", Books: 2},
+					{Author: "Jane Austen", Books: 2},
+					{Author: "Anonymous", Books: 1},
+				},
+				Decades: []decadeCount{{Decade: 1810, Books: 2}, {Decade: 1940, Books: 2}},
+			},
+		},
+		{
+			name: "only the top authors are kept",
+			books: []domain.Book{
+				{Author: "A", Year: 2001}, {Author: "B", Year: 2002}, {Author: "C", Year: 2003},
+				{Author: "D", Year: 2004}, {Author: "E", Year: 2005}, {Author: "F", Year: 2006},
+				{Author: "F", Year: 2016},
+			},
+			expected: stats{
+				Books:         7,
+				Authors:       6,
+				OldestYear:    2001,
+				NewestYear:    2016,
+				LastUpdatedAt: &time.Time{},
+				TopAuthors: []authorCount{
+					{Author: "F", Books: 2}, {Author: "A", Books: 1}, {Author: "B", Books: 1},
+					{Author: "C", Books: 1}, {Author: "D", Books: 1},
+				},
+				Decades: []decadeCount{{Decade: 2000, Books: 6}, {Decade: 2010, Books: 1}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, newStats(tt.books))
+		})
+	}
+}
+
+func TestPrintStats(t *testing.T) {
+	st := newStats([]domain.Book{{Author: "Jane Austen", Year: 1815}})
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printStats(printer{out: &buf, format: formatTable}, st))
+
+		assert.Contains(t, buf.String(), "books         1\n")
+		assert.Contains(t, buf.String(), "years         1815 - 1815\n")
+		assert.Contains(t, buf.String(), "Jane Austen  1\n")
+		assert.Contains(t, buf.String(), "1810s   1\n")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printStats(printer{out: &buf, format: formatJSON}, st))
+
+		assert.JSONEq(t, `{
+			"books": 1, "authors": 1, "oldest_year": 1815, "newest_year": 1815,
+			"last_updated_at": "0001-01-01T00:00:00Z",
+			"top_authors": [{"author": "Jane Austen", "books": 1}],
+			"decades": [{"decade": 1810, "books": 1}]
+		}`, buf.String())
+	})
+}
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	domain "booklib/internal/domain/book"
+	"booklib/internal/usecase/book"
+)
+
+const (
+	fileJSON  = "json"
+	fileJSONL = "jsonl"
+	fileCSV   = "csv"
+)
+
+var (
+	csvHeader = []string{"id", "title", "author", "year", "created_at", "updated_at"}
+
+	errImportFailed = errors.New("import failed")
+)
+
+// fileFormat returns the explicit format, or the one of the file extension.
+func fileFormat(file, format string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".json":
+			format = fileJSON
+		case ".jsonl", ".ndjson":
+			format = fileJSONL
+		case ".csv":
+			format = fileCSV
+		default:
+			return "", fmt.Errorf("cannot tell the format of %q, use -format json, jsonl or csv", file)
+		}
+	}
+
+	switch format {
+	case fileJSON, fileJSONL, fileCSV:
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown file format %q, expected json, jsonl or csv", format)
+}
+
+// readBooks decodes books from r. Only id, title, author and year are read;
+// the timestamps of an export are ignored on the way back in.
+func readBooks(r io.Reader, format string) ([]domain.Book, error) {
+	switch format {
+	case fileJSON:
+		var books []domain.Book
+		if err := json.NewDecoder(r).Decode(&books); err != nil {
+			return nil, fmt.Errorf("invalid json: %w", err)
+		}
+		return books, nil
+
+	case fileJSONL:
+		var (
+			books []domain.Book
+			dec   = json.NewDecoder(r)
+		)
+		for line := 1; ; line++ {
+			var bk domain.Book
+			if err := dec.Decode(&bk); err == io.EOF {
+				return books, nil
+			} else if err != nil {
+				return nil, fmt.Errorf("invalid json on record %d: %w", line, err)
+			}
+			books = append(books, bk)
+		}
+
+	case fileCSV:
+		return readCSV(r)
+	}
+
+	return nil, fmt.Errorf("unknown file format %q", format)
+}
+
+func readCSV(r io.Reader) ([]domain.Book, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	column := make(map[string]int, len(header))
+	for i, name := range header {
+		column[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"title", "author"} {
+		if _, ok := column[required]; !ok {
+			return nil, fmt.Errorf("csv header has no %q column", required)
+		}
+	}
+
+	cell := func(record []string, name string) string {
+		if i, ok := column[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var books []domain.Book
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return books, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := cr.FieldPos(0)
+		bk := domain.Book{
+			ID:     cell(record, "id"),
+			Title:  cell(record, "title"),
+			Author: cell(record, "author"),
+		}
+		if year := cell(record, "year"); year != "" {
+			if bk.Year, err = strconv.Atoi(year); err != nil {
+				return nil, fmt.Errorf("line %d: year must be a number, got %q", line, year)
+			}
+		}
+		books = append(books, bk)
+	}
+}
+
+func writeBooks(w io.Writer, format string, books []domain.Book) error {
+	switch format {
+	case fileJSON:
+		if books == nil {
+			books = []domain.Book{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(books)
+
+	case fileJSONL:
+		enc := json.NewEncoder(w)
+		for _, bk := range books {
+			if err := enc.Encode(bk); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case fileCSV:
+		cw := csv.NewWriter(w)
+		_ = cw.Write(csvHeader)
+		for _, bk := range books {
+			_ = cw.Write([]string{
+				bk.ID, bk.Title, bk.Author, strconv.Itoa(bk.Year),
+				bk.CreatedAt.UTC().Format(time.RFC3339Nano),
+				bk.UpdatedAt.UTC().Format(time.RFC3339Nano),
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+	return fmt.Errorf("unknown file format %q", format)
+}
+
+type importError struct {
+	// Record is the 1-based position of the book in the file
+	Record int    `json:"record"`
+	Error  string `json:"error"`
+}
+
+type importReport struct {
+	Created int           `json:"created"`
+	Updated int           `json:"updated"`
+	Failed  int           `json:"failed"`
+	Errors  []importError `json:"errors,omitempty"`
+}
+
+// importBooks updates the books whose id already exists and creates the
+// others with the id of the file, if any, in batches of
+// book.MaxBatchOperations. Unless bestEffort is set the
+// whole file is applied in one transaction and nothing is kept when a book
+// fails.
+func importBooks(ctx context.Context, a *app, books []domain.Book, bestEffort bool) (*importReport, error) {
+	existing, err := a.book.GetAllBooks(ctx, book.GetAllBooksInput{})
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(existing))
+	for _, bk := range existing {
+		known[bk.ID] = true
+	}
+
+	ops := make([]book.BatchOperation, len(books))
+	for i, bk := range books {
+		ops[i] = book.BatchOperation{Op: book.BatchOpCreate, ID: bk.ID, Title: bk.Title, Author: bk.Author, Year: bk.Year}
+		// an id that is not a UUID stays as it is and fails its create
+		if id, err := domain.ParseID(bk.ID); err == nil {
+			ops[i].ID = id
+			if known[id] {
+				ops[i].Op = book.BatchOpUpdate
+			}
+		}
+	}
+
+	report := &importReport{}
+	apply := func(ctx context.Context) error {
+		for start := 0; start < len(ops); start += book.MaxBatchOperations {
+			chunk := ops[start:min(start+book.MaxBatchOperations, len(ops))]
+			out, err := a.book.BatchBooks(ctx, book.BatchInput{Operations: chunk, BestEffort: bestEffort})
+			if err != nil {
+				return err
+			}
+			report.add(start, out)
+		}
+		if report.Failed > 0 && !bestEffort {
+			return errImportFailed
+		}
+		return nil
+	}
+
+	if bestEffort {
+		return report, apply(ctx)
+	}
+
+	err = a.tx.WithinTx(ctx, apply)
+	if errors.Is(err, errImportFailed) {
+		// the transaction was rolled back, nothing was kept
+		report.Created, report.Updated = 0, 0
+		return report, nil
+	}
+	return report, err
+}
+
+func (r *importReport) add(offset int, out *book.BatchOutput) {
+	for i, res := range out.Results {
+		switch res.Status {
+		case book.BatchStatusSuccess:
+			if res.Op == book.BatchOpCreate {
+				r.Created++
+			} else {
+				r.Updated++
+			}
+		case book.BatchStatusError:
+			r.Failed++
+			r.Errors = append(r.Errors, importError{Record: offset + i + 1, Error: res.Error})
+		}
+	}
+}
+
+func runImport(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("import")
+	var (
+		format     = fs.String("format", "", "json, jsonl or csv; taken from the file extension by default")
+		bestEffort = fs.Bool("best-effort", false, "keep the valid books when others fail")
+	)
+	files, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) != 1 {
+		return errors.New("usage: booklibctl import [-format f] [-best-effort] <file>")
+	}
+
+	if *format, err = fileFormat(files[0], *format); err != nil {
+		return err
+	}
+
+	f, err := os.Open(files[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	books, err := readBooks(f, *format)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", files[0], err)
+	}
+	if len(books) == 0 {
+		return a.out.message("nothing to import")
+	}
+
+	report, err := importBooks(ctx, a, books, *bestEffort)
+	if err != nil {
+		return err
+	}
+	if err = printImportReport(a.out, report); err != nil {
+		return err
+	}
+	if report.Failed > 0 && !*bestEffort {
+		return fmt.Errorf("%d book(s) failed, nothing was imported", report.Failed)
+	}
+	return nil
+}
+
+func printImportReport(p printer, report *importReport) error {
+	if p.format == formatJSON {
+		return p.json(report)
+	}
+
+	_, _ = fmt.Fprintf(p.out, "created %d, updated %d, failed %d\n", report.Created, report.Updated, report.Failed)
+	if len(report.Errors) == 0 {
+		return nil
+	}
+
+	rows := make([][]string, len(report.Errors))
+	for i, e := range report.Errors {
+		rows[i] = []string{strconv.Itoa(e.Record), e.Error}
+	}
+	return p.table([]string{"RECORD", "ERROR"}, rows)
+}
+
+func runExport(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("export")
+	format := fs.String("format", "", "json, jsonl or csv; taken from the file extension, json for stdout")
+	files, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) > 1 {
+		return errors.New("usage: booklibctl export [-format f] [file]")
+	}
+
+	file := "-"
+	if len(files) == 1 {
+		file = files[0]
+	}
+	if file == "-" && *format == "" {
+		*format = fileJSON
+	}
+	if *format, err = fileFormat(file, *format); err != nil {
+		return err
+	}
+
+	books, err := a.book.GetAllBooks(ctx, book.GetAllBooksInput{})
+	if err != nil {
+		return err
+	}
+
+	if file == "-" {
+		return writeBooks(a.out.out, *format, books)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err = writeBooks(f, *format, books); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	return a.out.message("exported %d book(s) to %s", len(books), file)
+}
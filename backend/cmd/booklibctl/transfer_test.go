@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/book"
+	"booklib/internal/usecase/book"
+	"booklib/internal/usecase/book/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// passthroughTx runs a unit of work without a transaction.
+type passthroughTx struct{}
+
+func (passthroughTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestFileFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		format      string
+		expected    string
+		expectedErr string
+	}{
+		{name: "json extension", file: "books.json", expected: fileJSON},
+		{name: "jsonl extension", file: "books.JSONL", expected: fileJSONL},
+		{name: "ndjson extension", file: "books.ndjson", expected: fileJSONL},
+		{name: "csv extension", file: "/tmp/books.csv", expected: fileCSV},
+		{name: "explicit format wins", file: "books.txt", format: fileCSV, expected: fileCSV},
+		{name: "unknown extension", file: "books.txt", expectedErr: `cannot tell the format of "books.txt", use -format json, jsonl or csv`},
+		{name: "unknown format", file: "books.json", format: "xml", expectedErr: `unknown file format "xml", expected json, jsonl or csv`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := fileFormat(tt.file, tt.format)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
+
+func TestReadBooks(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		input       string
+		expected    []domain.Book
+		expectedErr string
+	}{
+		{
+			name:     "json",
+			format:   fileJSON,
+			input:    `[{"id":"id-1","title":"Emma","author":"Jane Austen","year":1815},{"title":"Dune","author":"Frank Herbert"}]`,
+			expected: []domain.Book{{ID: "id-1", Title: "Emma", Author: "Jane Austen", Year: 1815}, {Title: "Dune", Author: "Frank Herbert"}},
+		},
+		{
+			name:     "jsonl",
+			format:   fileJSONL,
+			input:    "{\"title\":\"Emma\",\"author\":\"Jane Austen\",\"year\":1815}\n\n{\"title\":\"Dune\",\"author\":\"Frank Herbert\",\"year\":1965}\n",
+			expected: []domain.Book{{Title: "Emma", Author: "Jane Austen", Year: 1815}, {Title: "Dune", Author: "Frank Herbert", Year: 1965}},
+		},
+		{
+			name:        "invalid jsonl record",
+			format:      fileJSONL,
+			input:       "{\"title\":\"Emma\"}\n{nope}\n",
+			expectedErr: "invalid json on record 2",
+		},
+		{
+			name:     "csv with columns in any order",
+			format:   fileCSV,
+			input:    "Year,Author,Title,shelf\n1815,Jane Austen,Emma,A1\n,Frank Herbert,Dune,B2\n",
+			expected: []domain.Book{{Title: "Emma", Author: "Jane Austen", Year: 1815}, {Title: "Dune", Author: "Frank Herbert"}},
+		},
+		{
+			name:        "csv without an author column",
+			format:      fileCSV,
+			input:       "title,year\nEmma,1815\n",
+			expectedErr: `csv header has no "author" column`,
+		},
+		{
+			name:        "csv with an invalid year",
+			format:      fileCSV,
+			input:       "title,author,year\nEmma,Jane Austen,1815\nDune,Frank Herbert,soon\n",
+			expectedErr: `line 3: year must be a number, got "soon"`,
+		},
+		{
+			name:   "empty csv",
+			format: fileCSV,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			books, err := readBooks(strings.NewReader(tt.input), tt.format)
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, books)
+		})
+	}
+}
+
+func TestWriteBooksRoundTrip(t *testing.T) {
+	var (
+		at    = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+		books = []domain.Book{
+			{ID: "id-1", Title: "Emma", Author: "Jane Austen", Year: 1815, CreatedAt: at, UpdatedAt: at},
+			{ID: "id-2", Title: "Things Fall Apart, Part One", Author: "Chinua \"Albert\" Achebe", Year: 1958, CreatedAt: at, UpdatedAt: at},
+		}
+		// only the fields an import reads survive the trip
+		expected = []domain.Book{
+			{ID: "id-1", Title: "Emma", Author: "Jane Austen", Year: 1815},
+			{ID: "id-2", Title: "Things Fall Apart, Part One", Author: "Chinua \"Albert\" Achebe", Year: 1958},
+		}
+	)
+
+	for _, format := range []string{fileJSON, fileJSONL, fileCSV} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, writeBooks(&buf, format, books))
+
+			read, err := readBooks(&buf, format)
+			require.NoError(t, err)
+
+			if format != fileCSV {
+				for i := range read {
+					assert.Equal(t, at, read[i].CreatedAt.UTC())
+					read[i].CreatedAt, read[i].UpdatedAt = time.Time{}, time.Time{}
+				}
+			}
+			assert.Equal(t, expected, read)
+		})
+	}
+
+	t.Run("csv header", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeBooks(&buf, fileCSV, nil))
+		assert.Equal(t, "id,title,author,year,created_at,updated_at\n", buf.String())
+	})
+}
+
+func TestImportBooks(t *testing.T) {
+	ctx := context.Background()
+
+	// batchResults answers BatchBooks with a success for every operation,
+	// except the titles listed in failing.
+	batchResults := func(failing ...string) func(context.Context, book.BatchInput) (*book.BatchOutput, error) {
+		return func(_ context.Context, in book.BatchInput) (*book.BatchOutput, error) {
+			out := &book.BatchOutput{Results: make([]book.BatchResult, len(in.Operations))}
+			for i, op := range in.Operations {
+				out.Results[i] = book.BatchResult{Op: op.Op, ID: op.ID, Status: book.BatchStatusSuccess}
+				for _, title := range failing {
+					if op.Title == title {
+						out.Results[i].Status = book.BatchStatusError
+						out.Results[i].Error = "title cannot be empty"
+						out.Failed++
+					}
+				}
+			}
+			return out, nil
+		}
+	}
+
+	t.Run("known ids are updated, the others created with their ids", func(t *testing.T) {
+		uc := mocks.NewUseCase(t)
+		uc.On("GetAllBooks", mock.Anything, book.GetAllBooksInput{}).
+			Return([]domain.Book{{ID: "abcdef00-0000-0000-0000-000000000001"}}, nil)
+		uc.On("BatchBooks", mock.Anything, book.BatchInput{Operations: []book.BatchOperation{
+			{Op: book.BatchOpUpdate, ID: "abcdef00-0000-0000-0000-000000000001", Title: "Emma", Author: "Jane Austen", Year: 1815},
+			{Op: book.BatchOpCreate, ID: "abcdef00-0000-0000-0000-000000000002", Title: "Dune", Author: "Frank Herbert", Year: 1965},
+			{Op: book.BatchOpCreate, Title: "Beloved", Author: "Toni Morrison", Year: 1987},
+		}}).Return(batchResults())
+
+		report, err := importBooks(ctx, &app{book: uc, tx: passthroughTx{}}, []domain.Book{
+			{ID: "ABCDEF00-0000-0000-0000-000000000001", Title: "Emma", Author: "Jane Austen", Year: 1815},
+			{ID: "abcdef00-0000-0000-0000-000000000002", Title: "Dune", Author: "Frank Herbert", Year: 1965},
+			{Title: "Beloved", Author: "Toni Morrison", Year: 1987},
+		}, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &importReport{Created: 2, Updated: 1}, report)
+	})
+
+	t.Run("an id that is not a UUID is left to fail its create", func(t *testing.T) {
+		uc := mocks.NewUseCase(t)
+		uc.On("GetAllBooks", mock.Anything, mock.Anything).Return(nil, nil)
+		uc.On("BatchBooks", mock.Anything, book.BatchInput{Operations: []book.BatchOperation{
+			{Op: book.BatchOpCreate, ID: "id-1", Title: "Emma", Author: "Jane Austen", Year: 1815},
+		}}).Return(&book.BatchOutput{
+			Results: []book.BatchResult{{Op: book.BatchOpCreate, ID: "id-1", Status: book.BatchStatusError, Error: `id "id-1" is not a valid UUID`}},
+			Failed:  1,
+		}, nil)
+
+		report, err := importBooks(ctx, &app{book: uc, tx: passthroughTx{}}, []domain.Book{
+			{ID: "id-1", Title: "Emma", Author: "Jane Austen", Year: 1815},
+		}, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &importReport{
+			Failed: 1,
+			Errors: []importError{{Record: 1, Error: `id "id-1" is not a valid UUID`}},
+		}, report)
+	})
+
+	t.Run("large files are sent in batches", func(t *testing.T) {
+		books := make([]domain.Book, book.MaxBatchOperations+1)
+		for i := range books {
+			books[i] = domain.Book{Title: "Book", Author: "Author"}
+		}
+
+		uc := mocks.NewUseCase(t)
+		uc.On("GetAllBooks", mock.Anything, mock.Anything).Return(nil, nil)
+		uc.On("BatchBooks", mock.Anything, mock.MatchedBy(func(in book.BatchInput) bool {
+			return len(in.Operations) == book.MaxBatchOperations
+		})).Return(batchResults()).Once()
+		uc.On("BatchBooks", mock.Anything, mock.MatchedBy(func(in book.BatchInput) bool {
+			return len(in.Operations) == 1
+		})).Return(batchResults()).Once()
+
+		report, err := importBooks(ctx, &app{book: uc, tx: passthroughTx{}}, books, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, book.MaxBatchOperations+1, report.Created)
+	})
+
+	t.Run("a failing book keeps nothing", func(t *testing.T) {
+		uc := mocks.NewUseCase(t)
+		uc.On("GetAllBooks", mock.Anything, mock.Anything).Return(nil, nil)
+		uc.On("BatchBooks", mock.Anything, mock.Anything).Return(batchResults(""))
+
+		report, err := importBooks(ctx, &app{book: uc, tx: passthroughTx{}}, []domain.Book{
+			{Title: "Emma", Author: "Jane Austen"},
+			{Author: "Nobody"},
+		}, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &importReport{
+			Failed: 1,
+			Errors: []importError{{Record: 2, Error: "title cannot be empty"}},
+		}, report)
+	})
+
+	t.Run("best effort keeps the valid books", func(t *testing.T) {
+		uc := mocks.NewUseCase(t)
+		uc.On("GetAllBooks", mock.Anything, mock.Anything).Return(nil, nil)
+		uc.On("BatchBooks", mock.Anything, mock.MatchedBy(func(in book.BatchInput) bool { return in.BestEffort })).
+			Return(batchResults(""))
+
+		// no transaction is needed, so none is given
+		report, err := importBooks(ctx, &app{book: uc}, []domain.Book{
+			{Title: "Emma", Author: "Jane Austen"},
+			{Author: "Nobody"},
+		}, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &importReport{
+			Created: 1,
+			Failed:  1,
+			Errors:  []importError{{Record: 2, Error: "title cannot be empty"}},
+		}, report)
+	})
+}
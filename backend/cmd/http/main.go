@@ -14,9 +14,15 @@ package main
 
 // @BasePath /api/v1
 
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name Authorization
+// @description "Bearer <admin.token>", for the admin endpoints served when admin.enabled is set
+
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"booklib/internal/infra"
 	"booklib/internal/infra/config"
@@ -46,14 +52,36 @@ func main() {
 		log.Fatal(ctx, err, nil, "failed to build resources")
 	}
 
-	repo := newRepo(resources)
-	uc := newUseCase(repo)
+	// `booklib migrate ...` runs a migration command instead of the server
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err = runMigrate(ctx, resources, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(ctx, err, nil, "failed to migrate")
+		}
+		return
+	}
+
+	if conf.Database.AutoMigrate {
+		if err = autoMigrate(ctx, resources); err != nil {
+			log.Fatal(ctx, err, nil, "failed to migrate")
+		}
+	}
+
+	repo, err := newRepo(conf, resources)
+	if err != nil {
+		log.Fatal(ctx, err, nil, "failed to build repositories")
+	}
+	uc, err := newUseCase(conf, resources, repo)
+	if err != nil {
+		log.Fatal(ctx, err, nil, "failed to build use cases")
+	}
+	startWorkers(ctx, conf, uc)
 
 	srv := fiber.New(fiber.Config{
-		AppName: appName,
+		AppName:   appName,
+		BodyLimit: serverBodyLimit(conf),
 	})
 	srv.Use(cors.New())
-	routes(srv, uc)
+	routes(srv, conf, uc)
 
 	log.Infof(ctx, nil, nil, "⚡️server started on :%d", conf.Server.Port)
 	if err = srv.Listen(fmt.Sprintf(":%d", conf.Server.Port)); err != nil {
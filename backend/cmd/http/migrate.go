@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"io"
+
+	"booklib/internal/infra"
+	"github.com/rizanw/go-log"
+)
+
+// runMigrate runs `booklib migrate <command>` and reports to out.
+func runMigrate(ctx context.Context, res *infra.Resources, args []string, out io.Writer) error {
+	migrator, err := infra.NewMigrator(res)
+	if err != nil {
+		return err
+	}
+
+	return migrator.Run(ctx, args, out)
+}
+
+// autoMigrate applies pending migrations before the server starts. The memory
+// driver has no schema, so there is nothing to do for it.
+func autoMigrate(ctx context.Context, res *infra.Resources) error {
+	if res.Database == nil {
+		return nil
+	}
+
+	migrator, err := infra.NewMigrator(res)
+	if err != nil {
+		return err
+	}
+
+	done, err := migrator.Up(ctx)
+	for _, mig := range done {
+		log.Infof(ctx, nil, nil, "applied migration %s", mig.File())
+	}
+	return err
+}
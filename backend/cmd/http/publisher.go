@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+
+	"booklib/internal/domain/event"
+	"booklib/internal/domain/outbox"
+	"booklib/internal/infra"
+	"booklib/internal/infra/config"
+	"booklib/internal/infra/publisher"
+)
+
+const (
+	publisherPostgres = "postgres"
+	publisherKafka    = "kafka"
+)
+
+// newEventPublisher builds the publisher of the outbox relay. Events always go
+// to the in-process bus, which feeds handlers, and to the configured broker.
+func newEventPublisher(conf *config.Config, res *infra.Resources, handlers ...event.Handler) (outbox.EventPublisher, error) {
+	bus := publisher.NewBus(handlers...)
+
+	switch conf.Outbox.Publisher {
+	case "":
+		return bus, nil
+	case publisherPostgres:
+		if driver := conf.Database.Driver; driver != "" && driver != config.DriverPostgres {
+			return nil, fmt.Errorf("postgres publisher needs the postgres database driver")
+		}
+		return publisher.Fanout{bus, publisher.NewPostgres(res.Database, conf.Outbox.Postgres.Channel)}, nil
+	case publisherKafka:
+		if len(conf.Outbox.Kafka.Brokers) == 0 || conf.Outbox.Kafka.Topic == "" {
+			return nil, fmt.Errorf("kafka publisher needs brokers and a topic")
+		}
+		return publisher.Fanout{bus, publisher.NewKafka(conf.Outbox.Kafka.Brokers, conf.Outbox.Kafka.Topic)}, nil
+	}
+
+	return nil, fmt.Errorf("unknown event publisher %q", conf.Outbox.Publisher)
+}
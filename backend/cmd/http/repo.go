@@ -1,17 +1,66 @@
 package main
 
 import (
+	"fmt"
+
+	"booklib/internal/domain/backup"
 	"booklib/internal/domain/book"
+	"booklib/internal/domain/event"
+	"booklib/internal/domain/link"
+	"booklib/internal/domain/outbox"
+	"booklib/internal/domain/transaction"
+	urlprocessor "booklib/internal/domain/url-processor"
+	"booklib/internal/domain/webhook"
 	"booklib/internal/infra"
+	"booklib/internal/infra/config"
+	repobackup "booklib/internal/repo/backup"
 	repobook "booklib/internal/repo/book"
+	repoevent "booklib/internal/repo/event"
+	repolink "booklib/internal/repo/link"
+	"booklib/internal/repo/memory"
+	repooutbox "booklib/internal/repo/outbox"
+	"booklib/internal/repo/sqltx"
+	repourlprocessor "booklib/internal/repo/url-processor"
+	repowebhook "booklib/internal/repo/webhook"
 )
 
 type Repo struct {
-	Book book.Repository
+	// Backup is nil for the memory driver, which has nothing to back up
+	Backup  backup.Repository
+	Book    book.Repository
+	Event   event.Repository
+	Link    link.Repository
+	Outbox  outbox.Repository
+	RuleSet urlprocessor.RuleSetRepository
+	Tx      transaction.Manager
+	Webhook webhook.Repository
 }
 
-func newRepo(res *infra.Resources) *Repo {
-	return &Repo{
-		Book: repobook.New(res.Database),
+func newRepo(conf *config.Config, res *infra.Resources) (*Repo, error) {
+	switch conf.Database.Driver {
+	case "", config.DriverPostgres, config.DriverSQLite:
+		return &Repo{
+			Backup:  repobackup.New(res.Database),
+			Book:    repobook.New(res.Database),
+			Event:   repoevent.New(res.Database),
+			Link:    repolink.New(res.Database),
+			Outbox:  repooutbox.New(res.Database),
+			RuleSet: repourlprocessor.New(res.Database),
+			Tx:      sqltx.NewManager(res.Database),
+			Webhook: repowebhook.New(res.Database),
+		}, nil
+	case config.DriverMemory:
+		store := memory.NewStore()
+		return &Repo{
+			Book:    memory.NewBookRepository(store),
+			Event:   memory.NewEventRepository(store),
+			Link:    memory.NewLinkRepository(store),
+			Outbox:  memory.NewOutboxRepository(store),
+			RuleSet: memory.NewRuleSetRepository(store),
+			Tx:      store,
+			Webhook: memory.NewWebhookRepository(store),
+		}, nil
 	}
+
+	return nil, fmt.Errorf("unknown database driver %q", conf.Database.Driver)
 }
@@ -1,42 +1,165 @@
 package main
 
 import (
+	"strings"
+	"time"
+
 	_ "booklib/docs"
+	hbackup "booklib/internal/handler/http/backup"
 	hbook "booklib/internal/handler/http/book"
+	hevent "booklib/internal/handler/http/event"
+	hlink "booklib/internal/handler/http/link"
 	hurlprocessor "booklib/internal/handler/http/url-processor"
+	hurlrules "booklib/internal/handler/http/url-rules"
+	hwebhook "booklib/internal/handler/http/webhook"
+	"booklib/internal/infra/config"
 	"booklib/pkg/middleware"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/swagger"
 )
 
-func routes(srv *fiber.App, uc *UseCase) {
+const (
+	defaultIdempotencyTTL   = 24 * time.Hour
+	defaultRestoreBodyLimit = 100 << 20
+
+	restorePath = "/api/v1/admin/restore"
+)
+
+func routes(srv *fiber.App, conf *config.Config, uc *UseCase) {
 	srv.Get("/docs/*", swagger.HandlerDefault)
 
+	// before the access log, which logs whole bodies
+	srv.Use(middleware.BodyLimitMiddleware(bodyLimit(conf), isRestore))
 	srv.Use(middleware.AccessLogMiddleware())
 
 	srv.Get("/ping", func(c *fiber.Ctx) error {
 		return c.SendString("PONG!!")
 	})
 
+	links := hlink.New(uc.Link, conf.Links.BaseURL)
+	srv.Get(hlink.VisitPath+":code", links.VisitLink)
+
 	api := srv.Group("/api")
 	v1 := api.Group("/v1")
+	// registered first so admin requests are authenticated before anything
+	// else, idempotency included, sees them
+	adminRoutes(v1, conf, uc)
+	v1.Use(idempotencyMiddleware(conf))
 
 	bookRoutes(v1, uc)
+	eventRoutes(v1, conf, uc)
 	urlProcessorRoutes(v1, uc)
+	urlRuleRoutes(v1, uc)
+	linkRoutes(v1, links)
+	webhookRoutes(v1, uc)
+}
+
+func linkRoutes(router fiber.Router, handler *hlink.Handler) {
+	router.Post("links", handler.CreateLink)
+	router.Get("links/:code", handler.GetLink)
 }
 
 func urlProcessorRoutes(router fiber.Router, uc *UseCase) {
 	handler := hurlprocessor.New(uc.UrlProcessor)
 
 	router.Post("/process-url", handler.ProcessUrl)
+	router.Post("/process-urls", handler.ProcessUrls)
+	router.Post("/process-document", handler.ProcessDocument)
+}
+
+func urlRuleRoutes(router fiber.Router, uc *UseCase) {
+	handler := hurlrules.New(uc.UrlProcessor)
+
+	// registered before url-rules/:id so "test" is not taken for an id
+	router.Post("url-rules/test", handler.TestRuleSet)
+
+	router.Get("url-rules", handler.GetRuleSets)
+	router.Post("url-rules", handler.AddRuleSet)
+	router.Get("url-rules/:id", handler.GetRuleSet)
+	router.Put("url-rules/:id", handler.UpdateRuleSet)
+	router.Delete("url-rules/:id", handler.DeleteRuleSet)
 }
 
 func bookRoutes(router fiber.Router, uc *UseCase) {
 	handler := hbook.New(uc.Book)
 
 	router.Get("books", handler.GetAllBooks)
+	// registered before books/:id so "duplicates" is not taken for an id
+	router.Get("books/duplicates", handler.FindDuplicates)
 	router.Get("books/:id", handler.GetBook)
 	router.Post("books", handler.AddBook)
 	router.Put("books/:id", handler.UpdateBook)
 	router.Delete("books/:id", handler.DeleteBook)
+	router.Post("books\\:batch", handler.BatchBooks)
+	router.Post("books/:id\\:merge", handler.MergeBooks)
+}
+
+func eventRoutes(router fiber.Router, conf *config.Config, uc *UseCase) {
+	handler := hevent.New(uc.Event, time.Duration(conf.Events.PollInterval)*time.Millisecond)
+
+	router.Get("events/books", handler.StreamBookEvents)
+}
+
+func webhookRoutes(router fiber.Router, uc *UseCase) {
+	handler := hwebhook.New(uc.Webhook)
+
+	// registered before webhooks/:id so "deliveries" is not taken for an id
+	router.Get("webhooks/deliveries", handler.GetDeliveries)
+	router.Post("webhooks/deliveries/:id/retry", handler.RetryDelivery)
+
+	router.Get("webhooks", handler.GetSubscriptions)
+	router.Post("webhooks", handler.AddSubscription)
+	router.Get("webhooks/:id", handler.GetSubscription)
+	router.Put("webhooks/:id", handler.UpdateSubscription)
+	router.Delete("webhooks/:id", handler.DeleteSubscription)
+	router.Get("webhooks/:id/deliveries", handler.GetSubscriptionDeliveries)
+}
+
+// adminRoutes serves backups and restores only when admin.enabled is set,
+// the requests sending admin.token
+func adminRoutes(router fiber.Router, conf *config.Config, uc *UseCase) {
+	if !conf.Admin.Enabled || uc.Backup == nil {
+		return
+	}
+	handler := hbackup.New(uc.Backup)
+
+	admin := router.Group("admin", middleware.AdminTokenMiddleware(conf.Admin.Token))
+	admin.Get("backup", handler.GetBackup)
+	admin.Post("restore", handler.RestoreBackup)
+}
+
+// bodyLimit is the largest request body of every route but the restore.
+func bodyLimit(conf *config.Config) int {
+	if conf.Server.BodyLimit > 0 {
+		return conf.Server.BodyLimit
+	}
+	return fiber.DefaultBodyLimit
+}
+
+// serverBodyLimit is the largest request body the server reads, raised for
+// restores when they are routed.
+func serverBodyLimit(conf *config.Config) int {
+	limit := bodyLimit(conf)
+	if !conf.Admin.Enabled {
+		return limit
+	}
+
+	restore := conf.Admin.RestoreBodyLimit
+	if restore <= 0 {
+		restore = defaultRestoreBodyLimit
+	}
+	return max(limit, restore)
+}
+
+func isRestore(c *fiber.Ctx) bool {
+	return c.Method() == fiber.MethodPost && strings.EqualFold(strings.TrimSuffix(c.Path(), "/"), restorePath)
+}
+
+func idempotencyMiddleware(conf *config.Config) fiber.Handler {
+	ttl := time.Duration(conf.Idempotency.TTL) * time.Second
+	if ttl <= 0 {
+		ttl = defaultIdempotencyTTL
+	}
+
+	return middleware.IdempotencyMiddleware(middleware.NewMemoryIdempotencyStore(), ttl)
 }
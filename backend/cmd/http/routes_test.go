@@ -0,0 +1,143 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	domain "booklib/internal/domain/backup"
+	"booklib/internal/infra/config"
+	"booklib/internal/usecase/backup/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminRoutes(t *testing.T) {
+	tests := []struct {
+		name           string
+		admin          config.AdminConfig
+		noBackup       bool
+		authorization  string
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+	}{
+		{
+			name:           "not routed unless enabled",
+			admin:          config.AdminConfig{Token: "secret"},
+			authorization:  "Bearer secret",
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "not routed when the storage cannot be backed up",
+			admin:          config.AdminConfig{Enabled: true, Token: "secret"},
+			noBackup:       true,
+			authorization:  "Bearer secret",
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "missing token",
+			admin:          config.AdminConfig{Enabled: true, Token: "secret"},
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "wrong token",
+			admin:          config.AdminConfig{Enabled: true, Token: "secret"},
+			authorization:  "Bearer guess",
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "valid token",
+			admin:         config.AdminConfig{Enabled: true, Token: "secret"},
+			authorization: "Bearer secret",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("Backup", mock.Anything, mock.Anything).Return(&domain.Manifest{}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backup := mocks.NewUseCase(t)
+			tt.setupMocks(backup)
+
+			uc := &UseCase{Backup: backup}
+			if tt.noBackup {
+				uc.Backup = nil
+			}
+
+			srv := fiber.New()
+			routes(srv, &config.Config{Admin: tt.admin}, uc)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/backup", nil)
+			if tt.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+
+			resp, err := srv.Test(req)
+			require.NoError(t, err)
+			_, err = io.Copy(io.Discard, resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+
+	t.Run("restore is refused without a token", func(t *testing.T) {
+		srv := fiber.New()
+		routes(srv, &config.Config{Admin: config.AdminConfig{Enabled: true, Token: "secret"}}, &UseCase{Backup: mocks.NewUseCase(t)})
+
+		resp, err := srv.Test(httptest.NewRequest(http.MethodPost, "/api/v1/admin/restore", nil))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestBodyLimits(t *testing.T) {
+	conf := &config.Config{
+		Server: config.Server{BodyLimit: 16},
+		Admin:  config.AdminConfig{Enabled: true, Token: "secret", RestoreBodyLimit: 64},
+	}
+	body := strings.Repeat("a", 32)
+
+	backup := mocks.NewUseCase(t)
+	backup.On("Restore", mock.Anything, mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidArchive).Once()
+
+	srv := fiber.New(fiber.Config{BodyLimit: serverBodyLimit(conf)})
+	routes(srv, conf, &UseCase{Backup: backup})
+
+	t.Run("other routes keep server.body_limit", func(t *testing.T) {
+		resp, err := srv.Test(httptest.NewRequest(http.MethodPost, "/api/v1/process-url", strings.NewReader(body)))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+
+	t.Run("restores take up to admin.restore_body_limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/restore", strings.NewReader(body))
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+
+		resp, err := srv.Test(req)
+		require.NoError(t, err)
+
+		// the archive reached the use case
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("the server limit is raised only when restores are routed", func(t *testing.T) {
+		assert.Equal(t, 64, serverBodyLimit(conf))
+		assert.Equal(t, 16, serverBodyLimit(&config.Config{Server: conf.Server}))
+		assert.Equal(t, fiber.DefaultBodyLimit, serverBodyLimit(&config.Config{}))
+		assert.Equal(t, defaultRestoreBodyLimit, serverBodyLimit(&config.Config{Admin: config.AdminConfig{Enabled: true}}))
+	})
+}
@@ -1,18 +1,97 @@
 package main
 
 import (
+	"fmt"
+	"time"
+
+	"booklib/internal/infra"
+	"booklib/internal/infra/config"
+	"booklib/internal/infra/outbound"
+	"booklib/internal/usecase/backup"
 	"booklib/internal/usecase/book"
+	"booklib/internal/usecase/event"
+	"booklib/internal/usecase/link"
+	"booklib/internal/usecase/outbox"
 	"booklib/internal/usecase/url-processor"
+	"booklib/internal/usecase/webhook"
 )
 
 type UseCase struct {
+	// Backup is nil when the storage cannot be backed up
+	Backup       backup.UseCase
 	Book         book.UseCase
+	Event        event.UseCase
+	Link         link.UseCase
+	Outbox       outbox.UseCase
 	UrlProcessor urlprocessor.UseCase
+	Webhook      webhook.UseCase
 }
 
-func newUseCase(repo *Repo) *UseCase {
-	return &UseCase{
-		Book:         book.New(repo.Book),
-		UrlProcessor: urlprocessor.New(),
+func newUseCase(conf *config.Config, res *infra.Resources, repo *Repo) (*UseCase, error) {
+	guard, err := outbound.New(outbound.Config{
+		Schemes:          conf.Outbound.Schemes,
+		AllowedHosts:     conf.Outbound.AllowedHosts,
+		DeniedHosts:      conf.Outbound.DeniedHosts,
+		AllowedNetworks:  conf.Outbound.AllowedNetworks,
+		MaxResponseBytes: conf.Outbound.MaxResponseBytes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("outbound: %w", err)
+	}
+
+	webhookTimeout := time.Duration(conf.Webhooks.Timeout) * time.Millisecond
+	if webhookTimeout <= 0 {
+		webhookTimeout = webhook.DefaultTimeout
 	}
+	webhookUC := webhook.New(repo.Webhook, webhook.Config{
+		Client:         guard.Client(webhookTimeout),
+		Timeout:        webhookTimeout,
+		MaxAttempts:    conf.Webhooks.MaxAttempts,
+		InitialBackoff: time.Duration(conf.Webhooks.InitialBackoff) * time.Millisecond,
+		MaxBackoff:     time.Duration(conf.Webhooks.MaxBackoff) * time.Millisecond,
+		BatchSize:      conf.Webhooks.BatchSize,
+	})
+
+	publisher, err := newEventPublisher(conf, res, webhookUC)
+	if err != nil {
+		return nil, err
+	}
+
+	urlProcessorUC, err := urlprocessor.New(repo.RuleSet, urlprocessor.Config{
+		DefaultProfile:   conf.URLProcessor.DefaultProfile,
+		Profiles:         conf.URLProcessor.Profiles,
+		TrackingParams:   conf.URLProcessor.TrackingParams,
+		BatchWorkers:     conf.URLProcessor.BatchWorkers,
+		MaxBatchURLs:     conf.URLProcessor.MaxBatchURLs,
+		MaxDocumentLinks: conf.URLProcessor.MaxDocumentLinks,
+		Client:           guard.Client(0),
+		ResolveMaxHops:   conf.URLProcessor.ResolveMaxHops,
+		ResolveTimeout:   time.Duration(conf.URLProcessor.ResolveTimeout) * time.Millisecond,
+		RuleSetsRefresh:  time.Duration(conf.URLProcessor.RuleSetsRefresh) * time.Millisecond,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc := &UseCase{
+		Book:  book.New(repo.Book, repo.Event, repo.Tx),
+		Event: event.New(repo.Event),
+		Link: link.New(repo.Link, urlProcessorUC, link.Config{
+			Profile:   conf.Links.Profile,
+			Operation: conf.Links.Operation,
+		}),
+		Outbox: outbox.New(repo.Outbox, publisher, outbox.Config{
+			BatchSize:      conf.Outbox.BatchSize,
+			Lease:          time.Duration(conf.Outbox.Lease) * time.Millisecond,
+			InitialBackoff: time.Duration(conf.Outbox.InitialBackoff) * time.Millisecond,
+			MaxBackoff:     time.Duration(conf.Outbox.MaxBackoff) * time.Millisecond,
+		}),
+		UrlProcessor: urlProcessorUC,
+		Webhook:      webhookUC,
+	}
+	if repo.Backup != nil {
+		uc.Backup = backup.New(repo.Backup, repo.Tx)
+	}
+
+	return uc, nil
 }
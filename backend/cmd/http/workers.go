@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"booklib/internal/infra/config"
+	"booklib/internal/usecase/outbox"
+	"booklib/internal/usecase/webhook"
+	"github.com/rizanw/go-log"
+)
+
+const (
+	defaultWebhookPollInterval = time.Second
+	defaultOutboxPollInterval  = 500 * time.Millisecond
+)
+
+// startWorkers runs the background jobs of the server until ctx is done.
+func startWorkers(ctx context.Context, conf *config.Config, uc *UseCase) {
+	interval := time.Duration(conf.Webhooks.PollInterval) * time.Millisecond
+	if interval <= 0 {
+		interval = defaultWebhookPollInterval
+	}
+
+	go runWebhookDispatcher(ctx, uc.Webhook, interval)
+
+	relayInterval := time.Duration(conf.Outbox.PollInterval) * time.Millisecond
+	if relayInterval <= 0 {
+		relayInterval = defaultOutboxPollInterval
+	}
+
+	go runOutboxRelay(ctx, uc.Outbox, relayInterval)
+}
+
+// runWebhookDispatcher sends due webhook deliveries, draining the queue before
+// waiting for the next tick.
+func runWebhookDispatcher(ctx context.Context, uc webhook.UseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := uc.DeliverDue(ctx)
+		if err != nil {
+			log.Error(ctx, err, nil, "failed to deliver webhooks")
+		}
+		if err == nil && n > 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOutboxRelay publishes recorded events, draining the outbox before waiting
+// for the next tick.
+func runOutboxRelay(ctx context.Context, uc outbox.UseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := uc.Relay(ctx)
+		if err != nil {
+			log.Error(ctx, err, nil, "failed to relay outbox events")
+		}
+		if err == nil && n > 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -24,9 +24,116 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/backup": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Returns a gzipped tar archive holding a manifest, one JSON Lines file per entity and a\nSHA256SUMS file. Every entity is read in one transaction, so the archive is consistent.",
+                "produces": [
+                    "application/gzip"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Download a backup of the whole library",
+                "responses": {
+                    "200": {
+                        "description": "booklib-\u003ctimestamp\u003e.tar.gz",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/restore": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Replaces every book, event, outbox message and webhook with the content of an archive made by\nGET /admin/backup, in a single transaction. The archive is the raw request body. Archives of an\nolder schema are upgraded; archives of a newer schema are refused. With dry_run=true the archive\nis restored and rolled back, so nothing changes.",
+                "consumes": [
+                    "application/gzip"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Restore the whole library from a backup",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Validate and roll back instead of committing",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/books": {
             "get": {
-                "description": "Returns a list of all books",
+                "description": "Returns a list of all books, oldest first. Use updated_since for incremental sync.",
                 "consumes": [
                     "application/json"
                 ],
@@ -37,6 +144,14 @@ const docTemplate = `{
                     "books"
                 ],
                 "summary": "Get all books",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only books updated at or after this RFC 3339 timestamp",
+                        "name": "updated_since",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -45,6 +160,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -55,7 +177,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new book and returns success status",
+                "description": "Creates a new book and returns the created resource with its location",
                 "consumes": [
                     "application/json"
                 ],
@@ -75,17 +197,93 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/internal_handler_http_book.AddBookRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created book"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/books/duplicates": {
+            "get": {
+                "description": "Groups books that look like the same book. Pairs are scored from 0 to 1 on their normalised title\n(casing, punctuation and a leading article are ignored), author names (name order and initials are\nignored) and year. Only pairs scoring at least min_score are reported, best group first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Find duplicate books",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "default": 0.85,
+                        "description": "Lowest reported score, from 0 to 1",
+                        "name": "min_score",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -143,7 +341,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Updates the details of a book",
+                "description": "Updates the details of a book and returns the updated resource",
                 "consumes": [
                     "application/json"
                 ],
@@ -175,6 +373,13 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -182,8 +387,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -257,9 +462,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/process-url": {
+        "/books/{id}:merge": {
             "post": {
-                "description": "Cleans or modifies a URL based on the specified operation.",
+                "description": "Keeps the book {id} and removes the given duplicates in one transaction. The kept book keeps its\nfields and takes the year of a duplicate when it has none. Every duplicate gets a book.merged event\nwhose after snapshot is the kept book.",
                 "consumes": [
                     "application/json"
                 ],
@@ -267,23 +472,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "URLProcessor"
+                    "books"
                 ],
-                "summary": "Clean and process a URL",
+                "summary": "Merge duplicate books into one",
                 "parameters": [
                     {
-                        "description": "URL processor request payload",
-                        "name": "request",
+                        "type": "string",
+                        "description": "ID of the book to keep",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Duplicates to merge",
+                        "name": "merge",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_handler_http_url-processor.ProcessUrlRequest"
+                            "$ref": "#/definitions/internal_handler_http_book.MergeBooksRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Processed URL returned",
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -291,8 +516,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid input",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -301,7 +526,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -311,47 +536,1706 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "internal_handler_http_book.AddBookRequest": {
-            "type": "object",
-            "properties": {
-                "author": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
-                },
-                "year": {
-                    "type": "integer"
-                }
-            }
-        },
-        "internal_handler_http_book.UpdateBookRequest": {
-            "type": "object",
-            "properties": {
-                "author": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
-                },
-                "year": {
-                    "type": "integer"
-                }
-            }
         },
-        "internal_handler_http_url-processor.ProcessUrlRequest": {
-            "type": "object",
-            "properties": {
-                "operation": {
-                    "type": "string"
-                },
-                "url": {
-                    "type": "string"
-                }
-            }
+        "/books:batch": {
+            "post": {
+                "description": "Applies a list of operations in one transaction (mode=atomic, default) or one by one (mode=best_effort).\nReturns 200 when every operation succeeded, 207 when some best-effort operations failed\nand 422 when an atomic batch was rolled back.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Create, update and delete many books at once",
+                "parameters": [
+                    {
+                        "description": "Operations to apply",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_http_book.BatchBooksRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/events/books": {
+            "get": {
+                "description": "Server-Sent Events stream of book.created, book.updated, book.deleted and book.merged events.\nEach message carries the event id, so a reconnecting client resumes after the last\nevent it saw via the Last-Event-ID header (or the last_event_id query parameter).\nWithout either, only events that happen after connecting are sent.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Stream book changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resume after this event id",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resume after this event id, for clients that cannot set headers",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "text/event-stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/links": {
+            "post": {
+                "description": "Stores the canonical form of the URL, as the URL processor gives it, under a short code redirecting from /l/{code}.\nA URL without alias nor expiry that was already shortened returns the existing link with 200.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "links"
+                ],
+                "summary": "Shorten a URL",
+                "parameters": [
+                    {
+                        "description": "URL to shorten",
+                        "name": "link",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_http_link.CreateLinkRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created link"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/links/{code}": {
+            "get": {
+                "description": "Clicks are counted per UTC day, oldest first; days without clicks are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "links"
+                ],
+                "summary": "Get a short link with its clicks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Link code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/process-document": {
+            "post": {
+                "description": "Runs an operation on every link of the document sent as the body: the href of \u003ca\u003e and \u003clink\u003e and\nthe src of \u003cimg\u003e in HTML, the \u003cloc\u003e elements in sitemap and sitemap index XML. Links the operation\nchanges are rewritten in place and everything else is returned byte for byte as it was sent.\nOnly absolute http and https links are processed; relative, mailto: and other links are skipped.\nThe format is read from the Content-Type, text/html or application/xml, unless given. links\nreports the links changed, and those that failed, which are left as they were. A document with more\ndistinct links than the configured maximum is refused.",
+                "consumes": [
+                    "text/html",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "URLProcessor"
+                ],
+                "summary": "Rewrite the links of an HTML or sitemap document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operation to run",
+                        "name": "operation",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Profile holding the operation, the configured default profile when empty",
+                        "name": "profile",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "html",
+                            "sitemap"
+                        ],
+                        "type": "string",
+                        "description": "Format of the document, read from the Content-Type when empty",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "description": "HTML or sitemap document",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_http_url-processor.ProcessDocumentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/process-url": {
+            "post": {
+                "description": "Cleans or modifies a URL based on the specified operation of a rule profile. Operations are\npipelines of steps configured in config.yaml; the built-in default profile has canonical,\nredirection, all, clean, which only strips tracking params such as utm_* and fbclid, and\nnormalize, which applies RFC 3986 normalisation. removed_params lists the query params the\noperation removed; display_url is given when the host is an internationalised domain name.\nWith explain=true, steps lists every step run with the URL before and after it and the\ncomponents it changed. resolve follows the redirects of the URL and answers with the canonical\nlink of the page reached, chain listing every request made; a URL that cannot be resolved, for\na redirect loop or too many redirects, gets 422. The active URL rule sets matching the host of\nthe URL and defining the operation take precedence over the profile; rule_set names the one applied.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "URLProcessor"
+                ],
+                "summary": "Clean and process a URL",
+                "parameters": [
+                    {
+                        "description": "URL processor request payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_http_url-processor.ProcessUrlRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Explain what every step did",
+                        "name": "explain",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Processed URL returned",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_http_url-processor.ProcessUrlResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "URL cannot be resolved",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/process-urls": {
+            "post": {
+                "description": "Runs an operation on every URL of the body, several at once. The body is a JSON array of URLs\n(Content-Type application/json) or one URL per line (any other content type, such as text/plain\nor application/x-ndjson, where a line may also be a JSON string). Results are in input order;\na URL that cannot be processed gets an error instead of failing the batch. Returns 200 when\nevery URL was processed and 207 otherwise. With stream=true the results are written as\napplication/x-ndjson, one ProcessUrlsResult per line, as soon as they are ready.",
+                "consumes": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "URLProcessor"
+                ],
+                "summary": "Clean and process many URLs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operation to run",
+                        "name": "operation",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Profile holding the operation, the configured default profile when empty",
+                        "name": "profile",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Stream the results as NDJSON",
+                        "name": "stream",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Explain what every step did to every URL",
+                        "name": "explain",
+                        "in": "query"
+                    },
+                    {
+                        "description": "URLs to process",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_http_url-processor.ProcessUrlsResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_http_url-processor.ProcessUrlsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/url-rules": {
+            "get": {
+                "description": "Returns every rule set, active or not, by name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url-rules"
+                ],
+                "summary": "List URL rule sets",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Stores operations scoped to some hosts. They take precedence over the operations of the profiles\nfor the URLs of these hosts, whatever the profile requested: among the active rule sets matching\nthe host and defining the operation, the one of highest priority applies, then the one of most\nspecific host, an exact host before \"*.example.com\" before \"*\".",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url-rules"
+                ],
+                "summary": "Add a URL rule set",
+                "parameters": [
+                    {
+                        "description": "Rule set to create",
+                        "name": "rule_set",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_http_url-rules.RuleSetRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created rule set"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/url-rules/test": {
+            "post": {
+                "description": "Runs an operation on up to 100 sample URLs with the active rule sets, then again with the draft\nrule_set active in place of the stored rule set id, or next to the active ones when id is empty.\nNothing is stored. Every result holds both processed URLs, the URL components the draft\nrewrites otherwise and whether the draft changes the outcome; a URL that cannot be processed\ngets an error on its side instead of failing the request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url-rules"
+                ],
+                "summary": "Try a draft URL rule set",
+                "parameters": [
+                    {
+                        "description": "Draft rule set and sample URLs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_http_url-rules.TestRuleSetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/url-rules/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url-rules"
+                ],
+                "summary": "Get a URL rule set by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule set ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the name, hosts, priority, operations and active flag. The change applies at once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url-rules"
+                ],
+                "summary": "Update a URL rule set",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule set ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated rule set",
+                        "name": "rule_set",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_http_url-rules.RuleSetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes the rule set; the operations of the profiles apply again to its hosts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url-rules"
+                ],
+                "summary": "Delete a URL rule set",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule set ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Returns every webhook subscription, oldest first. Secrets are never returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a URL that receives a signed POST for every matching book event.\nThe X-Booklib-Signature-256 header holds \"sha256=\" followed by the hex HMAC-SHA256 of the body keyed with the secret.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Subscribe to book changes",
+                "parameters": [
+                    {
+                        "description": "Subscription to create",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_http_webhook.SubscriptionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created subscription"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries": {
+            "get": {
+                "description": "Returns deliveries across all subscriptions, newest first. Use status=dead for the dead-letter list.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Webhook delivery log",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "succeeded",
+                            "dead"
+                        ],
+                        "type": "string",
+                        "description": "Only deliveries with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of deliveries (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries/{id}/retry": {
+            "post": {
+                "description": "Moves a delivery from the dead-letter list back to the queue with a fresh set of attempts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Retry a dead webhook delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook subscription by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the URL, event types and active flag. An empty secret keeps the current one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated subscription",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_handler_http_webhook.SubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes the subscription together with its delivery log",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Returns the deliveries of one subscription, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delivery log of a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "succeeded",
+                            "dead"
+                        ],
+                        "type": "string",
+                        "description": "Only deliveries with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of deliveries (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "booklib_internal_domain_url-processor.Step": {
+            "type": "object",
+            "properties": {
+                "host": {
+                    "type": "string"
+                },
+                "operation": {
+                    "type": "string"
+                },
+                "params": {
+                    "description": "Params are query parameter names; ` + "`" + `*` + "`" + ` matches any run of characters,\nas in utm_*. Names are matched case-insensitively.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "policy": {
+                    "type": "string"
+                },
+                "scheme": {
+                    "type": "string"
+                },
+                "sort_query": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_handler_http_book.AddBookRequest": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_handler_http_book.BatchBooksRequest": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "atomic",
+                        "best_effort"
+                    ]
+                },
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_handler_http_book.BatchOperationRequest"
+                    }
+                }
+            }
+        },
+        "internal_handler_http_book.BatchOperationRequest": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete"
+                    ]
+                },
+                "title": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_handler_http_book.MergeBooksRequest": {
+            "type": "object",
+            "properties": {
+                "duplicate_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_handler_http_book.UpdateBookRequest": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_handler_http_link.CreateLinkRequest": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "description": "Alias is the code of the short URL, 3 to 64 letters, digits, '-' or '_'; a random one is generated when empty",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt is when the short URL stops redirecting; it never does when empty",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_handler_http_url-processor.ProcessDocumentLink": {
+            "type": "object",
+            "properties": {
+                "attribute": {
+                    "description": "Attribute holds the link in element, absent for loc",
+                    "type": "string"
+                },
+                "element": {
+                    "description": "Element holds the link: a, link, img or loc",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "processed_url": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_handler_http_url-processor.ProcessDocumentResponse": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "type": "integer"
+                },
+                "document": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "links": {
+                    "description": "Links lists the links the operation changed or failed on, in document order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_handler_http_url-processor.ProcessDocumentLink"
+                    }
+                },
+                "skipped": {
+                    "description": "Skipped counts the links that are not absolute http or https URLs, left as they are",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Total counts the links processed, Changed and Failed those of links",
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_handler_http_url-processor.ProcessUrlHop": {
+            "type": "object",
+            "properties": {
+                "location": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_handler_http_url-processor.ProcessUrlRequest": {
+            "type": "object",
+            "properties": {
+                "explain": {
+                    "description": "Explain adds what every step did to the response, as the explain query parameter does",
+                    "type": "boolean"
+                },
+                "operation": {
+                    "type": "string"
+                },
+                "profile": {
+                    "description": "Profile holds the operation, the configured default profile when empty",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_handler_http_url-processor.ProcessUrlResponse": {
+            "type": "object",
+            "properties": {
+                "canonical_url": {
+                    "description": "CanonicalUrl is the canonical link of the page a resolve step reached",
+                    "type": "string"
+                },
+                "chain": {
+                    "description": "Chain lists the requests made by resolve steps",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_handler_http_url-processor.ProcessUrlHop"
+                    }
+                },
+                "display_url": {
+                    "description": "DisplayUrl is the processed URL with its punycode host in Unicode",
+                    "type": "string"
+                },
+                "processed_url": {
+                    "type": "string"
+                },
+                "removed_params": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rule_set": {
+                    "description": "RuleSet is the stored rule set whose operation ran, absent when the one of the profile did",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_handler_http_url-processor.ProcessUrlRuleSet"
+                        }
+                    ]
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_handler_http_url-processor.ProcessUrlStep"
+                    }
+                }
+            }
+        },
+        "internal_handler_http_url-processor.ProcessUrlRuleSet": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_handler_http_url-processor.ProcessUrlStep": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "string"
+                },
+                "before": {
+                    "type": "string"
+                },
+                "changed": {
+                    "description": "Changed lists the changed components: scheme, userinfo, host, path, query and fragment",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "operation": {
+                    "description": "Operation is the operation defining the step, another one than requested for included operations",
+                    "type": "string"
+                },
+                "removed_params": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_handler_http_url-processor.ProcessUrlsResponse": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_handler_http_url-processor.ProcessUrlsResult"
+                    }
+                }
+            }
+        },
+        "internal_handler_http_url-processor.ProcessUrlsResult": {
+            "type": "object",
+            "properties": {
+                "canonical_url": {
+                    "type": "string"
+                },
+                "chain": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_handler_http_url-processor.ProcessUrlHop"
+                    }
+                },
+                "display_url": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "processed_url": {
+                    "type": "string"
+                },
+                "removed_params": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rule_set": {
+                    "$ref": "#/definitions/internal_handler_http_url-processor.ProcessUrlRuleSet"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_handler_http_url-processor.ProcessUrlStep"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_handler_http_url-rules.RuleSetRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Active defaults to true; inactive rule sets are drafts and never apply",
+                    "type": "boolean"
+                },
+                "hosts": {
+                    "description": "Hosts are the hosts the rule set applies to: \"example.com\", \"*.example.com\" for its subdomains or \"*\" for any host",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "operations": {
+                    "description": "Operations are pipelines of steps, as the operations of the profiles in config.yaml",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/booklib_internal_domain_url-processor.Step"
+                        }
+                    }
+                },
+                "priority": {
+                    "description": "Priority orders the rule sets matching a URL, highest first; at equal priority the most specific host wins",
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_handler_http_url-rules.TestRuleSetRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID is the stored rule set the draft replaces; the draft is added to the active ones when empty",
+                    "type": "string"
+                },
+                "operation": {
+                    "type": "string"
+                },
+                "profile": {
+                    "description": "Profile holds the operation, the configured default profile when empty",
+                    "type": "string"
+                },
+                "rule_set": {
+                    "description": "RuleSet is the draft, tested as if active; its name defaults to \"draft\"",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_handler_http_url-rules.RuleSetRequest"
+                        }
+                    ]
+                },
+                "urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_handler_http_webhook.SubscriptionRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Active defaults to true",
+                    "type": "boolean"
+                },
+                "event_types": {
+                    "description": "EventTypes limits the events sent to URL; empty means every event",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "book.created",
+                            "book.updated",
+                            "book.deleted",
+                            "book.merged"
+                        ]
+                    }
+                },
+                "secret": {
+                    "description": "Secret signs every delivery; it is required on creation and kept when empty on update",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "description": "\"Bearer \u003cadmin.token\u003e\", for the admin endpoints served when admin.enabled is set",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
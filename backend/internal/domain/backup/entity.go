@@ -0,0 +1,161 @@
+package backup
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	// FormatVersion is the layout of the archive itself. Readers refuse
+	// archives of a newer format. Version 2 added the short links, version 3
+	// the url rule sets.
+	FormatVersion = 3
+
+	ManifestFile  = "manifest.json"
+	ChecksumsFile = "SHA256SUMS"
+
+	EntityBooks                = "books"
+	EntityEvents               = "events"
+	EntityOutbox               = "outbox"
+	EntityWebhookSubscriptions = "webhook_subscriptions"
+	EntityWebhookDeliveries    = "webhook_deliveries"
+	EntityLinks                = "links"
+	EntityLinkClicks           = "link_clicks"
+	EntityURLRuleSets          = "url_rule_sets"
+)
+
+// Entities lists every entity of an archive in restore order, parents before
+// the records referencing them.
+var Entities = []string{
+	EntityBooks,
+	EntityEvents,
+	EntityOutbox,
+	EntityWebhookSubscriptions,
+	EntityWebhookDeliveries,
+	EntityLinks,
+	EntityLinkClicks,
+	EntityURLRuleSets,
+}
+
+// Manifest describes an archive. It is the first file of the archive and is
+// validated before anything is restored.
+type Manifest struct {
+	FormatVersion int `json:"format_version"`
+	// SchemaVersion is the newest migration applied to the database the
+	// archive was taken from
+	SchemaVersion string       `json:"schema_version"`
+	CreatedAt     time.Time    `json:"created_at"`
+	Entities      []EntityFile `json:"entities"`
+}
+
+// EntityFile is the JSON Lines file holding the records of one entity.
+type EntityFile struct {
+	Name    string `json:"name"`
+	File    string `json:"file"`
+	Records int    `json:"records"`
+	SHA256  string `json:"sha256"`
+}
+
+// Entity returns the file of the named entity.
+func (m *Manifest) Entity(name string) (EntityFile, bool) {
+	for _, ef := range m.Entities {
+		if ef.Name == name {
+			return ef, true
+		}
+	}
+	return EntityFile{}, false
+}
+
+// The records below are the rows of the tables, secrets and bookkeeping
+// columns included, so a restore gives back exactly what was backed up.
+
+type Book struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Author    string    `json:"author"`
+	Year      int       `json:"year"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Event struct {
+	ID            int64           `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	Before        json.RawMessage `json:"before,omitempty"`
+	After         json.RawMessage `json:"after,omitempty"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+}
+
+type OutboxMessage struct {
+	ID            int64      `json:"id"`
+	EventID       int64      `json:"event_id"`
+	AggregateType string     `json:"aggregate_type"`
+	AggregateID   string     `json:"aggregate_id"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastError     string     `json:"last_error"`
+	CreatedAt     time.Time  `json:"created_at"`
+	PublishedAt   *time.Time `json:"published_at"`
+}
+
+type Subscription struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Secret     string    `json:"secret"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type Delivery struct {
+	ID             int64           `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	EventID        int64           `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastError      string          `json:"last_error"`
+	ResponseStatus int             `json:"response_status"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+type Link struct {
+	Code      string     `json:"code"`
+	TargetURL string     `json:"target_url"`
+	Custom    bool       `json:"custom"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// LinkClicks counts the clicks of a link on one UTC day.
+type LinkClicks struct {
+	Code   string    `json:"code"`
+	Day    time.Time `json:"day"`
+	Clicks int64     `json:"clicks"`
+}
+
+// LinkClicksKey is the primary key of LinkClicks. The zero key comes before
+// every record.
+type LinkClicksKey struct {
+	Code string
+	Day  time.Time
+}
+
+type RuleSet struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Hosts    []string `json:"hosts"`
+	Priority int      `json:"priority"`
+	// Operations is stored as it is, it is only validated when the rule sets
+	// are loaded
+	Operations json.RawMessage `json:"operations"`
+	Active     bool            `json:"active"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
@@ -0,0 +1,11 @@
+package backup
+
+import "errors"
+
+var (
+	// ErrInvalidArchive wraps every reason an archive is refused: a corrupt
+	// file, a checksum mismatch or a manifest this build cannot restore.
+	ErrInvalidArchive = errors.New("invalid backup archive")
+	// ErrNoSchemaVersion is returned for databases without migration history.
+	ErrNoSchemaVersion = errors.New("the database has no applied migrations, run `booklib migrate up` or `booklib migrate baseline` first")
+)
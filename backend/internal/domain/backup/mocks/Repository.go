@@ -0,0 +1,495 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	backup "booklib/internal/domain/backup"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// AddBooks provides a mock function with given fields: ctx, books
+func (_m *Repository) AddBooks(ctx context.Context, books []backup.Book) error {
+	ret := _m.Called(ctx, books)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBooks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []backup.Book) error); ok {
+		r0 = rf(ctx, books)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddDeliveries provides a mock function with given fields: ctx, deliveries
+func (_m *Repository) AddDeliveries(ctx context.Context, deliveries []backup.Delivery) error {
+	ret := _m.Called(ctx, deliveries)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDeliveries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []backup.Delivery) error); ok {
+		r0 = rf(ctx, deliveries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddEvents provides a mock function with given fields: ctx, events
+func (_m *Repository) AddEvents(ctx context.Context, events []backup.Event) error {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []backup.Event) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddLinkClicks provides a mock function with given fields: ctx, clicks
+func (_m *Repository) AddLinkClicks(ctx context.Context, clicks []backup.LinkClicks) error {
+	ret := _m.Called(ctx, clicks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddLinkClicks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []backup.LinkClicks) error); ok {
+		r0 = rf(ctx, clicks)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddLinks provides a mock function with given fields: ctx, links
+func (_m *Repository) AddLinks(ctx context.Context, links []backup.Link) error {
+	ret := _m.Called(ctx, links)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddLinks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []backup.Link) error); ok {
+		r0 = rf(ctx, links)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddOutboxMessages provides a mock function with given fields: ctx, messages
+func (_m *Repository) AddOutboxMessages(ctx context.Context, messages []backup.OutboxMessage) error {
+	ret := _m.Called(ctx, messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddOutboxMessages")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []backup.OutboxMessage) error); ok {
+		r0 = rf(ctx, messages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddRuleSets provides a mock function with given fields: ctx, ruleSets
+func (_m *Repository) AddRuleSets(ctx context.Context, ruleSets []backup.RuleSet) error {
+	ret := _m.Called(ctx, ruleSets)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddRuleSets")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []backup.RuleSet) error); ok {
+		r0 = rf(ctx, ruleSets)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddSubscriptions provides a mock function with given fields: ctx, subs
+func (_m *Repository) AddSubscriptions(ctx context.Context, subs []backup.Subscription) error {
+	ret := _m.Called(ctx, subs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSubscriptions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []backup.Subscription) error); ok {
+		r0 = rf(ctx, subs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Clear provides a mock function with given fields: ctx
+func (_m *Repository) Clear(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Clear")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetBooks provides a mock function with given fields: ctx, after, limit
+func (_m *Repository) GetBooks(ctx context.Context, after string, limit int) ([]backup.Book, error) {
+	ret := _m.Called(ctx, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBooks")
+	}
+
+	var r0 []backup.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]backup.Book, error)); ok {
+		return rf(ctx, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []backup.Book); ok {
+		r0 = rf(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]backup.Book)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, after, limit
+func (_m *Repository) GetDeliveries(ctx context.Context, after int64, limit int) ([]backup.Delivery, error) {
+	ret := _m.Called(ctx, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveries")
+	}
+
+	var r0 []backup.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]backup.Delivery, error)); ok {
+		return rf(ctx, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []backup.Delivery); ok {
+		r0 = rf(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]backup.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEvents provides a mock function with given fields: ctx, after, limit
+func (_m *Repository) GetEvents(ctx context.Context, after int64, limit int) ([]backup.Event, error) {
+	ret := _m.Called(ctx, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEvents")
+	}
+
+	var r0 []backup.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]backup.Event, error)); ok {
+		return rf(ctx, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []backup.Event); ok {
+		r0 = rf(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]backup.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLinkClicks provides a mock function with given fields: ctx, after, limit
+func (_m *Repository) GetLinkClicks(ctx context.Context, after backup.LinkClicksKey, limit int) ([]backup.LinkClicks, error) {
+	ret := _m.Called(ctx, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLinkClicks")
+	}
+
+	var r0 []backup.LinkClicks
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, backup.LinkClicksKey, int) ([]backup.LinkClicks, error)); ok {
+		return rf(ctx, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, backup.LinkClicksKey, int) []backup.LinkClicks); ok {
+		r0 = rf(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]backup.LinkClicks)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, backup.LinkClicksKey, int) error); ok {
+		r1 = rf(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLinks provides a mock function with given fields: ctx, after, limit
+func (_m *Repository) GetLinks(ctx context.Context, after string, limit int) ([]backup.Link, error) {
+	ret := _m.Called(ctx, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLinks")
+	}
+
+	var r0 []backup.Link
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]backup.Link, error)); ok {
+		return rf(ctx, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []backup.Link); ok {
+		r0 = rf(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]backup.Link)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOutboxMessages provides a mock function with given fields: ctx, after, limit
+func (_m *Repository) GetOutboxMessages(ctx context.Context, after int64, limit int) ([]backup.OutboxMessage, error) {
+	ret := _m.Called(ctx, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOutboxMessages")
+	}
+
+	var r0 []backup.OutboxMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]backup.OutboxMessage, error)); ok {
+		return rf(ctx, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []backup.OutboxMessage); ok {
+		r0 = rf(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]backup.OutboxMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRuleSets provides a mock function with given fields: ctx, after, limit
+func (_m *Repository) GetRuleSets(ctx context.Context, after string, limit int) ([]backup.RuleSet, error) {
+	ret := _m.Called(ctx, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRuleSets")
+	}
+
+	var r0 []backup.RuleSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]backup.RuleSet, error)); ok {
+		return rf(ctx, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []backup.RuleSet); ok {
+		r0 = rf(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]backup.RuleSet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubscriptions provides a mock function with given fields: ctx, after, limit
+func (_m *Repository) GetSubscriptions(ctx context.Context, after string, limit int) ([]backup.Subscription, error) {
+	ret := _m.Called(ctx, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptions")
+	}
+
+	var r0 []backup.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]backup.Subscription, error)); ok {
+		return rf(ctx, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []backup.Subscription); ok {
+		r0 = rf(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]backup.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetSequences provides a mock function with given fields: ctx
+func (_m *Repository) ResetSequences(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetSequences")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SchemaVersion provides a mock function with given fields: ctx
+func (_m *Repository) SchemaVersion(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SchemaVersion")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Snapshot provides a mock function with given fields: ctx
+func (_m *Repository) Snapshot(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Snapshot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
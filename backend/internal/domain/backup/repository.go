@@ -0,0 +1,43 @@
+package backup
+
+import "context"
+
+// Repository reads and writes whole tables for backups. Methods join the
+// transaction carried by ctx, see transaction.Manager.
+//
+//go:generate mockery --name=Repository --output=./mocks
+type Repository interface {
+	// SchemaVersion returns the newest migration applied to the database, or
+	// ErrNoSchemaVersion.
+	SchemaVersion(ctx context.Context) (string, error)
+	// Snapshot makes the transaction carried by ctx read every entity as of
+	// the same moment. It must run before anything else in the transaction.
+	Snapshot(ctx context.Context) error
+
+	// The Get methods page through an entity in primary key order and return
+	// up to limit records with a key greater than after.
+	GetBooks(ctx context.Context, after string, limit int) ([]Book, error)
+	GetEvents(ctx context.Context, after int64, limit int) ([]Event, error)
+	GetOutboxMessages(ctx context.Context, after int64, limit int) ([]OutboxMessage, error)
+	GetSubscriptions(ctx context.Context, after string, limit int) ([]Subscription, error)
+	GetDeliveries(ctx context.Context, after int64, limit int) ([]Delivery, error)
+	GetLinks(ctx context.Context, after string, limit int) ([]Link, error)
+	GetLinkClicks(ctx context.Context, after LinkClicksKey, limit int) ([]LinkClicks, error)
+	GetRuleSets(ctx context.Context, after string, limit int) ([]RuleSet, error)
+
+	// Clear deletes the records of every entity.
+	Clear(ctx context.Context) error
+
+	// The Add methods insert records as they are, ids and timestamps included.
+	AddBooks(ctx context.Context, books []Book) error
+	AddEvents(ctx context.Context, events []Event) error
+	AddOutboxMessages(ctx context.Context, messages []OutboxMessage) error
+	AddSubscriptions(ctx context.Context, subs []Subscription) error
+	AddDeliveries(ctx context.Context, deliveries []Delivery) error
+	AddLinks(ctx context.Context, links []Link) error
+	AddLinkClicks(ctx context.Context, clicks []LinkClicks) error
+	AddRuleSets(ctx context.Context, ruleSets []RuleSet) error
+
+	// ResetSequences moves generated ids past the largest restored id.
+	ResetSequences(ctx context.Context) error
+}
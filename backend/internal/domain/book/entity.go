@@ -2,6 +2,9 @@ package book
 
 import (
 	"errors"
+	"fmt"
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -10,20 +13,54 @@ type Book struct {
 	Title  string `json:"title"`
 	Author string `json:"author"`
 	Year   int    `json:"year"`
+	// CreatedAt and UpdatedAt are managed by the repository
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
-func NewBook(title, author string, year int) (*Book, error) {
-	if title == "" {
-		return nil, errors.New("title cannot be empty")
-	}
-	if author == "" {
-		return nil, errors.New("author cannot be empty")
-	}
+// Filter narrows down GetAllBooks. Zero values mean no filtering.
+type Filter struct {
+	// UpdatedSince keeps books updated at or after the given time
+	UpdatedSince *time.Time
+}
 
-	return &Book{
+func NewBook(title, author string, year int) (*Book, error) {
+	bk := &Book{
 		ID:     uuid.NewString(),
 		Title:  title,
 		Author: author,
 		Year:   year,
-	}, nil
+	}
+	if err := bk.Validate(); err != nil {
+		return nil, err
+	}
+
+	return bk, nil
+}
+
+// ParseID checks that id has the form of a book id and returns it in the
+// lowercase form the storage returns, so it can be compared with stored ids.
+// Postgres refuses any other value when casting it to uuid, failing the whole
+// statement.
+func ParseID(id string) (string, error) {
+	if id == "" {
+		return "", errors.New("id cannot be empty")
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil || len(id) != len(uuid.Nil.String()) {
+		return "", fmt.Errorf("id %q is not a valid UUID", id)
+	}
+
+	return parsed.String(), nil
+}
+
+func (b *Book) Validate() error {
+	if b.Title == "" {
+		return errors.New("title cannot be empty")
+	}
+	if b.Author == "" {
+		return errors.New("author cannot be empty")
+	}
+
+	return nil
 }
@@ -0,0 +1,7 @@
+package book
+
+import "errors"
+
+var (
+	ErrNotFound = errors.New("book not found")
+)
@@ -15,21 +15,63 @@ type Repository struct {
 }
 
 // AddBook provides a mock function with given fields: ctx, _a1
-func (_m *Repository) AddBook(ctx context.Context, _a1 *book.Book) error {
+func (_m *Repository) AddBook(ctx context.Context, _a1 *book.Book) (*book.Book, error) {
 	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddBook")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *book.Book) error); ok {
+	var r0 *book.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *book.Book) (*book.Book, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *book.Book) *book.Book); ok {
 		r0 = rf(ctx, _a1)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*book.Book)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *book.Book) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddBooks provides a mock function with given fields: ctx, books
+func (_m *Repository) AddBooks(ctx context.Context, books []*book.Book) ([]book.Book, error) {
+	ret := _m.Called(ctx, books)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBooks")
+	}
+
+	var r0 []book.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*book.Book) ([]book.Book, error)); ok {
+		return rf(ctx, books)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*book.Book) []book.Book); ok {
+		r0 = rf(ctx, books)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]book.Book)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*book.Book) error); ok {
+		r1 = rf(ctx, books)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // DeleteBook provides a mock function with given fields: ctx, id
@@ -50,9 +92,39 @@ func (_m *Repository) DeleteBook(ctx context.Context, id string) error {
 	return r0
 }
 
-// GetAllBooks provides a mock function with given fields: ctx
-func (_m *Repository) GetAllBooks(ctx context.Context) ([]book.Book, error) {
-	ret := _m.Called(ctx)
+// DeleteBooks provides a mock function with given fields: ctx, ids
+func (_m *Repository) DeleteBooks(ctx context.Context, ids []string) ([]string, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBooks")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]string, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []string); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllBooks provides a mock function with given fields: ctx, filter
+func (_m *Repository) GetAllBooks(ctx context.Context, filter book.Filter) ([]book.Book, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllBooks")
@@ -60,19 +132,19 @@ func (_m *Repository) GetAllBooks(ctx context.Context) ([]book.Book, error) {
 
 	var r0 []book.Book
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]book.Book, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, book.Filter) ([]book.Book, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []book.Book); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, book.Filter) []book.Book); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]book.Book)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, book.Filter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -110,22 +182,124 @@ func (_m *Repository) GetBookByID(ctx context.Context, id string) (*book.Book, e
 	return r0, r1
 }
 
+// GetBooksByIDs provides a mock function with given fields: ctx, ids
+func (_m *Repository) GetBooksByIDs(ctx context.Context, ids []string) ([]book.Book, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBooksByIDs")
+	}
+
+	var r0 []book.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]book.Book, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []book.Book); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]book.Book)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBooksByIDsForUpdate provides a mock function with given fields: ctx, ids
+func (_m *Repository) GetBooksByIDsForUpdate(ctx context.Context, ids []string) ([]book.Book, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBooksByIDsForUpdate")
+	}
+
+	var r0 []book.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]book.Book, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []book.Book); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]book.Book)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateBook provides a mock function with given fields: ctx, _a1
-func (_m *Repository) UpdateBook(ctx context.Context, _a1 *book.Book) error {
+func (_m *Repository) UpdateBook(ctx context.Context, _a1 *book.Book) (*book.Book, error) {
 	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateBook")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *book.Book) error); ok {
+	var r0 *book.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *book.Book) (*book.Book, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *book.Book) *book.Book); ok {
 		r0 = rf(ctx, _a1)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*book.Book)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *book.Book) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateBooks provides a mock function with given fields: ctx, books
+func (_m *Repository) UpdateBooks(ctx context.Context, books []*book.Book) ([]book.Book, error) {
+	ret := _m.Called(ctx, books)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBooks")
+	}
+
+	var r0 []book.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*book.Book) ([]book.Book, error)); ok {
+		return rf(ctx, books)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*book.Book) []book.Book); ok {
+		r0 = rf(ctx, books)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]book.Book)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*book.Book) error); ok {
+		r1 = rf(ctx, books)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -2,11 +2,25 @@ package book
 
 import "context"
 
+// Repository methods join the transaction carried by ctx, see
+// transaction.Manager.
+//
 //go:generate mockery --name=Repository --output=./mocks
 type Repository interface {
-	AddBook(ctx context.Context, book *Book) error
-	GetAllBooks(ctx context.Context) ([]Book, error)
+	AddBook(ctx context.Context, book *Book) (*Book, error)
+	GetAllBooks(ctx context.Context, filter Filter) ([]Book, error)
 	GetBookByID(ctx context.Context, id string) (*Book, error)
-	UpdateBook(ctx context.Context, book *Book) error
+	GetBooksByIDs(ctx context.Context, ids []string) ([]Book, error)
+	// GetBooksByIDsForUpdate is GetBooksByIDs locking the rows until the
+	// transaction carried by ctx ends, so they are read as they are written.
+	GetBooksByIDsForUpdate(ctx context.Context, ids []string) ([]Book, error)
+	UpdateBook(ctx context.Context, book *Book) (*Book, error)
 	DeleteBook(ctx context.Context, id string) error
+
+	// AddBooks inserts all books and returns the persisted rows.
+	AddBooks(ctx context.Context, books []*Book) ([]Book, error)
+	// UpdateBooks updates all books and returns the rows that existed.
+	UpdateBooks(ctx context.Context, books []*Book) ([]Book, error)
+	// DeleteBooks deletes the books and returns the ids that existed.
+	DeleteBooks(ctx context.Context, ids []string) ([]string, error)
 }
@@ -0,0 +1,62 @@
+package event
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+const (
+	AggregateBook = "book"
+
+	TypeBookCreated = "book.created"
+	TypeBookUpdated = "book.updated"
+	TypeBookDeleted = "book.deleted"
+	// TypeBookMerged removes a duplicate book; After is the book it was
+	// merged into.
+	TypeBookMerged = "book.merged"
+)
+
+// Event is an immutable record of a change to an aggregate. Before is empty
+// for creations and After is empty for deletions.
+type Event struct {
+	ID            int64           `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	Before        json.RawMessage `json:"before,omitempty"`
+	After         json.RawMessage `json:"after,omitempty"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+}
+
+// NewEvent builds an event with JSON snapshots of the aggregate. Nil snapshots
+// are left empty.
+func NewEvent(eventType, aggregateType, aggregateID string, before, after interface{}) (*Event, error) {
+	ev := &Event{
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		OccurredAt:    time.Now(),
+	}
+
+	var err error
+	if ev.Before, err = snapshot(before); err != nil {
+		return nil, err
+	}
+	if ev.After, err = snapshot(after); err != nil {
+		return nil, err
+	}
+
+	return ev, nil
+}
+
+func snapshot(v interface{}) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil, nil
+	}
+
+	return json.Marshal(v)
+}
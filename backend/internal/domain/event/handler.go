@@ -0,0 +1,12 @@
+package event
+
+import "context"
+
+// Handler reacts to events once they have been published, e.g. by notifying
+// external systems. Delivery is at least once, so an event may be handled
+// more than once.
+//
+//go:generate mockery --name=Handler --output=./mocks
+type Handler interface {
+	HandleEvents(ctx context.Context, events []*Event) error
+}
@@ -0,0 +1,105 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	event "booklib/internal/domain/event"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// AddEvents provides a mock function with given fields: ctx, events
+func (_m *Repository) AddEvents(ctx context.Context, events []*event.Event) error {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*event.Event) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetEventsAfter provides a mock function with given fields: ctx, aggregateType, afterID, limit
+func (_m *Repository) GetEventsAfter(ctx context.Context, aggregateType string, afterID int64, limit int) ([]event.Event, error) {
+	ret := _m.Called(ctx, aggregateType, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsAfter")
+	}
+
+	var r0 []event.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int) ([]event.Event, error)); ok {
+		return rf(ctx, aggregateType, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int) []event.Event); ok {
+		r0 = rf(ctx, aggregateType, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]event.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int) error); ok {
+		r1 = rf(ctx, aggregateType, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestEventID provides a mock function with given fields: ctx, aggregateType
+func (_m *Repository) GetLatestEventID(ctx context.Context, aggregateType string) (int64, error) {
+	ret := _m.Called(ctx, aggregateType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestEventID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, aggregateType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, aggregateType)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, aggregateType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,19 @@
+package event
+
+import "context"
+
+//go:generate mockery --name=Repository --output=./mocks
+type Repository interface {
+	// AddEvents appends events to the log, queues them in the outbox for
+	// publication and sets their IDs. Pass the ctx of the transaction that made
+	// the change so the events are committed, or lost, together with it.
+	AddEvents(ctx context.Context, events []*Event) error
+	// GetEventsAfter returns up to limit events of an aggregate type that come
+	// after the event afterID, in the order they were committed, 0 reading from
+	// the start. IDs may not increase in that order; an event is only returned
+	// once no event before it can still be committed.
+	GetEventsAfter(ctx context.Context, aggregateType string, afterID int64, limit int) ([]Event, error)
+	// GetLatestEventID returns the last event GetEventsAfter would return,
+	// to read only the events committed from then on.
+	GetLatestEventID(ctx context.Context, aggregateType string) (int64, error)
+}
@@ -0,0 +1,32 @@
+package link
+
+import "time"
+
+// Link is a short code redirecting to a target URL.
+type Link struct {
+	Code string `json:"code"`
+	// TargetURL is canonicalised by the URL processor before it is stored
+	TargetURL string `json:"target_url"`
+	// Custom is set for codes chosen by the creator rather than generated
+	Custom bool `json:"custom"`
+	// ExpiresAt is nil for links that never expire
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// DailyClicks counts the redirects of a link on one UTC day.
+type DailyClicks struct {
+	Day    time.Time `json:"day"`
+	Clicks int64     `json:"clicks"`
+}
+
+// Expired reports whether the link no longer redirects at now.
+func (l *Link) Expired(now time.Time) bool {
+	return l.ExpiresAt != nil && !now.Before(*l.ExpiresAt)
+}
+
+// Shared reports whether the link is the one given to everyone shortening
+// its target: generated and never expiring.
+func (l *Link) Shared() bool {
+	return !l.Custom && l.ExpiresAt == nil
+}
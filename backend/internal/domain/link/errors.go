@@ -0,0 +1,14 @@
+package link
+
+import "errors"
+
+var (
+	ErrNotFound = errors.New("link not found")
+	ErrExpired  = errors.New("link has expired")
+	// ErrCodeTaken is returned when a custom alias is used by another link.
+	ErrCodeTaken = errors.New("link code is already taken")
+
+	ErrInvalidURL    = errors.New("url must be an absolute http or https URL")
+	ErrInvalidAlias  = errors.New("alias must be 3 to 64 letters, digits, '-' or '_'")
+	ErrInvalidExpiry = errors.New("expires_at must be in the future")
+)
@@ -0,0 +1,169 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	link "booklib/internal/domain/link"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// AddClick provides a mock function with given fields: ctx, code, day
+func (_m *Repository) AddClick(ctx context.Context, code string, day time.Time) error {
+	ret := _m.Called(ctx, code, day)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddClick")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, code, day)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddLink provides a mock function with given fields: ctx, l
+func (_m *Repository) AddLink(ctx context.Context, l *link.Link) (*link.Link, error) {
+	ret := _m.Called(ctx, l)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddLink")
+	}
+
+	var r0 *link.Link
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *link.Link) (*link.Link, error)); ok {
+		return rf(ctx, l)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *link.Link) *link.Link); ok {
+		r0 = rf(ctx, l)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*link.Link)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *link.Link) error); ok {
+		r1 = rf(ctx, l)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClicks provides a mock function with given fields: ctx, code
+func (_m *Repository) GetClicks(ctx context.Context, code string) ([]link.DailyClicks, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClicks")
+	}
+
+	var r0 []link.DailyClicks
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]link.DailyClicks, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []link.DailyClicks); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]link.DailyClicks)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLinkByCode provides a mock function with given fields: ctx, code
+func (_m *Repository) GetLinkByCode(ctx context.Context, code string) (*link.Link, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLinkByCode")
+	}
+
+	var r0 *link.Link
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*link.Link, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *link.Link); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*link.Link)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSharedLink provides a mock function with given fields: ctx, target
+func (_m *Repository) GetSharedLink(ctx context.Context, target string) (*link.Link, error) {
+	ret := _m.Called(ctx, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSharedLink")
+	}
+
+	var r0 *link.Link
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*link.Link, error)); ok {
+		return rf(ctx, target)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *link.Link); ok {
+		r0 = rf(ctx, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*link.Link)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,21 @@
+package link
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockery --name=Repository --output=./mocks
+type Repository interface {
+	// AddLink stores l and returns nil, nil when its code is taken or, for
+	// a shared link, when its target already has one.
+	AddLink(ctx context.Context, l *Link) (*Link, error)
+	GetLinkByCode(ctx context.Context, code string) (*Link, error)
+	// GetSharedLink returns the shared link of target, nil when it has none.
+	GetSharedLink(ctx context.Context, target string) (*Link, error)
+
+	// AddClick counts one redirect of the link on day.
+	AddClick(ctx context.Context, code string, day time.Time) error
+	// GetClicks returns the daily clicks of a link, oldest day first.
+	GetClicks(ctx context.Context, code string) ([]DailyClicks, error)
+}
@@ -0,0 +1,10 @@
+package outbox
+
+import "booklib/internal/domain/event"
+
+// Message is an event queued in the outbox, waiting to be published.
+type Message struct {
+	ID       int64
+	Attempts int
+	Event    event.Event
+}
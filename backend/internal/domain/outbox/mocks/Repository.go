@@ -0,0 +1,97 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	outbox "booklib/internal/domain/outbox"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// ClaimPending provides a mock function with given fields: ctx, now, leaseUntil, limit
+func (_m *Repository) ClaimPending(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]outbox.Message, error) {
+	ret := _m.Called(ctx, now, leaseUntil, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimPending")
+	}
+
+	var r0 []outbox.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) ([]outbox.Message, error)); ok {
+		return rf(ctx, now, leaseUntil, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) []outbox.Message); ok {
+		r0 = rf(ctx, now, leaseUntil, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]outbox.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, now, leaseUntil, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkFailed provides a mock function with given fields: ctx, id, lastError, retryAt
+func (_m *Repository) MarkFailed(ctx context.Context, id int64, lastError string, retryAt time.Time) error {
+	ret := _m.Called(ctx, id, lastError, retryAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkFailed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, time.Time) error); ok {
+		r0 = rf(ctx, id, lastError, retryAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkPublished provides a mock function with given fields: ctx, ids, at
+func (_m *Repository) MarkPublished(ctx context.Context, ids []int64, at time.Time) error {
+	ret := _m.Called(ctx, ids, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkPublished")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, time.Time) error); ok {
+		r0 = rf(ctx, ids, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,14 @@
+package outbox
+
+import (
+	"booklib/internal/domain/event"
+	"context"
+)
+
+// EventPublisher hands an event to its consumers. The relay retries an event
+// until Publish succeeds, so consumers may see it more than once.
+//
+//go:generate mockery --name=EventPublisher --output=./mocks
+type EventPublisher interface {
+	Publish(ctx context.Context, ev *event.Event) error
+}
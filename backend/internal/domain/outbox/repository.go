@@ -0,0 +1,18 @@
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockery --name=Repository --output=./mocks
+type Repository interface {
+	// ClaimPending returns up to limit unpublished messages due at now, oldest
+	// first, and holds them until leaseUntil. Only the oldest unpublished
+	// message of an aggregate is ever returned, so the events of an aggregate
+	// are published in order.
+	ClaimPending(ctx context.Context, now, leaseUntil time.Time, limit int) ([]Message, error)
+	MarkPublished(ctx context.Context, ids []int64, at time.Time) error
+	// MarkFailed records a failed attempt and holds the message until retryAt.
+	MarkFailed(ctx context.Context, id int64, lastError string, retryAt time.Time) error
+}
@@ -0,0 +1,15 @@
+package transaction
+
+import "context"
+
+// Manager runs a unit of work in a single transaction.
+//
+//go:generate mockery --name=Manager --output=./mocks
+type Manager interface {
+	// WithinTx runs fn in a transaction carried by the ctx passed to fn, so
+	// every repository called with that ctx takes part in it. The transaction
+	// commits when fn returns nil and rolls back when it returns an error or
+	// panics. A nested call runs in a savepoint of the outer transaction, so
+	// its failure only undoes its own work.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,114 @@
+package urlprocessor
+
+// Step types. Each step changes one part of the URL; the fields of Step it
+// reads are given next to it.
+const (
+	// StepSetHost replaces the host, port included, with Host.
+	StepSetHost = "set_host"
+	// StepForceScheme replaces the scheme with Scheme.
+	StepForceScheme = "force_scheme"
+	// StepLowercasePath lowercases the path.
+	StepLowercasePath = "lowercase_path"
+	// StepStripQuery removes the query parameters matching Params, or the
+	// whole query when Params is empty.
+	StepStripQuery = "strip_query"
+	// StepKeepQuery removes the query parameters not matching Params.
+	StepKeepQuery = "keep_query"
+	// StepStripTracking removes the query parameters matching TrackingParams,
+	// the configured tracking params or Params.
+	StepStripTracking = "strip_tracking"
+	// StepTrailingSlash applies the trailing slash Policy to the path.
+	StepTrailingSlash = "trailing_slash"
+	// StepNormalize applies the RFC 3986 normalisations that keep the URL
+	// pointing to the same resource, and sorts the query params by name when
+	// SortQuery is set.
+	StepNormalize = "normalize"
+	// StepResolve follows the HTTP redirects of the URL and replaces it with
+	// the canonical link of the page reached, or the page's URL when it has
+	// none.
+	StepResolve = "resolve"
+	// StepOperation runs the steps of the Operation of the same profile.
+	StepOperation = "operation"
+)
+
+// Trailing slash policies.
+const (
+	// TrailingSlashRemove removes one trailing slash.
+	TrailingSlashRemove = "remove"
+	// TrailingSlashAdd makes the path end with a slash.
+	TrailingSlashAdd = "add"
+	// TrailingSlashKeep leaves the path as it is.
+	TrailingSlashKeep = "keep"
+)
+
+const (
+	// DefaultProfile is the profile shipped with the service, the byfood
+	// rules it was written for.
+	DefaultProfile = "default"
+)
+
+// StepTypes lists every step type.
+var StepTypes = []string{
+	StepSetHost,
+	StepForceScheme,
+	StepLowercasePath,
+	StepStripQuery,
+	StepKeepQuery,
+	StepStripTracking,
+	StepTrailingSlash,
+	StepNormalize,
+	StepResolve,
+	StepOperation,
+}
+
+// Step is one configurable change of a URL.
+type Step struct {
+	Type   string `yaml:"type" json:"type"`
+	Host   string `yaml:"host,omitempty" json:"host,omitempty"`
+	Scheme string `yaml:"scheme,omitempty" json:"scheme,omitempty"`
+	// Params are query parameter names; `*` matches any run of characters,
+	// as in utm_*. Names are matched case-insensitively.
+	Params    []string `yaml:"params,omitempty" json:"params,omitempty"`
+	Policy    string   `yaml:"policy,omitempty" json:"policy,omitempty"`
+	SortQuery bool     `yaml:"sort_query,omitempty" json:"sort_query,omitempty"`
+	Operation string   `yaml:"operation,omitempty" json:"operation,omitempty"`
+}
+
+// Profile is a set of named operations, each a pipeline of steps run in
+// order.
+type Profile struct {
+	Operations map[string][]Step `yaml:"operations" json:"operations"`
+}
+
+// NewDefaultProfile returns the byfood rules: canonical strips the query and
+// a trailing slash, redirection moves the URL to https://www.byfood.com with
+// a lowercase path, and all runs both. clean only strips tracking params,
+// normalize applies RFC 3986 normalisation and resolve follows redirects.
+func NewDefaultProfile() Profile {
+	return Profile{
+		Operations: map[string][]Step{
+			"canonical": {
+				{Type: StepStripQuery},
+				{Type: StepTrailingSlash, Policy: TrailingSlashRemove},
+			},
+			"redirection": {
+				{Type: StepSetHost, Host: "www.byfood.com"},
+				{Type: StepForceScheme, Scheme: "https"},
+				{Type: StepLowercasePath},
+			},
+			"all": {
+				{Type: StepOperation, Operation: "canonical"},
+				{Type: StepOperation, Operation: "redirection"},
+			},
+			"clean": {
+				{Type: StepStripTracking},
+			},
+			"normalize": {
+				{Type: StepNormalize},
+			},
+			"resolve": {
+				{Type: StepResolve},
+			},
+		},
+	}
+}
@@ -0,0 +1,13 @@
+package urlprocessor
+
+import "errors"
+
+var (
+	ErrInvalidURL       = errors.New("invalid url")
+	ErrInvalidOperation = errors.New("invalid operation")
+	ErrUnknownProfile   = errors.New("unknown profile")
+	ErrUnresolvable     = errors.New("url cannot be resolved")
+
+	ErrRuleSetNotFound = errors.New("url rule set not found")
+	ErrInvalidRuleSet  = errors.New("invalid url rule set")
+)
@@ -0,0 +1,167 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	urlprocessor "booklib/internal/domain/url-processor"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RuleSetRepository is an autogenerated mock type for the RuleSetRepository type
+type RuleSetRepository struct {
+	mock.Mock
+}
+
+// AddRuleSet provides a mock function with given fields: ctx, rs
+func (_m *RuleSetRepository) AddRuleSet(ctx context.Context, rs *urlprocessor.RuleSet) (*urlprocessor.RuleSet, error) {
+	ret := _m.Called(ctx, rs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddRuleSet")
+	}
+
+	var r0 *urlprocessor.RuleSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *urlprocessor.RuleSet) (*urlprocessor.RuleSet, error)); ok {
+		return rf(ctx, rs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *urlprocessor.RuleSet) *urlprocessor.RuleSet); ok {
+		r0 = rf(ctx, rs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*urlprocessor.RuleSet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *urlprocessor.RuleSet) error); ok {
+		r1 = rf(ctx, rs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteRuleSet provides a mock function with given fields: ctx, id
+func (_m *RuleSetRepository) DeleteRuleSet(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRuleSet")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRuleSetByID provides a mock function with given fields: ctx, id
+func (_m *RuleSetRepository) GetRuleSetByID(ctx context.Context, id string) (*urlprocessor.RuleSet, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRuleSetByID")
+	}
+
+	var r0 *urlprocessor.RuleSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*urlprocessor.RuleSet, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *urlprocessor.RuleSet); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*urlprocessor.RuleSet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRuleSets provides a mock function with given fields: ctx
+func (_m *RuleSetRepository) GetRuleSets(ctx context.Context) ([]urlprocessor.RuleSet, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRuleSets")
+	}
+
+	var r0 []urlprocessor.RuleSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]urlprocessor.RuleSet, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []urlprocessor.RuleSet); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]urlprocessor.RuleSet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRuleSet provides a mock function with given fields: ctx, rs
+func (_m *RuleSetRepository) UpdateRuleSet(ctx context.Context, rs *urlprocessor.RuleSet) (*urlprocessor.RuleSet, error) {
+	ret := _m.Called(ctx, rs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRuleSet")
+	}
+
+	var r0 *urlprocessor.RuleSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *urlprocessor.RuleSet) (*urlprocessor.RuleSet, error)); ok {
+		return rf(ctx, rs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *urlprocessor.RuleSet) *urlprocessor.RuleSet); ok {
+		r0 = rf(ctx, rs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*urlprocessor.RuleSet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *urlprocessor.RuleSet) error); ok {
+		r1 = rf(ctx, rs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRuleSetRepository creates a new instance of RuleSetRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRuleSetRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RuleSetRepository {
+	mock := &RuleSetRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
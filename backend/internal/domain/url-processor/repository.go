@@ -0,0 +1,14 @@
+package urlprocessor
+
+import "context"
+
+//go:generate mockery --name=RuleSetRepository --output=./mocks
+type RuleSetRepository interface {
+	AddRuleSet(ctx context.Context, rs *RuleSet) (*RuleSet, error)
+	// GetRuleSets returns every rule set, active or not, by name.
+	GetRuleSets(ctx context.Context) ([]RuleSet, error)
+	GetRuleSetByID(ctx context.Context, id string) (*RuleSet, error)
+	// UpdateRuleSet returns nil, nil when the rule set does not exist.
+	UpdateRuleSet(ctx context.Context, rs *RuleSet) (*RuleSet, error)
+	DeleteRuleSet(ctx context.Context, id string) error
+}
@@ -0,0 +1,118 @@
+package urlprocessor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnyHost is the host pattern matching every host.
+const AnyHost = "*"
+
+var (
+	// hostPattern is a host name, without port, whose labels are not empty
+	hostPattern = regexp.MustCompile(`^[^*/:?#@\[\]\s.]+(\.[^*/:?#@\[\]\s.]+)*$`)
+)
+
+// RuleSet is a set of operations scoped to some hosts. Rule sets are stored
+// in the database and take precedence over the profiles of the configuration
+// for the URLs of their hosts.
+type RuleSet struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Hosts are the patterns of the hosts the rule set applies to:
+	// "example.com", "*.example.com" for its subdomains or "*" for any host.
+	Hosts []string `json:"hosts"`
+	// Priority orders the rule sets matching a URL, highest first; at equal
+	// priority the most specific matching pattern wins.
+	Priority int `json:"priority"`
+	// Operations are defined as in a Profile; operation steps include
+	// operations of the same rule set.
+	Operations map[string][]Step `json:"operations"`
+	// Active rule sets apply to processed URLs, inactive ones are drafts.
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewRuleSet(name string, hosts []string, priority int, operations map[string][]Step, active bool) (*RuleSet, error) {
+	rs := &RuleSet{
+		ID:         uuid.NewString(),
+		Name:       strings.TrimSpace(name),
+		Hosts:      NormalizeHosts(hosts),
+		Priority:   priority,
+		Operations: operations,
+		Active:     active,
+	}
+	if err := rs.Validate(); err != nil {
+		return nil, err
+	}
+
+	return rs, nil
+}
+
+// Validate checks the name, hosts and presence of operations; the steps are
+// checked when the operations are compiled.
+func (rs *RuleSet) Validate() error {
+	if rs.Name == "" {
+		return fmt.Errorf("%w: name cannot be empty", ErrInvalidRuleSet)
+	}
+	if len(rs.Hosts) == 0 {
+		return fmt.Errorf("%w: hosts cannot be empty", ErrInvalidRuleSet)
+	}
+	for _, h := range rs.Hosts {
+		if h == AnyHost {
+			continue
+		}
+		if !hostPattern.MatchString(strings.TrimPrefix(h, "*.")) {
+			return fmt.Errorf("%w: invalid host %q", ErrInvalidRuleSet, h)
+		}
+	}
+	if len(rs.Operations) == 0 {
+		return fmt.Errorf("%w: operations cannot be empty", ErrInvalidRuleSet)
+	}
+
+	return nil
+}
+
+// Match reports whether host matches one of the patterns of the rule set,
+// and how specific the most specific of them is: the number of labels it
+// fixes. An exact pattern is thus more specific than any wildcard matching
+// the same host, and "*" is the least specific.
+func (rs *RuleSet) Match(host string) (int, bool) {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" {
+		return 0, false
+	}
+
+	best, matched := 0, false
+	for _, p := range rs.Hosts {
+		var specificity int
+		switch suffix, wildcard := strings.CutPrefix(p, "*."); {
+		case p == AnyHost:
+		case wildcard && strings.HasSuffix(host, "."+suffix):
+			specificity = strings.Count(suffix, ".") + 1
+		case !wildcard && host == p:
+			specificity = strings.Count(p, ".") + 1
+		default:
+			continue
+		}
+		if !matched || specificity > best {
+			best, matched = specificity, true
+		}
+	}
+	return best, matched
+}
+
+// NormalizeHosts trims and lowercases host patterns, dropping the trailing
+// dot of fully qualified names.
+func NormalizeHosts(hosts []string) []string {
+	normalized := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		normalized = append(normalized, strings.TrimSuffix(strings.ToLower(strings.TrimSpace(h)), "."))
+	}
+	return normalized
+}
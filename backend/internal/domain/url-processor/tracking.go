@@ -0,0 +1,48 @@
+package urlprocessor
+
+// TrackingParams are the query parameters analytics and ad platforms append to
+// links, removed by StepStripTracking. Patterns follow Step.Params. Keep the
+// list to parameters that never carry page content; deployments add their own
+// through the url_processor.tracking_params config.
+var TrackingParams = []string{
+	// Google Analytics and Ads
+	"utm_*",
+	"gclid",
+	"gclsrc",
+	"dclid",
+	"gbraid",
+	"wbraid",
+	"_ga",
+	"_gl",
+	"srsltid",
+	// Meta
+	"fbclid",
+	"igshid",
+	"igsh",
+	// Microsoft
+	"msclkid",
+	// Mailchimp
+	"mc_cid",
+	"mc_eid",
+	// HubSpot
+	"_hsenc",
+	"_hsmi",
+	"__hssc",
+	"__hstc",
+	"__hsfp",
+	"hsctatracking",
+	// Marketo
+	"mkt_tok",
+	// Yandex
+	"yclid",
+	"_openstat",
+	// X, TikTok and LinkedIn
+	"twclid",
+	"ttclid",
+	"li_fat_id",
+	// Olytics and Vero
+	"oly_anon_id",
+	"oly_enc_id",
+	"vero_id",
+	"vero_conv",
+}
@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"booklib/internal/domain/event"
+	"github.com/google/uuid"
+)
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	// DeliveryStatusDead marks deliveries that ran out of attempts; together
+	// they form the dead-letter list.
+	DeliveryStatusDead = "dead"
+)
+
+// EventTypes are the event types a subscription can listen to.
+var EventTypes = []string{
+	event.TypeBookCreated,
+	event.TypeBookUpdated,
+	event.TypeBookDeleted,
+	event.TypeBookMerged,
+}
+
+type Subscription struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// EventTypes lists the events sent to URL; empty means every event
+	EventTypes []string `json:"event_types"`
+	// Secret signs every delivery and is never returned by the API
+	Secret    string    `json:"-"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Delivery is a single attempt series of sending one event to one subscription.
+type Delivery struct {
+	ID             int64           `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	EventID        int64           `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastError      string          `json:"last_error,omitempty"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// DeliveryFilter narrows down GetDeliveries. Zero values mean no filtering.
+type DeliveryFilter struct {
+	SubscriptionID string
+	Status         string
+	Limit          int
+}
+
+func NewSubscription(rawURL string, eventTypes []string, secret string, active bool) (*Subscription, error) {
+	sub := &Subscription{
+		ID:         uuid.NewString(),
+		URL:        rawURL,
+		EventTypes: eventTypes,
+		Secret:     secret,
+		Active:     active,
+	}
+	if err := sub.Validate(); err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+func (s *Subscription) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+	if s.Secret == "" {
+		return errors.New("secret cannot be empty")
+	}
+	for _, t := range s.EventTypes {
+		if !slices.Contains(EventTypes, t) {
+			return fmt.Errorf("unknown event type %q", t)
+		}
+	}
+
+	return nil
+}
+
+// Matches reports whether the subscription wants events of the given type.
+func (s *Subscription) Matches(eventType string) bool {
+	if !s.Active {
+		return false
+	}
+
+	return len(s.EventTypes) == 0 || slices.Contains(s.EventTypes, eventType)
+}
@@ -0,0 +1,8 @@
+package webhook
+
+import "errors"
+
+var (
+	ErrNotFound         = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+)
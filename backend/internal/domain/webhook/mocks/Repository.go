@@ -0,0 +1,295 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+
+	webhook "booklib/internal/domain/webhook"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// AddDeliveries provides a mock function with given fields: ctx, deliveries
+func (_m *Repository) AddDeliveries(ctx context.Context, deliveries []*webhook.Delivery) error {
+	ret := _m.Called(ctx, deliveries)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDeliveries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*webhook.Delivery) error); ok {
+		r0 = rf(ctx, deliveries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddSubscription provides a mock function with given fields: ctx, sub
+func (_m *Repository) AddSubscription(ctx context.Context, sub *webhook.Subscription) (*webhook.Subscription, error) {
+	ret := _m.Called(ctx, sub)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSubscription")
+	}
+
+	var r0 *webhook.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *webhook.Subscription) (*webhook.Subscription, error)); ok {
+		return rf(ctx, sub)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *webhook.Subscription) *webhook.Subscription); ok {
+		r0 = rf(ctx, sub)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhook.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *webhook.Subscription) error); ok {
+		r1 = rf(ctx, sub)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimDueDeliveries provides a mock function with given fields: ctx, now, leaseUntil, limit
+func (_m *Repository) ClaimDueDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]webhook.Delivery, error) {
+	ret := _m.Called(ctx, now, leaseUntil, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDueDeliveries")
+	}
+
+	var r0 []webhook.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) ([]webhook.Delivery, error)); ok {
+		return rf(ctx, now, leaseUntil, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) []webhook.Delivery); ok {
+		r0 = rf(ctx, now, leaseUntil, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhook.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, now, leaseUntil, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteSubscription provides a mock function with given fields: ctx, id
+func (_m *Repository) DeleteSubscription(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, filter
+func (_m *Repository) GetDeliveries(ctx context.Context, filter webhook.DeliveryFilter) ([]webhook.Delivery, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveries")
+	}
+
+	var r0 []webhook.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.DeliveryFilter) ([]webhook.Delivery, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.DeliveryFilter) []webhook.Delivery); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhook.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhook.DeliveryFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeliveryByID provides a mock function with given fields: ctx, id
+func (_m *Repository) GetDeliveryByID(ctx context.Context, id int64) (*webhook.Delivery, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveryByID")
+	}
+
+	var r0 *webhook.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*webhook.Delivery, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *webhook.Delivery); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhook.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubscriptionByID provides a mock function with given fields: ctx, id
+func (_m *Repository) GetSubscriptionByID(ctx context.Context, id string) (*webhook.Subscription, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionByID")
+	}
+
+	var r0 *webhook.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*webhook.Subscription, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *webhook.Subscription); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhook.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubscriptions provides a mock function with given fields: ctx
+func (_m *Repository) GetSubscriptions(ctx context.Context) ([]webhook.Subscription, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptions")
+	}
+
+	var r0 []webhook.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]webhook.Subscription, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []webhook.Subscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhook.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateDelivery provides a mock function with given fields: ctx, d
+func (_m *Repository) UpdateDelivery(ctx context.Context, d *webhook.Delivery) error {
+	ret := _m.Called(ctx, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *webhook.Delivery) error); ok {
+		r0 = rf(ctx, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateSubscription provides a mock function with given fields: ctx, sub
+func (_m *Repository) UpdateSubscription(ctx context.Context, sub *webhook.Subscription) (*webhook.Subscription, error) {
+	ret := _m.Called(ctx, sub)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSubscription")
+	}
+
+	var r0 *webhook.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *webhook.Subscription) (*webhook.Subscription, error)); ok {
+		return rf(ctx, sub)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *webhook.Subscription) *webhook.Subscription); ok {
+		r0 = rf(ctx, sub)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*webhook.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *webhook.Subscription) error); ok {
+		r1 = rf(ctx, sub)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,24 @@
+package webhook
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockery --name=Repository --output=./mocks
+type Repository interface {
+	AddSubscription(ctx context.Context, sub *Subscription) (*Subscription, error)
+	GetSubscriptions(ctx context.Context) ([]Subscription, error)
+	GetSubscriptionByID(ctx context.Context, id string) (*Subscription, error)
+	UpdateSubscription(ctx context.Context, sub *Subscription) (*Subscription, error)
+	DeleteSubscription(ctx context.Context, id string) error
+
+	AddDeliveries(ctx context.Context, deliveries []*Delivery) error
+	// ClaimDueDeliveries returns up to limit pending deliveries due at now and
+	// pushes their next attempt to leaseUntil, so concurrent workers do not
+	// send the same delivery twice.
+	ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]Delivery, error)
+	GetDeliveryByID(ctx context.Context, id int64) (*Delivery, error)
+	GetDeliveries(ctx context.Context, filter DeliveryFilter) ([]Delivery, error)
+	UpdateDelivery(ctx context.Context, d *Delivery) error
+}
@@ -0,0 +1,35 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	HeaderSignature = "X-Booklib-Signature-256"
+	HeaderEvent     = "X-Booklib-Event"
+	HeaderDelivery  = "X-Booklib-Delivery"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign returns the signature header value for body: the hex encoded
+// HMAC-SHA256 of the raw body keyed with the subscription secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks a signature header value in constant time. Receivers
+// written in Go can use it to authenticate deliveries.
+func VerifySignature(secret string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
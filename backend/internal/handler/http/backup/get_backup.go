@@ -0,0 +1,42 @@
+package backup
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+const (
+	archiveTimeLayout = "20060102T150405Z"
+)
+
+// GetBackup godoc
+// @Summary Download a backup of the whole library
+// @Description Returns a gzipped tar archive holding a manifest, one JSON Lines file per entity and a
+// @Description SHA256SUMS file. Every entity is read in one transaction, so the archive is consistent.
+// @Tags admin
+// @Security AdminToken
+// @Produce application/gzip
+// @Success 200 {file} file "booklib-<timestamp>.tar.gz"
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/backup [get]
+func (h *Handler) GetBackup(c *fiber.Ctx) error {
+	// the archive is built before answering, so a failure is still reported
+	// with a proper status
+	var buf bytes.Buffer
+	manifest, err := h.usecase.Backup(c.UserContext(), &buf)
+	if err != nil {
+		log.Error(c.UserContext(), err, nil, "failed to back up")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/gzip")
+	c.Attachment(fmt.Sprintf("booklib-%s.tar.gz", manifest.CreatedAt.UTC().Format(archiveTimeLayout)))
+	return c.Send(buf.Bytes())
+}
@@ -0,0 +1,73 @@
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/backup"
+	"booklib/internal/usecase/backup/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBackup(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+	}{
+		{
+			name: "sends the archive",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("Backup", mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						_, _ = args.Get(1).(io.Writer).Write([]byte("archive"))
+					}).
+					Return(&domain.Manifest{CreatedAt: time.Date(2026, 10, 19, 10, 30, 0, 0, time.UTC)}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "usecase error",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("Backup", mock.Anything, mock.Anything).Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Get("/admin/backup", handler.GetBackup)
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/admin/backup", nil))
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus != http.StatusOK {
+				var res map[string]interface{}
+				assert.NoError(t, json.Unmarshal(body, &res))
+				assert.NotEmpty(t, res["error"])
+				return
+			}
+			assert.Equal(t, "application/gzip", resp.Header.Get(fiber.HeaderContentType))
+			assert.Equal(t, `attachment; filename="booklib-20261019T103000Z.tar.gz"`, resp.Header.Get(fiber.HeaderContentDisposition))
+			assert.Equal(t, "archive", string(body))
+		})
+	}
+}
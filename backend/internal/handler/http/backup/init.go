@@ -0,0 +1,13 @@
+package backup
+
+import "booklib/internal/usecase/backup"
+
+type Handler struct {
+	usecase backup.UseCase
+}
+
+func New(usecase backup.UseCase) *Handler {
+	return &Handler{
+		usecase: usecase,
+	}
+}
@@ -0,0 +1,20 @@
+package backup
+
+import (
+	"testing"
+
+	"booklib/internal/usecase/backup/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("creates new handler with usecase", func(t *testing.T) {
+		usecase := mocks.NewUseCase(t)
+
+		handler := New(usecase)
+
+		assert.NotNil(t, handler)
+		assert.Equal(t, usecase, handler.usecase)
+	})
+}
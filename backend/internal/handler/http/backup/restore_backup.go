@@ -0,0 +1,91 @@
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"time"
+
+	domain "booklib/internal/domain/backup"
+	"booklib/internal/usecase/backup"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// RestoreResponse represents the outcome of a restore
+type RestoreResponse struct {
+	FormatVersion int `json:"format_version"`
+	// ArchiveSchemaVersion is the schema the archive was taken from
+	ArchiveSchemaVersion string `json:"archive_schema_version"`
+	// SchemaVersion is the schema of the database it was restored into
+	SchemaVersion string         `json:"schema_version"`
+	Upgraded      bool           `json:"upgraded"`
+	CreatedAt     time.Time      `json:"created_at"`
+	Restored      map[string]int `json:"restored"`
+	DryRun        bool           `json:"dry_run"`
+}
+
+// RestoreBackup godoc
+// @Summary Restore the whole library from a backup
+// @Description Replaces every book, event, outbox message and webhook with the content of an archive made by
+// @Description GET /admin/backup, in a single transaction. The archive is the raw request body. Archives of an
+// @Description older schema are upgraded; archives of a newer schema are refused. With dry_run=true the archive
+// @Description is restored and rolled back, so nothing changes.
+// @Tags admin
+// @Security AdminToken
+// @Accept application/gzip
+// @Produce json
+// @Param dry_run query bool false "Validate and roll back instead of committing"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/restore [post]
+func (h *Handler) RestoreBackup(c *fiber.Ctx) error {
+	var in backup.RestoreInput
+	if raw := c.Query("dry_run"); raw != "" {
+		dryRun, err := strconv.ParseBool(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status": "error",
+				"error":  "dry_run must be a boolean",
+			})
+		}
+		in.DryRun = dryRun
+	}
+
+	if len(c.Body()) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  "request body must be a backup archive",
+		})
+	}
+
+	out, err := h.usecase.Restore(c.UserContext(), bytes.NewReader(c.Body()), in)
+	switch {
+	case errors.Is(err, domain.ErrInvalidArchive):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	case err != nil:
+		log.Error(c.UserContext(), err, nil, "failed to restore backup")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data": RestoreResponse{
+			FormatVersion:        out.Manifest.FormatVersion,
+			ArchiveSchemaVersion: out.Manifest.SchemaVersion,
+			SchemaVersion:        out.SchemaVersion,
+			Upgraded:             out.Upgraded,
+			CreatedAt:            out.Manifest.CreatedAt,
+			Restored:             out.Restored,
+			DryRun:               out.DryRun,
+		},
+	})
+}
@@ -0,0 +1,129 @@
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	domain "booklib/internal/domain/backup"
+	usecaseBackup "booklib/internal/usecase/backup"
+	"booklib/internal/usecase/backup/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreBackup(t *testing.T) {
+	readsArchive := mock.MatchedBy(func(r io.Reader) bool {
+		raw, err := io.ReadAll(r)
+		return err == nil && string(raw) == "archive"
+	})
+
+	tests := []struct {
+		name           string
+		query          string
+		body           string
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+		expectedDryRun bool
+	}{
+		{
+			name: "restores the archive",
+			body: "archive",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("Restore", mock.Anything, readsArchive, usecaseBackup.RestoreInput{}).
+					Return(&usecaseBackup.RestoreOutput{
+						Manifest:      domain.Manifest{FormatVersion: 1, SchemaVersion: "20261019_04"},
+						SchemaVersion: "20261019_04",
+						Restored:      map[string]int{domain.EntityBooks: 2},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "dry run",
+			query: "?dry_run=true",
+			body:  "archive",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("Restore", mock.Anything, readsArchive, usecaseBackup.RestoreInput{DryRun: true}).
+					Return(&usecaseBackup.RestoreOutput{
+						Manifest:      domain.Manifest{FormatVersion: 1, SchemaVersion: "20261019_04"},
+						SchemaVersion: "20261019_04",
+						Restored:      map[string]int{domain.EntityBooks: 2},
+						DryRun:        true,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedDryRun: true,
+		},
+		{
+			name:           "invalid dry_run",
+			query:          "?dry_run=maybe",
+			body:           "archive",
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty body",
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid archive",
+			body: "archive",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("Restore", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("%w: checksum mismatch for books.jsonl", domain.ErrInvalidArchive))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "usecase error",
+			body: "archive",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("Restore", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Post("/admin/restore", handler.RestoreBackup)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/restore"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, "application/gzip")
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+
+			var res struct {
+				Status string          `json:"status"`
+				Error  string          `json:"error"`
+				Data   RestoreResponse `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus != http.StatusOK {
+				assert.NotEmpty(t, res.Error)
+				return
+			}
+			assert.Equal(t, "success", res.Status)
+			assert.Equal(t, "20261019_04", res.Data.ArchiveSchemaVersion)
+			assert.Equal(t, map[string]int{"books": 2}, res.Data.Restored)
+			assert.Equal(t, tt.expectedDryRun, res.Data.DryRun)
+		})
+	}
+}
@@ -3,6 +3,8 @@ package book
 import (
 	"booklib/internal/usecase/book"
 	"errors"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/rizanw/go-log"
 )
@@ -34,13 +36,17 @@ func (req *AddBookRequest) parseValidateRequest() (book.AddBookInput, error) {
 
 // AddBook godoc
 // @Summary Add a new book
-// @Description Creates a new book and returns success status
+// @Description Creates a new book and returns the created resource with its location
 // @Tags books
 // @Accept json
 // @Produce json
 // @Param book body book.AddBookRequest true "Book to create"
-// @Success 201 {object} map[string]string
+// @Param Idempotency-Key header string false "Unique key to safely retry the request"
+// @Success 201 {object} map[string]interface{}
+// @Header 201 {string} Location "URL of the created book"
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /books [post]
 func (h *Handler) AddBook(c *fiber.Ctx) error {
@@ -59,7 +65,8 @@ func (h *Handler) AddBook(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.usecase.AddBook(c.UserContext(), in); err != nil {
+	res, err := h.usecase.AddBook(c.UserContext(), in)
+	if err != nil {
 		log.Error(c.UserContext(), err, nil, "failed to add book")
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"status": "error",
@@ -67,7 +74,9 @@ func (h *Handler) AddBook(c *fiber.Ctx) error {
 		})
 	}
 
+	c.Location(strings.TrimSuffix(c.Path(), "/") + "/" + res.ID)
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"status": "success",
+		"data":   res,
 	})
 }
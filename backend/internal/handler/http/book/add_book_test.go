@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	domain "booklib/internal/domain/book"
 	usecaseBook "booklib/internal/usecase/book"
 	"booklib/internal/usecase/book/mocks"
 	"github.com/gofiber/fiber/v2"
@@ -17,11 +18,12 @@ import (
 
 func TestAddBook(t *testing.T) {
 	tests := []struct {
-		name           string
-		requestBody    interface{}
-		setupMocks     func(*mocks.UseCase)
-		expectedStatus int
-		expectedBody   map[string]interface{}
+		name             string
+		requestBody      interface{}
+		setupMocks       func(*mocks.UseCase)
+		expectedStatus   int
+		expectedBody     map[string]interface{}
+		expectedLocation string
 	}{
 		{
 			name: "successful add book",
@@ -35,12 +37,26 @@ func TestAddBook(t *testing.T) {
 					Title:  "Test Book",
 					Author: "Test Author",
 					Year:   2023,
-				}).Return(nil)
+				}).Return(&domain.Book{
+					ID:     "new-id",
+					Title:  "Test Book",
+					Author: "Test Author",
+					Year:   2023,
+				}, nil)
 			},
 			expectedStatus: http.StatusCreated,
 			expectedBody: map[string]interface{}{
 				"status": "success",
-			},
+				"data": map[string]interface{}{
+					"id":         "new-id",
+					"title":      "Test Book",
+					"author":     "Test Author",
+					"year":       float64(2023),
+					"created_at": "0001-01-01T00:00:00Z",
+					"updated_at": "0001-01-01T00:00:00Z",
+				},
+			},
+			expectedLocation: "/books/new-id",
 		},
 		{
 			name:           "invalid JSON body",
@@ -98,7 +114,7 @@ func TestAddBook(t *testing.T) {
 				Year:   2023,
 			},
 			setupMocks: func(uc *mocks.UseCase) {
-				uc.On("AddBook", mock.Anything, mock.Anything).Return(errors.New("database error"))
+				uc.On("AddBook", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: map[string]interface{}{
@@ -133,6 +149,7 @@ func TestAddBook(t *testing.T) {
 			resp, err := app.Test(req)
 			assert.NoError(t, err)
 			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectedLocation, resp.Header.Get("Location"))
 
 			var responseBody map[string]interface{}
 			err = json.NewDecoder(resp.Body).Decode(&responseBody)
@@ -142,6 +159,9 @@ func TestAddBook(t *testing.T) {
 				_, exists := responseBody[key]
 				assert.True(t, exists, "Expected key %s not found in response", key)
 			}
+			if data, ok := tt.expectedBody["data"]; ok {
+				assert.Equal(t, data, responseBody["data"])
+			}
 		})
 	}
 }
@@ -0,0 +1,182 @@
+package book
+
+import (
+	"errors"
+	"fmt"
+
+	domain "booklib/internal/domain/book"
+	"booklib/internal/usecase/book"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+const (
+	BatchModeAtomic     = "atomic"
+	BatchModeBestEffort = "best_effort"
+)
+
+// BatchBooksRequest represents the request payload for applying many book changes at once
+type BatchBooksRequest struct {
+	Mode       string                  `json:"mode" enums:"atomic,best_effort"`
+	Operations []BatchOperationRequest `json:"operations"`
+}
+
+// BatchOperationRequest represents a single create, update or delete inside a batch
+type BatchOperationRequest struct {
+	Op     string `json:"op" enums:"create,update,delete"`
+	ID     string `json:"id,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Author string `json:"author,omitempty"`
+	Year   int    `json:"year,omitempty"`
+}
+
+// BatchResultResponse represents the outcome of a single batch operation
+type BatchResultResponse struct {
+	Index  int          `json:"index"`
+	Op     string       `json:"op"`
+	ID     string       `json:"id,omitempty"`
+	Status string       `json:"status"`
+	Data   *domain.Book `json:"data,omitempty"`
+	Error  string       `json:"error,omitempty"`
+}
+
+func (req *BatchBooksRequest) parseValidateRequest() (book.BatchInput, error) {
+	var in book.BatchInput
+
+	switch req.Mode {
+	case "", BatchModeAtomic:
+	case BatchModeBestEffort:
+		in.BestEffort = true
+	default:
+		return book.BatchInput{}, fmt.Errorf("invalid mode %q", req.Mode)
+	}
+
+	if len(req.Operations) == 0 {
+		return book.BatchInput{}, book.ErrBatchEmpty
+	}
+	if len(req.Operations) > book.MaxBatchOperations {
+		return book.BatchInput{}, book.ErrBatchTooLarge
+	}
+
+	in.Operations = make([]book.BatchOperation, 0, len(req.Operations))
+	for i, op := range req.Operations {
+		if err := op.validate(); err != nil {
+			return book.BatchInput{}, fmt.Errorf("operations[%d]: %w", i, err)
+		}
+
+		bop := book.BatchOperation{
+			Op:     op.Op,
+			ID:     op.ID,
+			Title:  op.Title,
+			Author: op.Author,
+			Year:   op.Year,
+		}
+		if op.Op == book.BatchOpCreate {
+			// books created over the API always get a new id
+			bop.ID = ""
+		}
+		in.Operations = append(in.Operations, bop)
+	}
+
+	return in, nil
+}
+
+func (op *BatchOperationRequest) validate() error {
+	switch op.Op {
+	case book.BatchOpCreate, book.BatchOpUpdate, book.BatchOpDelete:
+	default:
+		return fmt.Errorf("invalid op %q", op.Op)
+	}
+
+	if op.Op != book.BatchOpCreate && op.ID == "" {
+		return errors.New("id cannot be empty")
+	}
+	if op.Op == book.BatchOpDelete {
+		return nil
+	}
+
+	if op.Title == "" {
+		return errors.New("title cannot be empty")
+	}
+	if op.Author == "" {
+		return errors.New("author cannot be empty")
+	}
+	if op.Year == 0 {
+		return errors.New("year cannot be empty")
+	}
+
+	return nil
+}
+
+// BatchBooks godoc
+// @Summary Create, update and delete many books at once
+// @Description Applies a list of operations in one transaction (mode=atomic, default) or one by one (mode=best_effort).
+// @Description Returns 200 when every operation succeeded, 207 when some best-effort operations failed
+// @Description and 422 when an atomic batch was rolled back.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param batch body book.BatchBooksRequest true "Operations to apply"
+// @Param Idempotency-Key header string false "Unique key to safely retry the request"
+// @Success 200 {object} map[string]interface{}
+// @Success 207 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /books:batch [post]
+func (h *Handler) BatchBooks(c *fiber.Ctx) error {
+	var req BatchBooksRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  "Cannot parse JSON",
+		})
+	}
+
+	in, err := req.parseValidateRequest()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	out, err := h.usecase.BatchBooks(c.UserContext(), in)
+	if err != nil {
+		log.Error(c.UserContext(), err, nil, "failed to apply book batch")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	results := make([]BatchResultResponse, 0, len(out.Results))
+	for i, res := range out.Results {
+		results = append(results, BatchResultResponse{
+			Index:  i,
+			Op:     res.Op,
+			ID:     res.ID,
+			Status: res.Status,
+			Data:   res.Book,
+			Error:  res.Error,
+		})
+	}
+
+	var (
+		httpStatus = fiber.StatusOK
+		status     = "success"
+	)
+	if out.Failed > 0 {
+		status = "error"
+		httpStatus = fiber.StatusUnprocessableEntity
+		if in.BestEffort {
+			httpStatus = fiber.StatusMultiStatus
+		}
+	}
+
+	return c.Status(httpStatus).JSON(fiber.Map{
+		"status": status,
+		"data":   results,
+	})
+}
@@ -0,0 +1,323 @@
+package book
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "booklib/internal/domain/book"
+	usecaseBook "booklib/internal/usecase/book"
+	"booklib/internal/usecase/book/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBatchBooks(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{
+			name: "all operations succeed",
+			requestBody: BatchBooksRequest{
+				Operations: []BatchOperationRequest{
+					{Op: "create", Title: "Book 1", Author: "Author 1", Year: 2021},
+					{Op: "delete", ID: "id-2"},
+				},
+			},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("BatchBooks", mock.Anything, usecaseBook.BatchInput{
+					Operations: []usecaseBook.BatchOperation{
+						{Op: "create", Title: "Book 1", Author: "Author 1", Year: 2021},
+						{Op: "delete", ID: "id-2"},
+					},
+				}).Return(&usecaseBook.BatchOutput{
+					Results: []usecaseBook.BatchResult{
+						{Op: "create", ID: "id-1", Status: "success", Book: &domain.Book{ID: "id-1", Title: "Book 1", Author: "Author 1", Year: 2021}},
+						{Op: "delete", ID: "id-2", Status: "success"},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"status": "success",
+				"data": []interface{}{
+					map[string]interface{}{
+						"index":  float64(0),
+						"op":     "create",
+						"id":     "id-1",
+						"status": "success",
+						"data": map[string]interface{}{
+							"id":         "id-1",
+							"title":      "Book 1",
+							"author":     "Author 1",
+							"year":       float64(2021),
+							"created_at": "0001-01-01T00:00:00Z",
+							"updated_at": "0001-01-01T00:00:00Z",
+						},
+					},
+					map[string]interface{}{
+						"index":  float64(1),
+						"op":     "delete",
+						"id":     "id-2",
+						"status": "success",
+					},
+				},
+			},
+		},
+		{
+			name: "best effort batch with failures",
+			requestBody: BatchBooksRequest{
+				Mode: "best_effort",
+				Operations: []BatchOperationRequest{
+					{Op: "update", ID: "missing", Title: "Book 1", Author: "Author 1", Year: 2021},
+				},
+			},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("BatchBooks", mock.Anything, mock.MatchedBy(func(in usecaseBook.BatchInput) bool {
+					return in.BestEffort
+				})).Return(&usecaseBook.BatchOutput{
+					Results: []usecaseBook.BatchResult{
+						{Op: "update", ID: "missing", Status: "error", Error: "book not found"},
+					},
+					Failed: 1,
+				}, nil)
+			},
+			expectedStatus: http.StatusMultiStatus,
+			expectedBody: map[string]interface{}{
+				"status": "error",
+				"data": []interface{}{
+					map[string]interface{}{
+						"index":  float64(0),
+						"op":     "update",
+						"id":     "missing",
+						"status": "error",
+						"error":  "book not found",
+					},
+				},
+			},
+		},
+		{
+			name: "atomic batch rolled back",
+			requestBody: BatchBooksRequest{
+				Mode: "atomic",
+				Operations: []BatchOperationRequest{
+					{Op: "update", ID: "missing", Title: "Book 1", Author: "Author 1", Year: 2021},
+				},
+			},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("BatchBooks", mock.Anything, mock.Anything).Return(&usecaseBook.BatchOutput{
+					Results: []usecaseBook.BatchResult{
+						{Op: "update", ID: "missing", Status: "error", Error: "book not found"},
+					},
+					Failed: 1,
+				}, nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody: map[string]interface{}{
+				"status": "error",
+			},
+		},
+		{
+			name:           "invalid JSON body",
+			requestBody:    `{"operations": "invalid"}`,
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"status": "error",
+				"error":  "Cannot parse JSON",
+			},
+		},
+		{
+			name: "invalid operation",
+			requestBody: BatchBooksRequest{
+				Operations: []BatchOperationRequest{
+					{Op: "create", Title: "Book 1", Author: "Author 1", Year: 2021},
+					{Op: "create", Title: "", Author: "Author 2", Year: 2022},
+				},
+			},
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"status": "error",
+				"error":  "operations[1]: title cannot be empty",
+			},
+		},
+		{
+			name:           "empty batch",
+			requestBody:    BatchBooksRequest{},
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"status": "error",
+				"error":  usecaseBook.ErrBatchEmpty.Error(),
+			},
+		},
+		{
+			name: "usecase error",
+			requestBody: BatchBooksRequest{
+				Operations: []BatchOperationRequest{
+					{Op: "delete", ID: "id-1"},
+				},
+			},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("BatchBooks", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]interface{}{
+				"status": "error",
+				"error":  "database error",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Post("/books\\:batch", handler.BatchBooks)
+
+			var body []byte
+			var err error
+
+			if str, ok := tt.requestBody.(string); ok {
+				body = []byte(str)
+			} else {
+				body, err = json.Marshal(tt.requestBody)
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/books:batch", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			var responseBody map[string]interface{}
+			err = json.NewDecoder(resp.Body).Decode(&responseBody)
+			assert.NoError(t, err)
+
+			for key, expectedValue := range tt.expectedBody {
+				actualValue, exists := responseBody[key]
+				assert.True(t, exists, "Expected key %s not found in response", key)
+				assert.Equal(t, expectedValue, actualValue)
+			}
+		})
+	}
+}
+
+func TestBatchBooksRequest_parseValidateRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     BatchBooksRequest
+		expected    usecaseBook.BatchInput
+		expectedErr string
+	}{
+		{
+			name: "valid atomic request",
+			request: BatchBooksRequest{
+				Operations: []BatchOperationRequest{
+					{Op: "create", ID: "id-0", Title: "Book", Author: "Author", Year: 2020},
+					{Op: "update", ID: "id-1", Title: "Book", Author: "Author", Year: 2020},
+					{Op: "delete", ID: "id-2"},
+				},
+			},
+			// the id of a create is not kept
+			expected: usecaseBook.BatchInput{
+				Operations: []usecaseBook.BatchOperation{
+					{Op: "create", Title: "Book", Author: "Author", Year: 2020},
+					{Op: "update", ID: "id-1", Title: "Book", Author: "Author", Year: 2020},
+					{Op: "delete", ID: "id-2"},
+				},
+			},
+		},
+		{
+			name: "valid best effort request",
+			request: BatchBooksRequest{
+				Mode:       "best_effort",
+				Operations: []BatchOperationRequest{{Op: "delete", ID: "id-1"}},
+			},
+			expected: usecaseBook.BatchInput{
+				BestEffort: true,
+				Operations: []usecaseBook.BatchOperation{{Op: "delete", ID: "id-1"}},
+			},
+		},
+		{
+			name: "invalid mode",
+			request: BatchBooksRequest{
+				Mode:       "sometimes",
+				Operations: []BatchOperationRequest{{Op: "delete", ID: "id-1"}},
+			},
+			expectedErr: `invalid mode "sometimes"`,
+		},
+		{
+			name: "invalid op",
+			request: BatchBooksRequest{
+				Operations: []BatchOperationRequest{{Op: "upsert", ID: "id-1"}},
+			},
+			expectedErr: `operations[0]: invalid op "upsert"`,
+		},
+		{
+			name: "update without id",
+			request: BatchBooksRequest{
+				Operations: []BatchOperationRequest{{Op: "update", Title: "Book", Author: "Author", Year: 2020}},
+			},
+			expectedErr: "operations[0]: id cannot be empty",
+		},
+		{
+			name: "delete without id",
+			request: BatchBooksRequest{
+				Operations: []BatchOperationRequest{{Op: "delete"}},
+			},
+			expectedErr: "operations[0]: id cannot be empty",
+		},
+		{
+			name: "create without author",
+			request: BatchBooksRequest{
+				Operations: []BatchOperationRequest{{Op: "create", Title: "Book", Year: 2020}},
+			},
+			expectedErr: "operations[0]: author cannot be empty",
+		},
+		{
+			name: "create without year",
+			request: BatchBooksRequest{
+				Operations: []BatchOperationRequest{{Op: "create", Title: "Book", Author: "Author"}},
+			},
+			expectedErr: "operations[0]: year cannot be empty",
+		},
+		{
+			name: "too many operations",
+			request: BatchBooksRequest{
+				Operations: make([]BatchOperationRequest, usecaseBook.MaxBatchOperations+1),
+			},
+			expectedErr: usecaseBook.ErrBatchTooLarge.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.request.parseValidateRequest()
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Equal(t, usecaseBook.BatchInput{}, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
@@ -30,7 +30,7 @@ func TestDeleteBook(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
-				"message": "success",
+				"status": "success",
 			},
 		},
 		{
@@ -77,7 +77,7 @@ func TestDeleteBook(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
-				"message": "success",
+				"status": "success",
 			},
 		},
 		{
@@ -88,7 +88,7 @@ func TestDeleteBook(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
-				"message": "success",
+				"status": "success",
 			},
 		},
 	}
@@ -0,0 +1,99 @@
+package book
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	domain "booklib/internal/domain/book"
+	"booklib/internal/usecase/book"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// DuplicateGroupResponse represents books that look like the same book
+type DuplicateGroupResponse struct {
+	Score float64 `json:"score"`
+	// Books are oldest first, the first one being the natural one to keep
+	Books []domain.Book           `json:"books"`
+	Pairs []DuplicatePairResponse `json:"pairs"`
+}
+
+// DuplicatePairResponse represents the score of two books and what it is made of
+type DuplicatePairResponse struct {
+	BookIDs [2]string `json:"book_ids"`
+	Score   float64   `json:"score"`
+	Title   float64   `json:"title"`
+	Author  float64   `json:"author"`
+	// Year is null when either book has no year
+	Year *float64 `json:"year"`
+}
+
+// FindDuplicates godoc
+// @Summary Find duplicate books
+// @Description Groups books that look like the same book. Pairs are scored from 0 to 1 on their normalised title
+// @Description (casing, punctuation and a leading article are ignored), author names (name order and initials are
+// @Description ignored) and year. Only pairs scoring at least min_score are reported, best group first.
+// @Tags books
+// @Produce json
+// @Param min_score query number false "Lowest reported score, from 0 to 1" default(0.85)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /books/duplicates [get]
+func (h *Handler) FindDuplicates(c *fiber.Ctx) error {
+	var in book.FindDuplicatesInput
+	if raw := c.Query("min_score"); raw != "" {
+		minScore, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status": "error",
+				"error":  "min_score must be a number",
+			})
+		}
+		in.MinScore = minScore
+	}
+
+	groups, err := h.usecase.FindDuplicates(c.UserContext(), in)
+	switch {
+	case errors.Is(err, book.ErrInvalidMinScore):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	case err != nil:
+		log.Error(c.UserContext(), err, nil, "failed to find duplicate books")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	res := make([]DuplicateGroupResponse, 0, len(groups))
+	for _, g := range groups {
+		pairs := make([]DuplicatePairResponse, 0, len(g.Pairs))
+		for _, p := range g.Pairs {
+			pair := DuplicatePairResponse{
+				BookIDs: p.BookIDs,
+				Score:   roundScore(p.Similarity.Score),
+				Title:   roundScore(p.Similarity.Title),
+				Author:  roundScore(p.Similarity.Author),
+			}
+			if p.Similarity.Year != nil {
+				year := roundScore(*p.Similarity.Year)
+				pair.Year = &year
+			}
+			pairs = append(pairs, pair)
+		}
+		res = append(res, DuplicateGroupResponse{Score: roundScore(g.Score), Books: g.Books, Pairs: pairs})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   res,
+	})
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(score*1000) / 1000
+}
@@ -0,0 +1,115 @@
+package book
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "booklib/internal/domain/book"
+	usecaseBook "booklib/internal/usecase/book"
+	"booklib/internal/usecase/book/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	year := 1.0
+	groups := []usecaseBook.DuplicateGroup{
+		{
+			Books: []domain.Book{{ID: "a", Title: "Dune"}, {ID: "b", Title: "DUNE"}},
+			Pairs: []usecaseBook.DuplicatePair{
+				{BookIDs: [2]string{"a", "b"}, Similarity: usecaseBook.Similarity{Title: 1, Author: 0.87654, Year: &year, Score: 0.9567891}},
+			},
+			Score: 0.9567891,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+		expectedGroups int
+	}{
+		{
+			name: "reports duplicate groups",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("FindDuplicates", mock.Anything, usecaseBook.FindDuplicatesInput{}).Return(groups, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedGroups: 1,
+		},
+		{
+			name:  "with a minimum score",
+			query: "?min_score=0.95",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("FindDuplicates", mock.Anything, usecaseBook.FindDuplicatesInput{MinScore: 0.95}).Return(nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedGroups: 0,
+		},
+		{
+			name:           "min_score is not a number",
+			query:          "?min_score=high",
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "min_score out of range",
+			query: "?min_score=2",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("FindDuplicates", mock.Anything, usecaseBook.FindDuplicatesInput{MinScore: 2}).
+					Return(nil, fmt.Errorf("%w, got 2", usecaseBook.ErrInvalidMinScore))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "usecase error",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("FindDuplicates", mock.Anything, mock.Anything).Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Get("/books/duplicates", handler.FindDuplicates)
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/books/duplicates"+tt.query, nil))
+			require.NoError(t, err)
+
+			var res struct {
+				Status string                   `json:"status"`
+				Error  string                   `json:"error"`
+				Data   []DuplicateGroupResponse `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus != http.StatusOK {
+				assert.NotEmpty(t, res.Error)
+				return
+			}
+			require.NotNil(t, res.Data)
+			require.Len(t, res.Data, tt.expectedGroups)
+			if tt.expectedGroups > 0 {
+				assert.Equal(t, 0.957, res.Data[0].Score)
+				assert.Equal(t, DuplicatePairResponse{BookIDs: [2]string{"a", "b"}, Score: 0.957, Title: 1, Author: 0.877, Year: &year},
+					res.Data[0].Pairs[0])
+				assert.Len(t, res.Data[0].Books, 2)
+			}
+		})
+	}
+}
@@ -1,21 +1,39 @@
 package book
 
 import (
+	"time"
+
+	"booklib/internal/usecase/book"
 	"github.com/gofiber/fiber/v2"
 	"github.com/rizanw/go-log"
 )
 
 // GetAllBooks godoc
 // @Summary Get all books
-// @Description Returns a list of all books
+// @Description Returns a list of all books, oldest first. Use updated_since for incremental sync.
 // @Tags books
 // @Accept json
 // @Produce json
+// @Param updated_since query string false "Only books updated at or after this RFC 3339 timestamp"
 // @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /books [get]
 func (h *Handler) GetAllBooks(c *fiber.Ctx) error {
-	books, err := h.usecase.GetAllBooks(c.UserContext())
+	var in book.GetAllBooksInput
+
+	if raw := c.Query("updated_since"); raw != "" {
+		since, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status": "error",
+				"error":  "updated_since must be an RFC 3339 timestamp",
+			})
+		}
+		in.UpdatedSince = &since
+	}
+
+	books, err := h.usecase.GetAllBooks(c.UserContext(), in)
 	if err != nil {
 		log.Error(c.UserContext(), err, nil, "failed to get all books")
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	domain "booklib/internal/domain/book"
+	usecaseBook "booklib/internal/usecase/book"
 	"booklib/internal/usecase/book/mocks"
 
 	"github.com/gofiber/fiber/v2"
@@ -18,6 +20,7 @@ import (
 func TestGetAllBooks(t *testing.T) {
 	tests := []struct {
 		name           string
+		query          string
 		setupMocks     func(*mocks.UseCase)
 		expectedStatus int
 		expectedBody   map[string]interface{}
@@ -45,29 +48,35 @@ func TestGetAllBooks(t *testing.T) {
 						Year:   2023,
 					},
 				}
-				uc.On("GetAllBooks", mock.Anything).Return(books, nil)
+				uc.On("GetAllBooks", mock.Anything, usecaseBook.GetAllBooksInput{}).Return(books, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
-				"message": "success",
+				"status": "success",
 				"data": []interface{}{
 					map[string]interface{}{
-						"id":     "1",
-						"title":  "Book 1",
-						"author": "Author 1",
-						"year":   float64(2021),
+						"id":         "1",
+						"title":      "Book 1",
+						"author":     "Author 1",
+						"year":       float64(2021),
+						"created_at": "0001-01-01T00:00:00Z",
+						"updated_at": "0001-01-01T00:00:00Z",
 					},
 					map[string]interface{}{
-						"id":     "2",
-						"title":  "Book 2",
-						"author": "Author 2",
-						"year":   float64(2022),
+						"id":         "2",
+						"title":      "Book 2",
+						"author":     "Author 2",
+						"year":       float64(2022),
+						"created_at": "0001-01-01T00:00:00Z",
+						"updated_at": "0001-01-01T00:00:00Z",
 					},
 					map[string]interface{}{
-						"id":     "3",
-						"title":  "Book 3",
-						"author": "Author 3",
-						"year":   float64(2023),
+						"id":         "3",
+						"title":      "Book 3",
+						"author":     "Author 3",
+						"year":       float64(2023),
+						"created_at": "0001-01-01T00:00:00Z",
+						"updated_at": "0001-01-01T00:00:00Z",
 					},
 				},
 			},
@@ -75,18 +84,18 @@ func TestGetAllBooks(t *testing.T) {
 		{
 			name: "empty books list",
 			setupMocks: func(uc *mocks.UseCase) {
-				uc.On("GetAllBooks", mock.Anything).Return([]domain.Book{}, nil)
+				uc.On("GetAllBooks", mock.Anything, usecaseBook.GetAllBooksInput{}).Return([]domain.Book{}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
-				"message": "success",
-				"data":    []interface{}{},
+				"status": "success",
+				"data":   []interface{}{},
 			},
 		},
 		{
 			name: "usecase error",
 			setupMocks: func(uc *mocks.UseCase) {
-				uc.On("GetAllBooks", mock.Anything).Return(nil, errors.New("database connection error"))
+				uc.On("GetAllBooks", mock.Anything, usecaseBook.GetAllBooksInput{}).Return(nil, errors.New("database connection error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: map[string]interface{}{
@@ -105,17 +114,19 @@ func TestGetAllBooks(t *testing.T) {
 						Year:   2023,
 					},
 				}
-				uc.On("GetAllBooks", mock.Anything).Return(books, nil)
+				uc.On("GetAllBooks", mock.Anything, usecaseBook.GetAllBooksInput{}).Return(books, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
-				"message": "success",
+				"status": "success",
 				"data": []interface{}{
 					map[string]interface{}{
-						"id":     "single-id",
-						"title":  "Single Book",
-						"author": "Single Author",
-						"year":   float64(2023),
+						"id":         "single-id",
+						"title":      "Single Book",
+						"author":     "Single Author",
+						"year":       float64(2023),
+						"created_at": "0001-01-01T00:00:00Z",
+						"updated_at": "0001-01-01T00:00:00Z",
 					},
 				},
 			},
@@ -131,21 +142,65 @@ func TestGetAllBooks(t *testing.T) {
 						Year:   2024,
 					},
 				}
-				uc.On("GetAllBooks", mock.Anything).Return(books, nil)
+				uc.On("GetAllBooks", mock.Anything, usecaseBook.GetAllBooksInput{}).Return(books, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
-				"message": "success",
+				"status": "success",
 				"data": []interface{}{
 					map[string]interface{}{
-						"id":     "special-1",
-						"title":  "Title with 特殊字符",
-						"author": "Author with éàü",
-						"year":   float64(2024),
+						"id":         "special-1",
+						"title":      "Title with 特殊字符",
+						"author":     "Author with éàü",
+						"year":       float64(2024),
+						"created_at": "0001-01-01T00:00:00Z",
+						"updated_at": "0001-01-01T00:00:00Z",
 					},
 				},
 			},
 		},
+		{
+			name:  "filter by updated_since",
+			query: "?updated_since=2025-08-07T10:00:00Z",
+			setupMocks: func(uc *mocks.UseCase) {
+				since := time.Date(2025, 8, 7, 10, 0, 0, 0, time.UTC)
+				books := []domain.Book{
+					{
+						ID:        "1",
+						Title:     "Book 1",
+						Author:    "Author 1",
+						Year:      2021,
+						CreatedAt: time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC),
+						UpdatedAt: time.Date(2025, 8, 7, 11, 30, 0, 0, time.UTC),
+					},
+				}
+				uc.On("GetAllBooks", mock.Anything, usecaseBook.GetAllBooksInput{UpdatedSince: &since}).Return(books, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"status": "success",
+				"data": []interface{}{
+					map[string]interface{}{
+						"id":         "1",
+						"title":      "Book 1",
+						"author":     "Author 1",
+						"year":       float64(2021),
+						"created_at": "2025-08-01T09:00:00Z",
+						"updated_at": "2025-08-07T11:30:00Z",
+					},
+				},
+			},
+		},
+		{
+			name:           "invalid updated_since",
+			query:          "?updated_since=yesterday",
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"status": "error",
+				"error":  "updated_since must be an RFC 3339 timestamp",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -157,7 +212,7 @@ func TestGetAllBooks(t *testing.T) {
 			handler := New(usecase)
 			app.Get("/books", handler.GetAllBooks)
 
-			req := httptest.NewRequest(http.MethodGet, "/books", nil)
+			req := httptest.NewRequest(http.MethodGet, "/books"+tt.query, nil)
 
 			resp, err := app.Test(req)
 			assert.NoError(t, err)
@@ -174,4 +229,4 @@ func TestGetAllBooks(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
@@ -37,12 +37,14 @@ func TestGetBook(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
-				"message": "success",
+				"status": "success",
 				"data": map[string]interface{}{
-					"id":     "test-id",
-					"title":  "Test Book",
-					"author": "Test Author",
-					"year":   float64(2023),
+					"id":         "test-id",
+					"title":      "Test Book",
+					"author":     "Test Author",
+					"year":       float64(2023),
+					"created_at": "0001-01-01T00:00:00Z",
+					"updated_at": "0001-01-01T00:00:00Z",
 				},
 			},
 		},
@@ -84,12 +86,14 @@ func TestGetBook(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
-				"message": "success",
+				"status": "success",
 				"data": map[string]interface{}{
-					"id":     "special-id-123",
-					"title":  "Title with 特殊字符 & symbols!",
-					"author": "Author with éàü accents",
-					"year":   float64(2024),
+					"id":         "special-id-123",
+					"title":      "Title with 特殊字符 & symbols!",
+					"author":     "Author with éàü accents",
+					"year":       float64(2024),
+					"created_at": "0001-01-01T00:00:00Z",
+					"updated_at": "0001-01-01T00:00:00Z",
 				},
 			},
 		},
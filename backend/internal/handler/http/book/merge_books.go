@@ -0,0 +1,81 @@
+package book
+
+import (
+	"errors"
+
+	domain "booklib/internal/domain/book"
+	"booklib/internal/usecase/book"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// MergeBooksRequest represents the duplicates to merge into a book
+type MergeBooksRequest struct {
+	DuplicateIDs []string `json:"duplicate_ids"`
+}
+
+// MergeBooksResponse represents the outcome of a merge
+type MergeBooksResponse struct {
+	Book   *domain.Book  `json:"book"`
+	Merged []domain.Book `json:"merged"`
+}
+
+// MergeBooks godoc
+// @Summary Merge duplicate books into one
+// @Description Keeps the book {id} and removes the given duplicates in one transaction. The kept book keeps its
+// @Description fields and takes the year of a duplicate when it has none. Every duplicate gets a book.merged event
+// @Description whose after snapshot is the kept book.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the book to keep"
+// @Param merge body book.MergeBooksRequest true "Duplicates to merge"
+// @Param Idempotency-Key header string false "Unique key to safely retry the request"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /books/{id}:merge [post]
+func (h *Handler) MergeBooks(c *fiber.Ctx) error {
+	var req MergeBooksRequest
+
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  "id cannot be empty",
+		})
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  "Cannot parse JSON",
+		})
+	}
+
+	out, err := h.usecase.MergeBooks(c.UserContext(), id, book.MergeBooksInput{DuplicateIDs: req.DuplicateIDs})
+	switch {
+	case errors.Is(err, book.ErrMergeEmpty), errors.Is(err, book.ErrMergeTooLarge), errors.Is(err, book.ErrMergeSelf):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	case errors.Is(err, domain.ErrNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	case err != nil:
+		log.Error(c.UserContext(), err, nil, "failed to merge books")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   MergeBooksResponse{Book: out.Book, Merged: out.Merged},
+	})
+}
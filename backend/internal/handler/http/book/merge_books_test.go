@@ -0,0 +1,111 @@
+package book
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "booklib/internal/domain/book"
+	usecaseBook "booklib/internal/usecase/book"
+	"booklib/internal/usecase/book/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeBooks(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+	}{
+		{
+			name: "merges duplicates",
+			body: `{"duplicate_ids":["dup-1","dup-2"]}`,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("MergeBooks", mock.Anything, "kept", usecaseBook.MergeBooksInput{DuplicateIDs: []string{"dup-1", "dup-2"}}).
+					Return(&usecaseBook.MergeBooksOutput{
+						Book:   &domain.Book{ID: "kept"},
+						Merged: []domain.Book{{ID: "dup-1"}, {ID: "dup-2"}},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid JSON",
+			body:           `{"duplicate_ids":`,
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "no duplicates",
+			body: `{}`,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("MergeBooks", mock.Anything, "kept", usecaseBook.MergeBooksInput{}).Return(nil, usecaseBook.ErrMergeEmpty)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "merging a book into itself",
+			body: `{"duplicate_ids":["kept"]}`,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("MergeBooks", mock.Anything, "kept", mock.Anything).Return(nil, usecaseBook.ErrMergeSelf)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "duplicate not found",
+			body: `{"duplicate_ids":["dup-1"]}`,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("MergeBooks", mock.Anything, "kept", mock.Anything).Return(nil, fmt.Errorf("%w: dup-1", domain.ErrNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "usecase error",
+			body: `{"duplicate_ids":["dup-1"]}`,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("MergeBooks", mock.Anything, "kept", mock.Anything).Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Post("/books/:id\\:merge", handler.MergeBooks)
+
+			req := httptest.NewRequest(http.MethodPost, "/books/kept:merge", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+
+			var res struct {
+				Status string             `json:"status"`
+				Error  string             `json:"error"`
+				Data   MergeBooksResponse `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus != http.StatusOK {
+				assert.NotEmpty(t, res.Error)
+				return
+			}
+			assert.Equal(t, "kept", res.Data.Book.ID)
+			assert.Len(t, res.Data.Merged, 2)
+		})
+	}
+}
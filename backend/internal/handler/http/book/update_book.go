@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/rizanw/go-log"
 
+	domain "booklib/internal/domain/book"
 	"booklib/internal/usecase/book"
 	"github.com/gofiber/fiber/v2"
 )
@@ -35,14 +36,15 @@ func (req *UpdateBookRequest) parseValidateRequest() (book.UpdateBookInput, erro
 
 // UpdateBook godoc
 // @Summary Update an existing book
-// @Description Updates the details of a book
+// @Description Updates the details of a book and returns the updated resource
 // @Tags books
 // @Accept json
 // @Produce json
 // @Param id path string true "Book ID"
 // @Param book body book.UpdateBookRequest true "Updated book data"
-// @Success 200 {object} map[string]string
+// @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /books/{id} [put]
 func (h *Handler) UpdateBook(c *fiber.Ctx) error {
@@ -71,7 +73,14 @@ func (h *Handler) UpdateBook(c *fiber.Ctx) error {
 		})
 	}
 
-	if err = h.usecase.UpdateBook(c.UserContext(), id, in); err != nil {
+	res, err := h.usecase.UpdateBook(c.UserContext(), id, in)
+	if errors.Is(err, domain.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+	if err != nil {
 		log.Error(c.UserContext(), err, nil, "failed to update book")
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"status": "error",
@@ -81,5 +90,6 @@ func (h *Handler) UpdateBook(c *fiber.Ctx) error {
 
 	return c.JSON(fiber.Map{
 		"status": "success",
+		"data":   res,
 	})
 }
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	domain "booklib/internal/domain/book"
 	usecaseBook "booklib/internal/usecase/book"
 	"booklib/internal/usecase/book/mocks"
 
@@ -38,11 +39,24 @@ func TestUpdateBook(t *testing.T) {
 					Title:  "Updated Book",
 					Author: "Updated Author",
 					Year:   2024,
-				}).Return(nil)
+				}).Return(&domain.Book{
+					ID:     "test-id",
+					Title:  "Updated Book",
+					Author: "Updated Author",
+					Year:   2024,
+				}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
 				"status": "success",
+				"data": map[string]interface{}{
+					"id":         "test-id",
+					"title":      "Updated Book",
+					"author":     "Updated Author",
+					"year":       float64(2024),
+					"created_at": "0001-01-01T00:00:00Z",
+					"updated_at": "0001-01-01T00:00:00Z",
+				},
 			},
 		},
 		{
@@ -110,9 +124,26 @@ func TestUpdateBook(t *testing.T) {
 				Year:   2024,
 			},
 			setupMocks: func(uc *mocks.UseCase) {
-				uc.On("UpdateBook", mock.Anything, "test-id", mock.Anything).Return(errors.New("book not found"))
+				uc.On("UpdateBook", mock.Anything, "test-id", mock.Anything).Return(nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]interface{}{
+				"status": "error",
+				"error":  "database error",
+			},
+		},
+		{
+			name:   "book not found",
+			bookID: "missing-id",
+			requestBody: UpdateBookRequest{
+				Title:  "Updated Book",
+				Author: "Updated Author",
+				Year:   2024,
+			},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("UpdateBook", mock.Anything, "missing-id", mock.Anything).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
 			expectedBody: map[string]interface{}{
 				"status": "error",
 				"error":  "book not found",
@@ -131,7 +162,12 @@ func TestUpdateBook(t *testing.T) {
 					Title:  "Updated Title with 特殊字符",
 					Author: "Updated Author with éàü",
 					Year:   2024,
-				}).Return(nil)
+				}).Return(&domain.Book{
+					ID:     "special-id",
+					Title:  "Updated Title with 特殊字符",
+					Author: "Updated Author with éàü",
+					Year:   2024,
+				}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
@@ -175,6 +211,9 @@ func TestUpdateBook(t *testing.T) {
 				_, exists := responseBody[key]
 				assert.True(t, exists, "Expected key %s not found in response", key)
 			}
+			if data, ok := tt.expectedBody["data"]; ok {
+				assert.Equal(t, data, responseBody["data"])
+			}
 		})
 	}
 }
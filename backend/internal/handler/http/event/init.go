@@ -0,0 +1,27 @@
+package event
+
+import (
+	"time"
+
+	"booklib/internal/usecase/event"
+)
+
+const (
+	DefaultPollInterval = time.Second
+)
+
+type Handler struct {
+	usecase      event.UseCase
+	pollInterval time.Duration
+}
+
+func New(usecase event.UseCase, pollInterval time.Duration) *Handler {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	return &Handler{
+		usecase:      usecase,
+		pollInterval: pollInterval,
+	}
+}
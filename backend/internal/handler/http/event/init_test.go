@@ -0,0 +1,28 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"booklib/internal/usecase/event/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("creates new handler with usecase", func(t *testing.T) {
+		usecase := mocks.NewUseCase(t)
+
+		handler := New(usecase, 500*time.Millisecond)
+
+		assert.NotNil(t, handler)
+		assert.Equal(t, usecase, handler.usecase)
+		assert.Equal(t, 500*time.Millisecond, handler.pollInterval)
+	})
+
+	t.Run("falls back to the default poll interval", func(t *testing.T) {
+		handler := New(mocks.NewUseCase(t), 0)
+
+		assert.Equal(t, DefaultPollInterval, handler.pollInterval)
+	})
+}
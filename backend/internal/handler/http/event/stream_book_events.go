@@ -0,0 +1,155 @@
+package event
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	domain "booklib/internal/domain/event"
+	"booklib/internal/usecase/event"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+const (
+	HeaderLastEventID = "Last-Event-ID"
+)
+
+// StreamBookEvents godoc
+// @Summary Stream book changes
+// @Description Server-Sent Events stream of book.created, book.updated, book.deleted and book.merged events.
+// @Description Each message carries the event id, so a reconnecting client resumes after the last
+// @Description event it saw via the Last-Event-ID header (or the last_event_id query parameter).
+// @Description Without either, only events that happen after connecting are sent.
+// @Tags events
+// @Produce text/event-stream
+// @Param Last-Event-ID header string false "Resume after this event id"
+// @Param last_event_id query string false "Resume after this event id, for clients that cannot set headers"
+// @Success 200 {string} string "text/event-stream"
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /events/books [get]
+func (h *Handler) StreamBookEvents(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	lastID, ok, err := parseLastEventID(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+	if !ok {
+		if lastID, err = h.usecase.GetLatestEventID(ctx, domain.AggregateBook); err != nil {
+			log.Error(ctx, err, nil, "failed to get latest book event")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status": "error",
+				"error":  err.Error(),
+			})
+		}
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		h.streamEvents(ctx, w, domain.AggregateBook, lastID)
+	})
+
+	return nil
+}
+
+func parseLastEventID(c *fiber.Ctx) (int64, bool, error) {
+	raw := c.Get(HeaderLastEventID)
+	if raw == "" {
+		raw = c.Query("last_event_id")
+	}
+	if raw == "" {
+		return 0, false, nil
+	}
+
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id < 0 {
+		return 0, false, fmt.Errorf("invalid last event id %q", raw)
+	}
+
+	return id, true, nil
+}
+
+// streamEvents polls the event log and writes every event after lastID until
+// ctx is done or the client goes away.
+func (h *Handler) streamEvents(ctx context.Context, w *bufio.Writer, aggregateType string, lastID int64) {
+	// the request context is not cancelled when the client goes away; this
+	// one is, on the first failed write
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	flush := func() bool {
+		if err := w.Flush(); err != nil {
+			cancel()
+			return false
+		}
+		return true
+	}
+
+	// clients wait this long before reconnecting after a dropped connection
+	fmt.Fprintf(w, "retry: %d\n\n", h.pollInterval.Milliseconds())
+	if !flush() {
+		return
+	}
+
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+
+	for ctx.Err() == nil {
+		events, err := h.usecase.GetEvents(ctx, event.GetEventsInput{
+			AggregateType: aggregateType,
+			AfterID:       lastID,
+		})
+		if err != nil {
+			log.Error(ctx, err, nil, "failed to read events for stream")
+		}
+
+		for _, ev := range events {
+			if err = writeEvent(w, ev); err != nil {
+				log.Error(ctx, err, nil, "failed to write event")
+				return
+			}
+			lastID = ev.ID
+		}
+		if len(events) == 0 {
+			// flushing nothing never fails, so idle polls write a comment
+			// to notice a client that went away, which also keeps the
+			// connection open through proxies
+			fmt.Fprint(w, ": heartbeat\n\n")
+		}
+		if !flush() {
+			return
+		}
+
+		// a full page means there is a backlog to catch up on
+		if len(events) == event.DefaultEventsLimit {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func writeEvent(w *bufio.Writer, ev domain.Event) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", ev.ID, ev.Type, data)
+	return err
+}
@@ -0,0 +1,184 @@
+package event
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/event"
+	usecaseEvent "booklib/internal/usecase/event"
+	"booklib/internal/usecase/event/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStreamBookEvents(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		lastEventID    string
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{
+			name:           "invalid Last-Event-ID header",
+			url:            "/events/books",
+			lastEventID:    "abc",
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"status": "error",
+				"error":  `invalid last event id "abc"`,
+			},
+		},
+		{
+			name:           "negative last_event_id query",
+			url:            "/events/books?last_event_id=-1",
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"status": "error",
+				"error":  `invalid last event id "-1"`,
+			},
+		},
+		{
+			name: "latest event lookup fails",
+			url:  "/events/books",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetLatestEventID", mock.Anything, domain.AggregateBook).Return(int64(0), errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]interface{}{
+				"status": "error",
+				"error":  "usecase error",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := mocks.NewUseCase(t)
+			tt.setupMocks(uc)
+
+			handler := New(uc, time.Millisecond)
+			app := fiber.New()
+			app.Get("/events/books", handler.StreamBookEvents)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.lastEventID != "" {
+				req.Header.Set(HeaderLastEventID, tt.lastEventID)
+			}
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			var body map[string]interface{}
+			err = json.NewDecoder(resp.Body).Decode(&body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedBody, body)
+		})
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	tests := []struct {
+		name         string
+		lastID       int64
+		setupMocks   func(*mocks.UseCase, context.CancelFunc)
+		expectedBody string
+	}{
+		{
+			name:   "writes events after the last id",
+			lastID: 3,
+			setupMocks: func(uc *mocks.UseCase, cancel context.CancelFunc) {
+				uc.On("GetEvents", mock.Anything, usecaseEvent.GetEventsInput{AggregateType: domain.AggregateBook, AfterID: 3}).
+					Return([]domain.Event{
+						{ID: 4, Type: domain.TypeBookCreated, AggregateType: domain.AggregateBook, AggregateID: "id-1", After: json.RawMessage(`{"id":"id-1"}`)},
+						{ID: 5, Type: domain.TypeBookDeleted, AggregateType: domain.AggregateBook, AggregateID: "id-1", Before: json.RawMessage(`{"id":"id-1"}`)},
+					}, nil).Once()
+				// the next poll resumes after the last written event
+				uc.On("GetEvents", mock.Anything, usecaseEvent.GetEventsInput{AggregateType: domain.AggregateBook, AfterID: 5}).
+					Run(func(mock.Arguments) { cancel() }).
+					Return([]domain.Event{}, nil).Once()
+			},
+			expectedBody: "retry: 1\n\n" +
+				"id: 4\nevent: book.created\ndata: " +
+				`{"id":4,"type":"book.created","aggregate_type":"book","aggregate_id":"id-1","after":{"id":"id-1"},"occurred_at":"0001-01-01T00:00:00Z"}` + "\n\n" +
+				"id: 5\nevent: book.deleted\ndata: " +
+				`{"id":5,"type":"book.deleted","aggregate_type":"book","aggregate_id":"id-1","before":{"id":"id-1"},"occurred_at":"0001-01-01T00:00:00Z"}` + "\n\n" +
+				": heartbeat\n\n",
+		},
+		{
+			name:   "read errors keep the stream open",
+			lastID: 0,
+			setupMocks: func(uc *mocks.UseCase, cancel context.CancelFunc) {
+				uc.On("GetEvents", mock.Anything, mock.Anything).
+					Run(func(mock.Arguments) { cancel() }).
+					Return(nil, errors.New("usecase error")).Once()
+			},
+			expectedBody: "retry: 1\n\n: heartbeat\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			uc := mocks.NewUseCase(t)
+			tt.setupMocks(uc, cancel)
+
+			var buf bytes.Buffer
+			w := bufio.NewWriter(&buf)
+
+			handler := New(uc, time.Millisecond)
+			handler.streamEvents(ctx, w, domain.AggregateBook, tt.lastID)
+
+			assert.Equal(t, tt.expectedBody, buf.String())
+		})
+	}
+}
+
+// failingWriter fails every write after the first n bytes, like a connection
+// the client closed.
+type failingWriter struct {
+	n int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		return 0, errors.New("broken pipe")
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+func TestStreamEvents_ClientGone(t *testing.T) {
+	uc := mocks.NewUseCase(t)
+	// an idle poll writes a heartbeat, whose flush fails, so the stream ends
+	// without polling again
+	uc.On("GetEvents", mock.Anything, mock.Anything).Return([]domain.Event{}, nil).Once()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler := New(uc, time.Millisecond)
+		handler.streamEvents(context.Background(), bufio.NewWriter(&failingWriter{n: len("retry: 1\n\n")}), domain.AggregateBook, 0)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("stream still running after the client went away")
+	}
+}
@@ -0,0 +1,67 @@
+package link
+
+import (
+	"errors"
+	"strings"
+
+	domain "booklib/internal/domain/link"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// CreateLink godoc
+// @Summary Shorten a URL
+// @Description Stores the canonical form of the URL, as the URL processor gives it, under a short code redirecting from /l/{code}.
+// @Description A URL without alias nor expiry that was already shortened returns the existing link with 200.
+// @Tags links
+// @Accept json
+// @Produce json
+// @Param link body link.CreateLinkRequest true "URL to shorten"
+// @Param Idempotency-Key header string false "Unique key to safely retry the request"
+// @Success 200 {object} map[string]interface{}
+// @Success 201 {object} map[string]interface{}
+// @Header 201 {string} Location "URL of the created link"
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /links [post]
+func (h *Handler) CreateLink(c *fiber.Ctx) error {
+	var req CreateLinkRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  "Cannot parse JSON",
+		})
+	}
+
+	res, err := h.usecase.CreateLink(c.UserContext(), req.toInput())
+	switch {
+	case errors.Is(err, domain.ErrInvalidURL), errors.Is(err, domain.ErrInvalidAlias), errors.Is(err, domain.ErrInvalidExpiry):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	case errors.Is(err, domain.ErrCodeTaken):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	case err != nil:
+		log.Error(c.UserContext(), err, nil, "failed to create link")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	c.Location(strings.TrimSuffix(c.Path(), "/") + "/" + res.Link.Code)
+	status := fiber.StatusOK
+	if res.Created {
+		status = fiber.StatusCreated
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"status": "success",
+		"data":   h.newLinkResponse(c, res.Link),
+	})
+}
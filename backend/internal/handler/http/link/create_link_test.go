@@ -0,0 +1,164 @@
+package link
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/link"
+	usecaseLink "booklib/internal/usecase/link"
+	"booklib/internal/usecase/link/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateLink(t *testing.T) {
+	var (
+		createdAt = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+		expiresAt = time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+		link      = &domain.Link{Code: "aB3dE5f", TargetURL: "https://example.com/a", CreatedAt: createdAt}
+	)
+
+	tests := []struct {
+		name             string
+		baseURL          string
+		requestBody      interface{}
+		setupMocks       func(*mocks.UseCase)
+		expectedStatus   int
+		expectedBody     map[string]interface{}
+		expectedLocation string
+	}{
+		{
+			name:        "successful create link",
+			baseURL:     "https://sho.rt/",
+			requestBody: CreateLinkRequest{URL: " https://Example.com/a "},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CreateLink", mock.Anything, usecaseLink.CreateLinkInput{URL: "https://Example.com/a"}).
+					Return(&usecaseLink.CreateLinkOutput{Link: link, Created: true}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: map[string]interface{}{
+				"status": "success",
+				"data": map[string]interface{}{
+					"code":       "aB3dE5f",
+					"short_url":  "https://sho.rt/l/aB3dE5f",
+					"target_url": "https://example.com/a",
+					"custom":     false,
+					"created_at": "2026-10-19T10:00:00Z",
+				},
+			},
+			expectedLocation: "/links/aB3dE5f",
+		},
+		{
+			name:        "existing link",
+			requestBody: CreateLinkRequest{URL: "https://example.com/a"},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CreateLink", mock.Anything, mock.Anything).Return(&usecaseLink.CreateLinkOutput{Link: link}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"status": "success",
+				"data": map[string]interface{}{
+					"code":       "aB3dE5f",
+					"short_url":  "http://example.com/l/aB3dE5f",
+					"target_url": "https://example.com/a",
+					"custom":     false,
+					"created_at": "2026-10-19T10:00:00Z",
+				},
+			},
+		},
+		{
+			name:        "custom alias with expiry",
+			requestBody: `{"url": "https://example.com/a", "alias": "dune", "expires_at": "2026-11-01T00:00:00Z"}`,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CreateLink", mock.Anything, usecaseLink.CreateLinkInput{URL: "https://example.com/a", Alias: "dune", ExpiresAt: &expiresAt}).
+					Return(&usecaseLink.CreateLinkOutput{
+						Link:    &domain.Link{Code: "dune", TargetURL: "https://example.com/a", Custom: true, ExpiresAt: &expiresAt, CreatedAt: createdAt},
+						Created: true,
+					}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: map[string]interface{}{
+				"status": "success",
+				"data": map[string]interface{}{
+					"code":       "dune",
+					"short_url":  "http://example.com/l/dune",
+					"target_url": "https://example.com/a",
+					"custom":     true,
+					"expires_at": "2026-11-01T00:00:00Z",
+					"created_at": "2026-10-19T10:00:00Z",
+				},
+			},
+		},
+		{
+			name:           "invalid JSON body",
+			requestBody:    `{"url": 1}`,
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "Cannot parse JSON"},
+		},
+		{
+			name:        "invalid url",
+			requestBody: CreateLinkRequest{URL: "/a"},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CreateLink", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidURL)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "url must be an absolute http or https URL"},
+		},
+		{
+			name:        "invalid alias",
+			requestBody: CreateLinkRequest{URL: "https://example.com/a", Alias: "a"},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CreateLink", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidAlias)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "alias must be 3 to 64 letters, digits, '-' or '_'"},
+		},
+		{
+			name:        "alias taken",
+			requestBody: CreateLinkRequest{URL: "https://example.com/a", Alias: "dune"},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CreateLink", mock.Anything, mock.Anything).Return(nil, domain.ErrCodeTaken)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "link code is already taken"},
+		},
+		{
+			name:        "usecase error",
+			requestBody: CreateLinkRequest{URL: "https://example.com/a"},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CreateLink", mock.Anything, mock.Anything).Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "usecase error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase, tt.baseURL)
+			app.Post("/links", func(c *fiber.Ctx) error {
+				err := handler.CreateLink(c)
+				if tt.expectedLocation != "" {
+					assert.Equal(t, tt.expectedLocation, string(c.Response().Header.Peek(fiber.HeaderLocation)))
+				}
+				return err
+			})
+
+			status, body := doRequest(t, app, http.MethodPost, "/links", tt.requestBody)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedBody != nil {
+				assert.Equal(t, tt.expectedBody, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package link
+
+import (
+	"errors"
+
+	domain "booklib/internal/domain/link"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// GetLink godoc
+// @Summary Get a short link with its clicks
+// @Description Clicks are counted per UTC day, oldest first; days without clicks are left out.
+// @Tags links
+// @Produce json
+// @Param code path string true "Link code"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /links/{code} [get]
+func (h *Handler) GetLink(c *fiber.Ctx) error {
+	res, err := h.usecase.GetLink(c.UserContext(), c.Params("code"))
+	if errors.Is(err, domain.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+	if err != nil {
+		log.Error(c.UserContext(), err, nil, "failed to get link")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data": LinkStatsResponse{
+			LinkResponse: h.newLinkResponse(c, res.Link),
+			Clicks:       res.Clicks,
+			TotalClicks:  res.TotalClicks,
+		},
+	})
+}
@@ -0,0 +1,89 @@
+package link
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/link"
+	usecaseLink "booklib/internal/usecase/link"
+	"booklib/internal/usecase/link/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetLink(t *testing.T) {
+	link := &domain.Link{Code: "aB3dE5f", TargetURL: "https://example.com/a", CreatedAt: time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name           string
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{
+			name: "link with clicks",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetLink", mock.Anything, "aB3dE5f").Return(&usecaseLink.GetLinkOutput{
+					Link: link,
+					Clicks: []domain.DailyClicks{
+						{Day: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), Clicks: 3},
+						{Day: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Clicks: 4},
+					},
+					TotalClicks: 7,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"status": "success",
+				"data": map[string]interface{}{
+					"code":       "aB3dE5f",
+					"short_url":  "https://sho.rt/l/aB3dE5f",
+					"target_url": "https://example.com/a",
+					"custom":     false,
+					"created_at": "2026-10-19T10:00:00Z",
+					"clicks": []interface{}{
+						map[string]interface{}{"day": "2026-10-18T00:00:00Z", "clicks": float64(3)},
+						map[string]interface{}{"day": "2026-10-19T00:00:00Z", "clicks": float64(4)},
+					},
+					"total_clicks": float64(7),
+				},
+			},
+		},
+		{
+			name: "not found",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetLink", mock.Anything, "aB3dE5f").Return(nil, domain.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "link not found"},
+		},
+		{
+			name: "usecase error",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetLink", mock.Anything, "aB3dE5f").Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "usecase error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase, "https://sho.rt")
+			app.Get("/links/:code", handler.GetLink)
+
+			status, body := doRequest(t, app, http.MethodGet, "/links/aB3dE5f", nil)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedBody, body)
+		})
+	}
+}
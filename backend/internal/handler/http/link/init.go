@@ -0,0 +1,19 @@
+package link
+
+import "booklib/internal/usecase/link"
+
+// VisitPath prefixes the codes of short URLs.
+const VisitPath = "/l/"
+
+type Handler struct {
+	usecase link.UseCase
+	// baseURL prefixes short URLs, the URL of the request when empty
+	baseURL string
+}
+
+func New(usecase link.UseCase, baseURL string) *Handler {
+	return &Handler{
+		usecase: usecase,
+		baseURL: baseURL,
+	}
+}
@@ -0,0 +1,60 @@
+package link
+
+import (
+	"strings"
+	"time"
+
+	domain "booklib/internal/domain/link"
+	"booklib/internal/usecase/link"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateLinkRequest represents the request payload for shortening a URL
+type CreateLinkRequest struct {
+	URL string `json:"url"`
+	// Alias is the code of the short URL, 3 to 64 letters, digits, '-' or '_'; a random one is generated when empty
+	Alias string `json:"alias"`
+	// ExpiresAt is when the short URL stops redirecting; it never does when empty
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (req *CreateLinkRequest) toInput() link.CreateLinkInput {
+	return link.CreateLinkInput{
+		URL:       strings.TrimSpace(req.URL),
+		Alias:     req.Alias,
+		ExpiresAt: req.ExpiresAt,
+	}
+}
+
+// LinkResponse is a short URL with what it redirects to
+type LinkResponse struct {
+	Code      string     `json:"code"`
+	ShortURL  string     `json:"short_url"`
+	TargetURL string     `json:"target_url"`
+	Custom    bool       `json:"custom"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// LinkStatsResponse is a short URL with its clicks per UTC day
+type LinkStatsResponse struct {
+	LinkResponse
+	Clicks      []domain.DailyClicks `json:"clicks"`
+	TotalClicks int64                `json:"total_clicks"`
+}
+
+func (h *Handler) newLinkResponse(c *fiber.Ctx, l *domain.Link) LinkResponse {
+	baseURL := h.baseURL
+	if baseURL == "" {
+		baseURL = c.BaseURL()
+	}
+
+	return LinkResponse{
+		Code:      l.Code,
+		ShortURL:  strings.TrimSuffix(baseURL, "/") + VisitPath + l.Code,
+		TargetURL: l.TargetURL,
+		Custom:    l.Custom,
+		ExpiresAt: l.ExpiresAt,
+		CreatedAt: l.CreatedAt,
+	}
+}
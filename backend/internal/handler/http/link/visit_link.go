@@ -0,0 +1,37 @@
+package link
+
+import (
+	"errors"
+
+	domain "booklib/internal/domain/link"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// VisitLink redirects a short URL, served at /l/:code outside of the API, to
+// its target and counts the click. The redirect is temporary so browsers
+// come back and every click is counted.
+func (h *Handler) VisitLink(c *fiber.Ctx) error {
+	res, err := h.usecase.VisitLink(c.UserContext(), c.Params("code"))
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	case errors.Is(err, domain.ErrExpired):
+		return c.Status(fiber.StatusGone).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	case err != nil:
+		log.Error(c.UserContext(), err, nil, "failed to visit link")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderCacheControl, "private, max-age=0")
+	return c.Redirect(res.TargetURL, fiber.StatusFound)
+}
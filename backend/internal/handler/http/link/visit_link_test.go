@@ -0,0 +1,71 @@
+package link
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "booklib/internal/domain/link"
+	"booklib/internal/usecase/link/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVisitLink(t *testing.T) {
+	tests := []struct {
+		name             string
+		setupMocks       func(*mocks.UseCase)
+		expectedStatus   int
+		expectedLocation string
+	}{
+		{
+			name: "redirects to the target",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("VisitLink", mock.Anything, "aB3dE5f").Return(&domain.Link{Code: "aB3dE5f", TargetURL: "https://example.com/a?b=1"}, nil)
+			},
+			expectedStatus:   http.StatusFound,
+			expectedLocation: "https://example.com/a?b=1",
+		},
+		{
+			name: "not found",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("VisitLink", mock.Anything, "aB3dE5f").Return(nil, domain.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "expired",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("VisitLink", mock.Anything, "aB3dE5f").Return(nil, domain.ErrExpired)
+			},
+			expectedStatus: http.StatusGone,
+		},
+		{
+			name: "usecase error",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("VisitLink", mock.Anything, "aB3dE5f").Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase, "")
+			app.Get("/l/:code", handler.VisitLink)
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/l/aB3dE5f", nil))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectedLocation, resp.Header.Get(fiber.HeaderLocation))
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package urlprocessor
+
+import (
+	"errors"
+	"mime"
+
+	domain "booklib/internal/domain/url-processor"
+	urlprocessor "booklib/internal/usecase/url-processor"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// ProcessDocumentResponse is the rewritten document and the report of its links
+type ProcessDocumentResponse struct {
+	Document string `json:"document"`
+	// Links lists the links the operation changed or failed on, in document order
+	Links []ProcessDocumentLink `json:"links"`
+	// Total counts the links processed, Changed and Failed those of links
+	Total   int `json:"total"`
+	Changed int `json:"changed"`
+	Failed  int `json:"failed"`
+	// Skipped counts the links that are not absolute http or https URLs, left as they are
+	Skipped int `json:"skipped"`
+}
+
+// ProcessDocumentLink is a link of the document the operation changed or failed on
+type ProcessDocumentLink struct {
+	// Element holds the link: a, link, img or loc
+	Element string `json:"element"`
+	// Attribute holds the link in element, absent for loc
+	Attribute    string `json:"attribute,omitempty"`
+	Line         int    `json:"line"`
+	Url          string `json:"url"`
+	ProcessedUrl string `json:"processed_url,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// ProcessDocument godoc
+// @Summary Rewrite the links of an HTML or sitemap document
+// @Description Runs an operation on every link of the document sent as the body: the href of <a> and <link> and
+// @Description the src of <img> in HTML, the <loc> elements in sitemap and sitemap index XML. Links the operation
+// @Description changes are rewritten in place and everything else is returned byte for byte as it was sent.
+// @Description Only absolute http and https links are processed; relative, mailto: and other links are skipped.
+// @Description The format is read from the Content-Type, text/html or application/xml, unless given. links
+// @Description reports the links changed, and those that failed, which are left as they were. A document with more
+// @Description distinct links than the configured maximum is refused.
+// @Tags URLProcessor
+// @Accept html,xml
+// @Produce json
+// @Param operation query string true "Operation to run"
+// @Param profile query string false "Profile holding the operation, the configured default profile when empty"
+// @Param format query string false "Format of the document, read from the Content-Type when empty" Enums(html, sitemap)
+// @Param request body string true "HTML or sitemap document"
+// @Success 200 {object} ProcessDocumentResponse
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /process-document [post]
+func (h *Handler) ProcessDocument(c *fiber.Ctx) error {
+	in, err := parseProcessDocumentRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	res, err := h.usecase.RewriteDocument(c.UserContext(), in)
+	if errors.Is(err, urlprocessor.ErrUnknownFormat) || errors.Is(err, urlprocessor.ErrInvalidDocument) ||
+		errors.Is(err, urlprocessor.ErrTooManyLinks) || errors.Is(err, domain.ErrInvalidOperation) ||
+		errors.Is(err, domain.ErrUnknownProfile) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if err != nil {
+		log.Error(c.UserContext(), err, nil, "failed to rewrite document")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	resp := ProcessDocumentResponse{
+		Document: string(res.Document),
+		Links:    make([]ProcessDocumentLink, 0, len(res.Links)),
+		Total:    res.Total,
+		Changed:  res.Changed,
+		Failed:   res.Failed,
+		Skipped:  res.Skipped,
+	}
+	for _, l := range res.Links {
+		link := ProcessDocumentLink{
+			Element:      l.Element,
+			Attribute:    l.Attribute,
+			Line:         l.Line,
+			Url:          l.URL,
+			ProcessedUrl: l.RewrittenURL,
+		}
+		if l.Err != nil {
+			link.Error = l.Err.Error()
+		}
+		resp.Links = append(resp.Links, link)
+	}
+	return c.JSON(resp)
+}
+
+func parseProcessDocumentRequest(c *fiber.Ctx) (urlprocessor.RewriteDocumentInput, error) {
+	in := urlprocessor.RewriteDocumentInput{
+		Format:    c.Query("format"),
+		Profile:   c.Query("profile"),
+		Operation: c.Query("operation"),
+		Document:  c.Body(),
+	}
+	if in.Operation == "" {
+		return in, errors.New("operation cannot be empty")
+	}
+	if len(in.Document) == 0 {
+		return in, errors.New("document cannot be empty")
+	}
+
+	if in.Format == "" {
+		mediaType, _, _ := mime.ParseMediaType(c.Get(fiber.HeaderContentType))
+		switch mediaType {
+		case fiber.MIMETextHTML, "application/xhtml+xml":
+			in.Format = urlprocessor.FormatHTML
+		case fiber.MIMEApplicationXML, fiber.MIMETextXML:
+			in.Format = urlprocessor.FormatSitemap
+		default:
+			return in, errors.New("format cannot be told from the content type, set it to html or sitemap")
+		}
+	}
+	return in, nil
+}
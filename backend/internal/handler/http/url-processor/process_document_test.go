@@ -0,0 +1,200 @@
+package urlprocessor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	domain "booklib/internal/domain/url-processor"
+	urlprocessor "booklib/internal/usecase/url-processor"
+	"booklib/internal/usecase/url-processor/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessDocument(t *testing.T) {
+	const (
+		page    = `<a href="https://example.com/a?utm_source=x">a</a><a href="https://example.com:99999/">b</a>`
+		sitemap = `<urlset><url><loc>https://example.com/a/</loc></url></urlset>`
+	)
+
+	tests := []struct {
+		name           string
+		query          string
+		contentType    string
+		body           string
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "HTML from the content type",
+			query:       "operation=clean&profile=shop",
+			contentType: "text/html; charset=utf-8",
+			body:        page,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("RewriteDocument", mock.Anything, urlprocessor.RewriteDocumentInput{
+					Format: urlprocessor.FormatHTML, Document: []byte(page), Profile: "shop", Operation: "clean",
+				}).Return(&urlprocessor.RewriteDocumentOutput{
+					Document: []byte(`<a href="https://example.com/a">a</a><a href="https://example.com:99999/">b</a>`),
+					Links: []urlprocessor.DocumentLink{
+						{Element: "a", Attribute: "href", Line: 1, URL: "https://example.com/a?utm_source=x", RewrittenURL: "https://example.com/a"},
+						{Element: "a", Attribute: "href", Line: 1, URL: "https://example.com:99999/", Err: fmt.Errorf("%w: invalid port", domain.ErrInvalidURL)},
+					},
+					Total: 2, Changed: 1, Failed: 1, Skipped: 3,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"document":"<a href=\"https://example.com/a\">a</a><a href=\"https://example.com:99999/\">b</a>","links":[` +
+				`{"element":"a","attribute":"href","line":1,"url":"https://example.com/a?utm_source=x","processed_url":"https://example.com/a"},` +
+				`{"element":"a","attribute":"href","line":1,"url":"https://example.com:99999/","error":"invalid url: invalid port"}],` +
+				`"total":2,"changed":1,"failed":1,"skipped":3}`,
+		},
+		{
+			name:        "sitemap from the content type",
+			query:       "operation=canonical",
+			contentType: fiber.MIMEApplicationXMLCharsetUTF8,
+			body:        sitemap,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("RewriteDocument", mock.Anything, urlprocessor.RewriteDocumentInput{
+					Format: urlprocessor.FormatSitemap, Document: []byte(sitemap), Operation: "canonical",
+				}).Return(&urlprocessor.RewriteDocumentOutput{
+					Document: []byte(`<urlset><url><loc>https://example.com/a</loc></url></urlset>`),
+					Links:    []urlprocessor.DocumentLink{{Element: "loc", Line: 1, URL: "https://example.com/a/", RewrittenURL: "https://example.com/a"}},
+					Total:    1, Changed: 1,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"document":"<urlset><url><loc>https://example.com/a</loc></url></urlset>","links":[` +
+				`{"element":"loc","line":1,"url":"https://example.com/a/","processed_url":"https://example.com/a"}],"total":1,"changed":1,"failed":0,"skipped":0}`,
+		},
+		{
+			name:        "format given over the content type",
+			query:       "operation=canonical&format=sitemap",
+			contentType: fiber.MIMETextPlain,
+			body:        sitemap,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("RewriteDocument", mock.Anything, urlprocessor.RewriteDocumentInput{
+					Format: urlprocessor.FormatSitemap, Document: []byte(sitemap), Operation: "canonical",
+				}).Return(&urlprocessor.RewriteDocumentOutput{Document: []byte(sitemap), Total: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"document":"<urlset><url><loc>https://example.com/a/</loc></url></urlset>",` +
+				`"links":[],"total":1,"changed":0,"failed":0,"skipped":0}`,
+		},
+		{
+			name:           "without operation",
+			contentType:    fiber.MIMETextHTML,
+			body:           page,
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"operation cannot be empty"}`,
+		},
+		{
+			name:           "empty body",
+			query:          "operation=clean",
+			contentType:    fiber.MIMETextHTML,
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"document cannot be empty"}`,
+		},
+		{
+			name:           "format unknown from the content type",
+			query:          "operation=clean",
+			contentType:    fiber.MIMEApplicationJSON,
+			body:           `{}`,
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"format cannot be told from the content type, set it to html or sitemap"}`,
+		},
+		{
+			name:        "unknown format",
+			query:       "operation=clean&format=pdf",
+			contentType: fiber.MIMETextHTML,
+			body:        page,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("RewriteDocument", mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("%w, got %q", urlprocessor.ErrUnknownFormat, "pdf"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"format must be html or sitemap, got \"pdf\""}`,
+		},
+		{
+			name:        "invalid document",
+			query:       "operation=clean",
+			contentType: fiber.MIMEApplicationXML,
+			body:        `<urlset>`,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("RewriteDocument", mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("%w: XML syntax error on line 1: unexpected EOF", urlprocessor.ErrInvalidDocument))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid document: XML syntax error on line 1: unexpected EOF"}`,
+		},
+		{
+			name:        "unknown operation",
+			query:       "operation=shout",
+			contentType: fiber.MIMETextHTML,
+			body:        page,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("RewriteDocument", mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("%w %q", domain.ErrInvalidOperation, "shout"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid operation \"shout\""}`,
+		},
+		{
+			name:        "too many links",
+			query:       "operation=clean",
+			contentType: fiber.MIMETextHTML,
+			body:        page,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("RewriteDocument", mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("%w, the maximum is 1", urlprocessor.ErrTooManyLinks))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"document contains too many links, the maximum is 1"}`,
+		},
+		{
+			name:        "usecase internal error",
+			query:       "operation=clean",
+			contentType: fiber.MIMETextHTML,
+			body:        page,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("RewriteDocument", mock.Anything, mock.Anything).
+					Return(nil, errors.New("internal server error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Post("/process-document", handler.ProcessDocument)
+
+			req := httptest.NewRequest(http.MethodPost, "/process-document?"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, tt.contentType)
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expectedBody, string(body))
+		})
+	}
+}
@@ -4,13 +4,60 @@ import (
 	"errors"
 	"github.com/rizanw/go-log"
 
+	domain "booklib/internal/domain/url-processor"
+	urlprocessor "booklib/internal/usecase/url-processor"
 	"github.com/gofiber/fiber/v2"
 )
 
-// ProcessUrlRequest represents the request payload for adding a book
+// ProcessUrlRequest represents the request payload for processing a URL
 type ProcessUrlRequest struct {
 	Url       string `json:"url"`
 	Operation string `json:"operation"`
+	// Profile holds the operation, the configured default profile when empty
+	Profile string `json:"profile,omitempty"`
+	// Explain adds what every step did to the response, as the explain query parameter does
+	Explain bool `json:"explain,omitempty"`
+}
+
+// ProcessUrlResponse is the processed URL and the query params removed from it
+type ProcessUrlResponse struct {
+	ProcessedUrl string `json:"processed_url"`
+	// DisplayUrl is the processed URL with its punycode host in Unicode
+	DisplayUrl    string           `json:"display_url,omitempty"`
+	RemovedParams []string         `json:"removed_params,omitempty"`
+	Steps         []ProcessUrlStep `json:"steps,omitempty"`
+	// Chain lists the requests made by resolve steps
+	Chain []ProcessUrlHop `json:"chain,omitempty"`
+	// CanonicalUrl is the canonical link of the page a resolve step reached
+	CanonicalUrl string `json:"canonical_url,omitempty"`
+	// RuleSet is the stored rule set whose operation ran, absent when the one of the profile did
+	RuleSet *ProcessUrlRuleSet `json:"rule_set,omitempty"`
+}
+
+// ProcessUrlRuleSet names the URL rule set applied to a URL
+type ProcessUrlRuleSet struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ProcessUrlHop is one request made following the redirects of a URL
+type ProcessUrlHop struct {
+	Url      string `json:"url"`
+	Method   string `json:"method"`
+	Status   int    `json:"status"`
+	Location string `json:"location,omitempty"`
+}
+
+// ProcessUrlStep explains what one step did to the URL
+type ProcessUrlStep struct {
+	Type string `json:"type"`
+	// Operation is the operation defining the step, another one than requested for included operations
+	Operation string `json:"operation"`
+	Before    string `json:"before"`
+	After     string `json:"after"`
+	// Changed lists the changed components: scheme, userinfo, host, path, query and fragment
+	Changed       []string `json:"changed"`
+	RemovedParams []string `json:"removed_params,omitempty"`
 }
 
 func (req *ProcessUrlRequest) validate() error {
@@ -25,13 +72,24 @@ func (req *ProcessUrlRequest) validate() error {
 
 // ProcessUrl godoc
 // @Summary Clean and process a URL
-// @Description Cleans or modifies a URL based on the specified operation.
+// @Description Cleans or modifies a URL based on the specified operation of a rule profile. Operations are
+// @Description pipelines of steps configured in config.yaml; the built-in default profile has canonical,
+// @Description redirection, all, clean, which only strips tracking params such as utm_* and fbclid, and
+// @Description normalize, which applies RFC 3986 normalisation. removed_params lists the query params the
+// @Description operation removed; display_url is given when the host is an internationalised domain name.
+// @Description With explain=true, steps lists every step run with the URL before and after it and the
+// @Description components it changed. resolve follows the redirects of the URL and answers with the canonical
+// @Description link of the page reached, chain listing every request made; a URL that cannot be resolved, for
+// @Description a redirect loop or too many redirects, gets 422. The active URL rule sets matching the host of
+// @Description the URL and defining the operation take precedence over the profile; rule_set names the one applied.
 // @Tags URLProcessor
 // @Accept json
 // @Produce json
 // @Param request body ProcessUrlRequest true "URL processor request payload"
-// @Success 201 {object} map[string]string "Processed URL returned"
+// @Param explain query bool false "Explain what every step did"
+// @Success 201 {object} ProcessUrlResponse "Processed URL returned"
 // @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 422 {object} map[string]string "URL cannot be resolved"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /process-url [post]
 func (h *Handler) ProcessUrl(c *fiber.Ctx) error {
@@ -49,7 +107,22 @@ func (h *Handler) ProcessUrl(c *fiber.Ctx) error {
 		})
 	}
 
-	res, err := h.usecase.CleanURL(c.UserContext(), req.Operation, req.Url)
+	res, err := h.usecase.CleanURL(c.UserContext(), urlprocessor.CleanURLInput{
+		Profile:   req.Profile,
+		Operation: req.Operation,
+		URL:       req.Url,
+		Explain:   req.Explain || c.QueryBool("explain"),
+	})
+	if errors.Is(err, domain.ErrInvalidURL) || errors.Is(err, domain.ErrInvalidOperation) || errors.Is(err, domain.ErrUnknownProfile) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if errors.Is(err, domain.ErrUnresolvable) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 	if err != nil {
 		log.Error(c.UserContext(), err, nil, "failed to clean url")
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -57,7 +130,56 @@ func (h *Handler) ProcessUrl(c *fiber.Ctx) error {
 		})
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"processed_url": res,
+	return c.Status(fiber.StatusCreated).JSON(ProcessUrlResponse{
+		ProcessedUrl:  res.URL,
+		DisplayUrl:    res.DisplayURL,
+		RemovedParams: res.RemovedParams,
+		Steps:         newProcessUrlSteps(res.Steps),
+		Chain:         newProcessUrlChain(res.Chain),
+		CanonicalUrl:  res.CanonicalURL,
+		RuleSet:       newProcessUrlRuleSet(res.RuleSet),
 	})
 }
+
+func newProcessUrlSteps(steps []urlprocessor.AppliedStep) []ProcessUrlStep {
+	if steps == nil {
+		return nil
+	}
+
+	result := make([]ProcessUrlStep, 0, len(steps))
+	for _, s := range steps {
+		result = append(result, ProcessUrlStep{
+			Type:          s.Type,
+			Operation:     s.Operation,
+			Before:        s.Before,
+			After:         s.After,
+			Changed:       s.Changed,
+			RemovedParams: s.RemovedParams,
+		})
+	}
+	return result
+}
+
+func newProcessUrlRuleSet(ref *urlprocessor.RuleSetRef) *ProcessUrlRuleSet {
+	if ref == nil {
+		return nil
+	}
+	return &ProcessUrlRuleSet{ID: ref.ID, Name: ref.Name}
+}
+
+func newProcessUrlChain(chain []urlprocessor.Hop) []ProcessUrlHop {
+	if chain == nil {
+		return nil
+	}
+
+	result := make([]ProcessUrlHop, 0, len(chain))
+	for _, hop := range chain {
+		result = append(result, ProcessUrlHop{
+			Url:      hop.URL,
+			Method:   hop.Method,
+			Status:   hop.Status,
+			Location: hop.Location,
+		})
+	}
+	return result
+}
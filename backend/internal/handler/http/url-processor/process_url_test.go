@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	domain "booklib/internal/domain/url-processor"
+	urlprocessor "booklib/internal/usecase/url-processor"
 	"booklib/internal/usecase/url-processor/mocks"
 
 	"github.com/gofiber/fiber/v2"
@@ -30,8 +33,8 @@ func TestProcessUrl(t *testing.T) {
 				Operation: "all",
 			},
 			setupMocks: func(uc *mocks.UseCase) {
-				uc.On("CleanURL", mock.Anything, "all", "https://BYFOOD.com/food-EXPeriences?query=abc/").
-					Return("https://www.byfood.com/food-experiences", nil)
+				uc.On("CleanURL", mock.Anything, urlprocessor.CleanURLInput{Operation: "all", URL: "https://BYFOOD.com/food-EXPeriences?query=abc/"}).
+					Return(&urlprocessor.CleanURLOutput{URL: "https://www.byfood.com/food-experiences"}, nil)
 			},
 			expectedStatus: http.StatusCreated,
 			expectedBody: map[string]interface{}{
@@ -45,8 +48,8 @@ func TestProcessUrl(t *testing.T) {
 				Operation: "canonical",
 			},
 			setupMocks: func(uc *mocks.UseCase) {
-				uc.On("CleanURL", mock.Anything, "canonical", "https://BYFOOD.com/food-EXPeriences?query=abc/").
-					Return("https://BYFOOD.com/food-EXPeriences", nil)
+				uc.On("CleanURL", mock.Anything, urlprocessor.CleanURLInput{Operation: "canonical", URL: "https://BYFOOD.com/food-EXPeriences?query=abc/"}).
+					Return(&urlprocessor.CleanURLOutput{URL: "https://BYFOOD.com/food-EXPeriences"}, nil)
 			},
 			expectedStatus: http.StatusCreated,
 			expectedBody: map[string]interface{}{
@@ -60,8 +63,8 @@ func TestProcessUrl(t *testing.T) {
 				Operation: "redirection",
 			},
 			setupMocks: func(uc *mocks.UseCase) {
-				uc.On("CleanURL", mock.Anything, "redirection", "https://BYFOOD.com/food-EXPeriences?query=abc/").
-					Return("https://www.byfood.com/food-experiences?query=abc/", nil)
+				uc.On("CleanURL", mock.Anything, urlprocessor.CleanURLInput{Operation: "redirection", URL: "https://BYFOOD.com/food-EXPeriences?query=abc/"}).
+					Return(&urlprocessor.CleanURLOutput{URL: "https://www.byfood.com/food-experiences?query=abc/"}, nil)
 			},
 			expectedStatus: http.StatusCreated,
 			expectedBody: map[string]interface{}{
@@ -108,8 +111,8 @@ func TestProcessUrl(t *testing.T) {
 				Operation: "invalid",
 			},
 			setupMocks: func(uc *mocks.UseCase) {
-				uc.On("CleanURL", mock.Anything, "invalid", "https://BYFOOD.com/food-EXPeriences?query=abc/").
-					Return("", errors.New("invalid operation"))
+				uc.On("CleanURL", mock.Anything, urlprocessor.CleanURLInput{Operation: "invalid", URL: "https://BYFOOD.com/food-EXPeriences?query=abc/"}).
+					Return(nil, errors.New("invalid operation"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: map[string]interface{}{
@@ -123,14 +126,164 @@ func TestProcessUrl(t *testing.T) {
 				Operation: "all",
 			},
 			setupMocks: func(uc *mocks.UseCase) {
-				uc.On("CleanURL", mock.Anything, "all", "://invalid-url").
-					Return("", errors.New("parse \"://invalid-url\": missing protocol scheme"))
+				uc.On("CleanURL", mock.Anything, urlprocessor.CleanURLInput{Operation: "all", URL: "://invalid-url"}).
+					Return(nil, errors.New("parse \"://invalid-url\": missing protocol scheme"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: map[string]interface{}{
 				"error": "parse \"://invalid-url\": missing protocol scheme",
 			},
 		},
+		{
+			name: "with a profile",
+			requestBody: ProcessUrlRequest{
+				Url:       "https://Shop.example.com/Item?utm_source=mail",
+				Operation: "canonical",
+				Profile:   "shop",
+			},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CleanURL", mock.Anything, urlprocessor.CleanURLInput{Profile: "shop", Operation: "canonical", URL: "https://Shop.example.com/Item?utm_source=mail"}).
+					Return(&urlprocessor.CleanURLOutput{URL: "https://Shop.example.com/Item", RemovedParams: []string{"utm_source"}}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: map[string]interface{}{
+				"processed_url":  "https://Shop.example.com/Item",
+				"removed_params": []interface{}{"utm_source"},
+			},
+		},
+		{
+			name: "with a display URL",
+			requestBody: ProcessUrlRequest{
+				Url:       "https://Bücher.example/a",
+				Operation: "normalize",
+			},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CleanURL", mock.Anything, urlprocessor.CleanURLInput{Operation: "normalize", URL: "https://Bücher.example/a"}).
+					Return(&urlprocessor.CleanURLOutput{URL: "https://xn--bcher-kva.example/a", DisplayURL: "https://bücher.example/a"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: map[string]interface{}{
+				"processed_url": "https://xn--bcher-kva.example/a",
+				"display_url":   "https://bücher.example/a",
+			},
+		},
+		{
+			name: "with explain",
+			requestBody: ProcessUrlRequest{
+				Url:       "https://example.com/a/",
+				Operation: "canonical",
+				Explain:   true,
+			},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CleanURL", mock.Anything, urlprocessor.CleanURLInput{Operation: "canonical", URL: "https://example.com/a/", Explain: true}).
+					Return(&urlprocessor.CleanURLOutput{URL: "https://example.com/a", Steps: []urlprocessor.AppliedStep{
+						{Type: "strip_query", Operation: "canonical", Before: "https://example.com/a/", After: "https://example.com/a/", Changed: []string{}},
+						{Type: "trailing_slash", Operation: "canonical", Before: "https://example.com/a/", After: "https://example.com/a", Changed: []string{"path"}},
+					}}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: map[string]interface{}{
+				"processed_url": "https://example.com/a",
+				"steps": []interface{}{
+					map[string]interface{}{"type": "strip_query", "operation": "canonical", "before": "https://example.com/a/", "after": "https://example.com/a/", "changed": []interface{}{}},
+					map[string]interface{}{"type": "trailing_slash", "operation": "canonical", "before": "https://example.com/a/", "after": "https://example.com/a", "changed": []interface{}{"path"}},
+				},
+			},
+		},
+		{
+			name: "resolve",
+			requestBody: ProcessUrlRequest{
+				Url:       "https://sho.rt/x",
+				Operation: "resolve",
+			},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CleanURL", mock.Anything, urlprocessor.CleanURLInput{Operation: "resolve", URL: "https://sho.rt/x"}).
+					Return(&urlprocessor.CleanURLOutput{
+						URL: "https://example.com/a",
+						Chain: []urlprocessor.Hop{
+							{URL: "https://sho.rt/x", Method: "HEAD", Status: 301, Location: "https://example.com/a?ref=x"},
+							{URL: "https://example.com/a?ref=x", Method: "GET", Status: 200},
+						},
+						CanonicalURL: "https://example.com/a",
+					}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: map[string]interface{}{
+				"processed_url": "https://example.com/a",
+				"chain": []interface{}{
+					map[string]interface{}{"url": "https://sho.rt/x", "method": "HEAD", "status": float64(301), "location": "https://example.com/a?ref=x"},
+					map[string]interface{}{"url": "https://example.com/a?ref=x", "method": "GET", "status": float64(200)},
+				},
+				"canonical_url": "https://example.com/a",
+			},
+		},
+		{
+			name: "operation of a rule set",
+			requestBody: ProcessUrlRequest{
+				Url:       "https://shop.example/a?id=1&ref=x",
+				Operation: "canonical",
+			},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CleanURL", mock.Anything, urlprocessor.CleanURLInput{Operation: "canonical", URL: "https://shop.example/a?id=1&ref=x"}).
+					Return(&urlprocessor.CleanURLOutput{
+						URL:           "https://shop.example/a?id=1",
+						RemovedParams: []string{"ref"},
+						RuleSet:       &urlprocessor.RuleSetRef{ID: "rs-1", Name: "shop"},
+					}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: map[string]interface{}{
+				"processed_url":  "https://shop.example/a?id=1",
+				"removed_params": []interface{}{"ref"},
+				"rule_set":       map[string]interface{}{"id": "rs-1", "name": "shop"},
+			},
+		},
+		{
+			name: "unresolvable url",
+			requestBody: ProcessUrlRequest{
+				Url:       "https://sho.rt/loop",
+				Operation: "resolve",
+			},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CleanURL", mock.Anything, urlprocessor.CleanURLInput{Operation: "resolve", URL: "https://sho.rt/loop"}).
+					Return(nil, fmt.Errorf("%w: redirect loop back to %s", domain.ErrUnresolvable, "https://sho.rt/loop"))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody: map[string]interface{}{
+				"error": "url cannot be resolved: redirect loop back to https://sho.rt/loop",
+			},
+		},
+		{
+			name: "unknown operation",
+			requestBody: ProcessUrlRequest{
+				Url:       "https://example.com",
+				Operation: "shout",
+			},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CleanURL", mock.Anything, urlprocessor.CleanURLInput{Operation: "shout", URL: "https://example.com"}).
+					Return(nil, fmt.Errorf("%w %q", domain.ErrInvalidOperation, "shout"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": `invalid operation "shout"`,
+			},
+		},
+		{
+			name: "unknown profile",
+			requestBody: ProcessUrlRequest{
+				Url:       "https://example.com",
+				Operation: "all",
+				Profile:   "blog",
+			},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CleanURL", mock.Anything, urlprocessor.CleanURLInput{Profile: "blog", Operation: "all", URL: "https://example.com"}).
+					Return(nil, fmt.Errorf("%w %q", domain.ErrUnknownProfile, "blog"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": `unknown profile "blog"`,
+			},
+		},
 		{
 			name: "usecase internal error",
 			requestBody: ProcessUrlRequest{
@@ -138,8 +291,8 @@ func TestProcessUrl(t *testing.T) {
 				Operation: "all",
 			},
 			setupMocks: func(uc *mocks.UseCase) {
-				uc.On("CleanURL", mock.Anything, "all", "https://example.com").
-					Return("", errors.New("internal server error"))
+				uc.On("CleanURL", mock.Anything, urlprocessor.CleanURLInput{Operation: "all", URL: "https://example.com"}).
+					Return(nil, errors.New("internal server error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: map[string]interface{}{
@@ -296,8 +449,8 @@ func TestProcessUrlEdgeCases(t *testing.T) {
 			},
 			setupMocks: func(uc *mocks.UseCase) {
 				longUrl := "https://example.com/" + string(make([]byte, 2000))
-				uc.On("CleanURL", mock.Anything, "all", longUrl).
-					Return("https://www.byfood.com/"+string(make([]byte, 2000)), nil)
+				uc.On("CleanURL", mock.Anything, urlprocessor.CleanURLInput{Operation: "all", URL: longUrl}).
+					Return(&urlprocessor.CleanURLOutput{URL: "https://www.byfood.com/"+string(make([]byte, 2000))}, nil)
 			},
 			expectedStatus: http.StatusCreated,
 			checkResponse: func(t *testing.T, responseBody map[string]interface{}) {
@@ -0,0 +1,236 @@
+package urlprocessor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	domain "booklib/internal/domain/url-processor"
+	urlprocessor "booklib/internal/usecase/url-processor"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+const (
+	MIMEApplicationNDJSON = "application/x-ndjson"
+)
+
+// ProcessUrlsResult represents the outcome of one URL of a batch
+type ProcessUrlsResult struct {
+	Index         int                `json:"index"`
+	Url           string             `json:"url"`
+	ProcessedUrl  string             `json:"processed_url,omitempty"`
+	DisplayUrl    string             `json:"display_url,omitempty"`
+	RemovedParams []string           `json:"removed_params,omitempty"`
+	Steps         []ProcessUrlStep   `json:"steps,omitempty"`
+	Chain         []ProcessUrlHop    `json:"chain,omitempty"`
+	CanonicalUrl  string             `json:"canonical_url,omitempty"`
+	RuleSet       *ProcessUrlRuleSet `json:"rule_set,omitempty"`
+	Error         string             `json:"error,omitempty"`
+}
+
+// ProcessUrlsResponse represents the outcome of a whole batch
+type ProcessUrlsResponse struct {
+	Results []ProcessUrlsResult `json:"results"`
+	Failed  int                 `json:"failed"`
+}
+
+// ProcessUrls godoc
+// @Summary Clean and process many URLs
+// @Description Runs an operation on every URL of the body, several at once. The body is a JSON array of URLs
+// @Description (Content-Type application/json) or one URL per line (any other content type, such as text/plain
+// @Description or application/x-ndjson, where a line may also be a JSON string). Results are in input order;
+// @Description a URL that cannot be processed gets an error instead of failing the batch. Returns 200 when
+// @Description every URL was processed and 207 otherwise. With stream=true the results are written as
+// @Description application/x-ndjson, one ProcessUrlsResult per line, as soon as they are ready.
+// @Tags URLProcessor
+// @Accept json,plain
+// @Produce json,application/x-ndjson
+// @Param operation query string true "Operation to run"
+// @Param profile query string false "Profile holding the operation, the configured default profile when empty"
+// @Param stream query bool false "Stream the results as NDJSON"
+// @Param explain query bool false "Explain what every step did to every URL"
+// @Param request body []string true "URLs to process"
+// @Success 200 {object} ProcessUrlsResponse
+// @Success 207 {object} ProcessUrlsResponse
+// @Failure 400 {object} map[string]string "Invalid input"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /process-urls [post]
+func (h *Handler) ProcessUrls(c *fiber.Ctx) error {
+	in, err := parseProcessUrlsRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if c.QueryBool("stream") {
+		return h.streamUrls(c, in)
+	}
+
+	resp := ProcessUrlsResponse{Results: make([]ProcessUrlsResult, 0, len(in.URLs))}
+	err = h.usecase.CleanURLs(c.UserContext(), in, func(res urlprocessor.CleanURLsResult) error {
+		if res.Err != nil {
+			resp.Failed++
+		}
+		resp.Results = append(resp.Results, newProcessUrlsResult(res))
+		return nil
+	})
+	if err != nil {
+		return h.processUrlsError(c, err)
+	}
+
+	status := fiber.StatusOK
+	if resp.Failed > 0 {
+		status = fiber.StatusMultiStatus
+	}
+	return c.Status(status).JSON(resp)
+}
+
+// streamUrls writes the results as NDJSON while the batch is processed. The
+// first result is awaited before answering, so a batch refused as a whole
+// still gets an error status.
+func (h *Handler) streamUrls(c *fiber.Ctx, in urlprocessor.CleanURLsInput) error {
+	// the stream goes on after the handler returns
+	ctx, cancel := context.WithCancel(c.UserContext())
+
+	var (
+		results = make(chan urlprocessor.CleanURLsResult)
+		done    = make(chan error, 1)
+	)
+	go func() {
+		err := h.usecase.CleanURLs(ctx, in, func(res urlprocessor.CleanURLsResult) error {
+			select {
+			case results <- res:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		close(results)
+		done <- err
+	}()
+
+	first, ok := <-results
+	if !ok {
+		cancel()
+		err := <-done
+		if err == nil {
+			err = urlprocessor.ErrBatchEmpty
+		}
+		return h.processUrlsError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, MIMEApplicationNDJSON)
+	c.Set("X-Accel-Buffering", "no")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		enc := json.NewEncoder(w)
+		res := first
+		for {
+			if err := enc.Encode(newProcessUrlsResult(res)); err != nil {
+				log.Error(ctx, err, nil, "failed to encode url result")
+				break
+			}
+			if err := w.Flush(); err != nil {
+				// the client disconnected
+				break
+			}
+			if res, ok = <-results; !ok {
+				break
+			}
+		}
+
+		cancel()
+		if err := <-done; err != nil && !errors.Is(err, context.Canceled) {
+			log.Error(ctx, err, nil, "failed to process urls")
+		}
+	})
+
+	return nil
+}
+
+func (h *Handler) processUrlsError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, urlprocessor.ErrBatchEmpty) || errors.Is(err, urlprocessor.ErrBatchTooLarge) ||
+		errors.Is(err, domain.ErrInvalidOperation) || errors.Is(err, domain.ErrUnknownProfile) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	log.Error(c.UserContext(), err, nil, "failed to process urls")
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
+
+func parseProcessUrlsRequest(c *fiber.Ctx) (urlprocessor.CleanURLsInput, error) {
+	in := urlprocessor.CleanURLsInput{
+		Profile:   c.Query("profile"),
+		Operation: c.Query("operation"),
+		Explain:   c.QueryBool("explain"),
+	}
+	if in.Operation == "" {
+		return in, errors.New("operation cannot be empty")
+	}
+
+	var err error
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEApplicationJSON) {
+		if err = json.Unmarshal(c.Body(), &in.URLs); err != nil {
+			return in, errors.New("body must be a JSON array of urls")
+		}
+	} else if in.URLs, err = parseUrlLines(c.Body()); err != nil {
+		return in, err
+	}
+
+	if len(in.URLs) == 0 {
+		return in, urlprocessor.ErrBatchEmpty
+	}
+	return in, nil
+}
+
+// parseUrlLines reads one URL per line, skipping blank lines. A line starting
+// with a quote is a JSON string, so NDJSON bodies are read too.
+func parseUrlLines(body []byte) ([]string, error) {
+	var urls []string
+	for i, line := range bytes.Split(body, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		if line[0] != '"' {
+			urls = append(urls, string(line))
+			continue
+		}
+		var u string
+		if err := json.Unmarshal(line, &u); err != nil {
+			return nil, fmt.Errorf("line %d is not a valid JSON string", i+1)
+		}
+		urls = append(urls, u)
+	}
+	return urls, nil
+}
+
+func newProcessUrlsResult(res urlprocessor.CleanURLsResult) ProcessUrlsResult {
+	result := ProcessUrlsResult{
+		Index: res.Index,
+		Url:   res.URL,
+	}
+	if res.Err != nil {
+		result.Error = res.Err.Error()
+		return result
+	}
+
+	result.ProcessedUrl = res.Output.URL
+	result.DisplayUrl = res.Output.DisplayURL
+	result.RemovedParams = res.Output.RemovedParams
+	result.Steps = newProcessUrlSteps(res.Output.Steps)
+	result.Chain = newProcessUrlChain(res.Output.Chain)
+	result.CanonicalUrl = res.Output.CanonicalURL
+	result.RuleSet = newProcessUrlRuleSet(res.Output.RuleSet)
+	return result
+}
@@ -0,0 +1,255 @@
+package urlprocessor
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	domain "booklib/internal/domain/url-processor"
+	urlprocessor "booklib/internal/usecase/url-processor"
+	"booklib/internal/usecase/url-processor/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// emitResults makes the CleanURLs mock pass results to emit.
+func emitResults(results ...urlprocessor.CleanURLsResult) func(args mock.Arguments) {
+	return func(args mock.Arguments) {
+		emit := args.Get(2).(func(urlprocessor.CleanURLsResult) error)
+		for _, res := range results {
+			if emit(res) != nil {
+				return
+			}
+		}
+	}
+}
+
+func TestProcessUrls(t *testing.T) {
+	var (
+		ok = urlprocessor.CleanURLsResult{
+			Index:  0,
+			URL:    "https://example.com/a/?utm_source=x",
+			Output: &urlprocessor.CleanURLOutput{URL: "https://example.com/a", RemovedParams: []string{"utm_source"}},
+		}
+		failed = urlprocessor.CleanURLsResult{
+			Index: 1,
+			URL:   "://bad",
+			Err:   fmt.Errorf("%w: missing protocol scheme", domain.ErrInvalidURL),
+		}
+	)
+
+	tests := []struct {
+		name           string
+		query          string
+		contentType    string
+		body           string
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "JSON array",
+			query:       "operation=canonical&profile=shop",
+			contentType: fiber.MIMEApplicationJSON,
+			body:        `["https://example.com/a/?utm_source=x"]`,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CleanURLs", mock.Anything, urlprocessor.CleanURLsInput{
+					Profile: "shop", Operation: "canonical", URLs: []string{"https://example.com/a/?utm_source=x"},
+				}, mock.Anything).Run(emitResults(ok)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"results":[{"index":0,"url":"https://example.com/a/?utm_source=x","processed_url":"https://example.com/a","removed_params":["utm_source"]}],"failed":0}`,
+		},
+		{
+			name:        "newline-delimited body with failures",
+			query:       "operation=canonical",
+			contentType: fiber.MIMETextPlain,
+			body:        "https://example.com/a/?utm_source=x\r\n\n  \"://bad\"  \n",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CleanURLs", mock.Anything, urlprocessor.CleanURLsInput{
+					Operation: "canonical", URLs: []string{"https://example.com/a/?utm_source=x", "://bad"},
+				}, mock.Anything).Run(emitResults(ok, failed)).Return(nil)
+			},
+			expectedStatus: http.StatusMultiStatus,
+			expectedBody: `{"results":[` +
+				`{"index":0,"url":"https://example.com/a/?utm_source=x","processed_url":"https://example.com/a","removed_params":["utm_source"]},` +
+				`{"index":1,"url":"://bad","error":"invalid url: missing protocol scheme"}],"failed":1}`,
+		},
+		{
+			name:        "with explain",
+			query:       "operation=canonical&explain=true",
+			contentType: fiber.MIMEApplicationJSON,
+			body:        `["https://example.com/a/?utm_source=x"]`,
+			setupMocks: func(uc *mocks.UseCase) {
+				explained := ok
+				explained.Output = &urlprocessor.CleanURLOutput{URL: "https://example.com/a", Steps: []urlprocessor.AppliedStep{
+					{Type: "strip_query", Operation: "canonical", Before: ok.URL, After: "https://example.com/a/", Changed: []string{"query"}, RemovedParams: []string{"utm_source"}},
+				}}
+				uc.On("CleanURLs", mock.Anything, urlprocessor.CleanURLsInput{
+					Operation: "canonical", URLs: []string{ok.URL}, Explain: true,
+				}, mock.Anything).Run(emitResults(explained)).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"results":[{"index":0,"url":"https://example.com/a/?utm_source=x","processed_url":"https://example.com/a","steps":[` +
+				`{"type":"strip_query","operation":"canonical","before":"https://example.com/a/?utm_source=x","after":"https://example.com/a/","changed":["query"],"removed_params":["utm_source"]}]}],"failed":0}`,
+		},
+		{
+			name:           "without operation",
+			contentType:    fiber.MIMEApplicationJSON,
+			body:           `["https://example.com"]`,
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"operation cannot be empty"}`,
+		},
+		{
+			name:           "not a JSON array",
+			query:          "operation=all",
+			contentType:    fiber.MIMEApplicationJSON,
+			body:           `{"url":"https://example.com"}`,
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"body must be a JSON array of urls"}`,
+		},
+		{
+			name:           "invalid JSON line",
+			query:          "operation=all",
+			contentType:    MIMEApplicationNDJSON,
+			body:           "\"https://example.com\"\n\"https://example.org\n",
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"line 2 is not a valid JSON string"}`,
+		},
+		{
+			name:           "empty body",
+			query:          "operation=all",
+			contentType:    fiber.MIMETextPlain,
+			body:           "\n\n",
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"batch must contain at least one url"}`,
+		},
+		{
+			name:        "batch refused",
+			query:       "operation=all",
+			contentType: fiber.MIMEApplicationJSON,
+			body:        `["https://example.com"]`,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CleanURLs", mock.Anything, mock.Anything, mock.Anything).
+					Return(fmt.Errorf("%w, the maximum is 1", urlprocessor.ErrBatchTooLarge))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"batch contains too many urls, the maximum is 1"}`,
+		},
+		{
+			name:        "unknown operation",
+			query:       "operation=shout",
+			contentType: fiber.MIMEApplicationJSON,
+			body:        `["https://example.com"]`,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CleanURLs", mock.Anything, mock.Anything, mock.Anything).
+					Return(fmt.Errorf("%w %q", domain.ErrInvalidOperation, "shout"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid operation \"shout\""}`,
+		},
+		{
+			name:        "usecase internal error",
+			query:       "operation=all",
+			contentType: fiber.MIMEApplicationJSON,
+			body:        `["https://example.com"]`,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("CleanURLs", mock.Anything, mock.Anything, mock.Anything).
+					Return(errors.New("internal server error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Post("/process-urls", handler.ProcessUrls)
+
+			req := httptest.NewRequest(http.MethodPost, "/process-urls?"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, tt.contentType)
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expectedBody, string(body))
+		})
+	}
+}
+
+func TestProcessUrlsStream(t *testing.T) {
+	results := make([]urlprocessor.CleanURLsResult, 50)
+	for i := range results {
+		results[i] = urlprocessor.CleanURLsResult{
+			Index:  i,
+			URL:    fmt.Sprintf("https://example.com/%d/", i),
+			Output: &urlprocessor.CleanURLOutput{URL: fmt.Sprintf("https://example.com/%d", i)},
+		}
+	}
+	results[7].Output, results[7].Err = nil, fmt.Errorf("%w: bad", domain.ErrInvalidURL)
+
+	t.Run("writes a line per result", func(t *testing.T) {
+		app := fiber.New()
+		usecase := mocks.NewUseCase(t)
+		usecase.On("CleanURLs", mock.Anything, mock.Anything, mock.Anything).Run(emitResults(results...)).Return(nil)
+		app.Post("/process-urls", New(usecase).ProcessUrls)
+
+		req := httptest.NewRequest(http.MethodPost, "/process-urls?operation=canonical&stream=true", strings.NewReader("https://example.com/0/"))
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, MIMEApplicationNDJSON, resp.Header.Get(fiber.HeaderContentType))
+
+		var lines []ProcessUrlsResult
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			var line ProcessUrlsResult
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+			lines = append(lines, line)
+		}
+		require.Len(t, lines, len(results))
+		for i, line := range lines {
+			assert.Equal(t, i, line.Index)
+		}
+		assert.Equal(t, "https://example.com/1", lines[1].ProcessedUrl)
+		assert.Equal(t, "invalid url: bad", lines[7].Error)
+	})
+
+	t.Run("refused batch gets an error status", func(t *testing.T) {
+		app := fiber.New()
+		usecase := mocks.NewUseCase(t)
+		usecase.On("CleanURLs", mock.Anything, mock.Anything, mock.Anything).
+			Return(fmt.Errorf("%w %q", domain.ErrUnknownProfile, "blog"))
+		app.Post("/process-urls", New(usecase).ProcessUrls)
+
+		req := httptest.NewRequest(http.MethodPost, "/process-urls?operation=all&profile=blog&stream=true", strings.NewReader("https://example.com"))
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"error":"unknown profile \"blog\""}`, string(body))
+	})
+}
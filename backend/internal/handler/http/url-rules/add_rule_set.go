@@ -0,0 +1,58 @@
+package urlrules
+
+import (
+	"errors"
+	"strings"
+
+	domain "booklib/internal/domain/url-processor"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// AddRuleSet godoc
+// @Summary Add a URL rule set
+// @Description Stores operations scoped to some hosts. They take precedence over the operations of the profiles
+// @Description for the URLs of these hosts, whatever the profile requested: among the active rule sets matching
+// @Description the host and defining the operation, the one of highest priority applies, then the one of most
+// @Description specific host, an exact host before "*.example.com" before "*".
+// @Tags url-rules
+// @Accept json
+// @Produce json
+// @Param rule_set body urlrules.RuleSetRequest true "Rule set to create"
+// @Param Idempotency-Key header string false "Unique key to safely retry the request"
+// @Success 201 {object} map[string]interface{}
+// @Header 201 {string} Location "URL of the created rule set"
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /url-rules [post]
+func (h *Handler) AddRuleSet(c *fiber.Ctx) error {
+	var req RuleSetRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  "Cannot parse JSON",
+		})
+	}
+
+	res, err := h.usecase.AddRuleSet(c.UserContext(), req.toInput())
+	if errors.Is(err, domain.ErrInvalidRuleSet) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+	if err != nil {
+		log.Error(c.UserContext(), err, nil, "failed to add url rule set")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	c.Location(strings.TrimSuffix(c.Path(), "/") + "/" + res.ID)
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status": "success",
+		"data":   res,
+	})
+}
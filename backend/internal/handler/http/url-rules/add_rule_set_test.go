@@ -0,0 +1,103 @@
+package urlrules
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	domain "booklib/internal/domain/url-processor"
+	urlprocessor "booklib/internal/usecase/url-processor"
+	"booklib/internal/usecase/url-processor/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddRuleSet(t *testing.T) {
+	operations := map[string][]domain.Step{"canonical": {{Type: domain.StepKeepQuery, Params: []string{"id"}}}}
+
+	tests := []struct {
+		name             string
+		requestBody      interface{}
+		setupMocks       func(*mocks.UseCase)
+		expectedStatus   int
+		expectedError    string
+		expectedLocation string
+	}{
+		{
+			name:        "successful add rule set",
+			requestBody: `{"name": "shop", "hosts": ["*.shop.example"], "priority": 10, "operations": {"canonical": [{"type": "keep_query", "params": ["id"]}]}}`,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("AddRuleSet", mock.Anything, urlprocessor.RuleSetInput{
+					Name: "shop", Hosts: []string{"*.shop.example"}, Priority: 10, Operations: operations, Active: true,
+				}).Return(&domain.RuleSet{ID: "rs-1", Name: "shop"}, nil)
+			},
+			expectedStatus:   http.StatusCreated,
+			expectedLocation: "/url-rules/rs-1",
+		},
+		{
+			name:        "inactive draft",
+			requestBody: RuleSetRequest{Name: "shop", Hosts: []string{"*"}, Operations: operations, Active: new(bool)},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("AddRuleSet", mock.Anything, mock.MatchedBy(func(in urlprocessor.RuleSetInput) bool { return !in.Active })).
+					Return(&domain.RuleSet{ID: "rs-1", Name: "shop"}, nil)
+			},
+			expectedStatus:   http.StatusCreated,
+			expectedLocation: "/url-rules/rs-1",
+		},
+		{
+			name:           "invalid JSON body",
+			requestBody:    `{"name": 1}`,
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Cannot parse JSON",
+		},
+		{
+			name:        "invalid rule set",
+			requestBody: RuleSetRequest{Name: "shop", Operations: operations},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("AddRuleSet", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: hosts cannot be empty", domain.ErrInvalidRuleSet))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid url rule set: hosts cannot be empty",
+		},
+		{
+			name:        "usecase error",
+			requestBody: RuleSetRequest{Name: "shop", Hosts: []string{"*"}, Operations: operations},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("AddRuleSet", mock.Anything, mock.Anything).Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "usecase error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Post("/url-rules", func(c *fiber.Ctx) error {
+				err := handler.AddRuleSet(c)
+				if tt.expectedLocation != "" {
+					assert.Equal(t, tt.expectedLocation, string(c.Response().Header.Peek(fiber.HeaderLocation)))
+				}
+				return err
+			})
+
+			status, body := doRequest(t, app, http.MethodPost, "/url-rules", tt.requestBody)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedError != "" {
+				assert.Equal(t, map[string]interface{}{"status": "error", "error": tt.expectedError}, body)
+				return
+			}
+			assert.Equal(t, "success", body["status"])
+			assert.Equal(t, "rs-1", body["data"].(map[string]interface{})["id"])
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package urlrules
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// DeleteRuleSet godoc
+// @Summary Delete a URL rule set
+// @Description Deletes the rule set; the operations of the profiles apply again to its hosts.
+// @Tags url-rules
+// @Produce json
+// @Param id path string true "Rule set ID"
+// @Success 200 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /url-rules/{id} [delete]
+func (h *Handler) DeleteRuleSet(c *fiber.Ctx) error {
+	if err := h.usecase.DeleteRuleSet(c.UserContext(), c.Params("id")); err != nil {
+		log.Error(c.UserContext(), err, nil, "failed to delete url rule set")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+	})
+}
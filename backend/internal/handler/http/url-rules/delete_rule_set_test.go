@@ -0,0 +1,55 @@
+package urlrules
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"booklib/internal/usecase/url-processor/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteRuleSet(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{
+			name: "successful delete rule set",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("DeleteRuleSet", mock.Anything, "rs-1").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   map[string]interface{}{"status": "success"},
+		},
+		{
+			name: "usecase error",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("DeleteRuleSet", mock.Anything, "rs-1").Return(errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "usecase error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Delete("/url-rules/:id", handler.DeleteRuleSet)
+
+			status, body := doRequest(t, app, http.MethodDelete, "/url-rules/rs-1", nil)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedBody, body)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package urlrules
+
+import (
+	"errors"
+
+	domain "booklib/internal/domain/url-processor"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// GetRuleSet godoc
+// @Summary Get a URL rule set by ID
+// @Tags url-rules
+// @Produce json
+// @Param id path string true "Rule set ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /url-rules/{id} [get]
+func (h *Handler) GetRuleSet(c *fiber.Ctx) error {
+	res, err := h.usecase.GetRuleSet(c.UserContext(), c.Params("id"))
+	if errors.Is(err, domain.ErrRuleSetNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+	if err != nil {
+		log.Error(c.UserContext(), err, nil, "failed to get url rule set")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   res,
+	})
+}
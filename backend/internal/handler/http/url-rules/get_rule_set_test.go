@@ -0,0 +1,65 @@
+package urlrules
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	domain "booklib/internal/domain/url-processor"
+	"booklib/internal/usecase/url-processor/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetRuleSet(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+	}{
+		{
+			name: "successful get rule set",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetRuleSet", mock.Anything, "rs-1").Return(&domain.RuleSet{ID: "rs-1"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "rule set not found",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetRuleSet", mock.Anything, "rs-1").Return(nil, domain.ErrRuleSetNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "usecase error",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetRuleSet", mock.Anything, "rs-1").Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Get("/url-rules/:id", handler.GetRuleSet)
+
+			status, body := doRequest(t, app, http.MethodGet, "/url-rules/rs-1", nil)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, "success", body["status"])
+			} else {
+				assert.Equal(t, "error", body["status"])
+				assert.NotEmpty(t, body["error"])
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package urlrules
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// GetRuleSets godoc
+// @Summary List URL rule sets
+// @Description Returns every rule set, active or not, by name.
+// @Tags url-rules
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /url-rules [get]
+func (h *Handler) GetRuleSets(c *fiber.Ctx) error {
+	res, err := h.usecase.GetRuleSets(c.UserContext())
+	if err != nil {
+		log.Error(c.UserContext(), err, nil, "failed to get url rule sets")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   res,
+	})
+}
@@ -0,0 +1,60 @@
+package urlrules
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	domain "booklib/internal/domain/url-processor"
+	"booklib/internal/usecase/url-processor/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetRuleSets(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+		expectedLen    int
+	}{
+		{
+			name: "successful get rule sets",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetRuleSets", mock.Anything).Return([]domain.RuleSet{{ID: "rs-1"}, {ID: "rs-2"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedLen:    2,
+		},
+		{
+			name: "usecase error",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetRuleSets", mock.Anything).Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Get("/url-rules", handler.GetRuleSets)
+
+			status, body := doRequest(t, app, http.MethodGet, "/url-rules", nil)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, "success", body["status"])
+				assert.Len(t, body["data"], tt.expectedLen)
+			} else {
+				assert.Equal(t, "error", body["status"])
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package urlrules
+
+import urlprocessor "booklib/internal/usecase/url-processor"
+
+type Handler struct {
+	usecase urlprocessor.UseCase
+}
+
+func New(usecase urlprocessor.UseCase) *Handler {
+	return &Handler{
+		usecase: usecase,
+	}
+}
@@ -0,0 +1,108 @@
+package urlrules
+
+import (
+	domain "booklib/internal/domain/url-processor"
+	urlprocessor "booklib/internal/usecase/url-processor"
+)
+
+// RuleSetRequest represents the request payload for creating or updating a URL rule set
+type RuleSetRequest struct {
+	Name string `json:"name"`
+	// Hosts are the hosts the rule set applies to: "example.com", "*.example.com" for its subdomains or "*" for any host
+	Hosts []string `json:"hosts"`
+	// Priority orders the rule sets matching a URL, highest first; at equal priority the most specific host wins
+	Priority int `json:"priority"`
+	// Operations are pipelines of steps, as the operations of the profiles in config.yaml
+	Operations map[string][]domain.Step `json:"operations"`
+	// Active defaults to true; inactive rule sets are drafts and never apply
+	Active *bool `json:"active"`
+}
+
+func (req *RuleSetRequest) toInput() urlprocessor.RuleSetInput {
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return urlprocessor.RuleSetInput{
+		Name:       req.Name,
+		Hosts:      req.Hosts,
+		Priority:   req.Priority,
+		Operations: req.Operations,
+		Active:     active,
+	}
+}
+
+// TestRuleSetRequest represents a draft rule set to try on sample URLs
+type TestRuleSetRequest struct {
+	// ID is the stored rule set the draft replaces; the draft is added to the active ones when empty
+	ID string `json:"id,omitempty"`
+	// RuleSet is the draft, tested as if active; its name defaults to "draft"
+	RuleSet   RuleSetRequest `json:"rule_set"`
+	Operation string         `json:"operation"`
+	// Profile holds the operation, the configured default profile when empty
+	Profile string   `json:"profile,omitempty"`
+	URLs    []string `json:"urls"`
+}
+
+func (req *TestRuleSetRequest) toInput() urlprocessor.TestRuleSetInput {
+	return urlprocessor.TestRuleSetInput{
+		ID:        req.ID,
+		Draft:     req.RuleSet.toInput(),
+		Profile:   req.Profile,
+		Operation: req.Operation,
+		URLs:      req.URLs,
+	}
+}
+
+// TestRuleSetResult compares what the active rule sets and the draft make of one URL
+type TestRuleSetResult struct {
+	Url    string             `json:"url"`
+	Active TestRuleSetOutcome `json:"active"`
+	Draft  TestRuleSetOutcome `json:"draft"`
+	// Changed lists the components the draft rewrites otherwise: scheme, userinfo, host, path, query and fragment
+	Changed []string `json:"changed"`
+	// Differs is set when the draft gives another URL or fails otherwise
+	Differs bool `json:"differs"`
+}
+
+// TestRuleSetOutcome is the processed URL, or the error processing it
+type TestRuleSetOutcome struct {
+	ProcessedUrl string `json:"processed_url,omitempty"`
+	// RuleSet is the rule set whose operation ran, absent when the one of the profile did
+	RuleSet *RuleSetRef `json:"rule_set,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// RuleSetRef names a rule set
+type RuleSetRef struct {
+	// ID is absent for a draft replacing no stored rule set
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+func newTestRuleSetResults(results []urlprocessor.TestRuleSetResult) []TestRuleSetResult {
+	resp := make([]TestRuleSetResult, 0, len(results))
+	for _, res := range results {
+		resp = append(resp, TestRuleSetResult{
+			Url:     res.URL,
+			Active:  newTestRuleSetOutcome(res.Active, res.ActiveErr),
+			Draft:   newTestRuleSetOutcome(res.Draft, res.DraftErr),
+			Changed: res.Changed,
+			Differs: res.Differs,
+		})
+	}
+	return resp
+}
+
+func newTestRuleSetOutcome(out *urlprocessor.CleanURLOutput, err error) TestRuleSetOutcome {
+	if err != nil {
+		return TestRuleSetOutcome{Error: err.Error()}
+	}
+
+	outcome := TestRuleSetOutcome{ProcessedUrl: out.URL}
+	if out.RuleSet != nil {
+		outcome.RuleSet = &RuleSetRef{ID: out.RuleSet.ID, Name: out.RuleSet.Name}
+	}
+	return outcome
+}
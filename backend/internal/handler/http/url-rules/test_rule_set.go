@@ -0,0 +1,63 @@
+package urlrules
+
+import (
+	"errors"
+
+	domain "booklib/internal/domain/url-processor"
+	urlprocessor "booklib/internal/usecase/url-processor"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// TestRuleSet godoc
+// @Summary Try a draft URL rule set
+// @Description Runs an operation on up to 100 sample URLs with the active rule sets, then again with the draft
+// @Description rule_set active in place of the stored rule set id, or next to the active ones when id is empty.
+// @Description Nothing is stored. Every result holds both processed URLs, the URL components the draft
+// @Description rewrites otherwise and whether the draft changes the outcome; a URL that cannot be processed
+// @Description gets an error on its side instead of failing the request.
+// @Tags url-rules
+// @Accept json
+// @Produce json
+// @Param request body urlrules.TestRuleSetRequest true "Draft rule set and sample URLs"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /url-rules/test [post]
+func (h *Handler) TestRuleSet(c *fiber.Ctx) error {
+	var req TestRuleSetRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  "Cannot parse JSON",
+		})
+	}
+
+	res, err := h.usecase.TestRuleSet(c.UserContext(), req.toInput())
+	switch {
+	case errors.Is(err, domain.ErrInvalidRuleSet), errors.Is(err, domain.ErrInvalidOperation), errors.Is(err, domain.ErrUnknownProfile),
+		errors.Is(err, urlprocessor.ErrBatchEmpty), errors.Is(err, urlprocessor.ErrBatchTooLarge):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	case errors.Is(err, domain.ErrRuleSetNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	case err != nil:
+		log.Error(c.UserContext(), err, nil, "failed to test url rule set")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   newTestRuleSetResults(res),
+	})
+}
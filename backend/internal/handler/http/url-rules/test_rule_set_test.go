@@ -0,0 +1,145 @@
+package urlrules
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	domain "booklib/internal/domain/url-processor"
+	urlprocessor "booklib/internal/usecase/url-processor"
+	"booklib/internal/usecase/url-processor/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTestRuleSet(t *testing.T) {
+	request := `{"id": "rs-1", "rule_set": {"hosts": ["shop.example"], "operations": {"canonical": [{"type": "strip_query"}]}},` +
+		` "operation": "canonical", "urls": ["https://shop.example/a?id=1", "ftp://shop.example/a"]}`
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{
+			name:        "successful test rule set",
+			requestBody: request,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("TestRuleSet", mock.Anything, urlprocessor.TestRuleSetInput{
+					ID: "rs-1",
+					Draft: urlprocessor.RuleSetInput{
+						Hosts:      []string{"shop.example"},
+						Operations: map[string][]domain.Step{"canonical": {{Type: domain.StepStripQuery}}},
+						Active:     true,
+					},
+					Operation: "canonical",
+					URLs:      []string{"https://shop.example/a?id=1", "ftp://shop.example/a"},
+				}).Return([]urlprocessor.TestRuleSetResult{
+					{
+						URL:     "https://shop.example/a?id=1",
+						Active:  &urlprocessor.CleanURLOutput{URL: "https://shop.example/a?id=1", RuleSet: &urlprocessor.RuleSetRef{ID: "rs-1", Name: "shop"}},
+						Draft:   &urlprocessor.CleanURLOutput{URL: "https://shop.example/a", RuleSet: &urlprocessor.RuleSetRef{ID: "rs-1", Name: "draft"}},
+						Changed: []string{urlprocessor.ComponentQuery},
+						Differs: true,
+					},
+					{
+						URL:      "ftp://shop.example/a",
+						Active:   &urlprocessor.CleanURLOutput{URL: "ftp://shop.example/a"},
+						DraftErr: domain.ErrUnresolvable,
+						Changed:  []string{},
+						Differs:  true,
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"status": "success",
+				"data": []interface{}{
+					map[string]interface{}{
+						"url": "https://shop.example/a?id=1",
+						"active": map[string]interface{}{
+							"processed_url": "https://shop.example/a?id=1",
+							"rule_set":      map[string]interface{}{"id": "rs-1", "name": "shop"},
+						},
+						"draft": map[string]interface{}{
+							"processed_url": "https://shop.example/a",
+							"rule_set":      map[string]interface{}{"id": "rs-1", "name": "draft"},
+						},
+						"changed": []interface{}{"query"},
+						"differs": true,
+					},
+					map[string]interface{}{
+						"url":     "ftp://shop.example/a",
+						"active":  map[string]interface{}{"processed_url": "ftp://shop.example/a"},
+						"draft":   map[string]interface{}{"error": "url cannot be resolved"},
+						"changed": []interface{}{},
+						"differs": true,
+					},
+				},
+			},
+		},
+		{
+			name:           "invalid JSON body",
+			requestBody:    `{"urls": "https://shop.example"}`,
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "Cannot parse JSON"},
+		},
+		{
+			name:        "invalid draft",
+			requestBody: request,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("TestRuleSet", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidRuleSet)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "invalid url rule set"},
+		},
+		{
+			name:        "too many urls",
+			requestBody: request,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("TestRuleSet", mock.Anything, mock.Anything).Return(nil, urlprocessor.ErrBatchTooLarge)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "batch contains too many urls"},
+		},
+		{
+			name:        "replaced rule set not found",
+			requestBody: request,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("TestRuleSet", mock.Anything, mock.Anything).Return(nil, domain.ErrRuleSetNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "url rule set not found"},
+		},
+		{
+			name:        "usecase error",
+			requestBody: request,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("TestRuleSet", mock.Anything, mock.Anything).Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "usecase error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Post("/url-rules/test", handler.TestRuleSet)
+
+			status, body := doRequest(t, app, http.MethodPost, "/url-rules/test", tt.requestBody)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedBody, body)
+		})
+	}
+}
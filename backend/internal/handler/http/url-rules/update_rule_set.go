@@ -0,0 +1,59 @@
+package urlrules
+
+import (
+	"errors"
+
+	domain "booklib/internal/domain/url-processor"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// UpdateRuleSet godoc
+// @Summary Update a URL rule set
+// @Description Replaces the name, hosts, priority, operations and active flag. The change applies at once.
+// @Tags url-rules
+// @Accept json
+// @Produce json
+// @Param id path string true "Rule set ID"
+// @Param rule_set body urlrules.RuleSetRequest true "Updated rule set"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /url-rules/{id} [put]
+func (h *Handler) UpdateRuleSet(c *fiber.Ctx) error {
+	var req RuleSetRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  "Cannot parse JSON",
+		})
+	}
+
+	res, err := h.usecase.UpdateRuleSet(c.UserContext(), c.Params("id"), req.toInput())
+	if errors.Is(err, domain.ErrInvalidRuleSet) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+	if errors.Is(err, domain.ErrRuleSetNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+	if err != nil {
+		log.Error(c.UserContext(), err, nil, "failed to update url rule set")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   res,
+	})
+}
@@ -0,0 +1,90 @@
+package urlrules
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	domain "booklib/internal/domain/url-processor"
+	urlprocessor "booklib/internal/usecase/url-processor"
+	"booklib/internal/usecase/url-processor/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateRuleSet(t *testing.T) {
+	operations := map[string][]domain.Step{"canonical": {{Type: domain.StepStripQuery}}}
+	validRequest := RuleSetRequest{Name: "shop", Hosts: []string{"shop.example"}, Priority: 1, Operations: operations}
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+	}{
+		{
+			name:        "successful update rule set",
+			requestBody: validRequest,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("UpdateRuleSet", mock.Anything, "rs-1", urlprocessor.RuleSetInput{
+					Name: "shop", Hosts: []string{"shop.example"}, Priority: 1, Operations: operations, Active: true,
+				}).Return(&domain.RuleSet{ID: "rs-1"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid JSON body",
+			requestBody:    `{"hosts": "shop.example"}`,
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "invalid rule set",
+			requestBody: validRequest,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("UpdateRuleSet", mock.Anything, "rs-1", mock.Anything).Return(nil, fmt.Errorf("%w: invalid host %q", domain.ErrInvalidRuleSet, "a*"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "rule set not found",
+			requestBody: validRequest,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("UpdateRuleSet", mock.Anything, "rs-1", mock.Anything).Return(nil, domain.ErrRuleSetNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:        "usecase error",
+			requestBody: validRequest,
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("UpdateRuleSet", mock.Anything, "rs-1", mock.Anything).Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Put("/url-rules/:id", handler.UpdateRuleSet)
+
+			status, body := doRequest(t, app, http.MethodPut, "/url-rules/rs-1", tt.requestBody)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, "success", body["status"])
+			} else {
+				assert.Equal(t, "error", body["status"])
+				assert.NotEmpty(t, body["error"])
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// AddSubscription godoc
+// @Summary Subscribe to book changes
+// @Description Registers a URL that receives a signed POST for every matching book event.
+// @Description The X-Booklib-Signature-256 header holds "sha256=" followed by the hex HMAC-SHA256 of the body keyed with the secret.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param subscription body webhook.SubscriptionRequest true "Subscription to create"
+// @Param Idempotency-Key header string false "Unique key to safely retry the request"
+// @Success 201 {object} map[string]interface{}
+// @Header 201 {string} Location "URL of the created subscription"
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks [post]
+func (h *Handler) AddSubscription(c *fiber.Ctx) error {
+	var req SubscriptionRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  "Cannot parse JSON",
+		})
+	}
+
+	in, err := req.parseValidateRequest(true)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	res, err := h.usecase.AddSubscription(c.UserContext(), in)
+	if err != nil {
+		log.Error(c.UserContext(), err, nil, "failed to add webhook subscription")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	c.Location(strings.TrimSuffix(c.Path(), "/") + "/" + res.ID)
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status": "success",
+		"data":   res,
+	})
+}
@@ -0,0 +1,118 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	domain "booklib/internal/domain/webhook"
+	usecaseWebhook "booklib/internal/usecase/webhook"
+	"booklib/internal/usecase/webhook/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddSubscription(t *testing.T) {
+	inactive := false
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{
+			name: "successful add subscription",
+			requestBody: SubscriptionRequest{
+				URL: "https://example.com/hook", EventTypes: []string{"book.created"}, Secret: "secret",
+			},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("AddSubscription", mock.Anything, usecaseWebhook.SubscriptionInput{
+					URL: "https://example.com/hook", EventTypes: []string{"book.created"}, Secret: "secret", Active: true,
+				}).Return(&domain.Subscription{
+					ID: "sub-1", URL: "https://example.com/hook", EventTypes: []string{"book.created"}, Secret: "secret", Active: true,
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: map[string]interface{}{
+				"status": "success",
+				"data": map[string]interface{}{
+					"id":          "sub-1",
+					"url":         "https://example.com/hook",
+					"event_types": []interface{}{"book.created"},
+					"active":      true,
+					"created_at":  "0001-01-01T00:00:00Z",
+					"updated_at":  "0001-01-01T00:00:00Z",
+				},
+			},
+		},
+		{
+			name:        "inactive subscription",
+			requestBody: SubscriptionRequest{URL: "http://example.com", Secret: "secret", Active: &inactive},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("AddSubscription", mock.Anything, usecaseWebhook.SubscriptionInput{
+					URL: "http://example.com", Secret: "secret", Active: false,
+				}).Return(&domain.Subscription{ID: "sub-1"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid JSON body",
+			requestBody:    `{"url": 1}`,
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "Cannot parse JSON"},
+		},
+		{
+			name:           "relative url",
+			requestBody:    SubscriptionRequest{URL: "/hook", Secret: "secret"},
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "url must be an absolute http or https URL"},
+		},
+		{
+			name:           "missing secret",
+			requestBody:    SubscriptionRequest{URL: "https://example.com/hook"},
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "secret cannot be empty"},
+		},
+		{
+			name:           "unknown event type",
+			requestBody:    SubscriptionRequest{URL: "https://example.com/hook", Secret: "secret", EventTypes: []string{"book.read"}},
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]interface{}{"status": "error", "error": `unknown event type "book.read"`},
+		},
+		{
+			name:        "usecase error",
+			requestBody: SubscriptionRequest{URL: "https://example.com/hook", Secret: "secret"},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("AddSubscription", mock.Anything, mock.Anything).Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "usecase error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Post("/webhooks", handler.AddSubscription)
+
+			status, body := doRequest(t, app, http.MethodPost, "/webhooks", tt.requestBody)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedBody != nil {
+				assert.Equal(t, tt.expectedBody, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package webhook
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// DeleteSubscription godoc
+// @Summary Delete a webhook subscription
+// @Description Deletes the subscription together with its delivery log
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{id} [delete]
+func (h *Handler) DeleteSubscription(c *fiber.Ctx) error {
+	if err := h.usecase.DeleteSubscription(c.UserContext(), c.Params("id")); err != nil {
+		log.Error(c.UserContext(), err, nil, "failed to delete webhook subscription")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+	})
+}
@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"booklib/internal/usecase/webhook/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteSubscription(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{
+			name: "successful delete subscription",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("DeleteSubscription", mock.Anything, "sub-1").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   map[string]interface{}{"status": "success"},
+		},
+		{
+			name: "usecase error",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("DeleteSubscription", mock.Anything, "sub-1").Return(errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "usecase error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Delete("/webhooks/:id", handler.DeleteSubscription)
+
+			status, body := doRequest(t, app, http.MethodDelete, "/webhooks/sub-1", nil)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedBody, body)
+		})
+	}
+}
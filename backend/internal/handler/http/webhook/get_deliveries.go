@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"errors"
+
+	domain "booklib/internal/domain/webhook"
+	"booklib/internal/usecase/webhook"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// GetDeliveries godoc
+// @Summary Webhook delivery log
+// @Description Returns deliveries across all subscriptions, newest first. Use status=dead for the dead-letter list.
+// @Tags webhooks
+// @Produce json
+// @Param status query string false "Only deliveries with this status" Enums(pending, succeeded, dead)
+// @Param limit query int false "Maximum number of deliveries (default 100, max 1000)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/deliveries [get]
+func (h *Handler) GetDeliveries(c *fiber.Ctx) error {
+	return h.getDeliveries(c, "")
+}
+
+// GetSubscriptionDeliveries godoc
+// @Summary Delivery log of a webhook subscription
+// @Description Returns the deliveries of one subscription, newest first.
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Param status query string false "Only deliveries with this status" Enums(pending, succeeded, dead)
+// @Param limit query int false "Maximum number of deliveries (default 100, max 1000)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{id}/deliveries [get]
+func (h *Handler) GetSubscriptionDeliveries(c *fiber.Ctx) error {
+	return h.getDeliveries(c, c.Params("id"))
+}
+
+func (h *Handler) getDeliveries(c *fiber.Ctx, subscriptionID string) error {
+	limit := c.QueryInt("limit", 0)
+	if limit < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  "limit cannot be negative",
+		})
+	}
+
+	status := c.Query("status")
+	switch status {
+	case "", domain.DeliveryStatusPending, domain.DeliveryStatusSucceeded, domain.DeliveryStatusDead:
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  "status must be one of pending, succeeded, dead",
+		})
+	}
+
+	res, err := h.usecase.GetDeliveries(c.UserContext(), webhook.GetDeliveriesInput{
+		SubscriptionID: subscriptionID,
+		Status:         status,
+		Limit:          limit,
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+	if err != nil {
+		log.Error(c.UserContext(), err, nil, "failed to get webhook deliveries")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   res,
+	})
+}
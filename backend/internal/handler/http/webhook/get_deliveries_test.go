@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	domain "booklib/internal/domain/webhook"
+	usecaseWebhook "booklib/internal/usecase/webhook"
+	"booklib/internal/usecase/webhook/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetDeliveries(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "dead-letter list",
+			url:  "/webhooks/deliveries?status=dead&limit=10",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetDeliveries", mock.Anything, usecaseWebhook.GetDeliveriesInput{Status: domain.DeliveryStatusDead, Limit: 10}).
+					Return([]domain.Delivery{{ID: 1, Status: domain.DeliveryStatusDead}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "log of one subscription",
+			url:  "/webhooks/sub-1/deliveries",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetDeliveries", mock.Anything, usecaseWebhook.GetDeliveriesInput{SubscriptionID: "sub-1"}).
+					Return([]domain.Delivery{{ID: 1}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid status",
+			url:            "/webhooks/deliveries?status=failed",
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "status must be one of pending, succeeded, dead",
+		},
+		{
+			name:           "negative limit",
+			url:            "/webhooks/deliveries?limit=-1",
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "limit cannot be negative",
+		},
+		{
+			name: "unknown subscription",
+			url:  "/webhooks/sub-1/deliveries",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetDeliveries", mock.Anything, mock.Anything).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  domain.ErrNotFound.Error(),
+		},
+		{
+			name: "usecase error",
+			url:  "/webhooks/deliveries",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetDeliveries", mock.Anything, mock.Anything).Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "usecase error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Get("/webhooks/deliveries", handler.GetDeliveries)
+			app.Get("/webhooks/:id/deliveries", handler.GetSubscriptionDeliveries)
+
+			status, body := doRequest(t, app, http.MethodGet, tt.url, nil)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedError != "" {
+				assert.Equal(t, tt.expectedError, body["error"])
+			} else {
+				assert.Equal(t, "success", body["status"])
+				assert.Len(t, body["data"], 1)
+			}
+		})
+	}
+}
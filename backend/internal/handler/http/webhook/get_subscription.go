@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"errors"
+
+	domain "booklib/internal/domain/webhook"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// GetSubscription godoc
+// @Summary Get a webhook subscription by ID
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{id} [get]
+func (h *Handler) GetSubscription(c *fiber.Ctx) error {
+	res, err := h.usecase.GetSubscription(c.UserContext(), c.Params("id"))
+	if errors.Is(err, domain.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+	if err != nil {
+		log.Error(c.UserContext(), err, nil, "failed to get webhook subscription")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   res,
+	})
+}
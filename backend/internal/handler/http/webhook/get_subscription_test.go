@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	domain "booklib/internal/domain/webhook"
+	"booklib/internal/usecase/webhook/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetSubscription(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+	}{
+		{
+			name: "successful get subscription",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetSubscription", mock.Anything, "sub-1").Return(&domain.Subscription{ID: "sub-1"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "subscription not found",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetSubscription", mock.Anything, "sub-1").Return(nil, domain.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "usecase error",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetSubscription", mock.Anything, "sub-1").Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Get("/webhooks/:id", handler.GetSubscription)
+
+			status, body := doRequest(t, app, http.MethodGet, "/webhooks/sub-1", nil)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, "success", body["status"])
+			} else {
+				assert.Equal(t, "error", body["status"])
+				assert.NotEmpty(t, body["error"])
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package webhook
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// GetSubscriptions godoc
+// @Summary List webhook subscriptions
+// @Description Returns every webhook subscription, oldest first. Secrets are never returned.
+// @Tags webhooks
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /webhooks [get]
+func (h *Handler) GetSubscriptions(c *fiber.Ctx) error {
+	subs, err := h.usecase.GetSubscriptions(c.UserContext())
+	if err != nil {
+		log.Error(c.UserContext(), err, nil, "failed to get webhook subscriptions")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   subs,
+	})
+}
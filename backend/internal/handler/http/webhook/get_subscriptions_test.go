@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	domain "booklib/internal/domain/webhook"
+	"booklib/internal/usecase/webhook/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetSubscriptions(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{
+			name: "secrets are not returned",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetSubscriptions", mock.Anything).Return([]domain.Subscription{
+					{ID: "sub-1", URL: "https://example.com/hook", EventTypes: []string{}, Secret: "secret", Active: true},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"status": "success",
+				"data": []interface{}{
+					map[string]interface{}{
+						"id":          "sub-1",
+						"url":         "https://example.com/hook",
+						"event_types": []interface{}{},
+						"active":      true,
+						"created_at":  "0001-01-01T00:00:00Z",
+						"updated_at":  "0001-01-01T00:00:00Z",
+					},
+				},
+			},
+		},
+		{
+			name: "usecase error",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("GetSubscriptions", mock.Anything).Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   map[string]interface{}{"status": "error", "error": "usecase error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Get("/webhooks", handler.GetSubscriptions)
+
+			status, body := doRequest(t, app, http.MethodGet, "/webhooks", nil)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedBody, body)
+		})
+	}
+}
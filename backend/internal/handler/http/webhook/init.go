@@ -0,0 +1,13 @@
+package webhook
+
+import "booklib/internal/usecase/webhook"
+
+type Handler struct {
+	usecase webhook.UseCase
+}
+
+func New(usecase webhook.UseCase) *Handler {
+	return &Handler{
+		usecase: usecase,
+	}
+}
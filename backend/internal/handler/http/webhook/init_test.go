@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"booklib/internal/usecase/webhook/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("creates new handler with usecase", func(t *testing.T) {
+		usecase := mocks.NewUseCase(t)
+
+		handler := New(usecase)
+
+		assert.NotNil(t, handler)
+		assert.Equal(t, usecase, handler.usecase)
+	})
+}
+
+// doRequest sends body (a raw string or a value encoded as JSON) to app and
+// decodes the JSON response.
+func doRequest(t *testing.T, app *fiber.App, method, url string, body interface{}) (int, map[string]interface{}) {
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case string:
+		reader = bytes.NewReader([]byte(b))
+	default:
+		raw, err := json.Marshal(b)
+		assert.NoError(t, err)
+		reader = bytes.NewReader(raw)
+	}
+
+	req := httptest.NewRequest(method, url, reader)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+
+	var res map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+
+	return resp.StatusCode, res
+}
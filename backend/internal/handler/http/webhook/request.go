@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+
+	domain "booklib/internal/domain/webhook"
+	"booklib/internal/usecase/webhook"
+)
+
+// SubscriptionRequest represents the request payload for creating or updating a webhook subscription
+type SubscriptionRequest struct {
+	URL string `json:"url"`
+	// EventTypes limits the events sent to URL; empty means every event
+	EventTypes []string `json:"event_types" enums:"book.created,book.updated,book.deleted,book.merged"`
+	// Secret signs every delivery; it is required on creation and kept when empty on update
+	Secret string `json:"secret"`
+	// Active defaults to true
+	Active *bool `json:"active"`
+}
+
+func (req *SubscriptionRequest) parseValidateRequest(requireSecret bool) (webhook.SubscriptionInput, error) {
+	u, err := url.Parse(req.URL)
+	if req.URL == "" || err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return webhook.SubscriptionInput{}, errors.New("url must be an absolute http or https URL")
+	}
+	if requireSecret && req.Secret == "" {
+		return webhook.SubscriptionInput{}, errors.New("secret cannot be empty")
+	}
+	for _, t := range req.EventTypes {
+		if !slices.Contains(domain.EventTypes, t) {
+			return webhook.SubscriptionInput{}, fmt.Errorf("unknown event type %q", t)
+		}
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return webhook.SubscriptionInput{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+		Active:     active,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"errors"
+	"strconv"
+
+	domain "booklib/internal/domain/webhook"
+	"booklib/internal/usecase/webhook"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// RetryDelivery godoc
+// @Summary Retry a dead webhook delivery
+// @Description Moves a delivery from the dead-letter list back to the queue with a fresh set of attempts.
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Delivery ID"
+// @Success 202 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/deliveries/{id}/retry [post]
+func (h *Handler) RetryDelivery(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  "id must be a number",
+		})
+	}
+
+	res, err := h.usecase.RetryDelivery(c.UserContext(), id)
+	switch {
+	case errors.Is(err, domain.ErrDeliveryNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	case errors.Is(err, webhook.ErrDeliveryNotDead):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	case err != nil:
+		log.Error(c.UserContext(), err, nil, "failed to retry webhook delivery")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"status": "success",
+		"data":   res,
+	})
+}
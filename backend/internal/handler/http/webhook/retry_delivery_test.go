@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	domain "booklib/internal/domain/webhook"
+	usecaseWebhook "booklib/internal/usecase/webhook"
+	"booklib/internal/usecase/webhook/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRetryDelivery(t *testing.T) {
+	tests := []struct {
+		name           string
+		deliveryID     string
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+	}{
+		{
+			name:       "requeues a dead delivery",
+			deliveryID: "7",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("RetryDelivery", mock.Anything, int64(7)).
+					Return(&domain.Delivery{ID: 7, Status: domain.DeliveryStatusPending}, nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "invalid id",
+			deliveryID:     "abc",
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "delivery not found",
+			deliveryID: "7",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("RetryDelivery", mock.Anything, int64(7)).Return(nil, domain.ErrDeliveryNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:       "delivery is not dead",
+			deliveryID: "7",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("RetryDelivery", mock.Anything, int64(7)).Return(nil, usecaseWebhook.ErrDeliveryNotDead)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:       "usecase error",
+			deliveryID: "7",
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("RetryDelivery", mock.Anything, int64(7)).Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Post("/webhooks/deliveries/:id/retry", handler.RetryDelivery)
+
+			status, body := doRequest(t, app, http.MethodPost, "/webhooks/deliveries/"+tt.deliveryID+"/retry", nil)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedStatus == http.StatusAccepted {
+				assert.Equal(t, "success", body["status"])
+			} else {
+				assert.NotEmpty(t, body["error"])
+			}
+		})
+	}
+}
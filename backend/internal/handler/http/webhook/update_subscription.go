@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"errors"
+
+	domain "booklib/internal/domain/webhook"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+// UpdateSubscription godoc
+// @Summary Update a webhook subscription
+// @Description Replaces the URL, event types and active flag. An empty secret keeps the current one.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Param subscription body webhook.SubscriptionRequest true "Updated subscription"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{id} [put]
+func (h *Handler) UpdateSubscription(c *fiber.Ctx) error {
+	var req SubscriptionRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  "Cannot parse JSON",
+		})
+	}
+
+	in, err := req.parseValidateRequest(false)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	res, err := h.usecase.UpdateSubscription(c.UserContext(), c.Params("id"), in)
+	if errors.Is(err, domain.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+	if err != nil {
+		log.Error(c.UserContext(), err, nil, "failed to update webhook subscription")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status": "error",
+			"error":  err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "success",
+		"data":   res,
+	})
+}
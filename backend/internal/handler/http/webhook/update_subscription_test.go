@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	domain "booklib/internal/domain/webhook"
+	usecaseWebhook "booklib/internal/usecase/webhook"
+	"booklib/internal/usecase/webhook/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateSubscription(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		setupMocks     func(*mocks.UseCase)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "secret is optional on update",
+			requestBody: SubscriptionRequest{URL: "https://example.com/new"},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("UpdateSubscription", mock.Anything, "sub-1", usecaseWebhook.SubscriptionInput{
+					URL: "https://example.com/new", Active: true,
+				}).Return(&domain.Subscription{ID: "sub-1", URL: "https://example.com/new"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid JSON body",
+			requestBody:    `{`,
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Cannot parse JSON",
+		},
+		{
+			name:           "invalid url",
+			requestBody:    SubscriptionRequest{URL: "example.com"},
+			setupMocks:     func(uc *mocks.UseCase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "url must be an absolute http or https URL",
+		},
+		{
+			name:        "subscription not found",
+			requestBody: SubscriptionRequest{URL: "https://example.com/new"},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("UpdateSubscription", mock.Anything, "sub-1", mock.Anything).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  domain.ErrNotFound.Error(),
+		},
+		{
+			name:        "usecase error",
+			requestBody: SubscriptionRequest{URL: "https://example.com/new"},
+			setupMocks: func(uc *mocks.UseCase) {
+				uc.On("UpdateSubscription", mock.Anything, "sub-1", mock.Anything).Return(nil, errors.New("usecase error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "usecase error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			usecase := mocks.NewUseCase(t)
+			tt.setupMocks(usecase)
+
+			handler := New(usecase)
+			app.Put("/webhooks/:id", handler.UpdateSubscription)
+
+			status, body := doRequest(t, app, http.MethodPut, "/webhooks/sub-1", tt.requestBody)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedError != "" {
+				assert.Equal(t, tt.expectedError, body["error"])
+			} else {
+				assert.Equal(t, "success", body["status"])
+			}
+		})
+	}
+}
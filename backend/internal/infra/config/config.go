@@ -1,6 +1,7 @@
 package config
 
 import (
+	urlprocessor "booklib/internal/domain/url-processor"
 	"fmt"
 	"gopkg.in/yaml.v3"
 	"os"
@@ -8,8 +9,12 @@ import (
 )
 
 func New(appName string) (*Config, error) {
-	fileConfig := getConfigFile(appName)
+	return NewFromFile(appName, getConfigFile(appName))
+}
 
+// NewFromFile loads the config from the given file instead of the default
+// files/etc/<app>/config.yaml.
+func NewFromFile(appName, fileConfig string) (*Config, error) {
 	f, err := os.Open(fileConfig)
 	if err != nil {
 		return nil, err
@@ -21,6 +26,10 @@ func New(appName string) (*Config, error) {
 		return nil, err
 	}
 
+	if cfg.Admin.Enabled && cfg.Admin.Token == "" {
+		return nil, fmt.Errorf("admin.token must be set when admin.enabled is true")
+	}
+
 	cfg.AppName = appName
 	return &cfg, nil
 }
@@ -36,22 +45,155 @@ func getConfigFile(appName string) string {
 }
 
 type Config struct {
-	AppName  string
-	Env      string   `yaml:"env"`
-	Server   Server   `yaml:"server"`
-	Database DBConfig `yaml:"database"`
+	AppName      string
+	Env          string             `yaml:"env"`
+	Server       Server             `yaml:"server"`
+	Database     DBConfig           `yaml:"database"`
+	Idempotency  IdempotencyConfig  `yaml:"idempotency"`
+	Events       EventsConfig       `yaml:"events"`
+	Webhooks     WebhooksConfig     `yaml:"webhooks"`
+	Outbox       OutboxConfig       `yaml:"outbox"`
+	URLProcessor URLProcessorConfig `yaml:"url_processor"`
+	Outbound     OutboundConfig     `yaml:"outbound"`
+	Links        LinksConfig        `yaml:"links"`
+	Admin        AdminConfig        `yaml:"admin"`
 }
 
 type Server struct {
 	Port         int32 `yaml:"port"`
 	WriteTimeout int64 `yaml:"write_timeout"`
 	ReadTimeout  int64 `yaml:"read_timeout"`
+	// BodyLimit is the largest accepted request body in bytes, fiber's 4MB
+	// default when unset
+	BodyLimit int `yaml:"body_limit"`
 }
 
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemory   = "memory"
+)
+
 type DBConfig struct {
-	Host     string `yaml:"host"`
-	Port     int32  `yaml:"port"`
-	DBName   string `yaml:"db_name"`
-	User     string `yaml:"user"`
-	Password string `yaml:"password"`
+	// Driver picks the storage: "postgres" (the default), "sqlite" or
+	// "memory", which keeps everything in process and is lost on restart
+	Driver string `yaml:"driver"`
+	// Path is the database file of the sqlite driver
+	Path string `yaml:"path"`
+	// AutoMigrate applies pending migrations when the server starts
+	AutoMigrate bool   `yaml:"auto_migrate"`
+	Host        string `yaml:"host"`
+	Port        int32  `yaml:"port"`
+	DBName      string `yaml:"db_name"`
+	User        string `yaml:"user"`
+	Password    string `yaml:"password"`
+}
+
+type IdempotencyConfig struct {
+	// TTL is how long a stored response is replayed, in seconds
+	TTL int64 `yaml:"ttl"`
+}
+
+type EventsConfig struct {
+	// PollInterval is how often open event streams check for new events, in milliseconds
+	PollInterval int64 `yaml:"poll_interval"`
+}
+
+// WebhooksConfig tunes webhook delivery. Durations are in milliseconds.
+type WebhooksConfig struct {
+	PollInterval   int64 `yaml:"poll_interval"`
+	Timeout        int64 `yaml:"timeout"`
+	MaxAttempts    int   `yaml:"max_attempts"`
+	InitialBackoff int64 `yaml:"initial_backoff"`
+	MaxBackoff     int64 `yaml:"max_backoff"`
+	BatchSize      int   `yaml:"batch_size"`
+}
+
+// OutboxConfig tunes the relay that publishes recorded events. Durations are
+// in milliseconds.
+type OutboxConfig struct {
+	PollInterval   int64 `yaml:"poll_interval"`
+	BatchSize      int   `yaml:"batch_size"`
+	Lease          int64 `yaml:"lease"`
+	InitialBackoff int64 `yaml:"initial_backoff"`
+	MaxBackoff     int64 `yaml:"max_backoff"`
+	// Publisher is the broker events are published to besides the in-process
+	// bus: empty for none, "postgres" or "kafka"
+	Publisher string         `yaml:"publisher"`
+	Postgres  PostgresNotify `yaml:"postgres"`
+	Kafka     KafkaConfig    `yaml:"kafka"`
+}
+
+type PostgresNotify struct {
+	Channel string `yaml:"channel"`
+}
+
+type KafkaConfig struct {
+	Brokers []string `yaml:"brokers"`
+	Topic   string   `yaml:"topic"`
+}
+
+// URLProcessorConfig holds the rule profiles of /process-url. A profile named
+// "default" replaces the built-in one.
+type URLProcessorConfig struct {
+	// DefaultProfile serves the requests naming no profile, "default" when empty
+	DefaultProfile string                          `yaml:"default_profile"`
+	Profiles       map[string]urlprocessor.Profile `yaml:"profiles"`
+	// TrackingParams are removed by strip_tracking steps on top of the
+	// built-in list, patterns as in step params
+	TrackingParams []string `yaml:"tracking_params"`
+	// BatchWorkers is how many URLs of a /process-urls batch are processed at once
+	BatchWorkers int `yaml:"batch_workers"`
+	MaxBatchURLs int `yaml:"max_batch_urls"`
+	// MaxDocumentLinks is the most distinct links a /process-document
+	// document may have processed
+	MaxDocumentLinks int `yaml:"max_document_links"`
+	// ResolveMaxHops is how many redirects a resolve step follows
+	ResolveMaxHops int `yaml:"resolve_max_hops"`
+	// ResolveTimeout bounds a whole resolve step, in milliseconds
+	ResolveTimeout int64 `yaml:"resolve_timeout"`
+	// RuleSetsRefresh is how long the rule sets stored in the database are
+	// cached, in milliseconds
+	RuleSetsRefresh int64 `yaml:"rule_sets_refresh"`
+}
+
+// OutboundConfig restricts the HTTP requests the service makes, resolving
+// URLs and delivering webhooks. Private, loopback, link-local and cloud
+// metadata addresses are always refused unless in AllowedNetworks.
+type OutboundConfig struct {
+	// Schemes requests may use, http and https when empty
+	Schemes []string `yaml:"schemes"`
+	// AllowedHosts, when set, are the only hosts requests may reach;
+	// "*.example.com" matches the subdomains of example.com
+	AllowedHosts []string `yaml:"allowed_hosts"`
+	DeniedHosts  []string `yaml:"denied_hosts"`
+	// AllowedNetworks are CIDRs reachable although blocked by default
+	AllowedNetworks []string `yaml:"allowed_networks"`
+	// MaxResponseBytes caps response bodies, 10 MB when unset
+	MaxResponseBytes int64 `yaml:"max_response_bytes"`
+}
+
+// LinksConfig tunes the short links served at /l/:code.
+type LinksConfig struct {
+	// BaseURL prefixes the short URLs returned, the URL of the request when
+	// empty
+	BaseURL string `yaml:"base_url"`
+	// Profile and Operation of the URL processor canonicalising targets, the
+	// default profile and "normalize" when empty
+	Profile   string `yaml:"profile"`
+	Operation string `yaml:"operation"`
+}
+
+// AdminConfig guards the backup and restore endpoints under /admin.
+type AdminConfig struct {
+	// Enabled serves the admin endpoints, which are not routed at all when
+	// false
+	Enabled bool `yaml:"enabled"`
+	// Token every admin request must send as "Authorization: Bearer <token>",
+	// required when Enabled
+	Token string `yaml:"token"`
+	// RestoreBodyLimit is the largest backup a restore accepts in bytes,
+	// 100MB when unset. It applies to the restore route only, the others
+	// keep server.body_limit.
+	RestoreBodyLimit int `yaml:"restore_body_limit"`
 }
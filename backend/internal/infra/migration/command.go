@@ -0,0 +1,91 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+)
+
+const (
+	// Usage lists the commands understood by Run.
+	Usage = "up | down [steps] | redo | status | baseline [version]"
+)
+
+var (
+	ErrUsage = errors.New("usage: migrate " + Usage)
+)
+
+// Run runs a migrate command, such as `up` or `down 2`, and reports to out.
+// It backs every binary that exposes a `migrate` command.
+func (m *Migrator) Run(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return ErrUsage
+	}
+
+	switch args[0] {
+	case "up":
+		done, err := m.Up(ctx)
+		printMigrations(out, "applied", done)
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			var err error
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("steps must be a positive number, got %q", args[1])
+			}
+		}
+		done, err := m.Down(ctx, steps)
+		printMigrations(out, "rolled back", done)
+		return err
+	case "redo":
+		redone, err := m.Redo(ctx)
+		if redone != nil {
+			_, _ = fmt.Fprintf(out, "redone %s\n", redone.File())
+		}
+		return err
+	case "status":
+		status, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(out, status)
+		return nil
+	case "baseline":
+		var version string
+		if len(args) > 1 {
+			version = args[1]
+		}
+		done, err := m.Baseline(ctx, version)
+		printMigrations(out, "marked as applied", done)
+		return err
+	}
+
+	return ErrUsage
+}
+
+func printMigrations(out io.Writer, verb string, done []Migration) {
+	if len(done) == 0 {
+		_, _ = fmt.Fprintln(out, "nothing to do")
+		return
+	}
+	for _, mig := range done {
+		_, _ = fmt.Fprintf(out, "%s %s\n", verb, mig.File())
+	}
+}
+
+func printStatus(out io.Writer, status []Status) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, st := range status {
+		appliedAt := "-"
+		if st.AppliedAt != nil {
+			appliedAt = st.AppliedAt.Local().Format("2006-01-02 15:04:05")
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", st.Version, st.Name, st.State, appliedAt)
+	}
+	_ = w.Flush()
+}
@@ -0,0 +1,95 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigratorRun(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		setup       []string
+		args        []string
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:     "up",
+			args:     []string{"up"},
+			expected: "applied 20261019_01_create_authors.sql\napplied 20261019_02_create_shelves.sql\n",
+		},
+		{
+			name:     "up with nothing pending",
+			setup:    []string{"up"},
+			args:     []string{"up"},
+			expected: "nothing to do\n",
+		},
+		{
+			name:     "down defaults to one step",
+			setup:    []string{"up"},
+			args:     []string{"down"},
+			expected: "rolled back 20261019_02_create_shelves.sql\n",
+		},
+		{
+			name:     "down with steps",
+			setup:    []string{"up"},
+			args:     []string{"down", "2"},
+			expected: "rolled back 20261019_02_create_shelves.sql\nrolled back 20261019_01_create_authors.sql\n",
+		},
+		{
+			name:        "down with invalid steps",
+			args:        []string{"down", "zero"},
+			expectedErr: `steps must be a positive number, got "zero"`,
+		},
+		{
+			name:     "redo",
+			setup:    []string{"up"},
+			args:     []string{"redo"},
+			expected: "redone 20261019_02_create_shelves.sql\n",
+		},
+		{
+			name:     "baseline",
+			args:     []string{"baseline", "20261019_01"},
+			expected: "marked as applied 20261019_01_create_authors.sql\n",
+		},
+		{
+			name:     "status",
+			args:     []string{"status"},
+			expected: "VERSION      NAME            STATE    APPLIED AT\n20261019_01  create_authors  pending  -\n20261019_02  create_shelves  pending  -\n",
+		},
+		{
+			name:        "no command",
+			expectedErr: ErrUsage.Error(),
+		},
+		{
+			name:        "unknown command",
+			args:        []string{"sideways"},
+			expectedErr: ErrUsage.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMigrator(t, newSQLite(t), testMigrations)
+			if tt.setup != nil {
+				require.NoError(t, m.Run(ctx, tt.setup, &bytes.Buffer{}))
+			}
+
+			var out bytes.Buffer
+			err := m.Run(ctx, tt.args, &out)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, out.String())
+		})
+	}
+}
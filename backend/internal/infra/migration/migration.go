@@ -0,0 +1,101 @@
+// Package migration applies versioned SQL migrations and records them in the
+// schema_migrations table, with the checksum of every applied file.
+package migration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Migration is one pair of up/<version>_<name>.sql and down/<version>_<name>.sql,
+// where version is <date>_<sequence>, e.g. 20250807_01.
+type Migration struct {
+	Version string
+	Name    string
+	Up      string
+	// Down is empty when the migration cannot be rolled back
+	Down string
+	// Checksum is the SHA-256 of Up
+	Checksum string
+}
+
+func (m Migration) File() string {
+	return m.Version + "_" + m.Name + ".sql"
+}
+
+// Load reads the migrations of fsys, sorted by version.
+func Load(fsys fs.FS) ([]Migration, error) {
+	ups, err := fs.Glob(fsys, "up/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		result   = make([]Migration, 0, len(ups))
+		versions = make(map[string]string, len(ups))
+	)
+	for _, file := range ups {
+		version, name, err := parseFile(path.Base(file))
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := versions[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s share version %s", other, path.Base(file), version)
+		}
+		versions[version] = path.Base(file)
+
+		up, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		down, err := fs.ReadFile(fsys, path.Join("down", path.Base(file)))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		sum := sha256.Sum256(up)
+		result = append(result, Migration{
+			Version:  version,
+			Name:     name,
+			Up:       string(up),
+			Down:     string(down),
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+	}
+
+	// a down file without its up file is a typo waiting to bite
+	downs, err := fs.Glob(fsys, "down/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range downs {
+		version, _, err := parseFile(path.Base(file))
+		if err != nil {
+			return nil, err
+		}
+		if versions[version] != path.Base(file) {
+			return nil, fmt.Errorf("down migration %s has no up migration", path.Base(file))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+
+	return result, nil
+}
+
+// parseFile splits <date>_<sequence>_<name>.sql.
+func parseFile(file string) (version, name string, err error) {
+	parts := strings.SplitN(strings.TrimSuffix(file, ".sql"), "_", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", fmt.Errorf("migration %s is not named <date>_<sequence>_<name>.sql", file)
+	}
+
+	return parts[0] + "_" + parts[1], parts[2], nil
+}
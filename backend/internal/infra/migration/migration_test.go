@@ -0,0 +1,97 @@
+package migration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"booklib/migrations"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func checksum(sql string) string {
+	sum := sha256.Sum256([]byte(sql))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       fstest.MapFS
+		expected    []Migration
+		expectedErr string
+	}{
+		{
+			name: "sorted by version with optional down",
+			files: fstest.MapFS{
+				"up/20261019_02_add_index.sql":      {Data: []byte("CREATE INDEX i ON t (c);")},
+				"up/20261019_01_create_table.sql":   {Data: []byte("CREATE TABLE t (c TEXT);")},
+				"down/20261019_01_create_table.sql": {Data: []byte("DROP TABLE t;")},
+				"README.md":                         {Data: []byte("not a migration")},
+			},
+			expected: []Migration{
+				{
+					Version: "20261019_01", Name: "create_table", Up: "CREATE TABLE t (c TEXT);", Down: "DROP TABLE t;",
+					Checksum: checksum("CREATE TABLE t (c TEXT);"),
+				},
+				{
+					Version: "20261019_02", Name: "add_index", Up: "CREATE INDEX i ON t (c);",
+					Checksum: checksum("CREATE INDEX i ON t (c);"),
+				},
+			},
+		},
+		{
+			name: "badly named file",
+			files: fstest.MapFS{
+				"up/create_table.sql": {Data: []byte("CREATE TABLE t (c TEXT);")},
+			},
+			expectedErr: "migration create_table.sql is not named <date>_<sequence>_<name>.sql",
+		},
+		{
+			name: "duplicate version",
+			files: fstest.MapFS{
+				"up/20261019_01_a.sql": {Data: []byte("SELECT 1;")},
+				"up/20261019_01_b.sql": {Data: []byte("SELECT 2;")},
+			},
+			expectedErr: "migrations 20261019_01_a.sql and 20261019_01_b.sql share version 20261019_01",
+		},
+		{
+			name: "down without up",
+			files: fstest.MapFS{
+				"up/20261019_01_a.sql":   {Data: []byte("SELECT 1;")},
+				"down/20261019_01_b.sql": {Data: []byte("SELECT 2;")},
+			},
+			expectedErr: "down migration 20261019_01_b.sql has no up migration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := Load(tt.files)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestLoad_Embedded(t *testing.T) {
+	for name, fsys := range map[string]fs.FS{"postgres": migrations.Postgres, "sqlite": migrations.SQLite} {
+		t.Run(name, func(t *testing.T) {
+			res, err := Load(fsys)
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, res)
+			for _, mig := range res {
+				assert.NotEmpty(t, mig.Down, "%s has no down migration", mig.File())
+			}
+		})
+	}
+}
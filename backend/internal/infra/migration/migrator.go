@@ -0,0 +1,360 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"time"
+
+	"booklib/internal/repo/dialect"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	StateApplied = "applied"
+	StatePending = "pending"
+	// StateModified marks applied migrations whose file changed since
+	StateModified = "modified"
+	// StateUnknown marks applied migrations this build does not ship
+	StateUnknown = "unknown"
+
+	// lockKey names the postgres advisory lock held while migrating, so
+	// servers starting together do not migrate at the same time.
+	lockKey = 20250807
+)
+
+type Migrator struct {
+	db         *sqlx.DB
+	dialect    dialect.Dialect
+	migrations []Migration
+}
+
+// Status is a migration together with whether and when it was applied.
+type Status struct {
+	Version   string
+	Name      string
+	State     string
+	AppliedAt *time.Time
+}
+
+// record is a row of schema_migrations.
+type record struct {
+	Version   string    `db:"version"`
+	Name      string    `db:"name"`
+	Checksum  string    `db:"checksum"`
+	AppliedAt time.Time `db:"applied_at"`
+}
+
+func New(db *sqlx.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		dialect:    dialect.Of(db),
+		migrations: migrations,
+	}, nil
+}
+
+// Up applies every pending migration, oldest first, and returns them.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := m.verified(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			if err = m.up(ctx, conn, mig); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+
+	return done, err
+}
+
+// Down rolls back the last steps applied migrations, newest first, and
+// returns them.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := m.verified(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			if err = m.down(ctx, conn, mig); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+
+	return done, err
+}
+
+// Redo rolls back the last applied migration and applies it again, which is
+// handy while writing it. It returns nil when nothing is applied.
+func (m *Migrator) Redo(ctx context.Context) (*Migration, error) {
+	var redone *Migration
+
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := m.verified(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			if err = m.down(ctx, conn, mig); err != nil {
+				return err
+			}
+			if err = m.up(ctx, conn, mig); err != nil {
+				return err
+			}
+			redone = &mig
+			return nil
+		}
+		return nil
+	})
+
+	return redone, err
+}
+
+// Baseline records every migration up to version, or all of them when version
+// is empty, as applied without running them. It adopts databases that were
+// migrated by hand before migrations were tracked.
+func (m *Migrator) Baseline(ctx context.Context, version string) ([]Migration, error) {
+	var done []Migration
+
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			if version != "" && mig.Version > version {
+				break
+			}
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			if err = m.record(ctx, conn, mig); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+
+	return done, err
+}
+
+// Status lists every known migration, oldest first, followed by applied
+// migrations this build does not know.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var result []Status
+
+	err := m.withConn(ctx, func(conn *sqlx.Conn) error {
+		if err := m.ensureTable(ctx, conn); err != nil {
+			return err
+		}
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		known := make(map[string]bool, len(m.migrations))
+		for _, mig := range m.migrations {
+			known[mig.Version] = true
+
+			st := Status{Version: mig.Version, Name: mig.Name, State: StatePending}
+			if rec, ok := applied[mig.Version]; ok {
+				st.State, st.AppliedAt = StateApplied, &rec.AppliedAt
+				if rec.Checksum != mig.Checksum {
+					st.State = StateModified
+				}
+			}
+			result = append(result, st)
+		}
+
+		for _, rec := range sortedRecords(applied) {
+			if !known[rec.Version] {
+				result = append(result, Status{Version: rec.Version, Name: rec.Name, State: StateUnknown, AppliedAt: &rec.AppliedAt})
+			}
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// verified returns the applied migrations, failing when any of them changed
+// or is unknown: migrating on top of them would build a schema nobody tested.
+func (m *Migrator) verified(ctx context.Context, conn *sqlx.Conn) (map[string]record, error) {
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[string]Migration, len(m.migrations))
+	for _, mig := range m.migrations {
+		byVersion[mig.Version] = mig
+	}
+
+	for _, rec := range sortedRecords(applied) {
+		mig, ok := byVersion[rec.Version]
+		if !ok {
+			return nil, fmt.Errorf("migration %s_%s is applied but unknown to this build", rec.Version, rec.Name)
+		}
+		if mig.Checksum != rec.Checksum {
+			return nil, fmt.Errorf("migration %s was changed after it was applied", mig.File())
+		}
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sqlx.Conn) (map[string]record, error) {
+	var records []record
+	if err := conn.SelectContext(ctx, &records, `SELECT version, name, checksum, applied_at FROM schema_migrations`); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]record, len(records))
+	for _, rec := range records {
+		result[rec.Version] = rec
+	}
+	return result, nil
+}
+
+// up applies mig in its own transaction. It checks again inside the
+// transaction, since sqlite has no lock to keep two processes apart before it.
+func (m *Migrator) up(ctx context.Context, conn *sqlx.Conn, mig Migration) error {
+	return m.inTx(ctx, conn, mig, func(tx *sqlx.Tx) error {
+		var n int
+		if err := tx.GetContext(ctx, &n, `SELECT COUNT(*) FROM schema_migrations WHERE version = $1`, mig.Version); err != nil {
+			return err
+		}
+		if n > 0 {
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx, mig.Up); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`, mig.Version, mig.Name, mig.Checksum)
+		return err
+	})
+}
+
+func (m *Migrator) down(ctx context.Context, conn *sqlx.Conn, mig Migration) error {
+	if mig.Down == "" {
+		return fmt.Errorf("migration %s has no down migration", mig.File())
+	}
+
+	return m.inTx(ctx, conn, mig, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, mig.Down); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version)
+		return err
+	})
+}
+
+func (m *Migrator) record(ctx context.Context, conn *sqlx.Conn, mig Migration) error {
+	_, err := conn.ExecContext(ctx, `INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`, mig.Version, mig.Name, mig.Checksum)
+	return err
+}
+
+func (m *Migrator) inTx(ctx context.Context, conn *sqlx.Conn, mig Migration, fn func(tx *sqlx.Tx) error) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		return errors.Join(fmt.Errorf("migration %s: %w", mig.File(), err), tx.Rollback())
+	}
+
+	return tx.Commit()
+}
+
+// withLock runs fn on a single connection holding the migration lock.
+// Postgres takes an advisory lock, which belongs to the session; sqlite
+// serializes the transactions of up and down instead.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	return m.withConn(ctx, func(conn *sqlx.Conn) error {
+		if m.dialect == dialect.Postgres {
+			if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+				return err
+			}
+			defer func() {
+				// release the lock even if ctx is done, the connection goes back to the pool
+				_, _ = conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey)
+			}()
+		}
+
+		if err := m.ensureTable(ctx, conn); err != nil {
+			return err
+		}
+		return fn(conn)
+	})
+}
+
+func (m *Migrator) withConn(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return fn(conn)
+}
+
+func (m *Migrator) ensureTable(ctx context.Context, conn *sqlx.Conn) error {
+	query := `CREATE TABLE IF NOT EXISTS schema_migrations (version TEXT PRIMARY KEY, name TEXT NOT NULL, ` +
+		`checksum TEXT NOT NULL, applied_at ` + m.timestampType() + ` NOT NULL DEFAULT (` + m.dialect.Now() + `))`
+
+	_, err := conn.ExecContext(ctx, query)
+	return err
+}
+
+func (m *Migrator) timestampType() string {
+	if m.dialect == dialect.SQLite {
+		return "TIMESTAMP"
+	}
+	return "TIMESTAMPTZ"
+}
+
+func sortedRecords(applied map[string]record) []record {
+	result := make([]record, 0, len(applied))
+	for _, rec := range applied {
+		result = append(result, rec)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result
+}
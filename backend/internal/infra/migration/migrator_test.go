@@ -0,0 +1,231 @@
+package migration
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"booklib/internal/repo/dialect"
+	"booklib/migrations"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMigrations = fstest.MapFS{
+	"up/20261019_01_create_authors.sql":   {Data: []byte("CREATE TABLE authors (id TEXT PRIMARY KEY);")},
+	"down/20261019_01_create_authors.sql": {Data: []byte("DROP TABLE authors;")},
+	"up/20261019_02_create_shelves.sql":   {Data: []byte("CREATE TABLE shelves (id TEXT PRIMARY KEY);")},
+	"down/20261019_02_create_shelves.sql": {Data: []byte("DROP TABLE shelves;")},
+}
+
+func newSQLite(t *testing.T) *sqlx.DB {
+	db, err := dialect.OpenSQLite(filepath.Join(t.TempDir(), "booklib.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func newMigrator(t *testing.T, db *sqlx.DB, files fstest.MapFS) *Migrator {
+	m, err := New(db, files)
+	require.NoError(t, err)
+	return m
+}
+
+func states(t *testing.T, m *Migrator) map[string]string {
+	status, err := m.Status(context.Background())
+	require.NoError(t, err)
+
+	res := make(map[string]string, len(status))
+	for _, st := range status {
+		res[st.Version] = st.State
+	}
+	return res
+}
+
+func tableExists(t *testing.T, db *sqlx.DB, table string) bool {
+	var n int
+	require.NoError(t, db.Get(&n, `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = $1`, table))
+	return n > 0
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("up applies pending migrations once", func(t *testing.T) {
+		db := newSQLite(t)
+		m := newMigrator(t, db, testMigrations)
+		assert.Equal(t, map[string]string{"20261019_01": StatePending, "20261019_02": StatePending}, states(t, m))
+
+		done, err := m.Up(ctx)
+		require.NoError(t, err)
+		assert.Len(t, done, 2)
+		assert.True(t, tableExists(t, db, "shelves"))
+
+		done, err = m.Up(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, done)
+
+		status, err := m.Status(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, StateApplied, status[1].State)
+		assert.NotNil(t, status[1].AppliedAt)
+	})
+
+	t.Run("down rolls back the newest migrations", func(t *testing.T) {
+		db := newSQLite(t)
+		m := newMigrator(t, db, testMigrations)
+		_, err := m.Up(ctx)
+		require.NoError(t, err)
+
+		done, err := m.Down(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, done, 1)
+		assert.Equal(t, "20261019_02", done[0].Version)
+		assert.False(t, tableExists(t, db, "shelves"))
+		assert.True(t, tableExists(t, db, "authors"))
+		assert.Equal(t, map[string]string{"20261019_01": StateApplied, "20261019_02": StatePending}, states(t, m))
+
+		done, err = m.Down(ctx, 5)
+		require.NoError(t, err)
+		assert.Len(t, done, 1)
+		assert.False(t, tableExists(t, db, "authors"))
+	})
+
+	t.Run("redo runs the last migration again", func(t *testing.T) {
+		db := newSQLite(t)
+		m := newMigrator(t, db, testMigrations)
+
+		redone, err := m.Redo(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, redone)
+
+		_, err = m.Up(ctx)
+		require.NoError(t, err)
+		db.MustExec(`INSERT INTO shelves (id) VALUES ('s-1')`)
+
+		redone, err = m.Redo(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "20261019_02", redone.Version)
+
+		// the table was dropped and created again
+		var n int
+		require.NoError(t, db.Get(&n, `SELECT COUNT(*) FROM shelves`))
+		assert.Zero(t, n)
+	})
+
+	t.Run("failed migration is rolled back", func(t *testing.T) {
+		db := newSQLite(t)
+		m := newMigrator(t, db, fstest.MapFS{
+			"up/20261019_01_create_authors.sql": testMigrations["up/20261019_01_create_authors.sql"],
+			"up/20261019_02_broken.sql":         {Data: []byte("CREATE TABLE broken (id TEXT); SELECT * FROM missing;")},
+		})
+
+		done, err := m.Up(ctx)
+		assert.ErrorContains(t, err, "migration 20261019_02_broken.sql")
+		assert.Len(t, done, 1)
+		assert.False(t, tableExists(t, db, "broken"))
+		assert.Equal(t, map[string]string{"20261019_01": StateApplied, "20261019_02": StatePending}, states(t, m))
+	})
+
+	t.Run("migration without down cannot be rolled back", func(t *testing.T) {
+		db := newSQLite(t)
+		m := newMigrator(t, db, fstest.MapFS{
+			"up/20261019_01_create_authors.sql": testMigrations["up/20261019_01_create_authors.sql"],
+		})
+		_, err := m.Up(ctx)
+		require.NoError(t, err)
+
+		_, err = m.Down(ctx, 1)
+		assert.EqualError(t, err, "migration 20261019_01_create_authors.sql has no down migration")
+		assert.True(t, tableExists(t, db, "authors"))
+	})
+
+	t.Run("changed migration stops up", func(t *testing.T) {
+		db := newSQLite(t)
+		_, err := newMigrator(t, db, testMigrations).Up(ctx)
+		require.NoError(t, err)
+
+		changed := fstest.MapFS{}
+		for name, file := range testMigrations {
+			changed[name] = file
+		}
+		changed["up/20261019_01_create_authors.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE authors (id TEXT PRIMARY KEY, name TEXT);")}
+		changed["up/20261019_03_create_loans.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE loans (id TEXT);")}
+		m := newMigrator(t, db, changed)
+
+		_, err = m.Up(ctx)
+		assert.EqualError(t, err, "migration 20261019_01_create_authors.sql was changed after it was applied")
+		assert.False(t, tableExists(t, db, "loans"))
+		assert.Equal(t, StateModified, states(t, m)["20261019_01"])
+	})
+
+	t.Run("unknown applied migration stops up", func(t *testing.T) {
+		db := newSQLite(t)
+		_, err := newMigrator(t, db, testMigrations).Up(ctx)
+		require.NoError(t, err)
+
+		m := newMigrator(t, db, fstest.MapFS{
+			"up/20261019_01_create_authors.sql": testMigrations["up/20261019_01_create_authors.sql"],
+		})
+
+		_, err = m.Up(ctx)
+		assert.EqualError(t, err, "migration 20261019_02_create_shelves is applied but unknown to this build")
+		assert.Equal(t, StateUnknown, states(t, m)["20261019_02"])
+	})
+
+	t.Run("baseline records migrations without running them", func(t *testing.T) {
+		db := newSQLite(t)
+		m := newMigrator(t, db, testMigrations)
+
+		done, err := m.Baseline(ctx, "20261019_01")
+		require.NoError(t, err)
+		assert.Len(t, done, 1)
+		assert.False(t, tableExists(t, db, "authors"))
+
+		done, err = m.Up(ctx)
+		require.NoError(t, err)
+		require.Len(t, done, 1)
+		assert.Equal(t, "20261019_02", done[0].Version)
+	})
+
+	t.Run("embedded sqlite migrations go up and down", func(t *testing.T) {
+		db := newSQLite(t)
+		m, err := New(db, migrations.SQLite)
+		require.NoError(t, err)
+
+		up, err := m.Up(ctx)
+		require.NoError(t, err)
+		assert.True(t, tableExists(t, db, "books"))
+
+		down, err := m.Down(ctx, len(up))
+		require.NoError(t, err)
+		assert.Len(t, down, len(up))
+		assert.False(t, tableExists(t, db, "books"))
+	})
+}
+
+func TestMigrator_PostgresLock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations \(.+ applied_at TIMESTAMPTZ NOT NULL DEFAULT \(NOW\(\)\)\)`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT version, name, checksum, applied_at FROM schema_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "name", "checksum", "applied_at"}))
+	mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	m, err := New(sqlx.NewDb(db, "sqlmock"), fstest.MapFS{})
+	require.NoError(t, err)
+
+	done, err := m.Up(context.Background())
+
+	assert.NoError(t, err)
+	assert.Empty(t, done)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,24 @@
+package infra
+
+import (
+	"fmt"
+
+	"booklib/internal/infra/migration"
+	"booklib/internal/repo/dialect"
+	"booklib/migrations"
+)
+
+// NewMigrator builds a migrator over the embedded migrations of the database
+// dialect. The memory driver has no schema, so it cannot be migrated.
+func NewMigrator(res *Resources) (*migration.Migrator, error) {
+	if res.Database == nil {
+		return nil, fmt.Errorf("the memory database driver has nothing to migrate")
+	}
+
+	files := migrations.Postgres
+	if dialect.Of(res.Database) == dialect.SQLite {
+		files = migrations.SQLite
+	}
+
+	return migration.New(res.Database, files)
+}
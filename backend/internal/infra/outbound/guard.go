@@ -0,0 +1,294 @@
+package outbound
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	DefaultMaxResponseBytes = 10 << 20
+
+	dialTimeout = 10 * time.Second
+)
+
+var (
+	ErrForbidden        = errors.New("outbound request is not allowed")
+	ErrResponseTooLarge = errors.New("response body is too large")
+)
+
+var (
+	// blockedNetworks are never dialed unless allowed in Config: private,
+	// loopback, link-local, cloud metadata and other non-public ranges.
+	blockedNetworks = []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/8"),
+		netip.MustParsePrefix("10.0.0.0/8"),
+		// shared address space, with the Alibaba Cloud metadata service
+		netip.MustParsePrefix("100.64.0.0/10"),
+		netip.MustParsePrefix("127.0.0.0/8"),
+		// link-local, with the AWS, GCP and Azure metadata services
+		netip.MustParsePrefix("169.254.0.0/16"),
+		netip.MustParsePrefix("172.16.0.0/12"),
+		// IETF protocol assignments, with the Oracle Cloud metadata service
+		netip.MustParsePrefix("192.0.0.0/24"),
+		netip.MustParsePrefix("192.0.2.0/24"),
+		netip.MustParsePrefix("192.168.0.0/16"),
+		netip.MustParsePrefix("198.18.0.0/15"),
+		netip.MustParsePrefix("198.51.100.0/24"),
+		netip.MustParsePrefix("203.0.113.0/24"),
+		netip.MustParsePrefix("224.0.0.0/4"),
+		netip.MustParsePrefix("240.0.0.0/4"),
+		netip.MustParsePrefix("::/128"),
+		netip.MustParsePrefix("::1/128"),
+		netip.MustParsePrefix("100::/64"),
+		netip.MustParsePrefix("2001:db8::/32"),
+		// unique local, with the AWS IPv6 metadata service
+		netip.MustParsePrefix("fc00::/7"),
+		netip.MustParsePrefix("fe80::/10"),
+		netip.MustParsePrefix("ff00::/8"),
+	}
+	// nat64 embeds an IPv4 address in its last four bytes
+	nat64 = netip.MustParsePrefix("64:ff9b::/96")
+	// sixToFour embeds an IPv4 address in the four bytes after the prefix
+	sixToFour = netip.MustParsePrefix("2002::/16")
+	// teredo embeds the IPv4 address of its server in the four bytes after
+	// the prefix, and the one of its client, inverted, in the last four
+	teredo = netip.MustParsePrefix("2001::/32")
+)
+
+// Config restricts where outbound requests may go. Blocked networks are
+// checked on the address actually dialed, after DNS resolution, so a name
+// resolving to a private address is refused as well.
+type Config struct {
+	// Schemes are the URL schemes requests may use, http and https when
+	// empty.
+	Schemes []string
+	// AllowedHosts, when set, are the only hosts requests may reach.
+	// "*.example.com" matches the subdomains of example.com.
+	AllowedHosts []string
+	// DeniedHosts are hosts requests may never reach, as in AllowedHosts.
+	DeniedHosts []string
+	// AllowedNetworks are CIDRs dialed although blocked by default, such as
+	// a private network running webhook receivers.
+	AllowedNetworks []string
+	// MaxResponseBytes caps response bodies, DefaultMaxResponseBytes when
+	// unset.
+	MaxResponseBytes int64
+}
+
+// Guard builds HTTP clients enforcing a Config.
+type Guard struct {
+	schemes          []string
+	allowedHosts     []string
+	deniedHosts      []string
+	allowedNetworks  []netip.Prefix
+	maxResponseBytes int64
+}
+
+// New checks conf and fails on the first invalid host or network.
+func New(conf Config) (*Guard, error) {
+	g := &Guard{
+		schemes:          []string{"http", "https"},
+		maxResponseBytes: conf.MaxResponseBytes,
+	}
+	if len(conf.Schemes) > 0 {
+		g.schemes = make([]string, 0, len(conf.Schemes))
+		for _, s := range conf.Schemes {
+			g.schemes = append(g.schemes, strings.ToLower(s))
+		}
+	}
+	if g.maxResponseBytes <= 0 {
+		g.maxResponseBytes = DefaultMaxResponseBytes
+	}
+
+	var err error
+	if g.allowedHosts, err = hostPatterns(conf.AllowedHosts); err != nil {
+		return nil, fmt.Errorf("allowed hosts: %w", err)
+	}
+	if g.deniedHosts, err = hostPatterns(conf.DeniedHosts); err != nil {
+		return nil, fmt.Errorf("denied hosts: %w", err)
+	}
+	for _, cidr := range conf.AllowedNetworks {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("allowed networks: %w", err)
+		}
+		g.allowedNetworks = append(g.allowedNetworks, prefix.Masked())
+	}
+
+	return g, nil
+}
+
+// Client returns a client whose requests, redirects included, are checked
+// by g. Environment proxies are not used, since they would dial in place of
+// the client.
+func (g *Guard) Client(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: 30 * time.Second,
+		Control:   g.control,
+	}
+	transport := &http.Transport{
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &roundTripper{guard: g, next: transport},
+	}
+}
+
+// CheckURL reports whether a request may use scheme and reach host. The
+// address host resolves to is checked when dialing.
+func (g *Guard) CheckURL(scheme, host string) error {
+	if !slices.Contains(g.schemes, strings.ToLower(scheme)) {
+		return fmt.Errorf("%w: scheme %q", ErrForbidden, scheme)
+	}
+
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" {
+		return fmt.Errorf("%w: empty host", ErrForbidden)
+	}
+	if matchHost(g.deniedHosts, host) {
+		return fmt.Errorf("%w: host %s is denied", ErrForbidden, host)
+	}
+	if len(g.allowedHosts) > 0 && !matchHost(g.allowedHosts, host) {
+		return fmt.Errorf("%w: host %s is not allowed", ErrForbidden, host)
+	}
+	return nil
+}
+
+// CheckAddr reports whether addr may be dialed.
+func (g *Guard) CheckAddr(addr netip.Addr) error {
+	addr = addr.Unmap()
+	if slices.ContainsFunc(g.allowedNetworks, func(p netip.Prefix) bool { return p.Contains(addr) }) {
+		return nil
+	}
+
+	for _, checked := range append(embeddedIPv4(addr), addr) {
+		if slices.ContainsFunc(blockedNetworks, func(p netip.Prefix) bool { return p.Contains(checked) }) {
+			return fmt.Errorf("%w: address %s", ErrForbidden, addr)
+		}
+	}
+	return nil
+}
+
+// embeddedIPv4 returns the IPv4 addresses a translation or tunnelling
+// address reaches, which are checked as if dialed themselves.
+func embeddedIPv4(addr netip.Addr) []netip.Addr {
+	b := addr.As16()
+	switch {
+	case nat64.Contains(addr):
+		return []netip.Addr{netip.AddrFrom4([4]byte(b[12:]))}
+	case sixToFour.Contains(addr):
+		return []netip.Addr{netip.AddrFrom4([4]byte(b[2:6]))}
+	case teredo.Contains(addr):
+		client := [4]byte(b[12:])
+		for i := range client {
+			client[i] ^= 0xff
+		}
+		return []netip.Addr{netip.AddrFrom4([4]byte(b[4:8])), netip.AddrFrom4(client)}
+	}
+	return nil
+}
+
+// control runs right before connecting, on the resolved address.
+func (g *Guard) control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: address %s", ErrForbidden, host)
+	}
+	return g.CheckAddr(addr)
+}
+
+type roundTripper struct {
+	guard *Guard
+	next  http.RoundTripper
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := rt.guard.CheckURL(req.URL.Scheme, req.URL.Hostname()); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	resp, err := rt.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// the length answering HEAD is the one of a body that is not sent
+	limit := rt.guard.maxResponseBytes
+	if req.Method != http.MethodHead && resp.ContentLength > limit {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %d bytes, the maximum is %d", ErrResponseTooLarge, resp.ContentLength, limit)
+	}
+	resp.Body = &cappedBody{ReadCloser: resp.Body, remaining: limit}
+	return resp, nil
+}
+
+// cappedBody fails reads going past the maximum response size.
+type cappedBody struct {
+	io.ReadCloser
+	remaining int64
+	exceeded  bool
+}
+
+func (b *cappedBody) Read(p []byte) (int, error) {
+	if b.exceeded {
+		return 0, ErrResponseTooLarge
+	}
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) > b.remaining {
+		n, b.exceeded = int(b.remaining), true
+		b.remaining = 0
+		return n, ErrResponseTooLarge
+	}
+	b.remaining -= int64(n)
+	return n, err
+}
+
+func hostPatterns(hosts []string) ([]string, error) {
+	patterns := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		h := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+		if name := strings.TrimPrefix(h, "*."); name == "" || strings.Contains(name, "*") {
+			return nil, fmt.Errorf("invalid host %q", host)
+		}
+		patterns = append(patterns, h)
+	}
+	return patterns, nil
+}
+
+// matchHost reports whether host is one of patterns, "*.example.com"
+// matching the subdomains of example.com.
+func matchHost(patterns []string, host string) bool {
+	return slices.ContainsFunc(patterns, func(p string) bool {
+		if suffix, ok := strings.CutPrefix(p, "*"); ok {
+			return strings.HasSuffix(host, suffix)
+		}
+		return host == p
+	})
+}
@@ -0,0 +1,222 @@
+package outbound
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name        string
+		conf        Config
+		expectedErr string
+	}{
+		{
+			name: "defaults",
+			conf: Config{},
+		},
+		{
+			name: "hosts and networks",
+			conf: Config{AllowedHosts: []string{"*.Example.com", "example.org."}, AllowedNetworks: []string{"10.1.0.0/16", "fd00::/8"}},
+		},
+		{
+			name:        "empty host",
+			conf:        Config{DeniedHosts: []string{"*."}},
+			expectedErr: `denied hosts: invalid host "*."`,
+		},
+		{
+			name:        "wildcard inside a host",
+			conf:        Config{AllowedHosts: []string{"api.*.example.com"}},
+			expectedErr: `allowed hosts: invalid host "api.*.example.com"`,
+		},
+		{
+			name:        "invalid network",
+			conf:        Config{AllowedNetworks: []string{"10.0.0.1"}},
+			expectedErr: "allowed networks:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := New(tt.conf)
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(DefaultMaxResponseBytes), g.maxResponseBytes)
+		})
+	}
+}
+
+func TestGuardCheckURL(t *testing.T) {
+	g, err := New(Config{
+		AllowedHosts: []string{"example.com", "*.example.org"},
+		DeniedHosts:  []string{"admin.example.org"},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		url         string
+		expectedErr string
+	}{
+		{url: "https://example.com/a"},
+		{url: "http://EXAMPLE.com./a"},
+		{url: "https://api.example.org/a"},
+		{url: "https://www.example.com/a", expectedErr: "host www.example.com is not allowed"},
+		{url: "https://example.org/a", expectedErr: "host example.org is not allowed"},
+		{url: "https://admin.example.org/a", expectedErr: "host admin.example.org is denied"},
+		{url: "ftp://example.com/a", expectedErr: `scheme "ftp"`},
+		{url: "file:///etc/passwd", expectedErr: `scheme "file"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			require.NoError(t, err)
+
+			err = g.CheckURL(u.Scheme, u.Hostname())
+
+			if tt.expectedErr != "" {
+				assert.ErrorIs(t, err, ErrForbidden)
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGuardCheckAddr(t *testing.T) {
+	g, err := New(Config{AllowedNetworks: []string{"10.20.0.0/16"}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		addr    string
+		blocked bool
+	}{
+		{addr: "93.184.216.34"},
+		{addr: "2606:4700:4700::1111"},
+		{addr: "10.20.3.4"},
+		{addr: "127.0.0.1", blocked: true},
+		{addr: "10.1.2.3", blocked: true},
+		{addr: "172.31.255.255", blocked: true},
+		{addr: "192.168.1.1", blocked: true},
+		{addr: "169.254.169.254", blocked: true},
+		{addr: "100.100.100.200", blocked: true},
+		{addr: "0.0.0.0", blocked: true},
+		{addr: "::1", blocked: true},
+		{addr: "::ffff:127.0.0.1", blocked: true},
+		{addr: "fe80::1", blocked: true},
+		{addr: "fd00:ec2::254", blocked: true},
+		{addr: "64:ff9b::a9fe:a9fe", blocked: true},
+		{addr: "64:ff9b::5db8:d822"},
+		{addr: "2002:7f00:1::", blocked: true},
+		{addr: "2002:a9fe:a9fe:1::1", blocked: true},
+		{addr: "2002:5db8:d822::1"},
+		// teredo server, then client inverted in the last four bytes
+		{addr: "2001:0:7f00:1::a247:27dd", blocked: true},
+		{addr: "2001:0:5db8:d822::80ff:fffe", blocked: true},
+		{addr: "2001:0:5db8:d822::5601:5601", blocked: true},
+		{addr: "2001:0:5db8:d822::a247:27dd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			err := g.CheckAddr(netip.MustParseAddr(tt.addr))
+
+			if tt.blocked {
+				assert.ErrorIs(t, err, ErrForbidden)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGuardClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/large":
+			w.Write([]byte(strings.Repeat("a", 100)))
+		case "/streamed":
+			for range 10 {
+				w.Write([]byte(strings.Repeat("a", 10)))
+				w.(http.Flusher).Flush()
+			}
+		case "/redirect":
+			http.Redirect(w, r, "http://localhost.localdomain/", http.StatusFound)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	port := srv.URL[strings.LastIndex(srv.URL, ":"):]
+
+	get := func(t *testing.T, conf Config, rawURL string) (string, error) {
+		g, err := New(conf)
+		require.NoError(t, err)
+
+		resp, err := g.Client(0).Get(rawURL)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	}
+
+	t.Run("blocks loopback", func(t *testing.T) {
+		_, err := get(t, Config{}, srv.URL)
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("blocks names resolving to loopback", func(t *testing.T) {
+		_, err := get(t, Config{}, "http://localhost"+port)
+		assert.ErrorIs(t, err, ErrForbidden)
+		assert.ErrorContains(t, err, "address 127.0.0.1")
+	})
+
+	t.Run("allowed network", func(t *testing.T) {
+		body, err := get(t, Config{AllowedNetworks: []string{"127.0.0.0/8"}}, "http://localhost"+port)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", body)
+	})
+
+	t.Run("denied host", func(t *testing.T) {
+		_, err := get(t, Config{AllowedNetworks: []string{"127.0.0.0/8"}, DeniedHosts: []string{"localhost"}}, "http://localhost"+port)
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("checks redirects", func(t *testing.T) {
+		conf := Config{AllowedNetworks: []string{"127.0.0.0/8"}, AllowedHosts: []string{"127.0.0.1"}}
+		_, err := get(t, conf, srv.URL+"/redirect")
+		assert.ErrorIs(t, err, ErrForbidden)
+		assert.ErrorContains(t, err, "host localhost.localdomain is not allowed")
+	})
+
+	t.Run("response declared too large", func(t *testing.T) {
+		_, err := get(t, Config{AllowedNetworks: []string{"127.0.0.0/8"}, MaxResponseBytes: 50}, srv.URL+"/large")
+		assert.ErrorIs(t, err, ErrResponseTooLarge)
+	})
+
+	t.Run("response growing too large", func(t *testing.T) {
+		_, err := get(t, Config{AllowedNetworks: []string{"127.0.0.0/8"}, MaxResponseBytes: 50}, srv.URL+"/streamed")
+		assert.ErrorIs(t, err, ErrResponseTooLarge)
+	})
+
+	t.Run("response at the limit", func(t *testing.T) {
+		body, err := get(t, Config{AllowedNetworks: []string{"127.0.0.0/8"}, MaxResponseBytes: 100}, srv.URL+"/streamed")
+		require.NoError(t, err)
+		assert.Len(t, body, 100)
+	})
+}
@@ -0,0 +1,35 @@
+// Package publisher holds the outbox.EventPublisher implementations.
+package publisher
+
+import (
+	"booklib/internal/domain/event"
+	"booklib/internal/domain/outbox"
+	"context"
+	"errors"
+)
+
+// Bus publishes events in process by handing them to its handlers.
+type Bus struct {
+	handlers []event.Handler
+}
+
+func NewBus(handlers ...event.Handler) *Bus {
+	return &Bus{
+		handlers: handlers,
+	}
+}
+
+var _ outbox.EventPublisher = (*Bus)(nil)
+
+// Publish passes ev to every handler, even when one of them fails. A failure
+// retries the event for all handlers.
+func (b *Bus) Publish(ctx context.Context, ev *event.Event) error {
+	var errs []error
+	for _, h := range b.handlers {
+		if err := h.HandleEvents(ctx, []*event.Event{ev}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,54 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"booklib/internal/domain/event"
+	"booklib/internal/domain/event/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_Publish(t *testing.T) {
+	ev := &event.Event{ID: 1, Type: event.TypeBookCreated, AggregateType: event.AggregateBook, AggregateID: "id-1"}
+
+	tests := []struct {
+		name        string
+		errs        []error
+		expectedErr string
+	}{
+		{
+			name: "hands the event to every handler",
+			errs: []error{nil, nil},
+		},
+		{
+			name:        "a failing handler does not stop the others",
+			errs:        []error{errors.New("handler error"), nil},
+			expectedErr: "handler error",
+		},
+		{
+			name: "no handlers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := make([]event.Handler, len(tt.errs))
+			for i, err := range tt.errs {
+				h := mocks.NewHandler(t)
+				h.On("HandleEvents", context.Background(), []*event.Event{ev}).Return(err).Once()
+				handlers[i] = h
+			}
+
+			err := NewBus(handlers...).Publish(context.Background(), ev)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package publisher
+
+import (
+	"booklib/internal/domain/event"
+	"booklib/internal/domain/outbox"
+	"context"
+)
+
+// Fanout publishes every event to each of its publishers in turn.
+type Fanout []outbox.EventPublisher
+
+var _ outbox.EventPublisher = Fanout(nil)
+
+// Publish stops at the first failing publisher. The event is retried for all
+// of them, so the publishers before it see it again; they must take an event
+// twice without repeating its effects, the way the webhooks queue one delivery
+// per subscription and event.
+func (f Fanout) Publish(ctx context.Context, ev *event.Event) error {
+	for _, p := range f {
+		if err := p.Publish(ctx, ev); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
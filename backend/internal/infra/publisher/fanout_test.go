@@ -0,0 +1,54 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"booklib/internal/domain/event"
+	"booklib/internal/domain/outbox/mocks"
+	domain "booklib/internal/domain/webhook"
+	"booklib/internal/repo/memory"
+	"booklib/internal/usecase/webhook"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFanout_Publish(t *testing.T) {
+	ev := &event.Event{ID: 1, Type: event.TypeBookCreated}
+
+	t.Run("publishes to every publisher", func(t *testing.T) {
+		first, second := mocks.NewEventPublisher(t), mocks.NewEventPublisher(t)
+		first.On("Publish", context.Background(), ev).Return(nil).Once()
+		second.On("Publish", context.Background(), ev).Return(nil).Once()
+
+		assert.NoError(t, Fanout{first, second}.Publish(context.Background(), ev))
+	})
+
+	t.Run("stops at the first failure", func(t *testing.T) {
+		first, second := mocks.NewEventPublisher(t), mocks.NewEventPublisher(t)
+		first.On("Publish", context.Background(), ev).Return(errors.New("publish error")).Once()
+
+		err := Fanout{first, second}.Publish(context.Background(), ev)
+
+		assert.EqualError(t, err, "publish error")
+		second.AssertNotCalled(t, "Publish")
+	})
+	t.Run("retrying after a later publisher fails queues no second delivery", func(t *testing.T) {
+		ctx := context.Background()
+		repo := memory.NewWebhookRepository(memory.NewStore())
+		_, err := repo.AddSubscription(ctx, &domain.Subscription{ID: "sub-1", URL: "https://example.com/hook", EventTypes: []string{event.TypeBookCreated}, Secret: "secret", Active: true})
+		assert.NoError(t, err)
+
+		broker := mocks.NewEventPublisher(t)
+		broker.On("Publish", ctx, ev).Return(errors.New("broker down")).Twice()
+		fanout := Fanout{NewBus(webhook.New(repo, webhook.Config{})), broker}
+
+		assert.EqualError(t, fanout.Publish(ctx, ev), "broker down")
+		assert.EqualError(t, fanout.Publish(ctx, ev), "broker down")
+
+		deliveries, err := repo.GetDeliveries(ctx, domain.DeliveryFilter{})
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 1)
+	})
+}
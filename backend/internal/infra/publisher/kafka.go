@@ -0,0 +1,68 @@
+package publisher
+
+import (
+	"booklib/internal/domain/event"
+	"booklib/internal/domain/outbox"
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	HeaderEventType = "event-type"
+	HeaderEventID   = "event-id"
+)
+
+// messageWriter is implemented by *kafka.Writer.
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// Kafka publishes events to a topic of a Kafka-compatible broker. Messages are
+// keyed by aggregate, so the events of an aggregate land on one partition and
+// keep their order.
+type Kafka struct {
+	writer messageWriter
+}
+
+func NewKafka(brokers []string, topic string) *Kafka {
+	return &Kafka{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			// the relay publishes one event at a time, waiting for a batch
+			// to fill up would only add latency
+			BatchTimeout: 10 * time.Millisecond,
+		},
+	}
+}
+
+var _ outbox.EventPublisher = (*Kafka)(nil)
+
+func (k *Kafka) Publish(ctx context.Context, ev *event.Event) error {
+	value, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	return k.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(ev.AggregateType + ":" + ev.AggregateID),
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: HeaderEventType, Value: []byte(ev.Type)},
+			{Key: HeaderEventID, Value: []byte(strconv.FormatInt(ev.ID, 10))},
+		},
+		Time: ev.OccurredAt,
+	})
+}
+
+// Close flushes pending messages and closes the broker connections.
+func (k *Kafka) Close() error {
+	return k.writer.Close()
+}
@@ -0,0 +1,78 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"booklib/internal/domain/event"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeWriter records the messages written to it.
+type fakeWriter struct {
+	msgs   []kafka.Message
+	err    error
+	closed bool
+}
+
+func (w *fakeWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.msgs = append(w.msgs, msgs...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestKafka_Publish(t *testing.T) {
+	occurredAt := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	ev := &event.Event{ID: 42, Type: event.TypeBookUpdated, AggregateType: event.AggregateBook, AggregateID: "id-1", OccurredAt: occurredAt}
+
+	t.Run("writes a message keyed by aggregate", func(t *testing.T) {
+		w := &fakeWriter{}
+
+		err := (&Kafka{writer: w}).Publish(context.Background(), ev)
+
+		assert.NoError(t, err)
+		assert.Len(t, w.msgs, 1)
+		assert.Equal(t, "book:id-1", string(w.msgs[0].Key))
+		assert.JSONEq(t, `{"id":42,"type":"book.updated","aggregate_type":"book","aggregate_id":"id-1","occurred_at":"2026-10-19T10:00:00Z"}`, string(w.msgs[0].Value))
+		assert.Equal(t, []kafka.Header{
+			{Key: HeaderEventType, Value: []byte(event.TypeBookUpdated)},
+			{Key: HeaderEventID, Value: []byte("42")},
+		}, w.msgs[0].Headers)
+		assert.Equal(t, occurredAt, w.msgs[0].Time)
+	})
+
+	t.Run("writer error", func(t *testing.T) {
+		w := &fakeWriter{err: errors.New("broker unavailable")}
+
+		err := (&Kafka{writer: w}).Publish(context.Background(), ev)
+
+		assert.EqualError(t, err, "broker unavailable")
+	})
+
+	t.Run("close closes the writer", func(t *testing.T) {
+		w := &fakeWriter{}
+
+		assert.NoError(t, (&Kafka{writer: w}).Close())
+		assert.True(t, w.closed)
+	})
+}
+
+func TestNewKafka(t *testing.T) {
+	k := NewKafka([]string{"localhost:9092"}, "booklib.events")
+
+	w, ok := k.writer.(*kafka.Writer)
+	assert.True(t, ok)
+	assert.Equal(t, "booklib.events", w.Topic)
+	assert.IsType(t, &kafka.Hash{}, w.Balancer)
+}
@@ -0,0 +1,49 @@
+package publisher
+
+import (
+	"booklib/internal/domain/event"
+	"booklib/internal/domain/outbox"
+	"context"
+	"encoding/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// maxNotifyPayload is the largest payload postgres accepts for NOTIFY.
+	maxNotifyPayload = 7999
+)
+
+// Postgres publishes events as JSON notifications on a LISTEN/NOTIFY channel.
+type Postgres struct {
+	db      *sqlx.DB
+	channel string
+}
+
+func NewPostgres(db *sqlx.DB, channel string) *Postgres {
+	return &Postgres{
+		db:      db,
+		channel: channel,
+	}
+}
+
+var _ outbox.EventPublisher = (*Postgres)(nil)
+
+// Publish sends ev on the channel. Snapshots that do not fit in a notification
+// are left out; listeners can read them from the change feed by event ID.
+func (p *Postgres) Publish(ctx context.Context, ev *event.Event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	if len(payload) > maxNotifyPayload {
+		envelope := *ev
+		envelope.Before, envelope.After = nil, nil
+		if payload, err = json.Marshal(&envelope); err != nil {
+			return err
+		}
+	}
+
+	_, err = p.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, p.channel, string(payload))
+	return err
+}
@@ -0,0 +1,81 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"booklib/internal/domain/event"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgres_Publish(t *testing.T) {
+	occurredAt := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	newEvent := func(after string) *event.Event {
+		return &event.Event{
+			ID: 1, Type: event.TypeBookCreated, AggregateType: event.AggregateBook, AggregateID: "id-1",
+			After: json.RawMessage(after), OccurredAt: occurredAt,
+		}
+	}
+	large := `{"title":"` + strings.Repeat("a", maxNotifyPayload) + `"}`
+
+	tests := []struct {
+		name        string
+		event       *event.Event
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedErr string
+	}{
+		{
+			name:  "notifies the channel",
+			event: newEvent(`{"id":"id-1"}`),
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`SELECT pg_notify\(\$1, \$2\)`).
+					WithArgs("book_events", `{"id":1,"type":"book.created","aggregate_type":"book","aggregate_id":"id-1","after":{"id":"id-1"},"occurred_at":"2026-10-19T10:00:00Z"}`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:  "leaves out snapshots that do not fit",
+			event: newEvent(large),
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`SELECT pg_notify`).
+					WithArgs("book_events", `{"id":1,"type":"book.created","aggregate_type":"book","aggregate_id":"id-1","occurred_at":"2026-10-19T10:00:00Z"}`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:  "database error",
+			event: newEvent(`{}`),
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`SELECT pg_notify`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMocks(mock)
+
+			err = NewPostgres(sqlx.NewDb(db, "sqlmock"), "book_events").Publish(context.Background(), tt.event)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -5,13 +5,14 @@ import (
 	"fmt"
 
 	"booklib/internal/infra/config"
+	"booklib/internal/repo/dialect"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"github.com/rizanw/go-log"
 )
 
 type Resources struct {
-	// rdbms
+	// rdbms, postgres or sqlite; nil when the memory driver is used
 	Database *sqlx.DB
 }
 
@@ -28,14 +29,30 @@ func NewResources(ctx context.Context, conf *config.Config) (*Resources, error)
 	}
 
 	// init db
-	psqlsource := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable", conf.Database.Host, conf.Database.Port, conf.Database.User, conf.Database.Password, conf.Database.DBName)
-	psqlx, err := sqlx.Connect("postgres", psqlsource)
-	if err != nil {
-		log.Fatalf(ctx, err, nil, "Failed create database conn, with err:%+v", err)
-		return nil, err
+	switch conf.Database.Driver {
+	case "", config.DriverPostgres:
+		psqlsource := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable", conf.Database.Host, conf.Database.Port, conf.Database.User, conf.Database.Password, conf.Database.DBName)
+		psqlx, err := sqlx.Connect("postgres", psqlsource)
+		if err != nil {
+			log.Fatalf(ctx, err, nil, "Failed create database conn, with err:%+v", err)
+			return nil, err
+		}
+
+		return &Resources{
+			Database: psqlx,
+		}, nil
+	case config.DriverSQLite:
+		sqlitex, err := dialect.OpenSQLite(conf.Database.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Resources{
+			Database: sqlitex,
+		}, nil
+	case config.DriverMemory:
+		return &Resources{}, nil
 	}
 
-	return &Resources{
-		Database: psqlx,
-	}, nil
+	return nil, fmt.Errorf("unknown database driver %q", conf.Database.Driver)
 }
@@ -0,0 +1,126 @@
+package backup
+
+import (
+	domain "booklib/internal/domain/backup"
+	"booklib/internal/repo/dialect"
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (r *repo) AddBooks(ctx context.Context, books []domain.Book) error {
+	rows := make([][]interface{}, len(books))
+	for i, b := range books {
+		rows[i] = bookValues(r.dialect, b)
+	}
+	return r.insert(ctx, "books", bookColumns, rows)
+}
+
+func (r *repo) AddEvents(ctx context.Context, events []domain.Event) error {
+	rows := make([][]interface{}, len(events))
+	ids := make([]int64, len(events))
+	for i, e := range events {
+		rows[i] = eventValues(r.dialect, e)
+		ids[i] = e.ID
+	}
+	if err := r.insert(ctx, "events", eventColumns, rows); err != nil {
+		return err
+	}
+
+	if r.dialect != dialect.SQLite || len(ids) == 0 {
+		return nil
+	}
+	// the sqlite trigger queued the events again; the outbox is restored
+	// from the archive, as it was
+	_, err := r.conn(ctx).ExecContext(ctx, `DELETE FROM outbox WHERE `+r.dialect.AnyOf("event_id", 1, "bigint[]"), r.dialect.Array(ids))
+	return err
+}
+
+func (r *repo) AddOutboxMessages(ctx context.Context, messages []domain.OutboxMessage) error {
+	rows := make([][]interface{}, len(messages))
+	for i, m := range messages {
+		rows[i] = outboxValues(r.dialect, m)
+	}
+	return r.insert(ctx, "outbox", outboxColumns, rows)
+}
+
+func (r *repo) AddSubscriptions(ctx context.Context, subs []domain.Subscription) error {
+	rows := make([][]interface{}, len(subs))
+	for i, s := range subs {
+		rows[i] = subscriptionValues(r.dialect, s)
+	}
+	return r.insert(ctx, "webhook_subscriptions", subscriptionColumns, rows)
+}
+
+func (r *repo) AddDeliveries(ctx context.Context, deliveries []domain.Delivery) error {
+	rows := make([][]interface{}, len(deliveries))
+	for i, d := range deliveries {
+		rows[i] = deliveryValues(r.dialect, d)
+	}
+	// archives taken before 20261019_08_unique_webhook_deliveries can queue
+	// an event twice for a subscription; the first delivery is kept, as the
+	// migration did
+	return r.insertSkipping(ctx, "webhook_deliveries", deliveryColumns, rows, "subscription_id, event_id")
+}
+
+func (r *repo) AddLinks(ctx context.Context, links []domain.Link) error {
+	rows := make([][]interface{}, len(links))
+	for i, l := range links {
+		rows[i] = linkValues(r.dialect, l)
+	}
+	return r.insert(ctx, "links", linkColumns, rows)
+}
+
+func (r *repo) AddLinkClicks(ctx context.Context, clicks []domain.LinkClicks) error {
+	rows := make([][]interface{}, len(clicks))
+	for i, c := range clicks {
+		rows[i] = linkClicksValues(c)
+	}
+	return r.insert(ctx, "link_clicks", linkClicksColumns, rows)
+}
+
+func (r *repo) AddRuleSets(ctx context.Context, ruleSets []domain.RuleSet) error {
+	rows := make([][]interface{}, len(ruleSets))
+	for i, rs := range ruleSets {
+		rows[i] = ruleSetValues(r.dialect, rs)
+	}
+	return r.insert(ctx, "url_rule_sets", ruleSetColumns, rows)
+}
+
+// insert writes rows into table in as few statements as the bind parameter
+// limit allows. Every row holds one value per column.
+func (r *repo) insert(ctx context.Context, table string, columns []string, rows [][]interface{}) error {
+	return r.insertSkipping(ctx, table, columns, rows, "")
+}
+
+// insertSkipping is insert, except that a row whose unique conflict columns
+// match a row already in table is skipped. An empty conflict skips nothing.
+func (r *repo) insertSkipping(ctx context.Context, table string, columns []string, rows [][]interface{}, conflict string) error {
+	perStatement := maxParamsPerStatement / len(columns)
+
+	for start := 0; start < len(rows); start += perStatement {
+		var (
+			chunk  = rows[start:min(start+perStatement, len(rows))]
+			values = make([]string, 0, len(chunk))
+			args   = make([]interface{}, 0, len(chunk)*len(columns))
+		)
+		for _, row := range chunk {
+			placeholders := make([]string, len(row))
+			for i := range row {
+				placeholders[i] = fmt.Sprintf("$%d", len(args)+i+1)
+			}
+			values = append(values, "("+strings.Join(placeholders, ", ")+")")
+			args = append(args, row...)
+		}
+
+		query := `INSERT INTO ` + table + ` (` + strings.Join(columns, ", ") + `) VALUES ` + strings.Join(values, ", ")
+		if conflict != "" {
+			query += ` ON CONFLICT (` + conflict + `) DO NOTHING`
+		}
+		if _, err := r.conn(ctx).ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
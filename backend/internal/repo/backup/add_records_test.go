@@ -0,0 +1,208 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/backup"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddBooks(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		books       []domain.Book
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedErr string
+	}{
+		{
+			name: "inserts books with their ids and timestamps",
+			books: []domain.Book{
+				{ID: "a", Title: "Emma", Author: "Jane Austen", Year: 1815, CreatedAt: now, UpdatedAt: now},
+				{ID: "b", Title: "Dune", Author: "Frank Herbert", Year: 1965, CreatedAt: now, UpdatedAt: now},
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO books \(id, title, author, year, created_at, updated_at\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\), \(\$7, \$8, \$9, \$10, \$11, \$12\)`).
+					WithArgs("a", "Emma", "Jane Austen", 1815, now, now, "b", "Dune", "Frank Herbert", 1965, now, now).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name:       "no books",
+			setupMocks: func(mock sqlmock.Sqlmock) {},
+		},
+		{
+			name:  "database error",
+			books: []domain.Book{{ID: "a"}},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO books`).
+					WillReturnError(errors.New("duplicate key"))
+			},
+			expectedErr: "failed to restore books: duplicate key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			err = repo.AddBooks(context.Background(), tt.books)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAddRecords_Chunks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	var (
+		perStatement = maxParamsPerStatement / len(eventColumns)
+		events       = make([]domain.Event, perStatement+1)
+	)
+	for i := range events {
+		events[i] = domain.Event{ID: int64(i + 1), Type: "book.created", AggregateType: "book", AggregateID: fmt.Sprint(i)}
+	}
+
+	mock.ExpectExec(`INSERT INTO events`).WillReturnResult(sqlmock.NewResult(0, int64(perStatement)))
+	mock.ExpectExec(`INSERT INTO events \(.*\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7\)$`).
+		WithArgs(int64(perStatement+1), "book.created", "book", fmt.Sprint(perStatement), nil, nil, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = New(sqlx.NewDb(db, "sqlmock")).AddEvents(context.Background(), events)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddOutboxMessages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(`INSERT INTO outbox \(id, event_id, aggregate_type, aggregate_id, attempts, next_attempt_at, last_error, created_at, published_at\) VALUES`).
+		WithArgs(int64(1), int64(3), "book", "a", 0, now, "", now, nil, int64(2), int64(4), "book", "b", 1, now, "", now, now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = New(sqlx.NewDb(db, "sqlmock")).AddOutboxMessages(context.Background(), []domain.OutboxMessage{
+		{ID: 1, EventID: 3, AggregateType: "book", AggregateID: "a", NextAttemptAt: now, CreatedAt: now},
+		{ID: 2, EventID: 4, AggregateType: "book", AggregateID: "b", Attempts: 1, NextAttemptAt: now, CreatedAt: now, PublishedAt: &now},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddSubscriptions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(`INSERT INTO webhook_subscriptions \(id, url, event_types, secret, active, created_at, updated_at\) VALUES`).
+		WithArgs("sub-1", "https://example.com", pq.Array([]string{}), "secret", true, now, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = New(sqlx.NewDb(db, "sqlmock")).AddSubscriptions(context.Background(), []domain.Subscription{
+		{ID: "sub-1", URL: "https://example.com", Secret: "secret", Active: true, CreatedAt: now, UpdatedAt: now},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(`INSERT INTO webhook_deliveries \(id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_error, response_status, created_at, updated_at\) VALUES \(.*\) ON CONFLICT \(subscription_id, event_id\) DO NOTHING`).
+		WithArgs(int64(1), "sub-1", int64(5), "book.created", []byte(`{}`), "dead", 8, now, "timeout", 502, now, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = New(sqlx.NewDb(db, "sqlmock")).AddDeliveries(context.Background(), []domain.Delivery{
+		{ID: 1, SubscriptionID: "sub-1", EventID: 5, EventType: "book.created", Payload: json.RawMessage(`{}`), Status: "dead",
+			Attempts: 8, NextAttemptAt: now, LastError: "timeout", ResponseStatus: 502, CreatedAt: now, UpdatedAt: now},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddLinks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(`INSERT INTO links \(code, target_url, custom, expires_at, created_at\) VALUES \(\$1, \$2, \$3, \$4, \$5\), \(\$6, \$7, \$8, \$9, \$10\)`).
+		WithArgs("abc", "https://example.com/a", false, nil, now, "sale", "https://example.com/b", true, now, now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = New(sqlx.NewDb(db, "sqlmock")).AddLinks(context.Background(), []domain.Link{
+		{Code: "abc", TargetURL: "https://example.com/a", CreatedAt: now},
+		{Code: "sale", TargetURL: "https://example.com/b", Custom: true, ExpiresAt: &now, CreatedAt: now},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddLinkClicks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(`INSERT INTO link_clicks \(code, day, clicks\) VALUES \(\$1, \$2, \$3\)`).
+		WithArgs("abc", "2026-10-19", int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = New(sqlx.NewDb(db, "sqlmock")).AddLinkClicks(context.Background(), []domain.LinkClicks{
+		{Code: "abc", Day: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Clicks: 3},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddRuleSets(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(`INSERT INTO url_rule_sets \(id, name, hosts, priority, operations, active, created_at, updated_at\) VALUES`).
+		WithArgs("rs-1", "shop", pq.Array([]string{"shop.example.com"}), 10, `{"canonical":[]}`, true, now, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = New(sqlx.NewDb(db, "sqlmock")).AddRuleSets(context.Background(), []domain.RuleSet{
+		{ID: "rs-1", Name: "shop", Hosts: []string{"shop.example.com"}, Priority: 10, Operations: json.RawMessage(`{"canonical":[]}`), Active: true, CreatedAt: now, UpdatedAt: now},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
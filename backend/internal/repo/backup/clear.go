@@ -0,0 +1,17 @@
+package backup
+
+import (
+	"context"
+)
+
+// clearOrder deletes the records referencing others first.
+var clearOrder = []string{"webhook_deliveries", "webhook_subscriptions", "outbox", "events", "books", "link_clicks", "links", "url_rule_sets"}
+
+func (r *repo) Clear(ctx context.Context) error {
+	for _, table := range clearOrder {
+		if _, err := r.conn(ctx).ExecContext(ctx, `DELETE FROM `+table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
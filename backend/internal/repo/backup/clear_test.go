@@ -0,0 +1,60 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClear(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedErr string
+	}{
+		{
+			name: "deletes children before parents",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM webhook_deliveries`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM webhook_subscriptions`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM outbox`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM events`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM books`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM link_clicks`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM links`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM url_rule_sets`).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM webhook_deliveries`).WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			err = repo.Clear(context.Background())
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
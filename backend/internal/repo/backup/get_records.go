@@ -0,0 +1,149 @@
+package backup
+
+import (
+	domain "booklib/internal/domain/backup"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// pageQuery selects up to $2 rows of table with a key greater than $1. Text
+// keys have no smallest value every database accepts, so the first page of a
+// table keyed by text leaves the condition out and binds only the limit.
+func pageQuery(table, key string, columns []string, first bool) string {
+	query := `SELECT ` + strings.Join(columns, ", ") + ` FROM ` + table
+	if first {
+		return query + ` ORDER BY ` + key + ` LIMIT $1`
+	}
+	return query + ` WHERE ` + key + ` > $1 ORDER BY ` + key + ` LIMIT $2`
+}
+
+func (r *repo) GetBooks(ctx context.Context, after string, limit int) ([]domain.Book, error) {
+	var rows []Book
+	if err := r.selectPage(ctx, &rows, "books", "id", bookColumns, after, limit); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Book, len(rows))
+	for i := range rows {
+		result[i] = rows[i].ToDomain()
+	}
+	return result, nil
+}
+
+func (r *repo) GetEvents(ctx context.Context, after int64, limit int) ([]domain.Event, error) {
+	var rows []Event
+	if err := r.selectPage(ctx, &rows, "events", "id", eventColumns, after, limit); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Event, len(rows))
+	for i := range rows {
+		result[i] = rows[i].ToDomain()
+	}
+	return result, nil
+}
+
+func (r *repo) GetOutboxMessages(ctx context.Context, after int64, limit int) ([]domain.OutboxMessage, error) {
+	var rows []OutboxMessage
+	if err := r.selectPage(ctx, &rows, "outbox", "id", outboxColumns, after, limit); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.OutboxMessage, len(rows))
+	for i := range rows {
+		result[i] = rows[i].ToDomain()
+	}
+	return result, nil
+}
+
+func (r *repo) GetSubscriptions(ctx context.Context, after string, limit int) ([]domain.Subscription, error) {
+	var rows []Subscription
+	if err := r.selectPage(ctx, &rows, "webhook_subscriptions", "id", subscriptionColumns, after, limit); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Subscription, len(rows))
+	for i := range rows {
+		result[i] = rows[i].ToDomain()
+	}
+	return result, nil
+}
+
+func (r *repo) GetDeliveries(ctx context.Context, after int64, limit int) ([]domain.Delivery, error) {
+	var rows []Delivery
+	if err := r.selectPage(ctx, &rows, "webhook_deliveries", "id", deliveryColumns, after, limit); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Delivery, len(rows))
+	for i := range rows {
+		result[i] = rows[i].ToDomain()
+	}
+	return result, nil
+}
+
+func (r *repo) GetLinks(ctx context.Context, after string, limit int) ([]domain.Link, error) {
+	var rows []Link
+	if err := r.selectPage(ctx, &rows, "links", "code", linkColumns, after, limit); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Link, len(rows))
+	for i := range rows {
+		result[i] = rows[i].ToDomain()
+	}
+	return result, nil
+}
+
+func (r *repo) GetLinkClicks(ctx context.Context, after domain.LinkClicksKey, limit int) ([]domain.LinkClicks, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive, got %d", limit)
+	}
+
+	var (
+		query = `SELECT ` + strings.Join(linkClicksColumns, ", ") + ` FROM link_clicks`
+		order = ` ORDER BY code, day`
+		rows  []LinkClicks
+		err   error
+	)
+	if after == (domain.LinkClicksKey{}) {
+		err = r.conn(ctx).SelectContext(ctx, &rows, query+order+` LIMIT $1`, limit)
+	} else {
+		query += ` WHERE code > $1 OR (code = $1 AND day > $2)` + order + ` LIMIT $3`
+		err = r.conn(ctx).SelectContext(ctx, &rows, query, after.Code, after.Day.UTC().Format(dayLayout), limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.LinkClicks, len(rows))
+	for i := range rows {
+		result[i] = rows[i].ToDomain()
+	}
+	return result, nil
+}
+
+func (r *repo) GetRuleSets(ctx context.Context, after string, limit int) ([]domain.RuleSet, error) {
+	var rows []RuleSet
+	if err := r.selectPage(ctx, &rows, "url_rule_sets", "id", ruleSetColumns, after, limit); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.RuleSet, len(rows))
+	for i := range rows {
+		result[i] = rows[i].ToDomain()
+	}
+	return result, nil
+}
+
+func (r *repo) selectPage(ctx context.Context, dest interface{}, table, key string, columns []string, after interface{}, limit int) error {
+	if limit <= 0 {
+		return fmt.Errorf("limit must be positive, got %d", limit)
+	}
+
+	if after == "" {
+		return r.conn(ctx).SelectContext(ctx, dest, pageQuery(table, key, columns, true), limit)
+	}
+	return r.conn(ctx).SelectContext(ctx, dest, pageQuery(table, key, columns, false), after, limit)
+}
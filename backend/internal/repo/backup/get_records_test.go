@@ -0,0 +1,258 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/backup"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBooks(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		after       string
+		limit       int
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedIDs []string
+		expectedErr string
+	}{
+		{
+			name:  "first page",
+			limit: 2,
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, title, author, year, created_at, updated_at FROM books ORDER BY id LIMIT \$1`).
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows(bookColumns).
+						AddRow("a", "Emma", "Jane Austen", 1815, now, now).
+						AddRow("b", "Dune", "Frank Herbert", 1965, now, now))
+			},
+			expectedIDs: []string{"a", "b"},
+		},
+		{
+			name:  "next page",
+			after: "b",
+			limit: 2,
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .* FROM books WHERE id > \$1 ORDER BY id LIMIT \$2`).
+					WithArgs("b", 2).
+					WillReturnRows(sqlmock.NewRows(bookColumns))
+			},
+			expectedIDs: []string{},
+		},
+		{
+			name:        "invalid limit",
+			setupMocks:  func(mock sqlmock.Sqlmock) {},
+			expectedErr: "limit must be positive, got 0",
+		},
+		{
+			name:  "database error",
+			limit: 2,
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .* FROM books`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			books, err := repo.GetBooks(context.Background(), tt.after, tt.limit)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				ids := []string{}
+				for _, bk := range books {
+					ids = append(ids, bk.ID)
+				}
+				assert.Equal(t, tt.expectedIDs, ids)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// integer keys always bind the key, zero for the first page
+	mock.ExpectQuery(`SELECT id, type, aggregate_type, aggregate_id, before, after, occurred_at FROM events WHERE id > \$1 ORDER BY id LIMIT \$2`).
+		WithArgs(int64(0), 100).
+		WillReturnRows(sqlmock.NewRows(eventColumns).
+			AddRow(1, "book.created", "book", "a", nil, []byte(`{"id":"a"}`), time.Now()))
+
+	events, err := New(sqlx.NewDb(db, "sqlmock")).GetEvents(context.Background(), 0, 100)
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Empty(t, events[0].Before)
+	assert.JSONEq(t, `{"id":"a"}`, string(events[0].After))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetOutboxMessages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT .* FROM outbox WHERE id > \$1 ORDER BY id LIMIT \$2`).
+		WithArgs(int64(4), 100).
+		WillReturnRows(sqlmock.NewRows(outboxColumns).
+			AddRow(5, 1, "book", "a", 0, now, "", now, nil).
+			AddRow(6, 2, "book", "b", 1, now, "", now, now))
+
+	messages, err := New(sqlx.NewDb(db, "sqlmock")).GetOutboxMessages(context.Background(), 4, 100)
+
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	assert.Nil(t, messages[0].PublishedAt)
+	assert.NotNil(t, messages[1].PublishedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSubscriptions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT id, url, event_types, secret, active, created_at, updated_at FROM webhook_subscriptions ORDER BY id LIMIT \$1`).
+		WithArgs(100).
+		WillReturnRows(sqlmock.NewRows(subscriptionColumns).
+			AddRow("sub-1", "https://example.com", "{book.created}", "secret", true, now, now).
+			AddRow("sub-2", "https://example.org", "{}", "secret", true, now, now))
+
+	subs, err := New(sqlx.NewDb(db, "sqlmock")).GetSubscriptions(context.Background(), "", 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"book.created"}, subs[0].EventTypes)
+	assert.Equal(t, []string{}, subs[1].EventTypes)
+	assert.Equal(t, "secret", subs[0].Secret)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT .* FROM webhook_deliveries WHERE id > \$1 ORDER BY id LIMIT \$2`).
+		WithArgs(int64(0), 100).
+		WillReturnRows(sqlmock.NewRows(deliveryColumns).
+			AddRow(1, "sub-1", 5, "book.created", []byte(`{}`), "dead", 8, now, "timeout", 502, now, now))
+
+	deliveries, err := New(sqlx.NewDb(db, "sqlmock")).GetDeliveries(context.Background(), 0, 100)
+
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, 502, deliveries[0].ResponseStatus)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLinks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT code, target_url, custom, expires_at, created_at FROM links WHERE code > \$1 ORDER BY code LIMIT \$2`).
+		WithArgs("abc", 100).
+		WillReturnRows(sqlmock.NewRows(linkColumns).
+			AddRow("def", "https://example.com/a", false, nil, now).
+			AddRow("sale", "https://example.com/b", true, now, now))
+
+	links, err := New(sqlx.NewDb(db, "sqlmock")).GetLinks(context.Background(), "abc", 100)
+
+	assert.NoError(t, err)
+	assert.Len(t, links, 2)
+	assert.Nil(t, links[0].ExpiresAt)
+	assert.NotNil(t, links[1].ExpiresAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLinkClicks(t *testing.T) {
+	day := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		after      domain.LinkClicksKey
+		setupMocks func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "first page",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT code, day, clicks FROM link_clicks ORDER BY code, day LIMIT \$1`).
+					WithArgs(100).
+					WillReturnRows(sqlmock.NewRows(linkClicksColumns).AddRow("abc", day, 3))
+			},
+		},
+		{
+			name:  "next page",
+			after: domain.LinkClicksKey{Code: "abc", Day: day.AddDate(0, 0, -1)},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .* FROM link_clicks WHERE code > \$1 OR \(code = \$1 AND day > \$2\) ORDER BY code, day LIMIT \$3`).
+					WithArgs("abc", "2026-10-18", 100).
+					WillReturnRows(sqlmock.NewRows(linkClicksColumns).AddRow("abc", day, 3))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMocks(mock)
+
+			clicks, err := New(sqlx.NewDb(db, "sqlmock")).GetLinkClicks(context.Background(), tt.after, 100)
+
+			assert.NoError(t, err)
+			assert.Equal(t, []domain.LinkClicks{{Code: "abc", Day: day, Clicks: 3}}, clicks)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetRuleSets(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT id, name, hosts, priority, operations, active, created_at, updated_at FROM url_rule_sets ORDER BY id LIMIT \$1`).
+		WithArgs(100).
+		WillReturnRows(sqlmock.NewRows(ruleSetColumns).
+			AddRow("rs-1", "shop", "{shop.example.com,*.shop.example.com}", 10, []byte(`{"canonical":[]}`), true, now, now).
+			AddRow("rs-2", "empty", "{}", 0, []byte(`{}`), false, now, now))
+
+	ruleSets, err := New(sqlx.NewDb(db, "sqlmock")).GetRuleSets(context.Background(), "", 100)
+
+	assert.NoError(t, err)
+	assert.Len(t, ruleSets, 2)
+	assert.Equal(t, []string{"shop.example.com", "*.shop.example.com"}, ruleSets[0].Hosts)
+	assert.JSONEq(t, `{"canonical":[]}`, string(ruleSets[0].Operations))
+	assert.Equal(t, []string{}, ruleSets[1].Hosts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,281 @@
+package backup
+
+import (
+	domain "booklib/internal/domain/backup"
+	"booklib/internal/repo/dialect"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+var (
+	bookColumns         = []string{"id", "title", "author", "year", "created_at", "updated_at"}
+	eventColumns        = []string{"id", "type", "aggregate_type", "aggregate_id", "before", "after", "occurred_at"}
+	outboxColumns       = []string{"id", "event_id", "aggregate_type", "aggregate_id", "attempts", "next_attempt_at", "last_error", "created_at", "published_at"}
+	subscriptionColumns = []string{"id", "url", "event_types", "secret", "active", "created_at", "updated_at"}
+	deliveryColumns     = []string{"id", "subscription_id", "event_id", "event_type", "payload", "status", "attempts", "next_attempt_at", "last_error", "response_status", "created_at", "updated_at"}
+	linkColumns         = []string{"code", "target_url", "custom", "expires_at", "created_at"}
+	linkClicksColumns   = []string{"code", "day", "clicks"}
+	ruleSetColumns      = []string{"id", "name", "hosts", "priority", "operations", "active", "created_at", "updated_at"}
+)
+
+// dayLayout is how link_clicks.day is written, a DATE in both databases.
+const dayLayout = "2006-01-02"
+
+type Book struct {
+	ID        string    `db:"id"`
+	Title     string    `db:"title"`
+	Author    string    `db:"author"`
+	Year      int       `db:"year"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (b *Book) ToDomain() domain.Book {
+	return domain.Book{
+		ID:        b.ID,
+		Title:     b.Title,
+		Author:    b.Author,
+		Year:      b.Year,
+		CreatedAt: b.CreatedAt,
+		UpdatedAt: b.UpdatedAt,
+	}
+}
+
+func bookValues(d dialect.Dialect, b domain.Book) []interface{} {
+	return []interface{}{b.ID, b.Title, b.Author, b.Year, d.Time(b.CreatedAt), d.Time(b.UpdatedAt)}
+}
+
+type Event struct {
+	ID            int64     `db:"id"`
+	Type          string    `db:"type"`
+	AggregateType string    `db:"aggregate_type"`
+	AggregateID   string    `db:"aggregate_id"`
+	Before        []byte    `db:"before"`
+	After         []byte    `db:"after"`
+	OccurredAt    time.Time `db:"occurred_at"`
+}
+
+func (e *Event) ToDomain() domain.Event {
+	return domain.Event{
+		ID:            e.ID,
+		Type:          e.Type,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		Before:        json.RawMessage(e.Before),
+		After:         json.RawMessage(e.After),
+		OccurredAt:    e.OccurredAt,
+	}
+}
+
+func eventValues(d dialect.Dialect, e domain.Event) []interface{} {
+	return []interface{}{e.ID, e.Type, e.AggregateType, e.AggregateID, nullJSON(e.Before), nullJSON(e.After), d.Time(e.OccurredAt)}
+}
+
+type OutboxMessage struct {
+	ID            int64        `db:"id"`
+	EventID       int64        `db:"event_id"`
+	AggregateType string       `db:"aggregate_type"`
+	AggregateID   string       `db:"aggregate_id"`
+	Attempts      int          `db:"attempts"`
+	NextAttemptAt time.Time    `db:"next_attempt_at"`
+	LastError     string       `db:"last_error"`
+	CreatedAt     time.Time    `db:"created_at"`
+	PublishedAt   sql.NullTime `db:"published_at"`
+}
+
+func (m *OutboxMessage) ToDomain() domain.OutboxMessage {
+	msg := domain.OutboxMessage{
+		ID:            m.ID,
+		EventID:       m.EventID,
+		AggregateType: m.AggregateType,
+		AggregateID:   m.AggregateID,
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+		CreatedAt:     m.CreatedAt,
+	}
+	if m.PublishedAt.Valid {
+		msg.PublishedAt = &m.PublishedAt.Time
+	}
+	return msg
+}
+
+func outboxValues(d dialect.Dialect, m domain.OutboxMessage) []interface{} {
+	var publishedAt interface{}
+	if m.PublishedAt != nil {
+		publishedAt = d.Time(*m.PublishedAt)
+	}
+	return []interface{}{
+		m.ID, m.EventID, m.AggregateType, m.AggregateID, m.Attempts,
+		d.Time(m.NextAttemptAt), m.LastError, d.Time(m.CreatedAt), publishedAt,
+	}
+}
+
+type Subscription struct {
+	ID         string              `db:"id"`
+	URL        string              `db:"url"`
+	EventTypes dialect.StringArray `db:"event_types"`
+	Secret     string              `db:"secret"`
+	Active     bool                `db:"active"`
+	CreatedAt  time.Time           `db:"created_at"`
+	UpdatedAt  time.Time           `db:"updated_at"`
+}
+
+func (s *Subscription) ToDomain() domain.Subscription {
+	eventTypes := []string(s.EventTypes)
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	return domain.Subscription{
+		ID:         s.ID,
+		URL:        s.URL,
+		EventTypes: eventTypes,
+		Secret:     s.Secret,
+		Active:     s.Active,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+}
+
+func subscriptionValues(d dialect.Dialect, s domain.Subscription) []interface{} {
+	eventTypes := s.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	return []interface{}{s.ID, s.URL, d.Array(eventTypes), s.Secret, s.Active, d.Time(s.CreatedAt), d.Time(s.UpdatedAt)}
+}
+
+type Delivery struct {
+	ID             int64     `db:"id"`
+	SubscriptionID string    `db:"subscription_id"`
+	EventID        int64     `db:"event_id"`
+	EventType      string    `db:"event_type"`
+	Payload        []byte    `db:"payload"`
+	Status         string    `db:"status"`
+	Attempts       int       `db:"attempts"`
+	NextAttemptAt  time.Time `db:"next_attempt_at"`
+	LastError      string    `db:"last_error"`
+	ResponseStatus int       `db:"response_status"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+func (d *Delivery) ToDomain() domain.Delivery {
+	return domain.Delivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        json.RawMessage(d.Payload),
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastError:      d.LastError,
+		ResponseStatus: d.ResponseStatus,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
+
+func deliveryValues(d dialect.Dialect, dl domain.Delivery) []interface{} {
+	return []interface{}{
+		dl.ID, dl.SubscriptionID, dl.EventID, dl.EventType, []byte(dl.Payload), dl.Status, dl.Attempts,
+		d.Time(dl.NextAttemptAt), dl.LastError, dl.ResponseStatus, d.Time(dl.CreatedAt), d.Time(dl.UpdatedAt),
+	}
+}
+
+type Link struct {
+	Code      string       `db:"code"`
+	TargetURL string       `db:"target_url"`
+	Custom    bool         `db:"custom"`
+	ExpiresAt sql.NullTime `db:"expires_at"`
+	CreatedAt time.Time    `db:"created_at"`
+}
+
+func (l *Link) ToDomain() domain.Link {
+	link := domain.Link{
+		Code:      l.Code,
+		TargetURL: l.TargetURL,
+		Custom:    l.Custom,
+		CreatedAt: l.CreatedAt,
+	}
+	if l.ExpiresAt.Valid {
+		link.ExpiresAt = &l.ExpiresAt.Time
+	}
+	return link
+}
+
+func linkValues(d dialect.Dialect, l domain.Link) []interface{} {
+	var expiresAt interface{}
+	if l.ExpiresAt != nil {
+		expiresAt = d.Time(*l.ExpiresAt)
+	}
+	return []interface{}{l.Code, l.TargetURL, l.Custom, expiresAt, d.Time(l.CreatedAt)}
+}
+
+type LinkClicks struct {
+	Code   string    `db:"code"`
+	Day    time.Time `db:"day"`
+	Clicks int64     `db:"clicks"`
+}
+
+func (c *LinkClicks) ToDomain() domain.LinkClicks {
+	return domain.LinkClicks{
+		Code:   c.Code,
+		Day:    c.Day.UTC(),
+		Clicks: c.Clicks,
+	}
+}
+
+func linkClicksValues(c domain.LinkClicks) []interface{} {
+	return []interface{}{c.Code, c.Day.UTC().Format(dayLayout), c.Clicks}
+}
+
+type RuleSet struct {
+	ID         string              `db:"id"`
+	Name       string              `db:"name"`
+	Hosts      dialect.StringArray `db:"hosts"`
+	Priority   int                 `db:"priority"`
+	Operations []byte              `db:"operations"`
+	Active     bool                `db:"active"`
+	CreatedAt  time.Time           `db:"created_at"`
+	UpdatedAt  time.Time           `db:"updated_at"`
+}
+
+func (rs *RuleSet) ToDomain() domain.RuleSet {
+	hosts := []string(rs.Hosts)
+	if hosts == nil {
+		hosts = []string{}
+	}
+
+	return domain.RuleSet{
+		ID:         rs.ID,
+		Name:       rs.Name,
+		Hosts:      hosts,
+		Priority:   rs.Priority,
+		Operations: json.RawMessage(rs.Operations),
+		Active:     rs.Active,
+		CreatedAt:  rs.CreatedAt,
+		UpdatedAt:  rs.UpdatedAt,
+	}
+}
+
+func ruleSetValues(d dialect.Dialect, rs domain.RuleSet) []interface{} {
+	hosts := rs.Hosts
+	if hosts == nil {
+		hosts = []string{}
+	}
+	return []interface{}{
+		rs.ID, rs.Name, d.Array(hosts), rs.Priority, string(rs.Operations), rs.Active, d.Time(rs.CreatedAt), d.Time(rs.UpdatedAt),
+	}
+}
+
+// nullJSON maps an empty snapshot to NULL instead of an invalid empty document.
+func nullJSON(raw json.RawMessage) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	return []byte(raw)
+}
@@ -0,0 +1,27 @@
+package backup
+
+import (
+	"booklib/internal/repo/dialect"
+	"context"
+)
+
+// sequenceTables have generated ids. Links are keyed by their code and url
+// rule sets by a uuid.
+var sequenceTables = []string{"events", "outbox", "webhook_deliveries"}
+
+func (r *repo) ResetSequences(ctx context.Context) error {
+	// sqlite moves AUTOINCREMENT past every id inserted explicitly
+	if r.dialect == dialect.SQLite {
+		return nil
+	}
+
+	for _, table := range sequenceTables {
+		// is_called = false makes the next id exactly MAX(id) + 1, which also
+		// works for an empty table
+		query := `SELECT setval(pg_get_serial_sequence('` + table + `', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM ` + table
+		if _, err := r.conn(ctx).ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResetSequences(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedErr string
+	}{
+		{
+			name: "moves every sequence past the largest id",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				for _, table := range []string{"events", "outbox", "webhook_deliveries"} {
+					mock.ExpectExec(`SELECT setval\(pg_get_serial_sequence\('` + table + `', 'id'\), COALESCE\(MAX\(id\), 0\) \+ 1, false\) FROM ` + table).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+			},
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`SELECT setval`).WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			err = repo.ResetSequences(context.Background())
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
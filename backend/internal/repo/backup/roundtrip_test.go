@@ -0,0 +1,197 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/backup"
+	"booklib/internal/repo/pgtest"
+	"booklib/internal/repo/sqlitetest"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepo_RoundTrip(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) {
+		testRoundTrip(t, sqlitetest.New(t))
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		testRoundTrip(t, pgtest.New(t))
+	})
+}
+
+func testRoundTrip(t *testing.T, db *sqlx.DB) {
+	var (
+		ctx       = context.Background()
+		at        = time.Date(2026, 10, 19, 10, 0, 0, 123000000, time.UTC)
+		published = at.Add(time.Second)
+		repo      = New(db)
+
+		books = []domain.Book{
+			{ID: "0a5f1b8e-58a4-4b51-9b0e-3f0f7c1d2a01", Title: "Emma", Author: "Jane Austen", Year: 1815, CreatedAt: at, UpdatedAt: at},
+			{ID: "1b6e2c9f-69b5-4c62-8c1f-4e1f8d2e3b02", Title: "Dune", Author: "Frank Herbert", Year: 1965, CreatedAt: at, UpdatedAt: at.Add(time.Minute)},
+			{ID: "2c7f3d0a-7ac6-4d73-9d2a-5f2a9e3f4c03", Title: "Beloved", Author: "Toni Morrison", Year: 1987, CreatedAt: at, UpdatedAt: at},
+		}
+		events = []domain.Event{
+			{ID: 3, Type: "book.created", AggregateType: "book", AggregateID: books[0].ID, After: json.RawMessage(`{"id": "a"}`), OccurredAt: at},
+			{ID: 7, Type: "book.deleted", AggregateType: "book", AggregateID: books[1].ID, Before: json.RawMessage(`{"id": "b"}`), OccurredAt: at},
+		}
+		messages = []domain.OutboxMessage{
+			{ID: 4, EventID: 3, AggregateType: "book", AggregateID: books[0].ID, Attempts: 1, NextAttemptAt: at, CreatedAt: at, PublishedAt: &published},
+			{ID: 9, EventID: 7, AggregateType: "book", AggregateID: books[1].ID, Attempts: 2, NextAttemptAt: at, LastError: "broker down", CreatedAt: at},
+		}
+		subs = []domain.Subscription{
+			{ID: "3d8a4e1b-8bd7-4e84-8e3b-6a3b0f4a5d04", URL: "https://example.com/hook", EventTypes: []string{"book.created"}, Secret: "s3cret", Active: true, CreatedAt: at, UpdatedAt: at},
+			{ID: "4e9b5f2c-9ce8-4f95-9f4c-7b4c1a5b6e05", URL: "https://example.org/hook", EventTypes: []string{}, Secret: "other", CreatedAt: at, UpdatedAt: at},
+		}
+		deliveries = []domain.Delivery{
+			{ID: 5, SubscriptionID: subs[0].ID, EventID: 3, EventType: "book.created", Payload: json.RawMessage(`{"id": 3}`), Status: "dead", Attempts: 8, NextAttemptAt: at, LastError: "timeout", ResponseStatus: 502, CreatedAt: at, UpdatedAt: at},
+			// archives older than the unique index can queue an event twice
+			{ID: 6, SubscriptionID: subs[0].ID, EventID: 3, EventType: "book.created", Payload: json.RawMessage(`{"id": 3}`), Status: "pending", NextAttemptAt: at, CreatedAt: at, UpdatedAt: at},
+		}
+		expires = at.Add(24 * time.Hour)
+		links   = []domain.Link{
+			{Code: "abc123", TargetURL: "https://example.com/books/emma", CreatedAt: at},
+			{Code: "sale", TargetURL: "https://example.com/sale", Custom: true, ExpiresAt: &expires, CreatedAt: at},
+		}
+		day    = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+		clicks = []domain.LinkClicks{
+			{Code: "abc123", Day: day.AddDate(0, 0, -1), Clicks: 4},
+			{Code: "abc123", Day: day, Clicks: 2},
+			{Code: "sale", Day: day, Clicks: 9},
+		}
+		ruleSets = []domain.RuleSet{
+			{ID: "5fac6a3d-adf9-4aa6-a05d-8c5d2b6c7f06", Name: "shop", Hosts: []string{"shop.example.com", "*.shop.example.com"}, Priority: 10,
+				Operations: json.RawMessage(`{"canonical": [{"op": "remove_query_params"}]}`), Active: true, CreatedAt: at, UpdatedAt: at.Add(time.Minute)},
+			{ID: "6abd7b4e-bea0-4bb7-b16e-9d6e3c7d8a07", Name: "draft", Hosts: []string{}, Operations: json.RawMessage(`{}`), CreatedAt: at, UpdatedAt: at},
+		}
+	)
+
+	require.NoError(t, repo.Clear(ctx))
+	require.NoError(t, repo.AddBooks(ctx, books))
+	require.NoError(t, repo.AddEvents(ctx, events))
+	require.NoError(t, repo.AddOutboxMessages(ctx, messages))
+	require.NoError(t, repo.AddSubscriptions(ctx, subs))
+	require.NoError(t, repo.AddDeliveries(ctx, deliveries))
+	require.NoError(t, repo.AddLinks(ctx, links))
+	require.NoError(t, repo.AddLinkClicks(ctx, clicks))
+	require.NoError(t, repo.AddRuleSets(ctx, ruleSets))
+	require.NoError(t, repo.ResetSequences(ctx))
+
+	t.Run("records come back as they were added", func(t *testing.T) {
+		gotBooks, err := repo.GetBooks(ctx, "", 10)
+		require.NoError(t, err)
+		for i := range gotBooks {
+			gotBooks[i].CreatedAt, gotBooks[i].UpdatedAt = gotBooks[i].CreatedAt.UTC(), gotBooks[i].UpdatedAt.UTC()
+		}
+		assert.Equal(t, books, gotBooks)
+
+		gotEvents, err := repo.GetEvents(ctx, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, gotEvents, 2)
+		assert.Equal(t, []int64{3, 7}, []int64{gotEvents[0].ID, gotEvents[1].ID})
+		assert.JSONEq(t, `{"id": "a"}`, string(gotEvents[0].After))
+		assert.Empty(t, gotEvents[0].Before)
+		assert.True(t, at.Equal(gotEvents[1].OccurredAt))
+
+		gotMessages, err := repo.GetOutboxMessages(ctx, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, gotMessages, 2)
+		assert.Equal(t, int64(4), gotMessages[0].ID)
+		require.NotNil(t, gotMessages[0].PublishedAt)
+		assert.True(t, published.Equal(*gotMessages[0].PublishedAt))
+		assert.Nil(t, gotMessages[1].PublishedAt)
+		assert.Equal(t, "broker down", gotMessages[1].LastError)
+
+		gotSubs, err := repo.GetSubscriptions(ctx, "", 10)
+		require.NoError(t, err)
+		require.Len(t, gotSubs, 2)
+		assert.Equal(t, []string{"book.created"}, gotSubs[0].EventTypes)
+		assert.Equal(t, []string{}, gotSubs[1].EventTypes)
+		assert.Equal(t, "s3cret", gotSubs[0].Secret)
+		assert.False(t, gotSubs[1].Active)
+
+		gotDeliveries, err := repo.GetDeliveries(ctx, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, gotDeliveries, 1)
+		assert.Equal(t, int64(5), gotDeliveries[0].ID)
+		assert.Equal(t, 502, gotDeliveries[0].ResponseStatus)
+		assert.JSONEq(t, `{"id": 3}`, string(gotDeliveries[0].Payload))
+
+		gotLinks, err := repo.GetLinks(ctx, "", 10)
+		require.NoError(t, err)
+		require.Len(t, gotLinks, 2)
+		assert.Equal(t, "https://example.com/books/emma", gotLinks[0].TargetURL)
+		assert.Nil(t, gotLinks[0].ExpiresAt)
+		assert.True(t, gotLinks[1].Custom)
+		require.NotNil(t, gotLinks[1].ExpiresAt)
+		assert.True(t, expires.Equal(*gotLinks[1].ExpiresAt))
+
+		gotClicks, err := repo.GetLinkClicks(ctx, domain.LinkClicksKey{}, 10)
+		require.NoError(t, err)
+		assert.Equal(t, clicks, gotClicks)
+
+		gotRuleSets, err := repo.GetRuleSets(ctx, "", 10)
+		require.NoError(t, err)
+		require.Len(t, gotRuleSets, 2)
+		assert.Equal(t, []string{"shop.example.com", "*.shop.example.com"}, gotRuleSets[0].Hosts)
+		assert.Equal(t, 10, gotRuleSets[0].Priority)
+		assert.JSONEq(t, `{"canonical": [{"op": "remove_query_params"}]}`, string(gotRuleSets[0].Operations))
+		assert.True(t, gotRuleSets[0].Active)
+		assert.True(t, at.Add(time.Minute).Equal(gotRuleSets[0].UpdatedAt))
+		assert.Equal(t, []string{}, gotRuleSets[1].Hosts)
+		assert.False(t, gotRuleSets[1].Active)
+	})
+
+	t.Run("pages follow the primary key", func(t *testing.T) {
+		page, err := repo.GetBooks(ctx, "", 2)
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+
+		page, err = repo.GetBooks(ctx, page[1].ID, 2)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, books[2].ID, page[0].ID)
+
+		events, err := repo.GetEvents(ctx, 3, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, int64(7), events[0].ID)
+
+		// link clicks are keyed by code and day
+		gotClicks, err := repo.GetLinkClicks(ctx, domain.LinkClicksKey{Code: "abc123", Day: day.AddDate(0, 0, -1)}, 10)
+		require.NoError(t, err)
+		assert.Equal(t, clicks[1:], gotClicks)
+
+		gotClicks, err = repo.GetLinkClicks(ctx, domain.LinkClicksKey{Code: "abc123", Day: day}, 10)
+		require.NoError(t, err)
+		assert.Equal(t, clicks[2:], gotClicks)
+	})
+
+	t.Run("new ids continue after the restored ones", func(t *testing.T) {
+		var id int64
+		require.NoError(t, db.Get(&id, `INSERT INTO events (type, aggregate_type, aggregate_id) VALUES ('book.created', 'book', 'x') RETURNING id`))
+		assert.Equal(t, int64(8), id)
+	})
+
+	t.Run("clear removes every record", func(t *testing.T) {
+		require.NoError(t, repo.Clear(ctx))
+
+		for _, table := range clearOrder {
+			var n int
+			require.NoError(t, db.Get(&n, `SELECT COUNT(*) FROM `+table))
+			assert.Zero(t, n, table)
+		}
+	})
+
+	t.Run("schema version is the newest migration", func(t *testing.T) {
+		version, err := repo.SchemaVersion(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "20261019_08", version)
+	})
+}
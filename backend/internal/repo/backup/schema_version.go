@@ -0,0 +1,20 @@
+package backup
+
+import (
+	domain "booklib/internal/domain/backup"
+	"context"
+	"database/sql"
+)
+
+func (r *repo) SchemaVersion(ctx context.Context) (string, error) {
+	var version sql.NullString
+	err := r.conn(ctx).GetContext(ctx, &version, `SELECT MAX(version) FROM schema_migrations`)
+	if err != nil {
+		return "", err
+	}
+	if !version.Valid {
+		return "", domain.ErrNoSchemaVersion
+	}
+
+	return version.String, nil
+}
@@ -0,0 +1,68 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "booklib/internal/domain/backup"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaVersion(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMocks      func(mock sqlmock.Sqlmock)
+		expectedVersion string
+		expectedErr     error
+	}{
+		{
+			name: "newest applied migration",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT MAX\(version\) FROM schema_migrations`).
+					WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow("20261019_04"))
+			},
+			expectedVersion: "20261019_04",
+		},
+		{
+			name: "no applied migrations",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT MAX\(version\) FROM schema_migrations`).
+					WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(nil))
+			},
+			expectedErr: domain.ErrNoSchemaVersion,
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT MAX\(version\) FROM schema_migrations`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			version, err := repo.SchemaVersion(context.Background())
+
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedVersion, version)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package backup
+
+import (
+	"booklib/internal/repo/dialect"
+	"context"
+)
+
+func (r *repo) Snapshot(ctx context.Context) error {
+	// a sqlite transaction already reads from a single snapshot
+	if r.dialect == dialect.SQLite {
+		return nil
+	}
+
+	_, err := r.conn(ctx).ExecContext(ctx, `SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY`)
+	return err
+}
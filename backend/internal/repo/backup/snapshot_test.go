@@ -0,0 +1,54 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedErr string
+	}{
+		{
+			name: "reads from a repeatable snapshot",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY`).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`SET TRANSACTION`).WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			err = repo.Snapshot(context.Background())
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
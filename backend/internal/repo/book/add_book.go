@@ -5,10 +5,15 @@ import (
 	"context"
 )
 
-func (r *repo) AddBook(ctx context.Context, book *domain.Book) error {
-	query := `INSERT INTO books (id, title, author, year) VALUES ($1, $2, $3, $4)`
+func (r *repo) AddBook(ctx context.Context, book *domain.Book) (*domain.Book, error) {
+	var (
+		query = `INSERT INTO books (id, title, author, year) VALUES ($1, $2, $3, $4) RETURNING ` + columns
+		res   Book
+	)
 
-	_, err := r.db.ExecContext(ctx, query, book.ID, book.Title, book.Author, book.Year)
+	if err := r.conn(ctx).GetContext(ctx, &res, query, book.ID, book.Title, book.Author, book.Year); err != nil {
+		return nil, err
+	}
 
-	return err
+	return res.ToDomain(), nil
 }
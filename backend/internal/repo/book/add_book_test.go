@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	domain "booklib/internal/domain/book"
 
@@ -14,10 +15,11 @@ import (
 
 func TestAddBook(t *testing.T) {
 	tests := []struct {
-		name        string
-		book        *domain.Book
-		setupMocks  func(mock sqlmock.Sqlmock)
-		expectedErr string
+		name         string
+		book         *domain.Book
+		setupMocks   func(mock sqlmock.Sqlmock)
+		expectedBook *domain.Book
+		expectedErr  string
 	}{
 		{
 			name: "successful add book",
@@ -28,9 +30,17 @@ func TestAddBook(t *testing.T) {
 				Year:   2023,
 			},
 			setupMocks: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`INSERT INTO books \(id, title, author, year\) VALUES \(\$1, \$2, \$3, \$4\)`).
+				rows := sqlmock.NewRows([]string{"id", "title", "author", "year", "created_at", "updated_at"}).
+					AddRow("test-id", "Test Book", "Test Author", 2023, time.Now(), time.Now())
+				mock.ExpectQuery(`INSERT INTO books \(id, title, author, year\) VALUES \(\$1, \$2, \$3, \$4\) RETURNING id, title, author, year, created_at, updated_at`).
 					WithArgs("test-id", "Test Book", "Test Author", 2023).
-					WillReturnResult(sqlmock.NewResult(0, 1))
+					WillReturnRows(rows)
+			},
+			expectedBook: &domain.Book{
+				ID:     "test-id",
+				Title:  "Test Book",
+				Author: "Test Author",
+				Year:   2023,
 			},
 			expectedErr: "",
 		},
@@ -43,7 +53,7 @@ func TestAddBook(t *testing.T) {
 				Year:   2023,
 			},
 			setupMocks: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`INSERT INTO books \(id, title, author, year\) VALUES \(\$1, \$2, \$3, \$4\)`).
+				mock.ExpectQuery(`INSERT INTO books \(id, title, author, year\) VALUES \(\$1, \$2, \$3, \$4\) RETURNING id, title, author, year, created_at, updated_at`).
 					WithArgs("test-id", "Test Book", "Test Author", 2023).
 					WillReturnError(errors.New("database error"))
 			},
@@ -58,7 +68,7 @@ func TestAddBook(t *testing.T) {
 				Year:   2023,
 			},
 			setupMocks: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`INSERT INTO books \(id, title, author, year\) VALUES \(\$1, \$2, \$3, \$4\)`).
+				mock.ExpectQuery(`INSERT INTO books \(id, title, author, year\) VALUES \(\$1, \$2, \$3, \$4\) RETURNING id, title, author, year, created_at, updated_at`).
 					WithArgs("duplicate-id", "Test Book", "Test Author", 2023).
 					WillReturnError(errors.New("duplicate key value violates unique constraint"))
 			},
@@ -77,16 +87,21 @@ func TestAddBook(t *testing.T) {
 
 			tt.setupMocks(mock)
 
-			err = repo.AddBook(context.Background(), tt.book)
+			book, err := repo.AddBook(context.Background(), tt.book)
 
 			if tt.expectedErr != "" {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, book)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBook.ID, book.ID)
+				assert.Equal(t, tt.expectedBook.Title, book.Title)
+				assert.Equal(t, tt.expectedBook.Author, book.Author)
+				assert.Equal(t, tt.expectedBook.Year, book.Year)
 			}
 
 			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,40 @@
+package book
+
+import (
+	domain "booklib/internal/domain/book"
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (r *repo) AddBooks(ctx context.Context, books []*domain.Book) ([]domain.Book, error) {
+	result := make([]domain.Book, 0, len(books))
+
+	for start := 0; start < len(books); start += maxRowsPerStatement {
+		end := min(start+maxRowsPerStatement, len(books))
+
+		var (
+			chunk  = books[start:end]
+			values = make([]string, 0, len(chunk))
+			args   = make([]interface{}, 0, len(chunk)*4)
+		)
+		for i, book := range chunk {
+			n := i * 4
+			values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4))
+			args = append(args, book.ID, book.Title, book.Author, book.Year)
+		}
+
+		query := `INSERT INTO books (id, title, author, year) VALUES ` + strings.Join(values, ", ") + ` RETURNING ` + columns
+
+		var rows []Book
+		if err := r.conn(ctx).SelectContext(ctx, &rows, query, args...); err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			result = append(result, *row.ToDomain())
+		}
+	}
+
+	return result, nil
+}
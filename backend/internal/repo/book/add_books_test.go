@@ -0,0 +1,119 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/book"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddBooks(t *testing.T) {
+	tests := []struct {
+		name          string
+		books         []*domain.Book
+		setupMocks    func(mock sqlmock.Sqlmock)
+		expectedBooks []domain.Book
+		expectedErr   string
+	}{
+		{
+			name: "inserts all books with a single statement",
+			books: []*domain.Book{
+				{ID: "id-1", Title: "Book 1", Author: "Author 1", Year: 2021},
+				{ID: "id-2", Title: "Book 2", Author: "Author 2", Year: 2022},
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "title", "author", "year", "created_at", "updated_at"}).
+					AddRow("id-1", "Book 1", "Author 1", 2021, time.Now(), time.Now()).
+					AddRow("id-2", "Book 2", "Author 2", 2022, time.Now(), time.Now())
+				mock.ExpectQuery(`INSERT INTO books \(id, title, author, year\) VALUES \(\$1, \$2, \$3, \$4\), \(\$5, \$6, \$7, \$8\) RETURNING id, title, author, year, created_at, updated_at`).
+					WithArgs("id-1", "Book 1", "Author 1", 2021, "id-2", "Book 2", "Author 2", 2022).
+					WillReturnRows(rows)
+			},
+			expectedBooks: []domain.Book{
+				{ID: "id-1", Title: "Book 1", Author: "Author 1", Year: 2021},
+				{ID: "id-2", Title: "Book 2", Author: "Author 2", Year: 2022},
+			},
+		},
+		{
+			name:          "no books",
+			books:         []*domain.Book{},
+			setupMocks:    func(mock sqlmock.Sqlmock) {},
+			expectedBooks: []domain.Book{},
+		},
+		{
+			name: "database error",
+			books: []*domain.Book{
+				{ID: "id-1", Title: "Book 1", Author: "Author 1", Year: 2021},
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO books`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+			repo := New(sqlxDB)
+
+			tt.setupMocks(mock)
+
+			books, err := repo.AddBooks(context.Background(), tt.books)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, books)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, len(tt.expectedBooks), len(books))
+				for i, expectedBook := range tt.expectedBooks {
+					assert.Equal(t, expectedBook.ID, books[i].ID)
+					assert.Equal(t, expectedBook.Title, books[i].Title)
+					assert.Equal(t, expectedBook.Author, books[i].Author)
+					assert.Equal(t, expectedBook.Year, books[i].Year)
+				}
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAddBooks_Chunks(t *testing.T) {
+	t.Run("splits large inserts into several statements", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		books := make([]*domain.Book, maxRowsPerStatement+1)
+		for i := range books {
+			books[i] = &domain.Book{ID: "id", Title: "Book", Author: "Author", Year: 2020}
+		}
+
+		columns := []string{"id", "title", "author", "year", "created_at", "updated_at"}
+		mock.ExpectQuery(`INSERT INTO books`).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("id", "Book", "Author", 2020, time.Now(), time.Now()))
+		mock.ExpectQuery(`INSERT INTO books \(id, title, author, year\) VALUES \(\$1, \$2, \$3, \$4\) RETURNING id, title, author, year, created_at, updated_at`).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("id", "Book", "Author", 2020, time.Now(), time.Now()))
+
+		repo := New(sqlx.NewDb(db, "sqlmock"))
+		res, err := repo.AddBooks(context.Background(), books)
+
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
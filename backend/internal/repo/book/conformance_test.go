@@ -0,0 +1,36 @@
+package book
+
+import (
+	"testing"
+
+	domain "booklib/internal/domain/book"
+	"booklib/internal/domain/transaction"
+	"booklib/internal/repo/pgtest"
+	"booklib/internal/repo/repotest"
+	"booklib/internal/repo/sqlitetest"
+	"booklib/internal/repo/sqltx"
+)
+
+func TestRepo_Conformance(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) {
+		repotest.RunBookRepositorySuite(t, func(t *testing.T) (domain.Repository, transaction.Manager) {
+			db := sqlitetest.New(t)
+			return New(db), sqltx.NewManager(db)
+		})
+	})
+
+	t.Run("sqlite with a column added by a later migration", func(t *testing.T) {
+		repotest.RunBookRepositorySuite(t, func(t *testing.T) (domain.Repository, transaction.Manager) {
+			db := sqlitetest.New(t)
+			db.MustExec(`ALTER TABLE books ADD COLUMN isbn TEXT`)
+			return New(db), sqltx.NewManager(db)
+		})
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		repotest.RunBookRepositorySuite(t, func(t *testing.T) (domain.Repository, transaction.Manager) {
+			db := pgtest.New(t)
+			return New(db), sqltx.NewManager(db)
+		})
+	})
+}
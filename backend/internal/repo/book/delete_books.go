@@ -0,0 +1,20 @@
+package book
+
+import "context"
+
+func (r *repo) DeleteBooks(ctx context.Context, ids []string) ([]string, error) {
+	var (
+		query   = `DELETE FROM books WHERE ` + r.dialect.AnyOf("id", 1, "uuid[]") + ` RETURNING id`
+		deleted = make([]string, 0, len(ids))
+	)
+
+	if len(ids) == 0 {
+		return deleted, nil
+	}
+
+	if err := r.conn(ctx).SelectContext(ctx, &deleted, query, r.dialect.Array(ids)); err != nil {
+		return nil, err
+	}
+
+	return deleted, nil
+}
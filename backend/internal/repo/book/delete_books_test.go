@@ -0,0 +1,74 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteBooks(t *testing.T) {
+	tests := []struct {
+		name        string
+		ids         []string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedIDs []string
+		expectedErr string
+	}{
+		{
+			name: "deletes all books with a single statement",
+			ids:  []string{"id-1", "id-2", "missing"},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`DELETE FROM books WHERE id = ANY\(\$1::uuid\[\]\) RETURNING id`).
+					WithArgs(pq.Array([]string{"id-1", "id-2", "missing"})).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("id-1").AddRow("id-2"))
+			},
+			expectedIDs: []string{"id-1", "id-2"},
+		},
+		{
+			name:        "no ids",
+			ids:         []string{},
+			setupMocks:  func(mock sqlmock.Sqlmock) {},
+			expectedIDs: []string{},
+		},
+		{
+			name: "database error",
+			ids:  []string{"id-1"},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`DELETE FROM books`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+			repo := New(sqlxDB)
+
+			tt.setupMocks(mock)
+
+			ids, err := repo.DeleteBooks(context.Background(), tt.ids)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, ids)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedIDs, ids)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -5,14 +5,21 @@ import (
 	"context"
 )
 
-func (r *repo) GetAllBooks(ctx context.Context) ([]domain.Book, error) {
+func (r *repo) GetAllBooks(ctx context.Context, filter domain.Filter) ([]domain.Book, error) {
 	var (
-		query  = `SELECT * FROM books`
+		query  = `SELECT ` + columns + ` FROM books`
+		args   []interface{}
 		result []domain.Book
 	)
 
+	if filter.UpdatedSince != nil {
+		query += ` WHERE updated_at >= $1`
+		args = append(args, r.dialect.Time(*filter.UpdatedSince))
+	}
+	query += ` ORDER BY created_at, id`
+
 	var books []Book
-	if err := r.db.SelectContext(ctx, &books, query); err != nil {
+	if err := r.conn(ctx).SelectContext(ctx, &books, query, args...); err != nil {
 		return result, err
 	}
 
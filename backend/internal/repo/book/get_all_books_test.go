@@ -14,8 +14,11 @@ import (
 )
 
 func TestGetAllBooks(t *testing.T) {
+	updatedSince := time.Date(2025, 8, 7, 0, 0, 0, 0, time.UTC)
+
 	tests := []struct {
 		name          string
+		filter        domain.Filter
 		setupMocks    func(mock sqlmock.Sqlmock)
 		expectedBooks []domain.Book
 		expectedErr   string
@@ -27,7 +30,7 @@ func TestGetAllBooks(t *testing.T) {
 					AddRow("1", "Book 1", "Author 1", 2021, time.Now(), time.Now()).
 					AddRow("2", "Book 2", "Author 2", 2022, time.Now(), time.Now()).
 					AddRow("3", "Book 3", "Author 3", 2023, time.Now(), time.Now())
-				mock.ExpectQuery(`SELECT \* FROM books`).
+				mock.ExpectQuery(`SELECT id, title, author, year, created_at, updated_at FROM books ORDER BY created_at, id`).
 					WillReturnRows(rows)
 			},
 			expectedBooks: []domain.Book{
@@ -56,7 +59,7 @@ func TestGetAllBooks(t *testing.T) {
 			name: "empty result",
 			setupMocks: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "title", "author", "year", "created_at", "updated_at"})
-				mock.ExpectQuery(`SELECT \* FROM books`).
+				mock.ExpectQuery(`SELECT id, title, author, year, created_at, updated_at FROM books ORDER BY created_at, id`).
 					WillReturnRows(rows)
 			},
 			expectedBooks: []domain.Book{},
@@ -65,7 +68,7 @@ func TestGetAllBooks(t *testing.T) {
 		{
 			name: "database error",
 			setupMocks: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT \* FROM books`).
+				mock.ExpectQuery(`SELECT id, title, author, year, created_at, updated_at FROM books ORDER BY created_at, id`).
 					WillReturnError(errors.New("database connection error"))
 			},
 			expectedBooks: []domain.Book{},
@@ -76,7 +79,7 @@ func TestGetAllBooks(t *testing.T) {
 			setupMocks: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "title", "author", "year", "created_at", "updated_at"}).
 					AddRow("1", "Book 1", "Author 1", "invalid-year", time.Now(), time.Now())
-				mock.ExpectQuery(`SELECT \* FROM books`).
+				mock.ExpectQuery(`SELECT id, title, author, year, created_at, updated_at FROM books ORDER BY created_at, id`).
 					WillReturnRows(rows)
 			},
 			expectedBooks: []domain.Book{},
@@ -87,7 +90,7 @@ func TestGetAllBooks(t *testing.T) {
 			setupMocks: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "title", "author", "year", "created_at", "updated_at"}).
 					AddRow("single-id", "Single Book", "Single Author", 2023, time.Now(), time.Now())
-				mock.ExpectQuery(`SELECT \* FROM books`).
+				mock.ExpectQuery(`SELECT id, title, author, year, created_at, updated_at FROM books ORDER BY created_at, id`).
 					WillReturnRows(rows)
 			},
 			expectedBooks: []domain.Book{
@@ -100,6 +103,28 @@ func TestGetAllBooks(t *testing.T) {
 			},
 			expectedErr: "",
 		},
+		{
+			name:   "filter by updated since",
+			filter: domain.Filter{UpdatedSince: &updatedSince},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "title", "author", "year", "created_at", "updated_at"}).
+					AddRow("1", "Book 1", "Author 1", 2021, updatedSince.Add(-time.Hour), updatedSince.Add(time.Hour))
+				mock.ExpectQuery(`SELECT id, title, author, year, created_at, updated_at FROM books WHERE updated_at >= \$1 ORDER BY created_at, id`).
+					WithArgs(updatedSince).
+					WillReturnRows(rows)
+			},
+			expectedBooks: []domain.Book{
+				{
+					ID:        "1",
+					Title:     "Book 1",
+					Author:    "Author 1",
+					Year:      2021,
+					CreatedAt: updatedSince.Add(-time.Hour),
+					UpdatedAt: updatedSince.Add(time.Hour),
+				},
+			},
+			expectedErr: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -113,7 +138,7 @@ func TestGetAllBooks(t *testing.T) {
 
 			tt.setupMocks(mock)
 
-			books, err := repo.GetAllBooks(context.Background())
+			books, err := repo.GetAllBooks(context.Background(), tt.filter)
 
 			if tt.expectedErr != "" {
 				assert.Error(t, err)
@@ -126,10 +151,14 @@ func TestGetAllBooks(t *testing.T) {
 					assert.Equal(t, expectedBook.Title, books[i].Title)
 					assert.Equal(t, expectedBook.Author, books[i].Author)
 					assert.Equal(t, expectedBook.Year, books[i].Year)
+					if !expectedBook.UpdatedAt.IsZero() {
+						assert.Equal(t, expectedBook.CreatedAt, books[i].CreatedAt)
+						assert.Equal(t, expectedBook.UpdatedAt, books[i].UpdatedAt)
+					}
 				}
 			}
 
 			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
-}
\ No newline at end of file
+}
@@ -9,11 +9,11 @@ import (
 
 func (r *repo) GetBookByID(ctx context.Context, id string) (*domain.Book, error) {
 	var (
-		query = `SELECT * FROM books WHERE id = $1`
+		query = `SELECT ` + columns + ` FROM books WHERE id = $1`
 		book  Book
 	)
 
-	if err := r.db.GetContext(ctx, &book, query, id); err != nil {
+	if err := r.conn(ctx).GetContext(ctx, &book, query, id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
@@ -28,7 +28,7 @@ func TestGetBookByID(t *testing.T) {
 			setupMocks: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "title", "author", "year", "created_at", "updated_at"}).
 					AddRow("test-id", "Test Book", "Test Author", 2023, time.Now(), time.Now())
-				mock.ExpectQuery(`SELECT \* FROM books WHERE id = \$1`).
+				mock.ExpectQuery(`SELECT id, title, author, year, created_at, updated_at FROM books WHERE id = \$1`).
 					WithArgs("test-id").
 					WillReturnRows(rows)
 			},
@@ -44,7 +44,7 @@ func TestGetBookByID(t *testing.T) {
 			name:   "book not found",
 			bookID: "non-existent-id",
 			setupMocks: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT \* FROM books WHERE id = \$1`).
+				mock.ExpectQuery(`SELECT id, title, author, year, created_at, updated_at FROM books WHERE id = \$1`).
 					WithArgs("non-existent-id").
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -55,7 +55,7 @@ func TestGetBookByID(t *testing.T) {
 			name:   "database error",
 			bookID: "test-id",
 			setupMocks: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT \* FROM books WHERE id = \$1`).
+				mock.ExpectQuery(`SELECT id, title, author, year, created_at, updated_at FROM books WHERE id = \$1`).
 					WithArgs("test-id").
 					WillReturnError(errors.New("database connection error"))
 			},
@@ -68,7 +68,7 @@ func TestGetBookByID(t *testing.T) {
 			setupMocks: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "title", "author", "year", "created_at", "updated_at"}).
 					AddRow("test-id", "Test Book", "Test Author", "invalid-year", time.Now(), time.Now())
-				mock.ExpectQuery(`SELECT \* FROM books WHERE id = \$1`).
+				mock.ExpectQuery(`SELECT id, title, author, year, created_at, updated_at FROM books WHERE id = \$1`).
 					WithArgs("test-id").
 					WillReturnRows(rows)
 			},
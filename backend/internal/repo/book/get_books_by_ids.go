@@ -0,0 +1,36 @@
+package book
+
+import (
+	domain "booklib/internal/domain/book"
+	"context"
+)
+
+func (r *repo) GetBooksByIDs(ctx context.Context, ids []string) ([]domain.Book, error) {
+	return r.getBooksByIDs(ctx, ids, "")
+}
+
+func (r *repo) GetBooksByIDsForUpdate(ctx context.Context, ids []string) ([]domain.Book, error) {
+	return r.getBooksByIDs(ctx, ids, r.dialect.ForUpdate())
+}
+
+func (r *repo) getBooksByIDs(ctx context.Context, ids []string, lock string) ([]domain.Book, error) {
+	var (
+		query  = `SELECT ` + columns + ` FROM books WHERE ` + r.dialect.AnyOf("id", 1, "uuid[]") + ` ORDER BY created_at, id` + lock
+		result = make([]domain.Book, 0, len(ids))
+	)
+
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	var books []Book
+	if err := r.conn(ctx).SelectContext(ctx, &books, query, r.dialect.Array(ids)); err != nil {
+		return nil, err
+	}
+
+	for _, book := range books {
+		result = append(result, *book.ToDomain())
+	}
+
+	return result, nil
+}
@@ -0,0 +1,102 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBooksByIDs(t *testing.T) {
+	tests := []struct {
+		name        string
+		ids         []string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedIDs []string
+		expectedErr string
+	}{
+		{
+			name: "returns existing books",
+			ids:  []string{"id-1", "id-2", "missing"},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "title", "author", "year", "created_at", "updated_at"}).
+					AddRow("id-1", "Book 1", "Author 1", 2021, time.Now(), time.Now()).
+					AddRow("id-2", "Book 2", "Author 2", 2022, time.Now(), time.Now())
+				mock.ExpectQuery(`SELECT id, title, author, year, created_at, updated_at FROM books WHERE id = ANY\(\$1::uuid\[\]\) ORDER BY created_at, id`).
+					WithArgs(pq.Array([]string{"id-1", "id-2", "missing"})).
+					WillReturnRows(rows)
+			},
+			expectedIDs: []string{"id-1", "id-2"},
+		},
+		{
+			name:        "no ids",
+			ids:         []string{},
+			setupMocks:  func(mock sqlmock.Sqlmock) {},
+			expectedIDs: []string{},
+		},
+		{
+			name: "database error",
+			ids:  []string{"id-1"},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, title, author, year, created_at, updated_at FROM books`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+			repo := New(sqlxDB)
+
+			tt.setupMocks(mock)
+
+			books, err := repo.GetBooksByIDs(context.Background(), tt.ids)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, books)
+			} else {
+				assert.NoError(t, err)
+				ids := make([]string, 0, len(books))
+				for _, b := range books {
+					ids = append(ids, b.ID)
+				}
+				assert.Equal(t, tt.expectedIDs, ids)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetBooksByIDsForUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(sqlx.NewDb(db, "sqlmock"))
+
+	rows := sqlmock.NewRows([]string{"id", "title", "author", "year", "created_at", "updated_at"}).
+		AddRow("id-1", "Book 1", "Author 1", 2021, time.Now(), time.Now())
+	mock.ExpectQuery(`SELECT id, title, author, year, created_at, updated_at FROM books WHERE id = ANY\(\$1::uuid\[\]\) ORDER BY created_at, id FOR UPDATE`).
+		WithArgs(pq.Array([]string{"id-1"})).
+		WillReturnRows(rows)
+
+	books, err := repo.GetBooksByIDsForUpdate(context.Background(), []string{"id-1"})
+
+	assert.NoError(t, err)
+	assert.Len(t, books, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,9 +1,12 @@
 package book
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	domain "booklib/internal/domain/book"
+	"booklib/internal/repo/sqltx"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
@@ -35,3 +38,35 @@ func TestNew(t *testing.T) {
 		assert.Implements(t, (*domain.Repository)(nil), repo)
 	})
 }
+
+func TestRepo_JoinsTransactionFromContext(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := New(sqlxDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM books WHERE id = \$1`).
+		WithArgs("id-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM books WHERE id = \$1`).
+		WithArgs("id-2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	err = sqltx.NewManager(sqlxDB).WithinTx(context.Background(), func(ctx context.Context) error {
+		if err := repo.DeleteBook(ctx, "id-1"); err != nil {
+			return err
+		}
+		if err := repo.DeleteBook(ctx, "id-2"); err != nil {
+			return err
+		}
+		return errors.New("something went wrong")
+	})
+
+	// both deletes ran in the transaction that was rolled back
+	assert.EqualError(t, err, "something went wrong")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
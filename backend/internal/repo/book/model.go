@@ -5,6 +5,10 @@ import (
 	"database/sql"
 )
 
+// columns are the columns of Book, named rather than selected with * so that a
+// column added by a later migration does not break scanning.
+const columns = `id, title, author, year, created_at, updated_at`
+
 type Book struct {
 	ID        string       `db:"id"`
 	Title     string       `db:"title"`
@@ -16,9 +20,11 @@ type Book struct {
 
 func (b *Book) ToDomain() *domain.Book {
 	return &domain.Book{
-		ID:     b.ID,
-		Title:  b.Title,
-		Author: b.Author,
-		Year:   b.Year,
+		ID:        b.ID,
+		Title:     b.Title,
+		Author:    b.Author,
+		Year:      b.Year,
+		CreatedAt: b.CreatedAt.Time,
+		UpdatedAt: b.UpdatedAt.Time,
 	}
 }
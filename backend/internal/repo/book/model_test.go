@@ -11,6 +11,8 @@ import (
 )
 
 func TestBook_ToDomain(t *testing.T) {
+	now := time.Now()
+
 	tests := []struct {
 		name         string
 		repoBook     Book
@@ -23,14 +25,16 @@ func TestBook_ToDomain(t *testing.T) {
 				Title:     "Test Book",
 				Author:    "Test Author",
 				Year:      2023,
-				CreatedAt: sql.NullTime{Time: time.Now(), Valid: true},
-				UpdatedAt: sql.NullTime{Time: time.Now(), Valid: true},
+				CreatedAt: sql.NullTime{Time: now, Valid: true},
+				UpdatedAt: sql.NullTime{Time: now.Add(time.Hour), Valid: true},
 			},
 			expectedBook: &domain.Book{
-				ID:     "test-id",
-				Title:  "Test Book",
-				Author: "Test Author",
-				Year:   2023,
+				ID:        "test-id",
+				Title:     "Test Book",
+				Author:    "Test Author",
+				Year:      2023,
+				CreatedAt: now,
+				UpdatedAt: now.Add(time.Hour),
 			},
 		},
 		{
@@ -112,6 +116,14 @@ func TestBook_ToDomain(t *testing.T) {
 			assert.Equal(t, tt.expectedBook.Title, domainBook.Title)
 			assert.Equal(t, tt.expectedBook.Author, domainBook.Author)
 			assert.Equal(t, tt.expectedBook.Year, domainBook.Year)
+			if !tt.expectedBook.CreatedAt.IsZero() {
+				assert.Equal(t, tt.expectedBook.CreatedAt, domainBook.CreatedAt)
+				assert.Equal(t, tt.expectedBook.UpdatedAt, domainBook.UpdatedAt)
+			}
+			if !tt.repoBook.CreatedAt.Valid {
+				assert.True(t, domainBook.CreatedAt.IsZero())
+				assert.True(t, domainBook.UpdatedAt.IsZero())
+			}
 		})
 	}
 }
@@ -133,4 +145,4 @@ func TestBook_ToDomain_Immutability(t *testing.T) {
 		assert.Equal(t, originalTitle, repoBook.Title)
 		assert.NotEqual(t, repoBook.Title, domainBook.Title)
 	})
-}
\ No newline at end of file
+}
@@ -3,12 +3,22 @@ package book
 import (
 	domain "booklib/internal/domain/book"
 	"context"
+	"database/sql"
+	"errors"
 )
 
-func (r *repo) UpdateBook(ctx context.Context, book *domain.Book) error {
-	query := `UPDATE books SET title = $1, author = $2, year = $3 WHERE id = $4`
+func (r *repo) UpdateBook(ctx context.Context, book *domain.Book) (*domain.Book, error) {
+	var (
+		query = `UPDATE books SET title = $1, author = $2, year = $3, updated_at = ` + r.dialect.Now() + ` WHERE id = $4 RETURNING ` + columns
+		res   Book
+	)
 
-	_, err := r.db.ExecContext(ctx, query, book.Title, book.Author, book.Year, book.ID)
+	if err := r.conn(ctx).GetContext(ctx, &res, query, book.Title, book.Author, book.Year, book.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
 
-	return err
+	return res.ToDomain(), nil
 }
@@ -2,8 +2,10 @@ package book
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	domain "booklib/internal/domain/book"
 
@@ -14,10 +16,11 @@ import (
 
 func TestUpdateBook(t *testing.T) {
 	tests := []struct {
-		name        string
-		book        *domain.Book
-		setupMocks  func(mock sqlmock.Sqlmock)
-		expectedErr string
+		name         string
+		book         *domain.Book
+		setupMocks   func(mock sqlmock.Sqlmock)
+		expectedBook *domain.Book
+		expectedErr  string
 	}{
 		{
 			name: "successful update book",
@@ -28,9 +31,16 @@ func TestUpdateBook(t *testing.T) {
 				Year:   2024,
 			},
 			setupMocks: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`UPDATE books SET title = \$1, author = \$2, year = \$3 WHERE id = \$4`).
+				mock.ExpectQuery(`UPDATE books SET title = \$1, author = \$2, year = \$3, updated_at = NOW\(\) WHERE id = \$4 RETURNING id, title, author, year, created_at, updated_at`).
 					WithArgs("Updated Book", "Updated Author", 2024, "test-id").
-					WillReturnResult(sqlmock.NewResult(0, 1))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "year", "created_at", "updated_at"}).
+						AddRow("test-id", "Updated Book", "Updated Author", 2024, time.Now(), time.Now()))
+			},
+			expectedBook: &domain.Book{
+				ID:     "test-id",
+				Title:  "Updated Book",
+				Author: "Updated Author",
+				Year:   2024,
 			},
 			expectedErr: "",
 		},
@@ -43,11 +53,12 @@ func TestUpdateBook(t *testing.T) {
 				Year:   2024,
 			},
 			setupMocks: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`UPDATE books SET title = \$1, author = \$2, year = \$3 WHERE id = \$4`).
+				mock.ExpectQuery(`UPDATE books SET title = \$1, author = \$2, year = \$3, updated_at = NOW\(\) WHERE id = \$4 RETURNING id, title, author, year, created_at, updated_at`).
 					WithArgs("Updated Book", "Updated Author", 2024, "non-existent-id").
-					WillReturnResult(sqlmock.NewResult(0, 0))
+					WillReturnError(sql.ErrNoRows)
 			},
-			expectedErr: "",
+			expectedBook: nil,
+			expectedErr:  "",
 		},
 		{
 			name: "database error",
@@ -58,7 +69,7 @@ func TestUpdateBook(t *testing.T) {
 				Year:   2024,
 			},
 			setupMocks: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`UPDATE books SET title = \$1, author = \$2, year = \$3 WHERE id = \$4`).
+				mock.ExpectQuery(`UPDATE books SET title = \$1, author = \$2, year = \$3, updated_at = NOW\(\) WHERE id = \$4 RETURNING id, title, author, year, created_at, updated_at`).
 					WithArgs("Updated Book", "Updated Author", 2024, "test-id").
 					WillReturnError(errors.New("database connection error"))
 			},
@@ -73,7 +84,7 @@ func TestUpdateBook(t *testing.T) {
 				Year:   2024,
 			},
 			setupMocks: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`UPDATE books SET title = \$1, author = \$2, year = \$3 WHERE id = \$4`).
+				mock.ExpectQuery(`UPDATE books SET title = \$1, author = \$2, year = \$3, updated_at = NOW\(\) WHERE id = \$4 RETURNING id, title, author, year, created_at, updated_at`).
 					WithArgs("", "Updated Author", 2024, "test-id").
 					WillReturnError(errors.New("null value in column violates not-null constraint"))
 			},
@@ -88,9 +99,16 @@ func TestUpdateBook(t *testing.T) {
 				Year:   2023,
 			},
 			setupMocks: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`UPDATE books SET title = \$1, author = \$2, year = \$3 WHERE id = \$4`).
+				mock.ExpectQuery(`UPDATE books SET title = \$1, author = \$2, year = \$3, updated_at = NOW\(\) WHERE id = \$4 RETURNING id, title, author, year, created_at, updated_at`).
 					WithArgs("Same Title", "Same Author", 2023, "test-id").
-					WillReturnResult(sqlmock.NewResult(0, 1))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "year", "created_at", "updated_at"}).
+						AddRow("test-id", "Same Title", "Same Author", 2023, time.Now(), time.Now()))
+			},
+			expectedBook: &domain.Book{
+				ID:     "test-id",
+				Title:  "Same Title",
+				Author: "Same Author",
+				Year:   2023,
 			},
 			expectedErr: "",
 		},
@@ -107,16 +125,26 @@ func TestUpdateBook(t *testing.T) {
 
 			tt.setupMocks(mock)
 
-			err = repo.UpdateBook(context.Background(), tt.book)
+			book, err := repo.UpdateBook(context.Background(), tt.book)
 
 			if tt.expectedErr != "" {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, book)
 			} else {
 				assert.NoError(t, err)
+				if tt.expectedBook == nil {
+					assert.Nil(t, book)
+				} else {
+					assert.NotNil(t, book)
+					assert.Equal(t, tt.expectedBook.ID, book.ID)
+					assert.Equal(t, tt.expectedBook.Title, book.Title)
+					assert.Equal(t, tt.expectedBook.Author, book.Author)
+					assert.Equal(t, tt.expectedBook.Year, book.Year)
+				}
 			}
 
 			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
-}
\ No newline at end of file
+}
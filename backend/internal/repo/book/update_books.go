@@ -0,0 +1,63 @@
+package book
+
+import (
+	domain "booklib/internal/domain/book"
+	"booklib/internal/repo/dialect"
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (r *repo) UpdateBooks(ctx context.Context, books []*domain.Book) ([]domain.Book, error) {
+	result := make([]domain.Book, 0, len(books))
+
+	for start := 0; start < len(books); start += maxRowsPerStatement {
+		end := min(start+maxRowsPerStatement, len(books))
+
+		var (
+			chunk = books[start:end]
+			args  = make([]interface{}, 0, len(chunk)*4)
+		)
+		for _, book := range chunk {
+			args = append(args, book.ID, book.Title, book.Author, book.Year)
+		}
+
+		var rows []Book
+		if err := r.conn(ctx).SelectContext(ctx, &rows, r.updateBooksQuery(len(chunk)), args...); err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			result = append(result, *row.ToDomain())
+		}
+	}
+
+	return result, nil
+}
+
+// updateBooksQuery updates n books from a VALUES list of (id, title, author,
+// year). Sqlite can neither cast placeholders nor name the VALUES columns, so
+// it refers to them as column1..column4.
+func (r *repo) updateBooksQuery(n int) string {
+	values := make([]string, 0, n)
+
+	if r.dialect == dialect.SQLite {
+		for i := 0; i < n; i++ {
+			k := i * 4
+			values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d)", k+1, k+2, k+3, k+4))
+		}
+
+		return `UPDATE books SET title = v.column2, author = v.column3, year = v.column4, updated_at = ` + r.dialect.Now() + ` ` +
+			`FROM (VALUES ` + strings.Join(values, ", ") + `) AS v ` +
+			`WHERE books.id = v.column1 RETURNING ` + columns
+	}
+
+	for i := 0; i < n; i++ {
+		k := i * 4
+		values = append(values, fmt.Sprintf("($%d::uuid, $%d, $%d, $%d::integer)", k+1, k+2, k+3, k+4))
+	}
+
+	return `UPDATE books AS b SET title = v.title, author = v.author, year = v.year, updated_at = NOW() ` +
+		`FROM (VALUES ` + strings.Join(values, ", ") + `) AS v (id, title, author, year) ` +
+		`WHERE b.id = v.id RETURNING b.id, b.title, b.author, b.year, b.created_at, b.updated_at`
+}
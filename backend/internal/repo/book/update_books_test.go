@@ -0,0 +1,101 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/book"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateBooks(t *testing.T) {
+	tests := []struct {
+		name          string
+		books         []*domain.Book
+		setupMocks    func(mock sqlmock.Sqlmock)
+		expectedBooks []domain.Book
+		expectedErr   string
+	}{
+		{
+			name: "updates all books with a single statement",
+			books: []*domain.Book{
+				{ID: "id-1", Title: "Book 1", Author: "Author 1", Year: 2021},
+				{ID: "id-2", Title: "Book 2", Author: "Author 2", Year: 2022},
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "title", "author", "year", "created_at", "updated_at"}).
+					AddRow("id-1", "Book 1", "Author 1", 2021, time.Now(), time.Now()).
+					AddRow("id-2", "Book 2", "Author 2", 2022, time.Now(), time.Now())
+				mock.ExpectQuery(`UPDATE books AS b SET title = v.title, author = v.author, year = v.year, updated_at = NOW\(\) FROM \(VALUES \(\$1::uuid, \$2, \$3, \$4::integer\), \(\$5::uuid, \$6, \$7, \$8::integer\)\) AS v \(id, title, author, year\) WHERE b.id = v.id RETURNING b.id, b.title, b.author, b.year, b.created_at, b.updated_at`).
+					WithArgs("id-1", "Book 1", "Author 1", 2021, "id-2", "Book 2", "Author 2", 2022).
+					WillReturnRows(rows)
+			},
+			expectedBooks: []domain.Book{
+				{ID: "id-1", Title: "Book 1", Author: "Author 1", Year: 2021},
+				{ID: "id-2", Title: "Book 2", Author: "Author 2", Year: 2022},
+			},
+		},
+		{
+			name: "missing books are not returned",
+			books: []*domain.Book{
+				{ID: "id-1", Title: "Book 1", Author: "Author 1", Year: 2021},
+				{ID: "missing", Title: "Book 2", Author: "Author 2", Year: 2022},
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "title", "author", "year", "created_at", "updated_at"}).
+					AddRow("id-1", "Book 1", "Author 1", 2021, time.Now(), time.Now())
+				mock.ExpectQuery(`UPDATE books AS b`).
+					WillReturnRows(rows)
+			},
+			expectedBooks: []domain.Book{
+				{ID: "id-1", Title: "Book 1", Author: "Author 1", Year: 2021},
+			},
+		},
+		{
+			name: "database error",
+			books: []*domain.Book{
+				{ID: "id-1", Title: "Book 1", Author: "Author 1", Year: 2021},
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE books AS b`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+			repo := New(sqlxDB)
+
+			tt.setupMocks(mock)
+
+			books, err := repo.UpdateBooks(context.Background(), tt.books)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, books)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, len(tt.expectedBooks), len(books))
+				for i, expectedBook := range tt.expectedBooks {
+					assert.Equal(t, expectedBook.ID, books[i].ID)
+					assert.Equal(t, expectedBook.Title, books[i].Title)
+				}
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Package dialect covers the few places where the SQL of the repositories
+// differs between postgres and sqlite. Everything else is written once, in the
+// subset both databases understand: $N placeholders, RETURNING and
+// column defaults for timestamps.
+package dialect
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type Dialect int
+
+const (
+	Postgres Dialect = iota
+	SQLite
+)
+
+const (
+	// DriverSQLite is the database/sql driver name of modernc.org/sqlite.
+	DriverSQLite = "sqlite"
+
+	// sqliteTimeLayout matches sqliteNow, so stored timestamps compare as text
+	// in the same order as the times they hold.
+	sqliteTimeLayout = "2006-01-02 15:04:05.000-07:00"
+	sqliteNow        = `strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')`
+)
+
+// Of tells which dialect db speaks. Anything that is not sqlite is treated as
+// postgres, which also covers sqlmock in tests.
+func Of(db *sqlx.DB) Dialect {
+	if db.DriverName() == DriverSQLite {
+		return SQLite
+	}
+	return Postgres
+}
+
+// Now is an SQL expression for the current time.
+func (d Dialect) Now() string {
+	if d == SQLite {
+		return sqliteNow
+	}
+	return "NOW()"
+}
+
+// Time converts t into an argument that compares correctly with stored
+// timestamps. Sqlite keeps them as text, in UTC with millisecond precision.
+func (d Dialect) Time(t time.Time) interface{} {
+	if d == SQLite {
+		return t.UTC().Format(sqliteTimeLayout)
+	}
+	return t
+}
+
+// Array converts a slice into a single argument: a postgres array, or a JSON
+// array for sqlite.
+func (d Dialect) Array(values interface{}) interface{} {
+	if d == SQLite {
+		// slices of strings and integers always marshal
+		raw, _ := json.Marshal(values)
+		return string(raw)
+	}
+	return pq.Array(values)
+}
+
+// AnyOf matches column against the Array bound to placeholder n. pgType is the
+// postgres array type the placeholder is cast to, empty for none.
+func (d Dialect) AnyOf(column string, n int, pgType string) string {
+	if d == SQLite {
+		return fmt.Sprintf("%s IN (SELECT value FROM json_each($%d))", column, n)
+	}
+	if pgType != "" {
+		return fmt.Sprintf("%s = ANY($%d::%s)", column, n, pgType)
+	}
+	return fmt.Sprintf("%s = ANY($%d)", column, n)
+}
+
+// SkipLocked is the locking clause of claim queries. Sqlite has a single
+// writer, so claims never overlap and need no row locks.
+func (d Dialect) SkipLocked() string {
+	if d == SQLite {
+		return ""
+	}
+	return " FOR UPDATE SKIP LOCKED"
+}
+
+// ForUpdate is the locking clause of reads whose rows are about to be
+// written. Sqlite transactions take the write lock when they begin, so rows
+// cannot change under them.
+func (d Dialect) ForUpdate() string {
+	if d == SQLite {
+		return ""
+	}
+	return " FOR UPDATE"
+}
+
+// StringArray scans a column written with Array in either dialect.
+type StringArray []string
+
+func (a *StringArray) Scan(src interface{}) error {
+	var raw []byte
+	switch v := src.(type) {
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	}
+
+	if len(raw) > 0 && raw[0] == '[' {
+		return json.Unmarshal(raw, (*[]string)(a))
+	}
+	return (*pq.StringArray)(a).Scan(src)
+}
@@ -0,0 +1,93 @@
+package dialect
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOf(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	assert.Equal(t, Postgres, Of(sqlx.NewDb(db, "postgres")))
+	assert.Equal(t, Postgres, Of(sqlx.NewDb(db, "sqlmock")))
+	assert.Equal(t, SQLite, Of(sqlx.NewDb(db, DriverSQLite)))
+}
+
+func TestDialect(t *testing.T) {
+	at := time.Date(2026, 10, 19, 17, 4, 5, 678900000, time.FixedZone("WIB", 7*60*60))
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		expected func(t *testing.T, d Dialect)
+	}{
+		{
+			name:    "postgres",
+			dialect: Postgres,
+			expected: func(t *testing.T, d Dialect) {
+				assert.Equal(t, "NOW()", d.Now())
+				assert.Equal(t, at, d.Time(at))
+				assert.Equal(t, pq.Array([]string{"a"}), d.Array([]string{"a"}))
+				assert.Equal(t, "id = ANY($1::uuid[])", d.AnyOf("id", 1, "uuid[]"))
+				assert.Equal(t, "id = ANY($2)", d.AnyOf("id", 2, ""))
+				assert.Equal(t, " FOR UPDATE SKIP LOCKED", d.SkipLocked())
+				assert.Equal(t, " FOR UPDATE", d.ForUpdate())
+			},
+		},
+		{
+			name:    "sqlite",
+			dialect: SQLite,
+			expected: func(t *testing.T, d Dialect) {
+				assert.Equal(t, `strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')`, d.Now())
+				assert.Equal(t, "2026-10-19 10:04:05.678+00:00", d.Time(at))
+				assert.Equal(t, `["a","b"]`, d.Array([]string{"a", "b"}))
+				assert.Equal(t, `[1,2]`, d.Array([]int64{1, 2}))
+				assert.Equal(t, "id IN (SELECT value FROM json_each($1))", d.AnyOf("id", 1, "uuid[]"))
+				assert.Empty(t, d.SkipLocked())
+				assert.Empty(t, d.ForUpdate())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.expected(t, tt.dialect)
+		})
+	}
+}
+
+func TestStringArray_Scan(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      interface{}
+		expected StringArray
+		wantErr  bool
+	}{
+		{name: "postgres array", src: []byte(`{book.created,book.deleted}`), expected: StringArray{"book.created", "book.deleted"}},
+		{name: "json array", src: `["book.created"]`, expected: StringArray{"book.created"}},
+		{name: "empty json array", src: []byte(`[]`), expected: StringArray{}},
+		{name: "null", src: nil, expected: nil},
+		{name: "invalid json", src: `["book`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a StringArray
+			err := a.Scan(tt.src)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, a)
+		})
+	}
+}
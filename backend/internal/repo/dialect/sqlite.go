@@ -0,0 +1,28 @@
+package dialect
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+)
+
+// OpenSQLite opens the sqlite database file at path.
+//
+// Transactions take the write lock when they begin, so a transaction that
+// reads before it writes waits for other writers instead of failing with
+// SQLITE_BUSY, and WAL mode keeps readers from blocking on the writer.
+func OpenSQLite(path string) (*sqlx.DB, error) {
+	if path == "" {
+		return nil, fmt.Errorf("sqlite database path is empty")
+	}
+
+	query := url.Values{}
+	query.Add("_pragma", "foreign_keys(1)")
+	query.Add("_pragma", "busy_timeout(5000)")
+	query.Add("_pragma", "journal_mode(WAL)")
+	query.Set("_txlock", "immediate")
+
+	return sqlx.Connect(DriverSQLite, "file:"+path+"?"+query.Encode())
+}
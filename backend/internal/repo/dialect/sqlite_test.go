@@ -0,0 +1,32 @@
+package dialect
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenSQLite(t *testing.T) {
+	t.Run("empty path", func(t *testing.T) {
+		_, err := OpenSQLite("")
+
+		assert.EqualError(t, err, "sqlite database path is empty")
+	})
+
+	t.Run("opens with foreign keys and WAL", func(t *testing.T) {
+		db, err := OpenSQLite(filepath.Join(t.TempDir(), "booklib.db"))
+		assert.NoError(t, err)
+		defer db.Close()
+
+		assert.Equal(t, SQLite, Of(db))
+
+		var foreignKeys int
+		assert.NoError(t, db.Get(&foreignKeys, `PRAGMA foreign_keys`))
+		assert.Equal(t, 1, foreignKeys)
+
+		var journalMode string
+		assert.NoError(t, db.Get(&journalMode, `PRAGMA journal_mode`))
+		assert.Equal(t, "wal", journalMode)
+	})
+}
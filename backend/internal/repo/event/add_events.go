@@ -0,0 +1,62 @@
+package event
+
+import (
+	domain "booklib/internal/domain/event"
+	"booklib/internal/repo/dialect"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+func (r *repo) AddEvents(ctx context.Context, events []*domain.Event) error {
+	for start := 0; start < len(events); start += maxRowsPerStatement {
+		end := min(start+maxRowsPerStatement, len(events))
+
+		var (
+			chunk  = events[start:end]
+			values = make([]string, 0, len(chunk))
+			args   = make([]interface{}, 0, len(chunk)*6)
+		)
+		for i, ev := range chunk {
+			n := i * 6
+			values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6))
+			args = append(args, ev.Type, ev.AggregateType, ev.AggregateID, nullJSON(ev.Before), nullJSON(ev.After), r.dialect.Time(ev.OccurredAt))
+		}
+
+		query := r.addEventsQuery(strings.Join(values, ", "))
+
+		var ids []int64
+		if err := r.conn(ctx).SelectContext(ctx, &ids, query, args...); err != nil {
+			return err
+		}
+		if len(ids) != len(chunk) {
+			return fmt.Errorf("expected %d event ids, got %d", len(chunk), len(ids))
+		}
+		// ids grow in insertion order, but sqlite does not promise to return
+		// them in that order
+		slices.Sort(ids)
+
+		for i, id := range ids {
+			chunk[i].ID = id
+		}
+	}
+
+	return nil
+}
+
+// addEventsQuery inserts the events in values and returns their ids. The
+// outbox rows are written by the same statement, so an event is never recorded
+// without being queued for publication; sqlite has no data-modifying CTEs and
+// queues them with a trigger instead.
+func (r *repo) addEventsQuery(values string) string {
+	insert := `INSERT INTO events (type, aggregate_type, aggregate_id, before, after, occurred_at) VALUES ` + values
+
+	if r.dialect == dialect.SQLite {
+		return insert + ` RETURNING id`
+	}
+
+	return `WITH inserted AS (` + insert + ` RETURNING id, aggregate_type, aggregate_id), ` +
+		`queued AS (INSERT INTO outbox (event_id, aggregate_type, aggregate_id) SELECT id, aggregate_type, aggregate_id FROM inserted) ` +
+		`SELECT id FROM inserted ORDER BY id`
+}
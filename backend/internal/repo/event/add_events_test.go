@@ -0,0 +1,128 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/event"
+	"booklib/internal/repo/sqltx"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddEvents(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		events      []*domain.Event
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedIDs []int64
+		expectedErr string
+	}{
+		{
+			name: "inserts events and sets their ids",
+			events: []*domain.Event{
+				{Type: domain.TypeBookCreated, AggregateType: domain.AggregateBook, AggregateID: "id-1", After: json.RawMessage(`{"id":"id-1"}`), OccurredAt: now},
+				{Type: domain.TypeBookDeleted, AggregateType: domain.AggregateBook, AggregateID: "id-2", Before: json.RawMessage(`{"id":"id-2"}`), OccurredAt: now},
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`WITH inserted AS \(INSERT INTO events \(type, aggregate_type, aggregate_id, before, after, occurred_at\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\), \(\$7, \$8, \$9, \$10, \$11, \$12\) RETURNING id, aggregate_type, aggregate_id\), queued AS \(INSERT INTO outbox \(event_id, aggregate_type, aggregate_id\) SELECT id, aggregate_type, aggregate_id FROM inserted\) SELECT id FROM inserted ORDER BY id`).
+					WithArgs(
+						domain.TypeBookCreated, domain.AggregateBook, "id-1", nil, []byte(`{"id":"id-1"}`), now,
+						domain.TypeBookDeleted, domain.AggregateBook, "id-2", []byte(`{"id":"id-2"}`), nil, now,
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7).AddRow(8))
+			},
+			expectedIDs: []int64{7, 8},
+		},
+		{
+			name:        "no events",
+			events:      []*domain.Event{},
+			setupMocks:  func(mock sqlmock.Sqlmock) {},
+			expectedIDs: []int64{},
+		},
+		{
+			name: "database error",
+			events: []*domain.Event{
+				{Type: domain.TypeBookCreated, AggregateType: domain.AggregateBook, AggregateID: "id-1", OccurredAt: now},
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO events`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+		{
+			name: "missing returned ids",
+			events: []*domain.Event{
+				{Type: domain.TypeBookCreated, AggregateType: domain.AggregateBook, AggregateID: "id-1", OccurredAt: now},
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO events`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			expectedErr: "expected 1 event ids, got 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+			repo := New(sqlxDB)
+
+			tt.setupMocks(mock)
+
+			err = repo.AddEvents(context.Background(), tt.events)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				ids := make([]int64, 0, len(tt.events))
+				for _, ev := range tt.events {
+					ids = append(ids, ev.ID)
+				}
+				assert.Equal(t, tt.expectedIDs, ids)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAddEvents_JoinsTransactionFromContext(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	repo := New(sqlxDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO events`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectRollback()
+
+	tx, err := sqlxDB.Beginx()
+	assert.NoError(t, err)
+
+	ev := &domain.Event{Type: domain.TypeBookCreated, AggregateType: domain.AggregateBook, AggregateID: "id-1", OccurredAt: time.Now()}
+	err = repo.AddEvents(sqltx.NewContext(context.Background(), tx), []*domain.Event{ev})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), ev.ID)
+
+	// rolling back the change discards the events with it
+	assert.NoError(t, tx.Rollback())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,41 @@
+package event
+
+import (
+	domain "booklib/internal/domain/event"
+	"booklib/internal/repo/dialect"
+	"context"
+)
+
+func (r *repo) GetEventsAfter(ctx context.Context, aggregateType string, afterID int64, limit int) ([]domain.Event, error) {
+	var (
+		query  = r.getEventsAfterQuery()
+		result = []domain.Event{}
+	)
+
+	var events []Event
+	if err := r.conn(ctx).SelectContext(ctx, &events, query, aggregateType, afterID, limit); err != nil {
+		return nil, err
+	}
+
+	for _, ev := range events {
+		result = append(result, *ev.ToDomain())
+	}
+
+	return result, nil
+}
+
+// getEventsAfterQuery reads the events after $2 in commit order. Sqlite runs
+// one write transaction at a time, so its ids already follow that order. A
+// postgres id is taken at insert time instead, and a transaction can commit
+// an event with a lower id after others were read: events are ordered by the
+// transaction that wrote them, and those of transactions that may still be
+// running, from the oldest one on, are held back until it ends.
+func (r *repo) getEventsAfterQuery() string {
+	if r.dialect == dialect.SQLite {
+		return `SELECT ` + columns + ` FROM events WHERE aggregate_type = $1 AND id > $2 ORDER BY id LIMIT $3`
+	}
+
+	return `SELECT ` + columns + ` FROM events WHERE aggregate_type = $1 ` +
+		`AND (xid, id) > (COALESCE((SELECT xid FROM events WHERE id = $2), '0'), $2) ` +
+		`AND xid < pg_snapshot_xmin(pg_current_snapshot()) ORDER BY xid, id LIMIT $3`
+}
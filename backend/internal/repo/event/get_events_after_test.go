@@ -0,0 +1,91 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/event"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEventsAfter(t *testing.T) {
+	columns := []string{"id", "type", "aggregate_type", "aggregate_id", "before", "after", "occurred_at"}
+
+	tests := []struct {
+		name        string
+		afterID     int64
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedIDs []int64
+		expectedErr string
+	}{
+		{
+			name:    "returns events after the given id",
+			afterID: 5,
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(6, domain.TypeBookCreated, domain.AggregateBook, "id-1", nil, []byte(`{"id":"id-1"}`), time.Now()).
+					AddRow(7, domain.TypeBookDeleted, domain.AggregateBook, "id-1", []byte(`{"id":"id-1"}`), nil, time.Now())
+				mock.ExpectQuery(`SELECT id, type, aggregate_type, aggregate_id, before, after, occurred_at FROM events WHERE aggregate_type = \$1 `+
+					`AND \(xid, id\) > \(COALESCE\(\(SELECT xid FROM events WHERE id = \$2\), '0'\), \$2\) `+
+					`AND xid < pg_snapshot_xmin\(pg_current_snapshot\(\)\) ORDER BY xid, id LIMIT \$3`).
+					WithArgs(domain.AggregateBook, int64(5), 10).
+					WillReturnRows(rows)
+			},
+			expectedIDs: []int64{6, 7},
+		},
+		{
+			name:    "no new events",
+			afterID: 7,
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, type, aggregate_type, aggregate_id, before, after, occurred_at FROM events`).
+					WithArgs(domain.AggregateBook, int64(7), 10).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expectedIDs: []int64{},
+		},
+		{
+			name:    "database error",
+			afterID: 0,
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, type, aggregate_type, aggregate_id, before, after, occurred_at FROM events`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+			repo := New(sqlxDB)
+
+			tt.setupMocks(mock)
+
+			events, err := repo.GetEventsAfter(context.Background(), domain.AggregateBook, tt.afterID, 10)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, events)
+			} else {
+				assert.NoError(t, err)
+				ids := make([]int64, 0, len(events))
+				for _, ev := range events {
+					ids = append(ids, ev.ID)
+				}
+				assert.Equal(t, tt.expectedIDs, ids)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package event
+
+import (
+	"booklib/internal/repo/dialect"
+	"context"
+)
+
+func (r *repo) GetLatestEventID(ctx context.Context, aggregateType string) (int64, error) {
+	var (
+		query = r.getLatestEventIDQuery()
+		id    int64
+	)
+
+	if err := r.conn(ctx).GetContext(ctx, &id, query, aggregateType); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// getLatestEventIDQuery returns the last event in the order of
+// getEventsAfterQuery, leaving out those it still holds back.
+func (r *repo) getLatestEventIDQuery() string {
+	if r.dialect == dialect.SQLite {
+		return `SELECT COALESCE(MAX(id), 0) FROM events WHERE aggregate_type = $1`
+	}
+
+	return `SELECT COALESCE((SELECT id FROM events WHERE aggregate_type = $1 ` +
+		`AND xid < pg_snapshot_xmin(pg_current_snapshot()) ORDER BY xid DESC, id DESC LIMIT 1), 0)`
+}
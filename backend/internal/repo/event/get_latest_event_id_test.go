@@ -0,0 +1,66 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "booklib/internal/domain/event"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLatestEventID(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedID  int64
+		expectedErr string
+	}{
+		{
+			name: "returns the newest id",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COALESCE\(\(SELECT id FROM events WHERE aggregate_type = \$1 ` +
+					`AND xid < pg_snapshot_xmin\(pg_current_snapshot\(\)\) ORDER BY xid DESC, id DESC LIMIT 1\), 0\)`).
+					WithArgs(domain.AggregateBook).
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(42))
+			},
+			expectedID: 42,
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COALESCE`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+			repo := New(sqlxDB)
+
+			tt.setupMocks(mock)
+
+			id, err := repo.GetLatestEventID(context.Background(), domain.AggregateBook)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedID, id)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package event
+
+import (
+	domain "booklib/internal/domain/event"
+	"encoding/json"
+	"time"
+)
+
+// columns are the columns of Event, named rather than selected with * so that a
+// column added by a later migration does not break scanning.
+const columns = `id, type, aggregate_type, aggregate_id, before, after, occurred_at`
+
+type Event struct {
+	ID            int64     `db:"id"`
+	Type          string    `db:"type"`
+	AggregateType string    `db:"aggregate_type"`
+	AggregateID   string    `db:"aggregate_id"`
+	Before        []byte    `db:"before"`
+	After         []byte    `db:"after"`
+	OccurredAt    time.Time `db:"occurred_at"`
+}
+
+func (e *Event) ToDomain() *domain.Event {
+	return &domain.Event{
+		ID:            e.ID,
+		Type:          e.Type,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		Before:        json.RawMessage(e.Before),
+		After:         json.RawMessage(e.After),
+		OccurredAt:    e.OccurredAt,
+	}
+}
+
+// nullJSON maps an empty snapshot to NULL instead of an invalid empty document.
+func nullJSON(raw json.RawMessage) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	return []byte(raw)
+}
@@ -0,0 +1,47 @@
+package event
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/event"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_ToDomain(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		model    Event
+		expected *domain.Event
+	}{
+		{
+			name: "maps every field",
+			model: Event{
+				ID: 1, Type: domain.TypeBookUpdated, AggregateType: domain.AggregateBook, AggregateID: "id-1",
+				Before: []byte(`{"year":2020}`), After: []byte(`{"year":2021}`), OccurredAt: now,
+			},
+			expected: &domain.Event{
+				ID: 1, Type: domain.TypeBookUpdated, AggregateType: domain.AggregateBook, AggregateID: "id-1",
+				Before: json.RawMessage(`{"year":2020}`), After: json.RawMessage(`{"year":2021}`), OccurredAt: now,
+			},
+		},
+		{
+			name:  "null snapshots stay empty",
+			model: Event{ID: 2, Type: domain.TypeBookCreated, AggregateType: domain.AggregateBook, AggregateID: "id-2", After: []byte(`{}`), OccurredAt: now},
+			expected: &domain.Event{
+				ID: 2, Type: domain.TypeBookCreated, AggregateType: domain.AggregateBook, AggregateID: "id-2",
+				After: json.RawMessage(`{}`), OccurredAt: now,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.model.ToDomain())
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package event
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/event"
+	"booklib/internal/repo/pgtest"
+	"booklib/internal/repo/sqltx"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepo_Postgres(t *testing.T) {
+	ctx := context.Background()
+
+	db := pgtest.New(t)
+	repo := New(db)
+
+	begin := func(t *testing.T) (context.Context, *sqlx.Tx) {
+		tx, err := db.Beginx()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = tx.Rollback() })
+		return sqltx.NewContext(ctx, tx), tx
+	}
+	add := func(t *testing.T, ctx context.Context, aggregateID string) int64 {
+		events := []*domain.Event{{Type: domain.TypeBookCreated, AggregateType: domain.AggregateBook, AggregateID: aggregateID, OccurredAt: time.Now()}}
+		require.NoError(t, repo.AddEvents(ctx, events))
+		return events[0].ID
+	}
+	read := func(t *testing.T, afterID int64) []string {
+		events, err := repo.GetEventsAfter(ctx, domain.AggregateBook, afterID, 10)
+		require.NoError(t, err)
+		ids := []string{}
+		for _, ev := range events {
+			ids = append(ids, ev.AggregateID)
+		}
+		return ids
+	}
+	// write transactions of other tests sharing the server may hold events
+	// back for a moment too
+	eventually := func(t *testing.T, expected []string, afterID int64) {
+		assert.Eventually(t, func() bool {
+			return slices.Equal(expected, read(t, afterID))
+		}, 5*time.Second, 10*time.Millisecond, "expected %v after %d", expected, afterID)
+	}
+
+	var b int64
+	t.Run("events committed out of id order", func(t *testing.T) {
+		ctxA, txA := begin(t)
+		a := add(t, ctxA, "a")
+
+		ctxB, txB := begin(t)
+		b = add(t, ctxB, "b")
+		require.NoError(t, txB.Commit())
+		require.Less(t, a, b)
+
+		// b is held back while a, with a lower id, can still be committed
+		assert.Empty(t, read(t, 0))
+		latest, err := repo.GetLatestEventID(ctx, domain.AggregateBook)
+		assert.NoError(t, err)
+		assert.Zero(t, latest)
+
+		require.NoError(t, txA.Commit())
+		eventually(t, []string{"a", "b"}, 0)
+		eventually(t, []string{"b"}, a)
+		eventually(t, []string{}, b)
+	})
+
+	t.Run("ids in the reverse order of their transactions", func(t *testing.T) {
+		// c's transaction writes first, but inserts its event after d's
+		ctxC, txC := begin(t)
+		_, err := txC.Exec(`SELECT pg_current_xact_id()`)
+		require.NoError(t, err)
+
+		ctxD, txD := begin(t)
+		d := add(t, ctxD, "d")
+		require.NoError(t, txD.Commit())
+		c := add(t, ctxC, "c")
+		require.Less(t, d, c)
+
+		assert.Empty(t, read(t, b))
+
+		require.NoError(t, txC.Commit())
+		eventually(t, []string{"c", "d"}, b)
+		eventually(t, []string{"d"}, c)
+		eventually(t, []string{}, d)
+
+		latest, err := repo.GetLatestEventID(ctx, domain.AggregateBook)
+		assert.NoError(t, err)
+		assert.Equal(t, d, latest)
+	})
+}
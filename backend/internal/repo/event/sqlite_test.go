@@ -0,0 +1,58 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/event"
+	"booklib/internal/repo/sqlitetest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepo_SQLite(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		occurredAt = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	)
+
+	db := sqlitetest.New(t)
+	repo := New(db)
+
+	id, err := repo.GetLatestEventID(ctx, domain.AggregateBook)
+	assert.NoError(t, err)
+	assert.Zero(t, id)
+
+	events := []*domain.Event{
+		{Type: domain.TypeBookCreated, AggregateType: domain.AggregateBook, AggregateID: "a", After: json.RawMessage(`{"id":"a"}`), OccurredAt: occurredAt},
+		{Type: "other.created", AggregateType: "other", AggregateID: "x", OccurredAt: occurredAt},
+		{Type: domain.TypeBookDeleted, AggregateType: domain.AggregateBook, AggregateID: "a", Before: json.RawMessage(`{"id":"a"}`), OccurredAt: occurredAt},
+	}
+	assert.NoError(t, repo.AddEvents(ctx, events))
+	assert.Equal(t, []int64{1, 2, 3}, []int64{events[0].ID, events[1].ID, events[2].ID})
+
+	// every event is queued for publication by the trigger
+	var queued int
+	assert.NoError(t, db.Get(&queued, `SELECT COUNT(*) FROM outbox`))
+	assert.Equal(t, 3, queued)
+
+	got, err := repo.GetEventsAfter(ctx, domain.AggregateBook, 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, int64(3), got[1].ID)
+	assert.JSONEq(t, `{"id":"a"}`, string(got[0].After))
+	assert.Empty(t, got[0].Before)
+	assert.True(t, occurredAt.Equal(got[0].OccurredAt))
+
+	id, err = repo.GetLatestEventID(ctx, domain.AggregateBook)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), id)
+
+	// a column added by a later migration is not selected
+	db.MustExec(`ALTER TABLE events ADD COLUMN note TEXT`)
+	got, err = repo.GetEventsAfter(ctx, domain.AggregateBook, 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+}
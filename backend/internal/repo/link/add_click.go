@@ -0,0 +1,14 @@
+package link
+
+import (
+	"context"
+	"time"
+)
+
+func (r *repo) AddClick(ctx context.Context, code string, day time.Time) error {
+	query := `INSERT INTO link_clicks (code, day, clicks) VALUES ($1, $2, 1)
+		ON CONFLICT (code, day) DO UPDATE SET clicks = link_clicks.clicks + 1`
+
+	_, err := r.conn(ctx).ExecContext(ctx, query, code, day.UTC().Format(dayLayout))
+	return err
+}
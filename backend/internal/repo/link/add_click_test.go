@@ -0,0 +1,61 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddClick(t *testing.T) {
+	// the day is taken in UTC
+	day := time.Date(2026, 10, 20, 1, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+
+	tests := []struct {
+		name        string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedErr string
+	}{
+		{
+			name: "counts the click",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO link_clicks \(code, day, clicks\) VALUES \(\$1, \$2, 1\)\s+ON CONFLICT \(code, day\) DO UPDATE SET clicks = link_clicks.clicks \+ 1`).
+					WithArgs("dune", "2026-10-19").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO link_clicks`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			err = repo.AddClick(context.Background(), "dune", day)
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package link
+
+import (
+	domain "booklib/internal/domain/link"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+func (r *repo) AddLink(ctx context.Context, l *domain.Link) (*domain.Link, error) {
+	var (
+		// a taken code and a target that already has a shared link both
+		// conflict
+		query = `INSERT INTO links (code, target_url, custom, expires_at) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING RETURNING ` + columns
+		res   Link
+	)
+
+	var expiresAt interface{}
+	if l.ExpiresAt != nil {
+		expiresAt = r.dialect.Time(*l.ExpiresAt)
+	}
+
+	if err := r.conn(ctx).GetContext(ctx, &res, query, l.Code, l.TargetURL, l.Custom, expiresAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return res.ToDomain(), nil
+}
@@ -0,0 +1,92 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/link"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddLink(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		link        *domain.Link
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedNil bool
+		expectedErr string
+	}{
+		{
+			name: "successful add link",
+			link: &domain.Link{Code: "aB3dE5f", TargetURL: "https://example.com/a"},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO links \(code, target_url, custom, expires_at\) VALUES \(\$1, \$2, \$3, \$4\) ON CONFLICT DO NOTHING RETURNING code, target_url, custom, expires_at, created_at`).
+					WithArgs("aB3dE5f", "https://example.com/a", false, nil).
+					WillReturnRows(sqlmock.NewRows(linkColumns).AddRow("aB3dE5f", "https://example.com/a", false, nil, time.Now()))
+			},
+		},
+		{
+			name: "with expiry",
+			link: &domain.Link{Code: "dune", TargetURL: "https://example.com/a", Custom: true, ExpiresAt: &expiresAt},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO links`).
+					WithArgs("dune", "https://example.com/a", true, expiresAt).
+					WillReturnRows(sqlmock.NewRows(linkColumns).AddRow("dune", "https://example.com/a", true, expiresAt, time.Now()))
+			},
+		},
+		{
+			name: "conflict",
+			link: &domain.Link{Code: "dune", TargetURL: "https://example.com/a", Custom: true},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO links`).
+					WillReturnRows(sqlmock.NewRows(linkColumns))
+			},
+			expectedNil: true,
+		},
+		{
+			name: "database error",
+			link: &domain.Link{Code: "dune", TargetURL: "https://example.com/a"},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO links`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			res, err := repo.AddLink(context.Background(), tt.link)
+
+			switch {
+			case tt.expectedErr != "":
+				assert.ErrorContains(t, err, tt.expectedErr)
+				assert.Nil(t, res)
+			case tt.expectedNil:
+				assert.NoError(t, err)
+				assert.Nil(t, res)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tt.link.Code, res.Code)
+				assert.Equal(t, tt.link.ExpiresAt, res.ExpiresAt)
+				assert.False(t, res.CreatedAt.IsZero())
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
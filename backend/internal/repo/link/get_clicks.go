@@ -0,0 +1,23 @@
+package link
+
+import (
+	domain "booklib/internal/domain/link"
+	"context"
+)
+
+func (r *repo) GetClicks(ctx context.Context, code string) ([]domain.DailyClicks, error) {
+	var (
+		query = `SELECT day, clicks FROM link_clicks WHERE code = $1 ORDER BY day`
+		rows  []DailyClicks
+	)
+
+	if err := r.conn(ctx).SelectContext(ctx, &rows, query, code); err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.DailyClicks, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, domain.DailyClicks{Day: row.Day.UTC(), Clicks: row.Clicks})
+	}
+	return res, nil
+}
@@ -0,0 +1,76 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/link"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetClicks(t *testing.T) {
+	day := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expected    []domain.DailyClicks
+		expectedErr string
+	}{
+		{
+			name: "oldest day first",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT day, clicks FROM link_clicks WHERE code = \$1 ORDER BY day`).
+					WithArgs("dune").
+					WillReturnRows(sqlmock.NewRows([]string{"day", "clicks"}).
+						AddRow(day, 3).
+						AddRow(day.AddDate(0, 0, 1), 1))
+			},
+			expected: []domain.DailyClicks{{Day: day, Clicks: 3}, {Day: day.AddDate(0, 0, 1), Clicks: 1}},
+		},
+		{
+			name: "never clicked",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT day, clicks FROM link_clicks`).
+					WillReturnRows(sqlmock.NewRows([]string{"day", "clicks"}))
+			},
+			expected: []domain.DailyClicks{},
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT day, clicks FROM link_clicks`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			res, err := repo.GetClicks(context.Background(), "dune")
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, res)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package link
+
+import (
+	domain "booklib/internal/domain/link"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+func (r *repo) GetLinkByCode(ctx context.Context, code string) (*domain.Link, error) {
+	var (
+		query = `SELECT ` + columns + ` FROM links WHERE code = $1`
+		res   Link
+	)
+
+	if err := r.conn(ctx).GetContext(ctx, &res, query, code); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return res.ToDomain(), nil
+}
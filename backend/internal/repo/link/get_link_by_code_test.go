@@ -0,0 +1,74 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLinkByCode(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMocks   func(mock sqlmock.Sqlmock)
+		expectedCode string
+		expectedErr  string
+	}{
+		{
+			name: "found",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT code, target_url, custom, expires_at, created_at FROM links WHERE code = \$1`).
+					WithArgs("dune").
+					WillReturnRows(sqlmock.NewRows(linkColumns).AddRow("dune", "https://example.com/a", true, nil, time.Now()))
+			},
+			expectedCode: "dune",
+		},
+		{
+			name: "not found",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT code, target_url, custom, expires_at, created_at FROM links WHERE code = \$1`).
+					WithArgs("dune").
+					WillReturnRows(sqlmock.NewRows(linkColumns))
+			},
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT code, target_url, custom, expires_at, created_at FROM links`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			res, err := repo.GetLinkByCode(context.Background(), "dune")
+
+			switch {
+			case tt.expectedErr != "":
+				assert.ErrorContains(t, err, tt.expectedErr)
+				assert.Nil(t, res)
+			case tt.expectedCode == "":
+				assert.NoError(t, err)
+				assert.Nil(t, res)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCode, res.Code)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package link
+
+import (
+	domain "booklib/internal/domain/link"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+func (r *repo) GetSharedLink(ctx context.Context, target string) (*domain.Link, error) {
+	var (
+		query = `SELECT ` + columns + ` FROM links WHERE target_url = $1 AND NOT custom AND expires_at IS NULL`
+		res   Link
+	)
+
+	if err := r.conn(ctx).GetContext(ctx, &res, query, target); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return res.ToDomain(), nil
+}
@@ -0,0 +1,74 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSharedLink(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMocks   func(mock sqlmock.Sqlmock)
+		expectedCode string
+		expectedErr  string
+	}{
+		{
+			name: "found",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT code, target_url, custom, expires_at, created_at FROM links WHERE target_url = \$1 AND NOT custom AND expires_at IS NULL`).
+					WithArgs("https://example.com/a").
+					WillReturnRows(sqlmock.NewRows(linkColumns).AddRow("aB3dE5f", "https://example.com/a", false, nil, time.Now()))
+			},
+			expectedCode: "aB3dE5f",
+		},
+		{
+			name: "not found",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT code, target_url, custom, expires_at, created_at FROM links WHERE target_url = \$1 AND NOT custom AND expires_at IS NULL`).
+					WithArgs("https://example.com/a").
+					WillReturnRows(sqlmock.NewRows(linkColumns))
+			},
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT code, target_url, custom, expires_at, created_at FROM links`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			res, err := repo.GetSharedLink(context.Background(), "https://example.com/a")
+
+			switch {
+			case tt.expectedErr != "":
+				assert.ErrorContains(t, err, tt.expectedErr)
+				assert.Nil(t, res)
+			case tt.expectedCode == "":
+				assert.NoError(t, err)
+				assert.Nil(t, res)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCode, res.Code)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package link
+
+import (
+	"context"
+
+	domain "booklib/internal/domain/link"
+	"booklib/internal/repo/dialect"
+	"booklib/internal/repo/sqltx"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	dayLayout = "2006-01-02"
+)
+
+type repo struct {
+	db      *sqlx.DB
+	dialect dialect.Dialect
+}
+
+func New(db *sqlx.DB) domain.Repository {
+	return &repo{
+		db:      db,
+		dialect: dialect.Of(db),
+	}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repo) conn(ctx context.Context) sqltx.Queryer {
+	return sqltx.Conn(ctx, r.db)
+}
@@ -0,0 +1,39 @@
+package link
+
+import (
+	domain "booklib/internal/domain/link"
+	"database/sql"
+	"time"
+)
+
+// columns are the columns of Link, named rather than selected with * so that a
+// column added by a later migration does not break scanning.
+const columns = `code, target_url, custom, expires_at, created_at`
+
+type Link struct {
+	Code      string       `db:"code"`
+	TargetURL string       `db:"target_url"`
+	Custom    bool         `db:"custom"`
+	ExpiresAt sql.NullTime `db:"expires_at"`
+	CreatedAt time.Time    `db:"created_at"`
+}
+
+func (l *Link) ToDomain() *domain.Link {
+	res := &domain.Link{
+		Code:      l.Code,
+		TargetURL: l.TargetURL,
+		Custom:    l.Custom,
+		CreatedAt: l.CreatedAt,
+	}
+	if l.ExpiresAt.Valid {
+		expiresAt := l.ExpiresAt.Time
+		res.ExpiresAt = &expiresAt
+	}
+
+	return res
+}
+
+type DailyClicks struct {
+	Day    time.Time `db:"day"`
+	Clicks int64     `db:"clicks"`
+}
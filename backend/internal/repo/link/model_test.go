@@ -0,0 +1,43 @@
+package link
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/link"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLink_ToDomain(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		model    Link
+		expected *domain.Link
+	}{
+		{
+			name: "maps every field",
+			model: Link{
+				Code: "dune", TargetURL: "https://example.com/a", Custom: true,
+				ExpiresAt: sql.NullTime{Time: now, Valid: true}, CreatedAt: now,
+			},
+			expected: &domain.Link{
+				Code: "dune", TargetURL: "https://example.com/a", Custom: true, ExpiresAt: &now, CreatedAt: now,
+			},
+		},
+		{
+			name:     "null expiry never expires",
+			model:    Link{Code: "aB3dE5f", TargetURL: "https://example.com/a", CreatedAt: now},
+			expected: &domain.Link{Code: "aB3dE5f", TargetURL: "https://example.com/a", CreatedAt: now},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.model.ToDomain())
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package link
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/link"
+	"booklib/internal/repo/sqlitetest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepo_SQLite(t *testing.T) {
+	ctx := context.Background()
+
+	db := sqlitetest.New(t)
+	// a column added by a later migration is not selected
+	db.MustExec(`ALTER TABLE links ADD COLUMN note TEXT`)
+	repo := New(db)
+
+	shared, err := repo.AddLink(ctx, &domain.Link{Code: "aB3dE5f", TargetURL: "https://example.com/a"})
+	require.NoError(t, err)
+	assert.Equal(t, "aB3dE5f", shared.Code)
+	assert.Nil(t, shared.ExpiresAt)
+	assert.False(t, shared.CreatedAt.IsZero())
+
+	// the target already has a shared link, and the code is taken
+	res, err := repo.AddLink(ctx, &domain.Link{Code: "other", TargetURL: "https://example.com/a"})
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+	res, err = repo.AddLink(ctx, &domain.Link{Code: "aB3dE5f", TargetURL: "https://example.com/b", Custom: true})
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	custom, err := repo.AddLink(ctx, &domain.Link{Code: "dune", TargetURL: "https://example.com/a", Custom: true, ExpiresAt: &expiresAt})
+	require.NoError(t, err)
+	require.NotNil(t, custom.ExpiresAt)
+	assert.True(t, expiresAt.Equal(*custom.ExpiresAt))
+
+	got, err := repo.GetSharedLink(ctx, "https://example.com/a")
+	assert.NoError(t, err)
+	assert.Equal(t, shared, got)
+	got, err = repo.GetSharedLink(ctx, "https://example.com/b")
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = repo.GetLinkByCode(ctx, "dune")
+	assert.NoError(t, err)
+	assert.Equal(t, custom, got)
+	got, err = repo.GetLinkByCode(ctx, "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	day := time.Date(2026, 10, 19, 23, 30, 0, 0, time.UTC)
+	assert.NoError(t, repo.AddClick(ctx, "dune", day))
+	assert.NoError(t, repo.AddClick(ctx, "dune", day.Add(-time.Hour)))
+	assert.NoError(t, repo.AddClick(ctx, "dune", day.Add(time.Hour)))
+	assert.Error(t, repo.AddClick(ctx, "missing", day))
+
+	clicks, err := repo.GetClicks(ctx, "dune")
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.DailyClicks{
+		{Day: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Clicks: 2},
+		{Day: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), Clicks: 1},
+	}, clicks)
+
+	clicks, err = repo.GetClicks(ctx, "aB3dE5f")
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.DailyClicks{}, clicks)
+}
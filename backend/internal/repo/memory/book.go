@@ -0,0 +1,195 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	domain "booklib/internal/domain/book"
+)
+
+type bookRepo struct {
+	store *Store
+}
+
+func NewBookRepository(store *Store) domain.Repository {
+	return &bookRepo{
+		store: store,
+	}
+}
+
+func (st *state) putBook(j *journal, bk domain.Book) {
+	prev, existed := st.books[bk.ID]
+	st.books[bk.ID] = bk
+	j.record(func() {
+		if existed {
+			st.books[bk.ID] = prev
+			return
+		}
+		delete(st.books, bk.ID)
+	})
+}
+
+func (st *state) removeBook(j *journal, id string) bool {
+	prev, existed := st.books[id]
+	if !existed {
+		return false
+	}
+	delete(st.books, id)
+	j.record(func() { st.books[id] = prev })
+	return true
+}
+
+// sortBooks orders books like the postgres repository, by creation then id.
+func sortBooks(books []domain.Book) {
+	slices.SortFunc(books, func(a, b domain.Book) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+}
+
+func (r *bookRepo) AddBook(ctx context.Context, book *domain.Book) (*domain.Book, error) {
+	rows, err := r.AddBooks(ctx, []*domain.Book{book})
+	if err != nil {
+		return nil, err
+	}
+
+	return &rows[0], nil
+}
+
+func (r *bookRepo) AddBooks(ctx context.Context, books []*domain.Book) ([]domain.Book, error) {
+	result := make([]domain.Book, 0, len(books))
+
+	err := r.store.write(ctx, func(st *state, j *journal) error {
+		// rows written together share their timestamps, like NOW() in sql
+		now := r.store.now().UTC()
+		for _, bk := range books {
+			if _, ok := st.books[bk.ID]; ok {
+				return fmt.Errorf("book %s already exists", bk.ID)
+			}
+
+			row := domain.Book{ID: bk.ID, Title: bk.Title, Author: bk.Author, Year: bk.Year, CreatedAt: now, UpdatedAt: now}
+			st.putBook(j, row)
+			result = append(result, row)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *bookRepo) GetAllBooks(ctx context.Context, filter domain.Filter) ([]domain.Book, error) {
+	var result []domain.Book
+
+	r.store.read(ctx, func(st *state) {
+		for _, bk := range st.books {
+			if filter.UpdatedSince != nil && bk.UpdatedAt.Before(*filter.UpdatedSince) {
+				continue
+			}
+			result = append(result, bk)
+		}
+	})
+	sortBooks(result)
+
+	return result, nil
+}
+
+func (r *bookRepo) GetBookByID(ctx context.Context, id string) (*domain.Book, error) {
+	var (
+		bk    domain.Book
+		found bool
+	)
+	r.store.read(ctx, func(st *state) {
+		bk, found = st.books[id]
+	})
+	if !found {
+		return nil, nil
+	}
+
+	return &bk, nil
+}
+
+func (r *bookRepo) GetBooksByIDs(ctx context.Context, ids []string) ([]domain.Book, error) {
+	result := []domain.Book{}
+
+	r.store.read(ctx, func(st *state) {
+		seen := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			if bk, ok := st.books[id]; ok && !seen[id] {
+				seen[id] = true
+				result = append(result, bk)
+			}
+		}
+	})
+	sortBooks(result)
+
+	return result, nil
+}
+
+// GetBooksByIDsForUpdate needs no lock: transactions of the store run one at
+// a time.
+func (r *bookRepo) GetBooksByIDsForUpdate(ctx context.Context, ids []string) ([]domain.Book, error) {
+	return r.GetBooksByIDs(ctx, ids)
+}
+
+func (r *bookRepo) UpdateBook(ctx context.Context, book *domain.Book) (*domain.Book, error) {
+	rows, err := r.UpdateBooks(ctx, []*domain.Book{book})
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+
+	return &rows[0], nil
+}
+
+func (r *bookRepo) UpdateBooks(ctx context.Context, books []*domain.Book) ([]domain.Book, error) {
+	result := make([]domain.Book, 0, len(books))
+
+	err := r.store.write(ctx, func(st *state, j *journal) error {
+		now := r.store.now().UTC()
+		for _, bk := range books {
+			row, ok := st.books[bk.ID]
+			if !ok {
+				continue
+			}
+
+			row.Title, row.Author, row.Year, row.UpdatedAt = bk.Title, bk.Author, bk.Year, now
+			st.putBook(j, row)
+			result = append(result, row)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *bookRepo) DeleteBook(ctx context.Context, id string) error {
+	_, err := r.DeleteBooks(ctx, []string{id})
+	return err
+}
+
+func (r *bookRepo) DeleteBooks(ctx context.Context, ids []string) ([]string, error) {
+	deleted := make([]string, 0, len(ids))
+
+	err := r.store.write(ctx, func(st *state, j *journal) error {
+		for _, id := range ids {
+			if st.removeBook(j, id) {
+				deleted = append(deleted, id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,171 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/book"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// clock returns times one second apart, starting at start.
+func clock(start time.Time) func() time.Time {
+	next := start
+	return func() time.Time {
+		now := next
+		next = next.Add(time.Second)
+		return now
+	}
+}
+
+func TestBookRepository(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		start = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	)
+
+	newRepo := func(t *testing.T) domain.Repository {
+		s := NewStore()
+		s.now = clock(start)
+		repo := NewBookRepository(s)
+
+		// b is added first, so it sorts before a
+		_, err := repo.AddBook(ctx, &domain.Book{ID: "b", Title: "Title B", Author: "Author", Year: 2001})
+		assert.NoError(t, err)
+		_, err = repo.AddBook(ctx, &domain.Book{ID: "a", Title: "Title A", Author: "Author", Year: 2002})
+		assert.NoError(t, err)
+		return repo
+	}
+
+	t.Run("add sets timestamps", func(t *testing.T) {
+		repo := newRepo(t)
+
+		res, err := repo.AddBook(ctx, &domain.Book{ID: "c", Title: "Title C", Author: "Author", Year: 2003})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.Book{ID: "c", Title: "Title C", Author: "Author", Year: 2003, CreatedAt: start.Add(2 * time.Second), UpdatedAt: start.Add(2 * time.Second)}, res)
+	})
+
+	t.Run("add rejects a duplicate id", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.AddBook(ctx, &domain.Book{ID: "a", Title: "Title", Author: "Author"})
+
+		assert.EqualError(t, err, "book a already exists")
+	})
+
+	t.Run("add books is all or nothing", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.AddBooks(ctx, []*domain.Book{
+			{ID: "c", Title: "Title C", Author: "Author"},
+			{ID: "a", Title: "Title", Author: "Author"},
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, []string{"b", "a"}, bookIDs(t, repo))
+	})
+
+	t.Run("books added together share timestamps and sort by id", func(t *testing.T) {
+		repo := NewBookRepository(NewStore())
+
+		rows, err := repo.AddBooks(ctx, []*domain.Book{
+			{ID: "z", Title: "Title", Author: "Author"},
+			{ID: "y", Title: "Title", Author: "Author"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, rows[0].CreatedAt, rows[1].CreatedAt)
+		assert.Equal(t, []string{"y", "z"}, bookIDs(t, repo))
+	})
+
+	t.Run("get all books orders by creation", func(t *testing.T) {
+		assert.Equal(t, []string{"b", "a"}, bookIDs(t, newRepo(t)))
+	})
+
+	t.Run("get all books filters by update time", func(t *testing.T) {
+		repo := newRepo(t)
+		since := start.Add(time.Second)
+
+		books, err := repo.GetAllBooks(ctx, domain.Filter{UpdatedSince: &since})
+
+		assert.NoError(t, err)
+		assert.Len(t, books, 1)
+		assert.Equal(t, "a", books[0].ID)
+	})
+
+	t.Run("get all books without books", func(t *testing.T) {
+		books, err := NewBookRepository(NewStore()).GetAllBooks(ctx, domain.Filter{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, books)
+	})
+
+	t.Run("get book by id", func(t *testing.T) {
+		repo := newRepo(t)
+
+		bk, err := repo.GetBookByID(ctx, "a")
+		assert.NoError(t, err)
+		assert.Equal(t, "Title A", bk.Title)
+
+		bk, err = repo.GetBookByID(ctx, "missing")
+		assert.NoError(t, err)
+		assert.Nil(t, bk)
+	})
+
+	t.Run("get books by ids skips missing ones", func(t *testing.T) {
+		books, err := newRepo(t).GetBooksByIDs(ctx, []string{"a", "missing", "b"})
+
+		assert.NoError(t, err)
+		assert.Len(t, books, 2)
+		assert.Equal(t, "b", books[0].ID)
+		assert.Equal(t, "a", books[1].ID)
+	})
+
+	t.Run("update keeps created at", func(t *testing.T) {
+		repo := newRepo(t)
+
+		res, err := repo.UpdateBook(ctx, &domain.Book{ID: "a", Title: "New Title", Author: "New Author", Year: 2020})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.Book{ID: "a", Title: "New Title", Author: "New Author", Year: 2020, CreatedAt: start.Add(time.Second), UpdatedAt: start.Add(2 * time.Second)}, res)
+	})
+
+	t.Run("update of a missing book", func(t *testing.T) {
+		res, err := newRepo(t).UpdateBook(ctx, &domain.Book{ID: "missing", Title: "Title", Author: "Author"})
+
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("update books returns the rows that existed", func(t *testing.T) {
+		rows, err := newRepo(t).UpdateBooks(ctx, []*domain.Book{
+			{ID: "a", Title: "New A", Author: "Author"},
+			{ID: "missing", Title: "Title", Author: "Author"},
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, rows, 1)
+		assert.Equal(t, "New A", rows[0].Title)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		repo := newRepo(t)
+
+		assert.NoError(t, repo.DeleteBook(ctx, "a"))
+		assert.NoError(t, repo.DeleteBook(ctx, "missing"))
+		assert.Equal(t, []string{"b"}, bookIDs(t, repo))
+	})
+
+	t.Run("delete books returns the ids that existed", func(t *testing.T) {
+		repo := newRepo(t)
+
+		deleted, err := repo.DeleteBooks(ctx, []string{"a", "missing"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a"}, deleted)
+		assert.Equal(t, []string{"b"}, bookIDs(t, repo))
+	})
+}
@@ -0,0 +1,16 @@
+package memory
+
+import (
+	"testing"
+
+	domain "booklib/internal/domain/book"
+	"booklib/internal/domain/transaction"
+	"booklib/internal/repo/repotest"
+)
+
+func TestBookRepository_Conformance(t *testing.T) {
+	repotest.RunBookRepositorySuite(t, func(t *testing.T) (domain.Repository, transaction.Manager) {
+		store := NewStore()
+		return NewBookRepository(store), store
+	})
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+
+	domain "booklib/internal/domain/event"
+)
+
+type eventRepo struct {
+	store *Store
+}
+
+func NewEventRepository(store *Store) domain.Repository {
+	return &eventRepo{
+		store: store,
+	}
+}
+
+func (st *state) appendEvent(j *journal, ev domain.Event) domain.Event {
+	ev.ID = int64(len(st.events)) + 1
+	st.events = append(st.events, ev)
+
+	st.outbox = append(st.outbox, outboxRow{
+		id:            int64(len(st.outbox)) + 1,
+		eventID:       ev.ID,
+		aggregateType: ev.AggregateType,
+		aggregateID:   ev.AggregateID,
+	})
+
+	n, m := len(st.events)-1, len(st.outbox)-1
+	j.record(func() {
+		st.events = st.events[:n]
+		st.outbox = st.outbox[:m]
+	})
+	return ev
+}
+
+func (r *eventRepo) AddEvents(ctx context.Context, events []*domain.Event) error {
+	return r.store.write(ctx, func(st *state, j *journal) error {
+		for _, ev := range events {
+			ev.ID = st.appendEvent(j, *ev).ID
+		}
+		return nil
+	})
+}
+
+func (r *eventRepo) GetEventsAfter(ctx context.Context, aggregateType string, afterID int64, limit int) ([]domain.Event, error) {
+	result := []domain.Event{}
+
+	r.store.read(ctx, func(st *state) {
+		// ids are positions in the log, so the scan can start right after afterID
+		for i := max(afterID, 0); i < int64(len(st.events)) && len(result) < limit; i++ {
+			if st.events[i].AggregateType == aggregateType {
+				result = append(result, st.events[i])
+			}
+		}
+	})
+
+	return result, nil
+}
+
+func (r *eventRepo) GetLatestEventID(ctx context.Context, aggregateType string) (int64, error) {
+	var id int64
+
+	r.store.read(ctx, func(st *state) {
+		for i := len(st.events) - 1; i >= 0; i-- {
+			if st.events[i].AggregateType == aggregateType {
+				id = st.events[i].ID
+				return
+			}
+		}
+	})
+
+	return id, nil
+}
@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"booklib/internal/domain/event"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventRepository(t *testing.T) {
+	ctx := context.Background()
+
+	newEvents := func(aggregateTypes ...string) []*event.Event {
+		events := make([]*event.Event, len(aggregateTypes))
+		for i, at := range aggregateTypes {
+			events[i] = &event.Event{Type: "test", AggregateType: at, AggregateID: "id"}
+		}
+		return events
+	}
+
+	t.Run("add events sets ids and queues them in the outbox", func(t *testing.T) {
+		s := NewStore()
+		repo := NewEventRepository(s)
+
+		events := newEvents(event.AggregateBook, "other", event.AggregateBook)
+		assert.NoError(t, repo.AddEvents(ctx, events))
+
+		assert.Equal(t, []int64{1, 2, 3}, []int64{events[0].ID, events[1].ID, events[2].ID})
+		assert.Len(t, s.state.outbox, 3)
+	})
+
+	t.Run("get events after", func(t *testing.T) {
+		repo := NewEventRepository(NewStore())
+		assert.NoError(t, repo.AddEvents(ctx, newEvents(event.AggregateBook, "other", event.AggregateBook, event.AggregateBook)))
+
+		events, err := repo.GetEventsAfter(ctx, event.AggregateBook, 1, 1)
+		assert.NoError(t, err)
+		assert.Len(t, events, 1)
+		assert.Equal(t, int64(3), events[0].ID)
+
+		events, err = repo.GetEventsAfter(ctx, event.AggregateBook, 4, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("get latest event id", func(t *testing.T) {
+		repo := NewEventRepository(NewStore())
+
+		id, err := repo.GetLatestEventID(ctx, event.AggregateBook)
+		assert.NoError(t, err)
+		assert.Zero(t, id)
+
+		assert.NoError(t, repo.AddEvents(ctx, newEvents(event.AggregateBook, "other")))
+		id, err = repo.GetLatestEventID(ctx, event.AggregateBook)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), id)
+	})
+
+	t.Run("rolled back events leave no trace", func(t *testing.T) {
+		s := NewStore()
+		repo := NewEventRepository(s)
+
+		err := s.WithinTx(ctx, func(ctx context.Context) error {
+			assert.NoError(t, repo.AddEvents(ctx, newEvents(event.AggregateBook)))
+			return errors.New("something went wrong")
+		})
+		assert.Error(t, err)
+
+		events := newEvents(event.AggregateBook)
+		assert.NoError(t, repo.AddEvents(ctx, events))
+		assert.Equal(t, int64(1), events[0].ID)
+		assert.Len(t, s.state.outbox, 1)
+	})
+}
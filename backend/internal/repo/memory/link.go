@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	domain "booklib/internal/domain/link"
+)
+
+type linkRepo struct {
+	store *Store
+}
+
+func NewLinkRepository(store *Store) domain.Repository {
+	return &linkRepo{
+		store: store,
+	}
+}
+
+func (st *state) putLink(j *journal, l domain.Link) {
+	st.links[l.Code] = l
+	j.record(func() { delete(st.links, l.Code) })
+}
+
+func (st *state) addClick(j *journal, code string, day time.Time) {
+	days, ok := st.clicks[code]
+	if !ok {
+		days = make(map[time.Time]int64)
+		st.clicks[code] = days
+	}
+	days[day]++
+	j.record(func() {
+		if days[day]--; days[day] == 0 {
+			delete(days, day)
+		}
+	})
+}
+
+// copyLink keeps callers from sharing the stored expiry.
+func copyLink(l domain.Link) domain.Link {
+	if l.ExpiresAt != nil {
+		expiresAt := *l.ExpiresAt
+		l.ExpiresAt = &expiresAt
+	}
+	return l
+}
+
+func (r *linkRepo) AddLink(ctx context.Context, l *domain.Link) (*domain.Link, error) {
+	var (
+		res   domain.Link
+		added bool
+	)
+
+	err := r.store.write(ctx, func(st *state, j *journal) error {
+		if _, ok := st.links[l.Code]; ok {
+			return nil
+		}
+		if l.Shared() && st.sharedLink(l.TargetURL) != nil {
+			return nil
+		}
+
+		res = copyLink(*l)
+		if res.ExpiresAt != nil {
+			*res.ExpiresAt = res.ExpiresAt.UTC()
+		}
+		res.CreatedAt = r.store.now().UTC()
+		st.putLink(j, res)
+		added = true
+		return nil
+	})
+	if err != nil || !added {
+		return nil, err
+	}
+
+	res = copyLink(res)
+	return &res, nil
+}
+
+func (r *linkRepo) GetLinkByCode(ctx context.Context, code string) (*domain.Link, error) {
+	var (
+		l     domain.Link
+		found bool
+	)
+	r.store.read(ctx, func(st *state) {
+		l, found = st.links[code]
+	})
+	if !found {
+		return nil, nil
+	}
+
+	l = copyLink(l)
+	return &l, nil
+}
+
+func (r *linkRepo) GetSharedLink(ctx context.Context, target string) (*domain.Link, error) {
+	var l *domain.Link
+	r.store.read(ctx, func(st *state) {
+		l = st.sharedLink(target)
+	})
+	if l == nil {
+		return nil, nil
+	}
+
+	res := copyLink(*l)
+	return &res, nil
+}
+
+func (st *state) sharedLink(target string) *domain.Link {
+	for _, l := range st.links {
+		if l.TargetURL == target && l.Shared() {
+			return &l
+		}
+	}
+	return nil
+}
+
+func (r *linkRepo) AddClick(ctx context.Context, code string, day time.Time) error {
+	return r.store.write(ctx, func(st *state, j *journal) error {
+		if _, ok := st.links[code]; !ok {
+			return fmt.Errorf("link %s does not exist", code)
+		}
+
+		st.addClick(j, code, truncateDay(day))
+		return nil
+	})
+}
+
+func (r *linkRepo) GetClicks(ctx context.Context, code string) ([]domain.DailyClicks, error) {
+	result := []domain.DailyClicks{}
+
+	r.store.read(ctx, func(st *state) {
+		for day, clicks := range st.clicks[code] {
+			result = append(result, domain.DailyClicks{Day: day, Clicks: clicks})
+		}
+	})
+	slices.SortFunc(result, func(a, b domain.DailyClicks) int { return a.Day.Compare(b.Day) })
+
+	return result, nil
+}
+
+// truncateDay returns the start of the UTC day of t, as a DATE column keeps
+// it.
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
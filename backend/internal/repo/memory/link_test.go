@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/link"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkRepository(t *testing.T) {
+	var (
+		ctx = context.Background()
+		now = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	)
+
+	newRepo := func(t *testing.T) (*Store, domain.Repository) {
+		s := NewStore()
+		s.now = clock(now)
+		repo := NewLinkRepository(s)
+
+		_, err := repo.AddLink(ctx, &domain.Link{Code: "aB3dE5f", TargetURL: "https://example.com/a"})
+		require.NoError(t, err)
+		return s, repo
+	}
+
+	t.Run("add and get", func(t *testing.T) {
+		_, repo := newRepo(t)
+		expiresAt := now.Add(time.Hour)
+
+		added, err := repo.AddLink(ctx, &domain.Link{Code: "dune", TargetURL: "https://example.com/a", Custom: true, ExpiresAt: &expiresAt})
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(time.Second), added.CreatedAt)
+
+		got, err := repo.GetLinkByCode(ctx, "dune")
+		assert.NoError(t, err)
+		assert.Equal(t, added, got)
+
+		got, err = repo.GetLinkByCode(ctx, "missing")
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("add skips conflicts", func(t *testing.T) {
+		_, repo := newRepo(t)
+
+		res, err := repo.AddLink(ctx, &domain.Link{Code: "aB3dE5f", TargetURL: "https://example.com/b", Custom: true})
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+
+		res, err = repo.AddLink(ctx, &domain.Link{Code: "other", TargetURL: "https://example.com/a"})
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("shared link", func(t *testing.T) {
+		_, repo := newRepo(t)
+		_, err := repo.AddLink(ctx, &domain.Link{Code: "dune", TargetURL: "https://example.com/b", Custom: true})
+		require.NoError(t, err)
+
+		got, err := repo.GetSharedLink(ctx, "https://example.com/a")
+		assert.NoError(t, err)
+		assert.Equal(t, "aB3dE5f", got.Code)
+
+		got, err = repo.GetSharedLink(ctx, "https://example.com/b")
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("clicks by day", func(t *testing.T) {
+		_, repo := newRepo(t)
+
+		assert.NoError(t, repo.AddClick(ctx, "aB3dE5f", now))
+		assert.NoError(t, repo.AddClick(ctx, "aB3dE5f", now.Add(time.Hour)))
+		assert.NoError(t, repo.AddClick(ctx, "aB3dE5f", now.Add(-24*time.Hour)))
+		assert.EqualError(t, repo.AddClick(ctx, "missing", now), "link missing does not exist")
+
+		clicks, err := repo.GetClicks(ctx, "aB3dE5f")
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.DailyClicks{
+			{Day: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), Clicks: 1},
+			{Day: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Clicks: 2},
+		}, clicks)
+	})
+
+	t.Run("rolled back with the transaction", func(t *testing.T) {
+		s, repo := newRepo(t)
+		rollback := errors.New("rollback")
+
+		err := s.WithinTx(ctx, func(ctx context.Context) error {
+			_, err := repo.AddLink(ctx, &domain.Link{Code: "dune", TargetURL: "https://example.com/b", Custom: true})
+			require.NoError(t, err)
+			require.NoError(t, repo.AddClick(ctx, "dune", now))
+			return rollback
+		})
+		assert.ErrorIs(t, err, rollback)
+
+		got, err := repo.GetLinkByCode(ctx, "dune")
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+		clicks, err := repo.GetClicks(ctx, "dune")
+		assert.NoError(t, err)
+		assert.Empty(t, clicks)
+	})
+}
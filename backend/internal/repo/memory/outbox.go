@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	domain "booklib/internal/domain/outbox"
+)
+
+type outboxRepo struct {
+	store *Store
+}
+
+func NewOutboxRepository(store *Store) domain.Repository {
+	return &outboxRepo{
+		store: store,
+	}
+}
+
+func (st *state) setOutboxRow(j *journal, row outboxRow) {
+	i := row.id - 1
+	prev := st.outbox[i]
+	st.outbox[i] = row
+	j.record(func() { st.outbox[i] = prev })
+}
+
+func (r *outboxRepo) ClaimPending(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.Message, error) {
+	result := []domain.Message{}
+
+	err := r.store.write(ctx, func(st *state, j *journal) error {
+		// an aggregate is blocked by its oldest unpublished message, due or not
+		blocked := make(map[[2]string]bool)
+		for _, row := range st.outbox {
+			if len(result) >= limit {
+				break
+			}
+			if row.published {
+				continue
+			}
+
+			key := [2]string{row.aggregateType, row.aggregateID}
+			if blocked[key] {
+				continue
+			}
+			blocked[key] = true
+			if row.nextAttemptAt.After(now) {
+				continue
+			}
+
+			row.nextAttemptAt = leaseUntil
+			st.setOutboxRow(j, row)
+			result = append(result, domain.Message{ID: row.id, Attempts: row.attempts, Event: st.events[row.eventID-1]})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *outboxRepo) MarkPublished(ctx context.Context, ids []int64, at time.Time) error {
+	return r.store.write(ctx, func(st *state, j *journal) error {
+		for _, id := range ids {
+			if id < 1 || id > int64(len(st.outbox)) {
+				continue
+			}
+
+			row := st.outbox[id-1]
+			row.published = true
+			st.setOutboxRow(j, row)
+		}
+		return nil
+	})
+}
+
+func (r *outboxRepo) MarkFailed(ctx context.Context, id int64, lastError string, retryAt time.Time) error {
+	return r.store.write(ctx, func(st *state, j *journal) error {
+		if id < 1 || id > int64(len(st.outbox)) {
+			return nil
+		}
+
+		row := st.outbox[id-1]
+		row.attempts++
+		row.lastError = lastError
+		row.nextAttemptAt = retryAt
+		st.setOutboxRow(j, row)
+		return nil
+	})
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"booklib/internal/domain/event"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxRepository(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		now   = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+		lease = now.Add(time.Minute)
+	)
+
+	setup := func(t *testing.T, aggregateIDs ...string) *Store {
+		s := NewStore()
+		events := make([]*event.Event, len(aggregateIDs))
+		for i, id := range aggregateIDs {
+			events[i] = &event.Event{Type: event.TypeBookUpdated, AggregateType: event.AggregateBook, AggregateID: id}
+		}
+		assert.NoError(t, NewEventRepository(s).AddEvents(ctx, events))
+		return s
+	}
+	claimedIDs := func(t *testing.T, s *Store, at time.Time) []int64 {
+		messages, err := NewOutboxRepository(s).ClaimPending(ctx, at, at.Add(time.Minute), 10)
+		assert.NoError(t, err)
+		ids := []int64{}
+		for _, m := range messages {
+			ids = append(ids, m.ID)
+		}
+		return ids
+	}
+
+	t.Run("claims the oldest message of each aggregate", func(t *testing.T) {
+		s := setup(t, "a", "b", "a", "c")
+
+		messages, err := NewOutboxRepository(s).ClaimPending(ctx, now, lease, 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, messages, 3)
+		assert.Equal(t, "a", messages[0].Event.AggregateID)
+		assert.Equal(t, int64(1), messages[0].Event.ID)
+		assert.Equal(t, []int64{1, 2, 4}, []int64{messages[0].ID, messages[1].ID, messages[2].ID})
+	})
+
+	t.Run("claimed messages are held until the lease ends", func(t *testing.T) {
+		s := setup(t, "a")
+
+		assert.Equal(t, []int64{1}, claimedIDs(t, s, now))
+		assert.Empty(t, claimedIDs(t, s, now))
+		assert.Equal(t, []int64{1}, claimedIDs(t, s, lease))
+	})
+
+	t.Run("publishing unblocks the next message of the aggregate", func(t *testing.T) {
+		s := setup(t, "a", "a")
+		repo := NewOutboxRepository(s)
+
+		assert.Equal(t, []int64{1}, claimedIDs(t, s, now))
+		assert.NoError(t, repo.MarkPublished(ctx, []int64{1}, now))
+		assert.Equal(t, []int64{2}, claimedIDs(t, s, now))
+	})
+
+	t.Run("failed message waits for its retry and blocks its aggregate", func(t *testing.T) {
+		s := setup(t, "a", "a", "b")
+		repo := NewOutboxRepository(s)
+
+		assert.Equal(t, []int64{1, 3}, claimedIDs(t, s, now))
+		assert.NoError(t, repo.MarkPublished(ctx, []int64{3}, now))
+		assert.NoError(t, repo.MarkFailed(ctx, 1, "broker unavailable", now.Add(time.Hour)))
+
+		assert.Empty(t, claimedIDs(t, s, now.Add(2*time.Minute)))
+
+		messages, err := repo.ClaimPending(ctx, now.Add(time.Hour), lease, 10)
+		assert.NoError(t, err)
+		assert.Len(t, messages, 1)
+		assert.Equal(t, 1, messages[0].Attempts)
+	})
+
+	t.Run("unknown ids are ignored", func(t *testing.T) {
+		repo := NewOutboxRepository(setup(t))
+
+		assert.NoError(t, repo.MarkPublished(ctx, []int64{7}, now))
+		assert.NoError(t, repo.MarkFailed(ctx, 7, "error", now))
+	})
+}
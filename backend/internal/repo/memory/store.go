@@ -0,0 +1,156 @@
+// Package memory keeps every aggregate in process memory. It implements the
+// repositories and the transaction manager with the same semantics as the
+// postgres ones, so the server runs without any external dependency. Data is
+// lost when the process exits.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"booklib/internal/domain/book"
+	"booklib/internal/domain/event"
+	"booklib/internal/domain/link"
+	"booklib/internal/domain/transaction"
+	urlprocessor "booklib/internal/domain/url-processor"
+	"booklib/internal/domain/webhook"
+)
+
+type txKey struct{}
+
+// Store holds the state shared by the repositories of this package. It is
+// safe for concurrent use; a transaction holds the store exclusively until it
+// ends.
+type Store struct {
+	mu    sync.Mutex
+	state *state
+	now   func() time.Time
+}
+
+// state is the data of every aggregate. It is only changed through methods
+// that record how to undo the change in a journal.
+type state struct {
+	books map[string]book.Book
+
+	// events and outbox are ordered by ID, which starts at 1
+	events []event.Event
+	outbox []outboxRow
+
+	subscriptions  map[string]webhook.Subscription
+	deliveries     map[int64]webhook.Delivery
+	nextDeliveryID int64
+
+	links map[string]link.Link
+	// clicks counts the clicks of every link by UTC day
+	clicks map[string]map[time.Time]int64
+
+	ruleSets map[string]urlprocessor.RuleSet
+}
+
+type outboxRow struct {
+	id            int64
+	eventID       int64
+	aggregateType string
+	aggregateID   string
+	attempts      int
+	nextAttemptAt time.Time
+	lastError     string
+	published     bool
+}
+
+// txFrame is the unit of work carried by a context.
+type txFrame struct {
+	store   *Store
+	journal *journal
+}
+
+// journal records how to undo the changes of a unit of work.
+type journal struct {
+	undo []func()
+}
+
+func (j *journal) record(undo func()) {
+	j.undo = append(j.undo, undo)
+}
+
+func (j *journal) rollback() {
+	for i := len(j.undo) - 1; i >= 0; i-- {
+		j.undo[i]()
+	}
+	j.undo = nil
+}
+
+func NewStore() *Store {
+	return &Store{
+		state: &state{
+			books:         make(map[string]book.Book),
+			subscriptions: make(map[string]webhook.Subscription),
+			deliveries:    make(map[int64]webhook.Delivery),
+			links:         make(map[string]link.Link),
+			clicks:        make(map[string]map[time.Time]int64),
+			ruleSets:      make(map[string]urlprocessor.RuleSet),
+		},
+		now: time.Now,
+	}
+}
+
+var _ transaction.Manager = (*Store)(nil)
+
+// WithinTx runs fn with the store locked and undoes its changes when fn fails.
+// A nested call undoes only its own changes. Repositories must be called with
+// the ctx passed to fn; calling them with another ctx from within fn
+// deadlocks.
+func (s *Store) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	parent := s.frame(ctx)
+	if parent == nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+
+	j := &journal{}
+	defer func() {
+		if p := recover(); p != nil {
+			j.rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, &txFrame{store: s, journal: j})); err != nil {
+		j.rollback()
+		return err
+	}
+
+	// the outer unit of work may still roll back what this one did
+	if parent != nil {
+		parent.journal.undo = append(parent.journal.undo, j.undo...)
+	}
+	return nil
+}
+
+// frame returns the unit of work of s carried by ctx, if any. Its transaction
+// already holds the lock.
+func (s *Store) frame(ctx context.Context) *txFrame {
+	f, ok := ctx.Value(txKey{}).(*txFrame)
+	if !ok || f.store != s {
+		return nil
+	}
+	return f
+}
+
+// read runs fn against the current state.
+func (s *Store) read(ctx context.Context, fn func(st *state)) {
+	if s.frame(ctx) == nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	fn(s.state)
+}
+
+// write runs fn against the current state. Like a single SQL statement, the
+// changes of a failing fn are not kept.
+func (s *Store) write(ctx context.Context, fn func(st *state, j *journal) error) error {
+	return s.WithinTx(ctx, func(ctx context.Context) error {
+		return fn(s.state, s.frame(ctx).journal)
+	})
+}
@@ -0,0 +1,151 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"booklib/internal/domain/book"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_WithinTx(t *testing.T) {
+	add := func(repo book.Repository, id string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			_, err := repo.AddBook(ctx, &book.Book{ID: id, Title: "Title", Author: "Author"})
+			return err
+		}
+	}
+
+	tests := []struct {
+		name        string
+		fn          func(s *Store, repo book.Repository) func(ctx context.Context) error
+		expectedIDs []string
+		expectedErr string
+	}{
+		{
+			name: "keeps the changes when fn succeeds",
+			fn: func(s *Store, repo book.Repository) func(ctx context.Context) error {
+				return add(repo, "id-1")
+			},
+			expectedIDs: []string{"id-0", "id-1"},
+		},
+		{
+			name: "undoes the changes when fn fails",
+			fn: func(s *Store, repo book.Repository) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := add(repo, "id-1")(ctx); err != nil {
+						return err
+					}
+					if err := repo.DeleteBook(ctx, "id-0"); err != nil {
+						return err
+					}
+					return errors.New("something went wrong")
+				}
+			},
+			expectedIDs: []string{"id-0"},
+			expectedErr: "something went wrong",
+		},
+		{
+			name: "nested failure only undoes its own changes",
+			fn: func(s *Store, repo book.Repository) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := add(repo, "id-1")(ctx); err != nil {
+						return err
+					}
+					err := s.WithinTx(ctx, func(ctx context.Context) error {
+						if err := add(repo, "id-2")(ctx); err != nil {
+							return err
+						}
+						return errors.New("inner failed")
+					})
+					assert.EqualError(t, err, "inner failed")
+					return nil
+				}
+			},
+			expectedIDs: []string{"id-0", "id-1"},
+		},
+		{
+			name: "outer failure undoes committed nested changes",
+			fn: func(s *Store, repo book.Repository) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := s.WithinTx(ctx, add(repo, "id-1")); err != nil {
+						return err
+					}
+					return errors.New("outer failed")
+				}
+			},
+			expectedIDs: []string{"id-0"},
+			expectedErr: "outer failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStore()
+			repo := NewBookRepository(s)
+			assert.NoError(t, add(repo, "id-0")(context.Background()))
+
+			err := s.WithinTx(context.Background(), tt.fn(s, repo))
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.ElementsMatch(t, tt.expectedIDs, bookIDs(t, repo))
+		})
+	}
+}
+
+func TestStore_WithinTx_Panic(t *testing.T) {
+	s := NewStore()
+	repo := NewBookRepository(s)
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = s.WithinTx(context.Background(), func(ctx context.Context) error {
+			_, _ = repo.AddBook(ctx, &book.Book{ID: "id-1", Title: "Title", Author: "Author"})
+			panic("boom")
+		})
+	})
+
+	// the lock was released and the change undone
+	assert.Empty(t, bookIDs(t, repo))
+}
+
+func TestStore_Concurrency(t *testing.T) {
+	s := NewStore()
+	repo := NewBookRepository(s)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bk, err := book.NewBook("Title", "Author", 2020)
+			assert.NoError(t, err)
+			assert.NoError(t, s.WithinTx(context.Background(), func(ctx context.Context) error {
+				_, err := repo.AddBook(ctx, bk)
+				return err
+			}))
+			_, err = repo.GetAllBooks(context.Background(), book.Filter{})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, bookIDs(t, repo), 50)
+}
+
+func bookIDs(t *testing.T, repo book.Repository) []string {
+	books, err := repo.GetAllBooks(context.Background(), book.Filter{})
+	assert.NoError(t, err)
+
+	ids := make([]string, 0, len(books))
+	for _, bk := range books {
+		ids = append(ids, bk.ID)
+	}
+	return ids
+}
@@ -0,0 +1,147 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	domain "booklib/internal/domain/url-processor"
+)
+
+type ruleSetRepo struct {
+	store *Store
+}
+
+func NewRuleSetRepository(store *Store) domain.RuleSetRepository {
+	return &ruleSetRepo{
+		store: store,
+	}
+}
+
+func (st *state) putRuleSet(j *journal, rs domain.RuleSet) {
+	prev, existed := st.ruleSets[rs.ID]
+	st.ruleSets[rs.ID] = rs
+	j.record(func() {
+		if existed {
+			st.ruleSets[rs.ID] = prev
+			return
+		}
+		delete(st.ruleSets, rs.ID)
+	})
+}
+
+func (st *state) removeRuleSet(j *journal, id string) {
+	prev, existed := st.ruleSets[id]
+	if !existed {
+		return
+	}
+	delete(st.ruleSets, id)
+	j.record(func() { st.ruleSets[id] = prev })
+}
+
+// copyRuleSet keeps callers from sharing the stored hosts and steps.
+func copyRuleSet(rs domain.RuleSet) domain.RuleSet {
+	rs.Hosts = append([]string{}, rs.Hosts...)
+
+	operations := make(map[string][]domain.Step, len(rs.Operations))
+	for name, steps := range rs.Operations {
+		copied := make([]domain.Step, len(steps))
+		for i, s := range steps {
+			s.Params = slices.Clone(s.Params)
+			copied[i] = s
+		}
+		operations[name] = copied
+	}
+	rs.Operations = operations
+
+	return rs
+}
+
+func (r *ruleSetRepo) AddRuleSet(ctx context.Context, rs *domain.RuleSet) (*domain.RuleSet, error) {
+	var res domain.RuleSet
+
+	err := r.store.write(ctx, func(st *state, j *journal) error {
+		if _, ok := st.ruleSets[rs.ID]; ok {
+			return fmt.Errorf("url rule set %s already exists", rs.ID)
+		}
+
+		now := r.store.now().UTC()
+		res = copyRuleSet(*rs)
+		res.CreatedAt, res.UpdatedAt = now, now
+		st.putRuleSet(j, res)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res = copyRuleSet(res)
+	return &res, nil
+}
+
+func (r *ruleSetRepo) GetRuleSets(ctx context.Context) ([]domain.RuleSet, error) {
+	result := []domain.RuleSet{}
+
+	r.store.read(ctx, func(st *state) {
+		for _, rs := range st.ruleSets {
+			result = append(result, copyRuleSet(rs))
+		}
+	})
+	slices.SortFunc(result, func(a, b domain.RuleSet) int {
+		if c := cmp.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return result, nil
+}
+
+func (r *ruleSetRepo) GetRuleSetByID(ctx context.Context, id string) (*domain.RuleSet, error) {
+	var (
+		rs    domain.RuleSet
+		found bool
+	)
+	r.store.read(ctx, func(st *state) {
+		rs, found = st.ruleSets[id]
+	})
+	if !found {
+		return nil, nil
+	}
+
+	rs = copyRuleSet(rs)
+	return &rs, nil
+}
+
+func (r *ruleSetRepo) UpdateRuleSet(ctx context.Context, rs *domain.RuleSet) (*domain.RuleSet, error) {
+	var (
+		res   domain.RuleSet
+		found bool
+	)
+
+	err := r.store.write(ctx, func(st *state, j *journal) error {
+		var prev domain.RuleSet
+		if prev, found = st.ruleSets[rs.ID]; !found {
+			return nil
+		}
+
+		res = copyRuleSet(*rs)
+		res.CreatedAt, res.UpdatedAt = prev.CreatedAt, r.store.now().UTC()
+		st.putRuleSet(j, res)
+		return nil
+	})
+	if err != nil || !found {
+		return nil, err
+	}
+
+	res = copyRuleSet(res)
+	return &res, nil
+}
+
+func (r *ruleSetRepo) DeleteRuleSet(ctx context.Context, id string) error {
+	return r.store.write(ctx, func(st *state, j *journal) error {
+		st.removeRuleSet(j, id)
+		return nil
+	})
+}
@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/url-processor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleSetRepository(t *testing.T) {
+	var (
+		ctx = context.Background()
+		now = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+		ops = map[string][]domain.Step{"canonical": {{Type: domain.StepKeepQuery, Params: []string{"page"}}}}
+	)
+
+	newRepo := func(t *testing.T) (*Store, domain.RuleSetRepository) {
+		s := NewStore()
+		s.now = clock(now)
+		repo := NewRuleSetRepository(s)
+
+		_, err := repo.AddRuleSet(ctx, &domain.RuleSet{ID: "rs-1", Name: "shop", Hosts: []string{"example.com"}, Operations: ops, Active: true})
+		require.NoError(t, err)
+		return s, repo
+	}
+
+	t.Run("add and get", func(t *testing.T) {
+		_, repo := newRepo(t)
+
+		added, err := repo.AddRuleSet(ctx, &domain.RuleSet{ID: "rs-2", Name: "blog", Hosts: []string{"*.example.com"}, Operations: ops})
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(time.Second), added.CreatedAt)
+
+		_, err = repo.AddRuleSet(ctx, &domain.RuleSet{ID: "rs-2", Name: "again"})
+		assert.EqualError(t, err, "url rule set rs-2 already exists")
+
+		got, err := repo.GetRuleSetByID(ctx, "rs-2")
+		assert.NoError(t, err)
+		assert.Equal(t, added, got)
+
+		sets, err := repo.GetRuleSets(ctx)
+		assert.NoError(t, err)
+		require.Len(t, sets, 2)
+		assert.Equal(t, "blog", sets[0].Name)
+		assert.Equal(t, "shop", sets[1].Name)
+
+		got, err = repo.GetRuleSetByID(ctx, "missing")
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("returned rule sets are copies", func(t *testing.T) {
+		_, repo := newRepo(t)
+
+		got, err := repo.GetRuleSetByID(ctx, "rs-1")
+		require.NoError(t, err)
+		got.Hosts[0] = "changed.com"
+		got.Operations["canonical"][0].Params[0] = "changed"
+
+		got, err = repo.GetRuleSetByID(ctx, "rs-1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"example.com"}, got.Hosts)
+		assert.Equal(t, ops, got.Operations)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		_, repo := newRepo(t)
+
+		updated, err := repo.UpdateRuleSet(ctx, &domain.RuleSet{ID: "rs-1", Name: "shop", Hosts: []string{"*"}, Priority: 3, Operations: ops})
+		require.NoError(t, err)
+		assert.Equal(t, now, updated.CreatedAt)
+		assert.Equal(t, now.Add(time.Second), updated.UpdatedAt)
+		assert.Equal(t, 3, updated.Priority)
+		assert.False(t, updated.Active)
+
+		updated, err = repo.UpdateRuleSet(ctx, &domain.RuleSet{ID: "missing"})
+		assert.NoError(t, err)
+		assert.Nil(t, updated)
+	})
+
+	t.Run("delete rolled back with the transaction", func(t *testing.T) {
+		s, repo := newRepo(t)
+		rollback := errors.New("rollback")
+
+		err := s.WithinTx(ctx, func(ctx context.Context) error {
+			require.NoError(t, repo.DeleteRuleSet(ctx, "rs-1"))
+			return rollback
+		})
+		assert.ErrorIs(t, err, rollback)
+
+		got, err := repo.GetRuleSetByID(ctx, "rs-1")
+		assert.NoError(t, err)
+		assert.NotNil(t, got)
+
+		assert.NoError(t, repo.DeleteRuleSet(ctx, "rs-1"))
+		got, err = repo.GetRuleSetByID(ctx, "rs-1")
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,303 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	domain "booklib/internal/domain/webhook"
+)
+
+const (
+	defaultDeliveriesLimit = 100
+)
+
+type webhookRepo struct {
+	store *Store
+}
+
+func NewWebhookRepository(store *Store) domain.Repository {
+	return &webhookRepo{
+		store: store,
+	}
+}
+
+func (st *state) putSubscription(j *journal, sub domain.Subscription) {
+	prev, existed := st.subscriptions[sub.ID]
+	st.subscriptions[sub.ID] = sub
+	j.record(func() {
+		if existed {
+			st.subscriptions[sub.ID] = prev
+			return
+		}
+		delete(st.subscriptions, sub.ID)
+	})
+}
+
+func (st *state) removeSubscription(j *journal, id string) {
+	prev, existed := st.subscriptions[id]
+	if !existed {
+		return
+	}
+	delete(st.subscriptions, id)
+	j.record(func() { st.subscriptions[id] = prev })
+
+	// deliveries go with their subscription, like ON DELETE CASCADE
+	for _, d := range st.deliveries {
+		if d.SubscriptionID == id {
+			st.removeDelivery(j, d.ID)
+		}
+	}
+}
+
+func (st *state) putDelivery(j *journal, d domain.Delivery) {
+	prev, existed := st.deliveries[d.ID]
+	st.deliveries[d.ID] = d
+	j.record(func() {
+		if existed {
+			st.deliveries[d.ID] = prev
+			return
+		}
+		delete(st.deliveries, d.ID)
+	})
+}
+
+func (st *state) removeDelivery(j *journal, id int64) {
+	prev := st.deliveries[id]
+	delete(st.deliveries, id)
+	j.record(func() { st.deliveries[id] = prev })
+}
+
+func (st *state) nextDelivery(j *journal) int64 {
+	st.nextDeliveryID++
+	j.record(func() { st.nextDeliveryID-- })
+	return st.nextDeliveryID
+}
+
+// copySubscription keeps callers from sharing the stored event types.
+func copySubscription(sub domain.Subscription) domain.Subscription {
+	sub.EventTypes = append([]string{}, sub.EventTypes...)
+	return sub
+}
+
+func (r *webhookRepo) AddSubscription(ctx context.Context, sub *domain.Subscription) (*domain.Subscription, error) {
+	var res domain.Subscription
+
+	err := r.store.write(ctx, func(st *state, j *journal) error {
+		if _, ok := st.subscriptions[sub.ID]; ok {
+			return fmt.Errorf("subscription %s already exists", sub.ID)
+		}
+
+		now := r.store.now().UTC()
+		res = copySubscription(*sub)
+		res.CreatedAt, res.UpdatedAt = now, now
+		st.putSubscription(j, res)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res = copySubscription(res)
+	return &res, nil
+}
+
+func (r *webhookRepo) GetSubscriptions(ctx context.Context) ([]domain.Subscription, error) {
+	result := []domain.Subscription{}
+
+	r.store.read(ctx, func(st *state) {
+		for _, sub := range st.subscriptions {
+			result = append(result, copySubscription(sub))
+		}
+	})
+	slices.SortFunc(result, func(a, b domain.Subscription) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return result, nil
+}
+
+func (r *webhookRepo) GetSubscriptionByID(ctx context.Context, id string) (*domain.Subscription, error) {
+	var (
+		sub   domain.Subscription
+		found bool
+	)
+	r.store.read(ctx, func(st *state) {
+		sub, found = st.subscriptions[id]
+	})
+	if !found {
+		return nil, nil
+	}
+
+	sub = copySubscription(sub)
+	return &sub, nil
+}
+
+func (r *webhookRepo) UpdateSubscription(ctx context.Context, sub *domain.Subscription) (*domain.Subscription, error) {
+	var (
+		res   domain.Subscription
+		found bool
+	)
+
+	err := r.store.write(ctx, func(st *state, j *journal) error {
+		var prev domain.Subscription
+		if prev, found = st.subscriptions[sub.ID]; !found {
+			return nil
+		}
+
+		res = copySubscription(*sub)
+		res.CreatedAt, res.UpdatedAt = prev.CreatedAt, r.store.now().UTC()
+		st.putSubscription(j, res)
+		return nil
+	})
+	if err != nil || !found {
+		return nil, err
+	}
+
+	res = copySubscription(res)
+	return &res, nil
+}
+
+func (r *webhookRepo) DeleteSubscription(ctx context.Context, id string) error {
+	return r.store.write(ctx, func(st *state, j *journal) error {
+		st.removeSubscription(j, id)
+		return nil
+	})
+}
+
+// AddDeliveries skips the deliveries of an event already queued for the
+// subscription, leaving their ID zero, like the unique index of the database.
+func (r *webhookRepo) AddDeliveries(ctx context.Context, deliveries []*domain.Delivery) error {
+	type key struct {
+		subscriptionID string
+		eventID        int64
+	}
+
+	return r.store.write(ctx, func(st *state, j *journal) error {
+		queued := make(map[key]bool, len(st.deliveries))
+		for _, d := range st.deliveries {
+			queued[key{d.SubscriptionID, d.EventID}] = true
+		}
+
+		now := r.store.now().UTC()
+		for _, d := range deliveries {
+			if _, ok := st.subscriptions[d.SubscriptionID]; !ok {
+				return fmt.Errorf("subscription %s does not exist", d.SubscriptionID)
+			}
+			k := key{d.SubscriptionID, d.EventID}
+			if queued[k] {
+				d.ID = 0
+				continue
+			}
+			queued[k] = true
+
+			row := domain.Delivery{
+				ID:             st.nextDelivery(j),
+				SubscriptionID: d.SubscriptionID,
+				EventID:        d.EventID,
+				EventType:      d.EventType,
+				Payload:        d.Payload,
+				Status:         d.Status,
+				NextAttemptAt:  d.NextAttemptAt,
+				CreatedAt:      now,
+				UpdatedAt:      now,
+			}
+			st.putDelivery(j, row)
+			d.ID = row.ID
+		}
+		return nil
+	})
+}
+
+func (r *webhookRepo) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.Delivery, error) {
+	result := []domain.Delivery{}
+
+	err := r.store.write(ctx, func(st *state, j *journal) error {
+		var due []domain.Delivery
+		for _, d := range st.deliveries {
+			if d.Status == domain.DeliveryStatusPending && !d.NextAttemptAt.After(now) {
+				due = append(due, d)
+			}
+		}
+		slices.SortFunc(due, func(a, b domain.Delivery) int {
+			if c := a.NextAttemptAt.Compare(b.NextAttemptAt); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.ID, b.ID)
+		})
+
+		updatedAt := r.store.now().UTC()
+		for _, d := range due[:min(limit, len(due))] {
+			d.NextAttemptAt, d.UpdatedAt = leaseUntil, updatedAt
+			st.putDelivery(j, d)
+			result = append(result, d)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *webhookRepo) GetDeliveryByID(ctx context.Context, id int64) (*domain.Delivery, error) {
+	var (
+		d     domain.Delivery
+		found bool
+	)
+	r.store.read(ctx, func(st *state) {
+		d, found = st.deliveries[id]
+	})
+	if !found {
+		return nil, nil
+	}
+
+	return &d, nil
+}
+
+func (r *webhookRepo) GetDeliveries(ctx context.Context, filter domain.DeliveryFilter) ([]domain.Delivery, error) {
+	result := []domain.Delivery{}
+
+	r.store.read(ctx, func(st *state) {
+		for _, d := range st.deliveries {
+			if filter.SubscriptionID != "" && d.SubscriptionID != filter.SubscriptionID {
+				continue
+			}
+			if filter.Status != "" && d.Status != filter.Status {
+				continue
+			}
+			result = append(result, d)
+		}
+	})
+
+	// newest first, which is what a delivery log is read for
+	slices.SortFunc(result, func(a, b domain.Delivery) int { return cmp.Compare(b.ID, a.ID) })
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultDeliveriesLimit
+	}
+
+	return result[:min(limit, len(result))], nil
+}
+
+func (r *webhookRepo) UpdateDelivery(ctx context.Context, d *domain.Delivery) error {
+	return r.store.write(ctx, func(st *state, j *journal) error {
+		row, ok := st.deliveries[d.ID]
+		if !ok {
+			return nil
+		}
+
+		row.Status, row.Attempts, row.NextAttemptAt = d.Status, d.Attempts, d.NextAttemptAt
+		row.LastError, row.ResponseStatus = d.LastError, d.ResponseStatus
+		row.UpdatedAt = r.store.now().UTC()
+		st.putDelivery(j, row)
+		return nil
+	})
+}
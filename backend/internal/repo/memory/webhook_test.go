@@ -0,0 +1,181 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/webhook"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookRepository(t *testing.T) {
+	var (
+		ctx = context.Background()
+		now = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	)
+
+	newRepo := func(t *testing.T) domain.Repository {
+		s := NewStore()
+		s.now = clock(now)
+		repo := NewWebhookRepository(s)
+
+		_, err := repo.AddSubscription(ctx, &domain.Subscription{ID: "sub-1", URL: "https://example.com/hook", EventTypes: []string{"book.created"}, Secret: "secret", Active: true})
+		assert.NoError(t, err)
+		_, err = repo.AddSubscription(ctx, &domain.Subscription{ID: "sub-2", URL: "https://example.com/other", Secret: "secret", Active: true})
+		assert.NoError(t, err)
+		return repo
+	}
+	addDeliveries := func(t *testing.T, repo domain.Repository, subscriptionIDs ...string) []*domain.Delivery {
+		deliveries := make([]*domain.Delivery, len(subscriptionIDs))
+		for i, id := range subscriptionIDs {
+			deliveries[i] = &domain.Delivery{SubscriptionID: id, EventID: int64(i + 1), EventType: "book.created", Status: domain.DeliveryStatusPending, NextAttemptAt: now}
+		}
+		assert.NoError(t, repo.AddDeliveries(ctx, deliveries))
+		return deliveries
+	}
+
+	t.Run("subscriptions are listed in creation order", func(t *testing.T) {
+		subs, err := newRepo(t).GetSubscriptions(ctx)
+
+		assert.NoError(t, err)
+		assert.Len(t, subs, 2)
+		assert.Equal(t, "sub-1", subs[0].ID)
+		assert.Equal(t, []string{}, subs[1].EventTypes)
+	})
+
+	t.Run("add rejects a duplicate id", func(t *testing.T) {
+		_, err := newRepo(t).AddSubscription(ctx, &domain.Subscription{ID: "sub-1"})
+
+		assert.EqualError(t, err, "subscription sub-1 already exists")
+	})
+
+	t.Run("returned subscriptions do not share state", func(t *testing.T) {
+		repo := newRepo(t)
+
+		sub, err := repo.GetSubscriptionByID(ctx, "sub-1")
+		assert.NoError(t, err)
+		sub.EventTypes[0] = "changed"
+
+		sub, err = repo.GetSubscriptionByID(ctx, "sub-1")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"book.created"}, sub.EventTypes)
+	})
+
+	t.Run("missing subscription", func(t *testing.T) {
+		repo := newRepo(t)
+
+		sub, err := repo.GetSubscriptionByID(ctx, "missing")
+		assert.NoError(t, err)
+		assert.Nil(t, sub)
+
+		sub, err = repo.UpdateSubscription(ctx, &domain.Subscription{ID: "missing"})
+		assert.NoError(t, err)
+		assert.Nil(t, sub)
+	})
+
+	t.Run("update keeps created at", func(t *testing.T) {
+		repo := newRepo(t)
+
+		sub, err := repo.UpdateSubscription(ctx, &domain.Subscription{ID: "sub-1", URL: "https://example.com/new", Active: false})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/new", sub.URL)
+		assert.Equal(t, now, sub.CreatedAt)
+		assert.Equal(t, now.Add(2*time.Second), sub.UpdatedAt)
+	})
+
+	t.Run("deliveries need an existing subscription", func(t *testing.T) {
+		repo := newRepo(t)
+
+		err := repo.AddDeliveries(ctx, []*domain.Delivery{{SubscriptionID: "sub-1"}, {SubscriptionID: "missing"}})
+
+		assert.EqualError(t, err, "subscription missing does not exist")
+		deliveries, err := repo.GetDeliveries(ctx, domain.DeliveryFilter{})
+		assert.NoError(t, err)
+		assert.Empty(t, deliveries)
+	})
+
+	t.Run("an event is queued once per subscription", func(t *testing.T) {
+		repo := newRepo(t)
+		first := addDeliveries(t, repo, "sub-1", "sub-2")
+
+		again := []*domain.Delivery{
+			{SubscriptionID: "sub-1", EventID: 1, Status: domain.DeliveryStatusPending, NextAttemptAt: now},
+			{SubscriptionID: "sub-1", EventID: 3, Status: domain.DeliveryStatusPending, NextAttemptAt: now},
+			{SubscriptionID: "sub-1", EventID: 3, Status: domain.DeliveryStatusPending, NextAttemptAt: now},
+		}
+		assert.NoError(t, repo.AddDeliveries(ctx, again))
+
+		assert.Zero(t, again[0].ID)
+		assert.Greater(t, again[1].ID, first[1].ID)
+		assert.Zero(t, again[2].ID)
+		deliveries, err := repo.GetDeliveries(ctx, domain.DeliveryFilter{})
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 3)
+	})
+
+	t.Run("deleting a subscription deletes its deliveries", func(t *testing.T) {
+		repo := newRepo(t)
+		addDeliveries(t, repo, "sub-1", "sub-2", "sub-1")
+
+		assert.NoError(t, repo.DeleteSubscription(ctx, "sub-1"))
+
+		deliveries, err := repo.GetDeliveries(ctx, domain.DeliveryFilter{})
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 1)
+		assert.Equal(t, "sub-2", deliveries[0].SubscriptionID)
+	})
+
+	t.Run("get deliveries filters and returns the newest first", func(t *testing.T) {
+		repo := newRepo(t)
+		added := addDeliveries(t, repo, "sub-1", "sub-2", "sub-1", "sub-1")
+
+		deliveries, err := repo.GetDeliveries(ctx, domain.DeliveryFilter{SubscriptionID: "sub-1", Limit: 2})
+
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 2)
+		assert.Equal(t, added[3].ID, deliveries[0].ID)
+		assert.Equal(t, added[2].ID, deliveries[1].ID)
+	})
+
+	t.Run("claim leases due deliveries in order", func(t *testing.T) {
+		repo := newRepo(t)
+		added := addDeliveries(t, repo, "sub-1", "sub-2", "sub-1")
+		lease := now.Add(time.Minute)
+
+		claimed, err := repo.ClaimDueDeliveries(ctx, now, lease, 2)
+		assert.NoError(t, err)
+		assert.Len(t, claimed, 2)
+		assert.Equal(t, added[0].ID, claimed[0].ID)
+		assert.Equal(t, lease, claimed[0].NextAttemptAt)
+
+		claimed, err = repo.ClaimDueDeliveries(ctx, now, lease, 10)
+		assert.NoError(t, err)
+		assert.Len(t, claimed, 1)
+		assert.Equal(t, added[2].ID, claimed[0].ID)
+	})
+
+	t.Run("update delivery", func(t *testing.T) {
+		repo := newRepo(t)
+		added := addDeliveries(t, repo, "sub-1")
+
+		d := *added[0]
+		d.Status, d.Attempts, d.ResponseStatus = domain.DeliveryStatusSucceeded, 1, 204
+		assert.NoError(t, repo.UpdateDelivery(ctx, &d))
+
+		res, err := repo.GetDeliveryByID(ctx, d.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DeliveryStatusSucceeded, res.Status)
+		assert.Equal(t, 204, res.ResponseStatus)
+
+		claimed, err := repo.ClaimDueDeliveries(ctx, now, now, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, claimed)
+
+		res, err = repo.GetDeliveryByID(ctx, 99)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
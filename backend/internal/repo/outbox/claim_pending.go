@@ -0,0 +1,70 @@
+package outbox
+
+import (
+	domain "booklib/internal/domain/outbox"
+	"booklib/internal/repo/dialect"
+	"context"
+	"time"
+)
+
+const (
+	// a message is skipped while an older message of its aggregate is
+	// unpublished, and SKIP LOCKED lets several relays claim disjoint sets
+	claimableQuery = `SELECT o.id FROM outbox o WHERE o.published_at IS NULL AND o.next_attempt_at <= $2 ` +
+		`AND NOT EXISTS (SELECT 1 FROM outbox p WHERE p.aggregate_type = o.aggregate_type ` +
+		`AND p.aggregate_id = o.aggregate_id AND p.published_at IS NULL AND p.id < o.id) ` +
+		`ORDER BY o.id LIMIT $3`
+	claimedColumns = `c.id, c.attempts, e.id AS event_id, e.type, e.aggregate_type, e.aggregate_id, e.before, e.after, e.occurred_at`
+)
+
+func (r *repo) ClaimPending(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.Message, error) {
+	var (
+		args   = []interface{}{r.dialect.Time(leaseUntil), r.dialect.Time(now), limit}
+		result = []domain.Message{}
+	)
+
+	var (
+		messages []Message
+		err      error
+	)
+	if r.dialect == dialect.SQLite {
+		messages, err = r.claimPendingSQLite(ctx, args)
+	} else {
+		query := `WITH claimed AS (UPDATE outbox SET next_attempt_at = $1 WHERE id IN (` + claimableQuery + ` FOR UPDATE SKIP LOCKED) ` +
+			`RETURNING id, event_id, attempts) ` +
+			`SELECT ` + claimedColumns + ` FROM claimed c JOIN events e ON e.id = c.event_id ORDER BY c.id`
+		err = r.conn(ctx).SelectContext(ctx, &messages, query, args...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range messages {
+		result = append(result, *m.ToDomain())
+	}
+
+	return result, nil
+}
+
+// claimPendingSQLite claims in two statements, since sqlite cannot join the
+// rows an UPDATE returns. Claimed rows stay leased in between, so the second
+// statement reads exactly what the first one claimed.
+func (r *repo) claimPendingSQLite(ctx context.Context, args []interface{}) ([]Message, error) {
+	var ids []int64
+	query := `UPDATE outbox SET next_attempt_at = $1 WHERE id IN (` + claimableQuery + `) RETURNING id`
+	if err := r.conn(ctx).SelectContext(ctx, &ids, query, args...); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var messages []Message
+	query = `SELECT ` + claimedColumns + ` FROM outbox c JOIN events e ON e.id = c.event_id ` +
+		`WHERE ` + r.dialect.AnyOf("c.id", 1, "") + ` ORDER BY c.id`
+	if err := r.conn(ctx).SelectContext(ctx, &messages, query, r.dialect.Array(ids)); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
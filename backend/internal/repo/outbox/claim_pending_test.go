@@ -0,0 +1,86 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"booklib/internal/domain/event"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimPending(t *testing.T) {
+	var (
+		now   = time.Now()
+		lease = now.Add(time.Minute)
+	)
+
+	tests := []struct {
+		name        string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedIDs []int64
+		expectedErr string
+	}{
+		{
+			name: "claims the oldest pending message of each aggregate",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`WITH claimed AS \(UPDATE outbox SET next_attempt_at = \$1 WHERE id IN \(SELECT o.id FROM outbox o WHERE o.published_at IS NULL AND o.next_attempt_at <= \$2 AND NOT EXISTS \(SELECT 1 FROM outbox p WHERE p.aggregate_type = o.aggregate_type AND p.aggregate_id = o.aggregate_id AND p.published_at IS NULL AND p.id < o.id\) ORDER BY o.id LIMIT \$3 FOR UPDATE SKIP LOCKED\) RETURNING id, event_id, attempts\) SELECT .+ FROM claimed c JOIN events e ON e.id = c.event_id ORDER BY c.id`).
+					WithArgs(lease, now, 10).
+					WillReturnRows(sqlmock.NewRows(messageColumns).
+						AddRow(1, 0, 5, event.TypeBookCreated, event.AggregateBook, "id-1", nil, []byte(`{}`), now).
+						AddRow(2, 1, 6, event.TypeBookCreated, event.AggregateBook, "id-2", nil, []byte(`{}`), now))
+			},
+			expectedIDs: []int64{1, 2},
+		},
+		{
+			name: "nothing pending",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`WITH claimed AS`).
+					WillReturnRows(sqlmock.NewRows(messageColumns))
+			},
+			expectedIDs: []int64{},
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`WITH claimed AS`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+			repo := New(sqlxDB)
+
+			tt.setupMocks(mock)
+
+			messages, err := repo.ClaimPending(context.Background(), now, lease, 10)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, messages)
+			} else {
+				assert.NoError(t, err)
+				ids := make([]int64, 0, len(messages))
+				for _, m := range messages {
+					ids = append(ids, m.ID)
+				}
+				assert.Equal(t, tt.expectedIDs, ids)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
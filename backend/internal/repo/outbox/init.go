@@ -0,0 +1,27 @@
+package outbox
+
+import (
+	"context"
+
+	domain "booklib/internal/domain/outbox"
+	"booklib/internal/repo/dialect"
+	"booklib/internal/repo/sqltx"
+	"github.com/jmoiron/sqlx"
+)
+
+type repo struct {
+	db      *sqlx.DB
+	dialect dialect.Dialect
+}
+
+func New(db *sqlx.DB) domain.Repository {
+	return &repo{
+		db:      db,
+		dialect: dialect.Of(db),
+	}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repo) conn(ctx context.Context) sqltx.Queryer {
+	return sqltx.Conn(ctx, r.db)
+}
@@ -0,0 +1,27 @@
+package outbox
+
+import (
+	"testing"
+
+	domain "booklib/internal/domain/outbox"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var messageColumns = []string{"id", "attempts", "event_id", "type", "aggregate_type", "aggregate_id", "before", "after", "occurred_at"}
+
+func TestNew(t *testing.T) {
+	t.Run("creates new repository with database connection", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		sqlxDB := sqlx.NewDb(db, "sqlmock")
+		repo := New(sqlxDB)
+
+		assert.NotNil(t, repo)
+		assert.Implements(t, (*domain.Repository)(nil), repo)
+	})
+}
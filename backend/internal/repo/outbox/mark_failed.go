@@ -0,0 +1,16 @@
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+func (r *repo) MarkFailed(ctx context.Context, id int64, lastError string, retryAt time.Time) error {
+	query := `UPDATE outbox SET attempts = attempts + 1, last_error = $1, next_attempt_at = $2 WHERE id = $3`
+
+	if _, err := r.conn(ctx).ExecContext(ctx, query, lastError, r.dialect.Time(retryAt), id); err != nil {
+		return err
+	}
+
+	return nil
+}
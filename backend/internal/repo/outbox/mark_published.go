@@ -0,0 +1,20 @@
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+func (r *repo) MarkPublished(ctx context.Context, ids []int64, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `UPDATE outbox SET published_at = $1 WHERE ` + r.dialect.AnyOf("id", 2, "")
+
+	if _, err := r.conn(ctx).ExecContext(ctx, query, r.dialect.Time(at), r.dialect.Array(ids)); err != nil {
+		return err
+	}
+
+	return nil
+}
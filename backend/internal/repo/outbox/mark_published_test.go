@@ -0,0 +1,72 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkPublished(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		ids         []int64
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedErr string
+	}{
+		{
+			name: "marks messages as published",
+			ids:  []int64{1, 2},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE outbox SET published_at = \$1 WHERE id = ANY\(\$2\)`).
+					WithArgs(now, pq.Array([]int64{1, 2})).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name:       "no ids",
+			ids:        nil,
+			setupMocks: func(mock sqlmock.Sqlmock) {},
+		},
+		{
+			name: "database error",
+			ids:  []int64{1},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE outbox`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			sqlxDB := sqlx.NewDb(db, "sqlmock")
+			repo := New(sqlxDB)
+
+			tt.setupMocks(mock)
+
+			err = repo.MarkPublished(context.Background(), tt.ids, now)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
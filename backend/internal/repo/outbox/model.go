@@ -0,0 +1,37 @@
+package outbox
+
+import (
+	"booklib/internal/domain/event"
+	domain "booklib/internal/domain/outbox"
+	"encoding/json"
+	"time"
+)
+
+// Message is an outbox row joined with the event it queues.
+type Message struct {
+	ID            int64     `db:"id"`
+	Attempts      int       `db:"attempts"`
+	EventID       int64     `db:"event_id"`
+	Type          string    `db:"type"`
+	AggregateType string    `db:"aggregate_type"`
+	AggregateID   string    `db:"aggregate_id"`
+	Before        []byte    `db:"before"`
+	After         []byte    `db:"after"`
+	OccurredAt    time.Time `db:"occurred_at"`
+}
+
+func (m *Message) ToDomain() *domain.Message {
+	return &domain.Message{
+		ID:       m.ID,
+		Attempts: m.Attempts,
+		Event: event.Event{
+			ID:            m.EventID,
+			Type:          m.Type,
+			AggregateType: m.AggregateType,
+			AggregateID:   m.AggregateID,
+			Before:        json.RawMessage(m.Before),
+			After:         json.RawMessage(m.After),
+			OccurredAt:    m.OccurredAt,
+		},
+	}
+}
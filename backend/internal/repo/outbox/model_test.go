@@ -0,0 +1,40 @@
+package outbox
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"booklib/internal/domain/event"
+	domain "booklib/internal/domain/outbox"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage_ToDomain(t *testing.T) {
+	now := time.Now()
+
+	m := &Message{
+		ID:            3,
+		Attempts:      2,
+		EventID:       7,
+		Type:          event.TypeBookCreated,
+		AggregateType: event.AggregateBook,
+		AggregateID:   "id-1",
+		After:         []byte(`{"id":"id-1"}`),
+		OccurredAt:    now,
+	}
+
+	assert.Equal(t, &domain.Message{
+		ID:       3,
+		Attempts: 2,
+		Event: event.Event{
+			ID:            7,
+			Type:          event.TypeBookCreated,
+			AggregateType: event.AggregateBook,
+			AggregateID:   "id-1",
+			After:         json.RawMessage(`{"id":"id-1"}`),
+			OccurredAt:    now,
+		},
+	}, m.ToDomain())
+}
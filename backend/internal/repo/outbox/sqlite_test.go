@@ -0,0 +1,58 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"booklib/internal/domain/event"
+	repoevent "booklib/internal/repo/event"
+	"booklib/internal/repo/sqlitetest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepo_SQLite(t *testing.T) {
+	ctx := context.Background()
+
+	db := sqlitetest.New(t)
+	repo := New(db)
+
+	var events []*event.Event
+	for _, id := range []string{"a", "b", "a"} {
+		events = append(events, &event.Event{Type: event.TypeBookUpdated, AggregateType: event.AggregateBook, AggregateID: id, OccurredAt: time.Now()})
+	}
+	assert.NoError(t, repoevent.New(db).AddEvents(ctx, events))
+
+	// messages are due from when they are queued
+	var (
+		now   = time.Now()
+		lease = now.Add(time.Minute)
+	)
+
+	// the second message of "a" waits for the first one
+	messages, err := repo.ClaimPending(ctx, now, lease, 10)
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, "a", messages[0].Event.AggregateID)
+	assert.Equal(t, event.TypeBookUpdated, messages[0].Event.Type)
+	assert.Equal(t, events[1].ID, messages[1].Event.ID)
+
+	// claimed messages are leased
+	messages, err = repo.ClaimPending(ctx, now, lease, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, messages)
+
+	assert.NoError(t, repo.MarkPublished(ctx, []int64{1, 2}, now))
+	assert.NoError(t, repo.MarkFailed(ctx, 3, "broker unavailable", lease))
+
+	messages, err = repo.ClaimPending(ctx, now, lease, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, messages)
+
+	messages, err = repo.ClaimPending(ctx, lease, lease.Add(time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, int64(3), messages[0].ID)
+	assert.Equal(t, 1, messages[0].Attempts)
+}
@@ -0,0 +1,88 @@
+// Package pgtest gives repository tests a throwaway postgres schema.
+//
+// Tests using it are skipped unless BOOKLIB_TEST_POSTGRES_DSN points at a
+// database the tests may create schemas in, e.g. the one of docker-compose:
+//
+//	BOOKLIB_TEST_POSTGRES_DSN="host=localhost port=5656 user=booklib password=booklib dbname=booklib sslmode=disable" go test ./...
+package pgtest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"booklib/internal/infra/migration"
+	"booklib/migrations"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+)
+
+const (
+	EnvDSN = "BOOKLIB_TEST_POSTGRES_DSN"
+)
+
+// New creates an empty schema, applies the postgres migrations to it and
+// returns a connection that uses it. The schema is dropped when the test ends.
+func New(t testing.TB) *sqlx.DB {
+	t.Helper()
+
+	dsn := os.Getenv(EnvDSN)
+	if dsn == "" {
+		t.Skipf("%s is not set", EnvDSN)
+	}
+
+	admin, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("connect postgres: %v", err)
+	}
+	t.Cleanup(func() { _ = admin.Close() })
+
+	schema := "booklib_test_" + randomSuffix(t)
+	if _, err = admin.Exec(`CREATE SCHEMA ` + schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() { _, _ = admin.Exec(`DROP SCHEMA ` + schema + ` CASCADE`) })
+
+	db, err := sqlx.Connect("postgres", withSearchPath(dsn, schema))
+	if err != nil {
+		t.Fatalf("connect postgres: %v", err)
+	}
+	// closed before the schema is dropped, cleanups run last in first out
+	t.Cleanup(func() { _ = db.Close() })
+
+	migrator, err := migration.New(db, migrations.Postgres)
+	if err != nil {
+		t.Fatalf("load postgres migrations: %v", err)
+	}
+	if _, err = migrator.Up(context.Background()); err != nil {
+		t.Fatalf("apply postgres migrations: %v", err)
+	}
+
+	return db
+}
+
+// withSearchPath makes every connection of dsn resolve tables in schema.
+func withSearchPath(dsn, schema string) string {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err == nil {
+			q := u.Query()
+			q.Set("search_path", schema)
+			u.RawQuery = q.Encode()
+			return u.String()
+		}
+	}
+	return dsn + " search_path=" + schema
+}
+
+func randomSuffix(t testing.TB) string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatalf("random schema name: %v", err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,454 @@
+// Package repotest holds conformance suites that every repository
+// implementation has to pass, whatever it stores the data in.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"booklib/internal/domain/book"
+	"booklib/internal/domain/transaction"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// largeBatch is more than one bulk statement holds, so batches are split
+	largeBatch = 1200
+
+	concurrentWriters = 16
+)
+
+// BookFactory returns a repository over an empty store, together with the
+// transaction manager its methods join. It is called once per subtest.
+type BookFactory func(t *testing.T) (book.Repository, transaction.Manager)
+
+// RunBookRepositorySuite checks that repo behaves like every other
+// book.Repository: CRUD, not-found results, ordering, batches that span
+// several statements, paging through updates, concurrent writers, locked
+// reads and transaction rollback.
+func RunBookRepositorySuite(t *testing.T, newRepo BookFactory) {
+	ctx := context.Background()
+
+	t.Run("add and get", func(t *testing.T) {
+		repo, _ := newRepo(t)
+
+		added, err := repo.AddBook(ctx, &book.Book{ID: "id-1", Title: "Title", Author: "Author", Year: 2001})
+		require.NoError(t, err)
+		assert.Equal(t, "id-1", added.ID)
+		assert.Equal(t, "Title", added.Title)
+		assert.Equal(t, "Author", added.Author)
+		assert.Equal(t, 2001, added.Year)
+		assert.WithinDuration(t, time.Now(), added.CreatedAt, time.Minute)
+		assert.True(t, added.UpdatedAt.Equal(added.CreatedAt))
+
+		got, err := repo.GetBookByID(ctx, "id-1")
+		require.NoError(t, err)
+		assertSameBook(t, *added, *got)
+	})
+
+	t.Run("add rejects a duplicate id", func(t *testing.T) {
+		repo, _ := newRepo(t)
+		mustAdd(t, repo, "id-1")
+
+		_, err := repo.AddBook(ctx, &book.Book{ID: "id-1", Title: "Other", Author: "Author"})
+		assert.Error(t, err)
+
+		got, err := repo.GetBookByID(ctx, "id-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Title id-1", got.Title)
+	})
+
+	t.Run("add books returns the persisted rows", func(t *testing.T) {
+		repo, _ := newRepo(t)
+
+		rows, err := repo.AddBooks(ctx, newBooks("id-2", "id-1"))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"id-1", "id-2"}, ids(rows))
+		for _, row := range rows {
+			assert.False(t, row.CreatedAt.IsZero())
+		}
+	})
+
+	t.Run("add books is all or nothing", func(t *testing.T) {
+		repo, _ := newRepo(t)
+		mustAdd(t, repo, "id-1")
+
+		_, err := repo.AddBooks(ctx, newBooks("id-2", "id-1"))
+		assert.Error(t, err)
+		assert.Equal(t, []string{"id-1"}, allIDs(t, repo))
+	})
+
+	t.Run("update keeps created at", func(t *testing.T) {
+		repo, _ := newRepo(t)
+		added := mustAdd(t, repo, "id-1")
+		time.Sleep(5 * time.Millisecond)
+
+		updated, err := repo.UpdateBook(ctx, &book.Book{ID: "id-1", Title: "New Title", Author: "New Author", Year: 2020})
+		require.NoError(t, err)
+		assert.Equal(t, "New Title", updated.Title)
+		assert.Equal(t, "New Author", updated.Author)
+		assert.Equal(t, 2020, updated.Year)
+		assert.True(t, updated.CreatedAt.Equal(added.CreatedAt))
+		assert.True(t, updated.UpdatedAt.After(added.UpdatedAt))
+
+		got, err := repo.GetBookByID(ctx, "id-1")
+		require.NoError(t, err)
+		assertSameBook(t, *updated, *got)
+	})
+
+	t.Run("update books returns the rows that existed", func(t *testing.T) {
+		repo, _ := newRepo(t)
+		mustAdd(t, repo, "id-1")
+		mustAdd(t, repo, "id-2")
+
+		rows, err := repo.UpdateBooks(ctx, []*book.Book{
+			{ID: "id-1", Title: "New 1", Author: "Author 1", Year: 1},
+			{ID: "missing", Title: "Title", Author: "Author"},
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "New 1", rows[0].Title)
+
+		got, err := repo.GetBookByID(ctx, "id-2")
+		require.NoError(t, err)
+		assert.Equal(t, "Title id-2", got.Title)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		repo, _ := newRepo(t)
+		mustAdd(t, repo, "id-1")
+		mustAdd(t, repo, "id-2")
+
+		require.NoError(t, repo.DeleteBook(ctx, "id-1"))
+		assert.Equal(t, []string{"id-2"}, allIDs(t, repo))
+
+		deleted, err := repo.DeleteBooks(ctx, []string{"id-2", "missing"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"id-2"}, deleted)
+		assert.Empty(t, allIDs(t, repo))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo, _ := newRepo(t)
+
+		got, err := repo.GetBookByID(ctx, "missing")
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+
+		updated, err := repo.UpdateBook(ctx, &book.Book{ID: "missing", Title: "Title", Author: "Author"})
+		assert.NoError(t, err)
+		assert.Nil(t, updated)
+
+		assert.NoError(t, repo.DeleteBook(ctx, "missing"))
+
+		books, err := repo.GetBooksByIDs(ctx, []string{"missing"})
+		assert.NoError(t, err)
+		assert.Empty(t, books)
+
+		rows, err := repo.UpdateBooks(ctx, newBooks("missing"))
+		assert.NoError(t, err)
+		assert.Empty(t, rows)
+
+		deleted, err := repo.DeleteBooks(ctx, []string{"missing"})
+		assert.NoError(t, err)
+		assert.Empty(t, deleted)
+
+		all, err := repo.GetAllBooks(ctx, book.Filter{})
+		assert.NoError(t, err)
+		assert.Empty(t, all)
+	})
+
+	t.Run("empty id lists", func(t *testing.T) {
+		repo, _ := newRepo(t)
+
+		books, err := repo.GetBooksByIDs(ctx, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, books)
+		assert.Empty(t, books)
+
+		deleted, err := repo.DeleteBooks(ctx, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, deleted)
+		assert.Empty(t, deleted)
+	})
+
+	t.Run("books are ordered by creation, then id", func(t *testing.T) {
+		repo, _ := newRepo(t)
+		mustAdd(t, repo, "id-c")
+		time.Sleep(5 * time.Millisecond)
+		_, err := repo.AddBooks(ctx, newBooks("id-b", "id-a"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"id-c", "id-a", "id-b"}, allIDs(t, repo))
+
+		books, err := repo.GetBooksByIDs(ctx, []string{"id-b", "id-c", "id-a"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"id-c", "id-a", "id-b"}, ids(books))
+	})
+
+	t.Run("batches larger than one statement", func(t *testing.T) {
+		repo, _ := newRepo(t)
+
+		var (
+			batch   = make([]*book.Book, largeBatch)
+			batchID = make([]string, largeBatch)
+		)
+		for i := range batch {
+			batchID[i] = fmt.Sprintf("id-%05d", i)
+			batch[i] = &book.Book{ID: batchID[i], Title: "Title", Author: "Author", Year: i}
+		}
+
+		added, err := repo.AddBooks(ctx, batch)
+		require.NoError(t, err)
+		assert.Len(t, added, largeBatch)
+
+		for _, bk := range batch {
+			bk.Title = "New Title"
+		}
+		updated, err := repo.UpdateBooks(ctx, batch)
+		require.NoError(t, err)
+		assert.Len(t, updated, largeBatch)
+
+		got, err := repo.GetBooksByIDs(ctx, batchID)
+		require.NoError(t, err)
+		require.Len(t, got, largeBatch)
+		assert.Equal(t, batchID, ids(got))
+		assert.Equal(t, "New Title", got[largeBatch-1].Title)
+		assert.Equal(t, largeBatch-1, got[largeBatch-1].Year)
+
+		deleted, err := repo.DeleteBooks(ctx, batchID)
+		require.NoError(t, err)
+		assert.Len(t, deleted, largeBatch)
+		assert.Empty(t, allIDs(t, repo))
+	})
+
+	t.Run("paging through updates with updated since", func(t *testing.T) {
+		repo, _ := newRepo(t)
+		mustAdd(t, repo, "id-1")
+		mustAdd(t, repo, "id-2")
+		time.Sleep(5 * time.Millisecond)
+
+		// a client that synced everything so far asks only for what changed
+		since := time.Now()
+		time.Sleep(5 * time.Millisecond)
+		updated, err := repo.UpdateBook(ctx, &book.Book{ID: "id-1", Title: "New Title", Author: "Author"})
+		require.NoError(t, err)
+		mustAdd(t, repo, "id-3")
+
+		books, err := repo.GetAllBooks(ctx, book.Filter{UpdatedSince: &since})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"id-1", "id-3"}, ids(books))
+
+		// the bound is inclusive, so the next page starts at the last update seen
+		books, err = repo.GetAllBooks(ctx, book.Filter{UpdatedSince: &updated.UpdatedAt})
+		require.NoError(t, err)
+		assert.Contains(t, ids(books), "id-1")
+
+		future := time.Now().Add(time.Hour)
+		books, err = repo.GetAllBooks(ctx, book.Filter{UpdatedSince: &future})
+		require.NoError(t, err)
+		assert.Empty(t, books)
+	})
+
+	t.Run("concurrent writers", func(t *testing.T) {
+		repo, tx := newRepo(t)
+
+		var (
+			wg   sync.WaitGroup
+			errs = make(chan error, concurrentWriters)
+		)
+		for i := 0; i < concurrentWriters; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				id := fmt.Sprintf("id-%02d", i)
+				errs <- tx.WithinTx(ctx, func(ctx context.Context) error {
+					if _, err := repo.AddBook(ctx, &book.Book{ID: id, Title: "Title", Author: "Author"}); err != nil {
+						return err
+					}
+					if _, err := repo.GetAllBooks(ctx, book.Filter{}); err != nil {
+						return err
+					}
+					_, err := repo.UpdateBook(ctx, &book.Book{ID: id, Title: "Title " + id, Author: "Author"})
+					return err
+				})
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			assert.NoError(t, err)
+		}
+
+		books, err := repo.GetAllBooks(ctx, book.Filter{})
+		require.NoError(t, err)
+		require.Len(t, books, concurrentWriters)
+		for _, bk := range books {
+			assert.Equal(t, "Title "+bk.ID, bk.Title)
+		}
+	})
+
+	t.Run("locked reads wait for the transaction holding the rows", func(t *testing.T) {
+		repo, tx := newRepo(t)
+		mustAdd(t, repo, "id-1")
+
+		var (
+			locked = make(chan struct{})
+			seen   = make(chan string, 1)
+		)
+		go func() {
+			<-locked
+			_ = tx.WithinTx(ctx, func(ctx context.Context) error {
+				books, err := repo.GetBooksByIDsForUpdate(ctx, []string{"id-1"})
+				if err != nil || len(books) == 0 {
+					seen <- fmt.Sprint(err)
+					return err
+				}
+				seen <- books[0].Title
+				return nil
+			})
+		}()
+
+		err := tx.WithinTx(ctx, func(ctx context.Context) error {
+			books, err := repo.GetBooksByIDsForUpdate(ctx, []string{"id-1"})
+			if err != nil {
+				return err
+			}
+			require.Len(t, books, 1)
+			close(locked)
+
+			// give the other transaction time to read, were the row not locked
+			time.Sleep(100 * time.Millisecond)
+			_, err = repo.UpdateBook(ctx, &book.Book{ID: "id-1", Title: "Locked Title", Author: "Author"})
+			return err
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "Locked Title", <-seen)
+	})
+
+	t.Run("transaction commit", func(t *testing.T) {
+		repo, tx := newRepo(t)
+
+		err := tx.WithinTx(ctx, func(ctx context.Context) error {
+			mustAddCtx(t, ctx, repo, "id-1")
+
+			// the transaction sees its own writes
+			got, err := repo.GetBookByID(ctx, "id-1")
+			if err != nil {
+				return err
+			}
+			assert.NotNil(t, got)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"id-1"}, allIDs(t, repo))
+	})
+
+	t.Run("transaction rollback", func(t *testing.T) {
+		repo, tx := newRepo(t)
+		mustAdd(t, repo, "id-1")
+		mustAdd(t, repo, "id-2")
+
+		err := tx.WithinTx(ctx, func(ctx context.Context) error {
+			mustAddCtx(t, ctx, repo, "id-3")
+			if _, err := repo.UpdateBook(ctx, &book.Book{ID: "id-1", Title: "New Title", Author: "Author"}); err != nil {
+				return err
+			}
+			if _, err := repo.DeleteBooks(ctx, []string{"id-2"}); err != nil {
+				return err
+			}
+			return errors.New("something went wrong")
+		})
+		assert.EqualError(t, err, "something went wrong")
+
+		assert.Equal(t, []string{"id-1", "id-2"}, sortedIDs(t, repo))
+		got, err := repo.GetBookByID(ctx, "id-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Title id-1", got.Title)
+	})
+
+	t.Run("nested transaction rollback keeps the outer work", func(t *testing.T) {
+		repo, tx := newRepo(t)
+
+		err := tx.WithinTx(ctx, func(ctx context.Context) error {
+			mustAddCtx(t, ctx, repo, "id-1")
+
+			nestedErr := tx.WithinTx(ctx, func(ctx context.Context) error {
+				mustAddCtx(t, ctx, repo, "id-2")
+				return errors.New("nested went wrong")
+			})
+			assert.EqualError(t, nestedErr, "nested went wrong")
+
+			mustAddCtx(t, ctx, repo, "id-3")
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"id-1", "id-3"}, sortedIDs(t, repo))
+	})
+}
+
+func newBooks(ids ...string) []*book.Book {
+	books := make([]*book.Book, len(ids))
+	for i, id := range ids {
+		books[i] = &book.Book{ID: id, Title: "Title " + id, Author: "Author"}
+	}
+	return books
+}
+
+func mustAdd(t *testing.T, repo book.Repository, id string) *book.Book {
+	return mustAddCtx(t, context.Background(), repo, id)
+}
+
+func mustAddCtx(t *testing.T, ctx context.Context, repo book.Repository, id string) *book.Book {
+	t.Helper()
+
+	added, err := repo.AddBook(ctx, newBooks(id)[0])
+	require.NoError(t, err)
+	return added
+}
+
+func ids(books []book.Book) []string {
+	res := make([]string, len(books))
+	for i, bk := range books {
+		res[i] = bk.ID
+	}
+	return res
+}
+
+// allIDs lists every stored book in the order GetAllBooks returns them.
+func allIDs(t *testing.T, repo book.Repository) []string {
+	t.Helper()
+
+	books, err := repo.GetAllBooks(context.Background(), book.Filter{})
+	require.NoError(t, err)
+	return ids(books)
+}
+
+func sortedIDs(t *testing.T, repo book.Repository) []string {
+	res := allIDs(t, repo)
+	sort.Strings(res)
+	return res
+}
+
+// assertSameBook compares books field by field, since equal times may differ
+// in location or monotonic clock reading.
+func assertSameBook(t *testing.T, expected, actual book.Book) {
+	t.Helper()
+
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.Title, actual.Title)
+	assert.Equal(t, expected.Author, actual.Author)
+	assert.Equal(t, expected.Year, actual.Year)
+	assert.True(t, expected.CreatedAt.Equal(actual.CreatedAt), "created at %s, expected %s", actual.CreatedAt, expected.CreatedAt)
+	assert.True(t, expected.UpdatedAt.Equal(actual.UpdatedAt), "updated at %s, expected %s", actual.UpdatedAt, expected.UpdatedAt)
+}
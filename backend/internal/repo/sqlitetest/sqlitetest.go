@@ -0,0 +1,35 @@
+// Package sqlitetest opens throwaway sqlite databases for repository tests.
+package sqlitetest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"booklib/internal/infra/migration"
+	"booklib/internal/repo/dialect"
+	"booklib/migrations"
+	"github.com/jmoiron/sqlx"
+)
+
+// New opens an empty database in a temporary directory and applies the sqlite
+// migrations to it. The database is closed when the test ends.
+func New(t testing.TB) *sqlx.DB {
+	t.Helper()
+
+	db, err := dialect.OpenSQLite(filepath.Join(t.TempDir(), "booklib.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	migrator, err := migration.New(db, migrations.SQLite)
+	if err != nil {
+		t.Fatalf("load sqlite migrations: %v", err)
+	}
+	if _, err = migrator.Up(context.Background()); err != nil {
+		t.Fatalf("apply sqlite migrations: %v", err)
+	}
+
+	return db
+}
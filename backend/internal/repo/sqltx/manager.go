@@ -0,0 +1,76 @@
+package sqltx
+
+import (
+	"booklib/internal/domain/transaction"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type manager struct {
+	db *sqlx.DB
+}
+
+// NewManager returns a transaction.Manager for db. A transaction is not safe
+// for concurrent use, so fn must not share its ctx between goroutines.
+func NewManager(db *sqlx.DB) transaction.Manager {
+	return &manager{
+		db: db,
+	}
+}
+
+func (m *manager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if st, ok := ctx.Value(ctxKey{}).(*txState); ok && st.tx != nil {
+		return withinSavepoint(ctx, st, fn)
+	}
+
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, ctxKey{}, &txState{tx: tx})); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Join(err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// withinSavepoint runs fn nested in the transaction of st.
+func withinSavepoint(ctx context.Context, st *txState, fn func(ctx context.Context) error) error {
+	var (
+		inner = &txState{tx: st.tx, depth: st.depth + 1}
+		name  = fmt.Sprintf("sp_%d", inner.depth)
+	)
+
+	if _, err := st.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = st.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, ctxKey{}, inner)); err != nil {
+		if _, rbErr := st.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			return errors.Join(err, rbErr)
+		}
+		return err
+	}
+
+	_, err := st.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return err
+}
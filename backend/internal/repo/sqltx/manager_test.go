@@ -0,0 +1,171 @@
+package sqltx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager_WithinTx(t *testing.T) {
+	// exec runs a statement on whatever connection ctx carries
+	exec := func(db *sqlx.DB, query string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			_, err := Conn(ctx, db).ExecContext(ctx, query)
+			return err
+		}
+	}
+
+	tests := []struct {
+		name        string
+		fn          func(m *manager) func(ctx context.Context) error
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedErr string
+	}{
+		{
+			name: "commits when fn succeeds",
+			fn: func(m *manager) func(ctx context.Context) error {
+				return exec(m.db, "DELETE FROM books")
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM books`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "rolls back when fn fails",
+			fn: func(m *manager) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := exec(m.db, "DELETE FROM books")(ctx); err != nil {
+						return err
+					}
+					return errors.New("something went wrong")
+				}
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM books`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectRollback()
+			},
+			expectedErr: "something went wrong",
+		},
+		{
+			name: "nested call runs in a savepoint",
+			fn: func(m *manager) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					return m.WithinTx(ctx, exec(m.db, "DELETE FROM books"))
+				}
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`SAVEPOINT sp_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`DELETE FROM books`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`RELEASE SAVEPOINT sp_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "nested failure only undoes the savepoint",
+			fn: func(m *manager) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					err := m.WithinTx(ctx, func(ctx context.Context) error {
+						return m.WithinTx(ctx, func(ctx context.Context) error {
+							return errors.New("inner failed")
+						})
+					})
+					assert.EqualError(t, err, "inner failed")
+					return exec(m.db, "DELETE FROM books")(ctx)
+				}
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`SAVEPOINT sp_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`SAVEPOINT sp_2`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`ROLLBACK TO SAVEPOINT sp_2`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`ROLLBACK TO SAVEPOINT sp_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`DELETE FROM books`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "joins a transaction carried by ctx",
+			fn: func(m *manager) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					tx, ok := FromContext(ctx)
+					assert.True(t, ok)
+					return m.WithinTx(NewContext(ctx, tx), exec(m.db, "DELETE FROM books"))
+				}
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`SAVEPOINT sp_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`DELETE FROM books`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`RELEASE SAVEPOINT sp_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "begin error",
+			fn: func(m *manager) func(ctx context.Context) error {
+				return func(ctx context.Context) error { return nil }
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin().WillReturnError(errors.New("begin failed"))
+			},
+			expectedErr: "begin failed",
+		},
+		{
+			name: "rollback error is joined",
+			fn: func(m *manager) func(ctx context.Context) error {
+				return func(ctx context.Context) error { return errors.New("something went wrong") }
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback().WillReturnError(errors.New("rollback failed"))
+			},
+			expectedErr: "something went wrong\nrollback failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			m := NewManager(sqlx.NewDb(db, "sqlmock")).(*manager)
+			tt.setupMocks(mock)
+
+			err = m.WithinTx(context.Background(), tt.fn(m))
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestManager_WithinTx_Panic(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	m := NewManager(sqlx.NewDb(db, "sqlmock"))
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = m.WithinTx(context.Background(), func(ctx context.Context) error {
+			panic("boom")
+		})
+	})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
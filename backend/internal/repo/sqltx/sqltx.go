@@ -0,0 +1,48 @@
+// Package sqltx carries a database transaction through a context so that
+// repositories of different aggregates can write within the same transaction.
+package sqltx
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type ctxKey struct{}
+
+// txState is the transaction carried by a context and how deeply the current
+// unit of work is nested in it.
+type txState struct {
+	tx    *sqlx.Tx
+	depth int
+}
+
+// Queryer is implemented by both *sqlx.DB and *sqlx.Tx.
+type Queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// NewContext returns a copy of ctx carrying tx.
+func NewContext(ctx context.Context, tx *sqlx.Tx) context.Context {
+	return context.WithValue(ctx, ctxKey{}, &txState{tx: tx})
+}
+
+// FromContext returns the transaction carried by ctx, if any.
+func FromContext(ctx context.Context) (*sqlx.Tx, bool) {
+	st, ok := ctx.Value(ctxKey{}).(*txState)
+	if !ok || st.tx == nil {
+		return nil, false
+	}
+	return st.tx, true
+}
+
+// Conn returns the transaction carried by ctx, falling back to db.
+func Conn(ctx context.Context, db *sqlx.DB) Queryer {
+	if tx, ok := FromContext(ctx); ok {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,51 @@
+package sqltx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConn(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	mock.ExpectBegin()
+	tx, err := db.Beginx()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected Queryer
+	}{
+		{
+			name:     "falls back to db",
+			ctx:      context.Background(),
+			expected: db,
+		},
+		{
+			name:     "uses the transaction in context",
+			ctx:      NewContext(context.Background(), tx),
+			expected: tx,
+		},
+		{
+			name:     "ignores a nil transaction",
+			ctx:      NewContext(context.Background(), nil),
+			expected: db,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Same(t, tt.expected, Conn(tt.ctx, db))
+		})
+	}
+}
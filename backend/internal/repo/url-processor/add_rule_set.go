@@ -0,0 +1,24 @@
+package urlprocessor
+
+import (
+	domain "booklib/internal/domain/url-processor"
+	"context"
+)
+
+func (r *repo) AddRuleSet(ctx context.Context, rs *domain.RuleSet) (*domain.RuleSet, error) {
+	var (
+		query = `INSERT INTO url_rule_sets (id, name, hosts, priority, operations, active) VALUES ($1, $2, $3, $4, $5, $6) RETURNING ` + columns
+		res   RuleSet
+	)
+
+	ops, err := operations(rs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.conn(ctx).GetContext(ctx, &res, query, rs.ID, rs.Name, r.dialect.Array(rs.Hosts), rs.Priority, ops, rs.Active); err != nil {
+		return nil, err
+	}
+
+	return res.ToDomain()
+}
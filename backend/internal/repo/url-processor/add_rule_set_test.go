@@ -0,0 +1,71 @@
+package urlprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/url-processor"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddRuleSet(t *testing.T) {
+	rs := &domain.RuleSet{
+		ID: "rs-1", Name: "shop", Hosts: []string{"example.com"}, Priority: 5, Active: true,
+		Operations: map[string][]domain.Step{"canonical": {{Type: domain.StepStripQuery}}},
+	}
+	ops := `{"canonical":[{"type":"strip_query"}]}`
+
+	tests := []struct {
+		name        string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedErr string
+	}{
+		{
+			name: "successful add rule set",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO url_rule_sets \(id, name, hosts, priority, operations, active\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\) RETURNING id, name, hosts, priority, operations, active, created_at, updated_at`).
+					WithArgs("rs-1", "shop", pq.Array([]string{"example.com"}), 5, ops, true).
+					WillReturnRows(sqlmock.NewRows(ruleSetColumns).
+						AddRow("rs-1", "shop", "{example.com}", 5, []byte(ops), true, time.Now(), time.Now()))
+			},
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO url_rule_sets`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			res, err := repo.AddRuleSet(context.Background(), rs)
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, rs.Operations, res.Operations)
+				assert.Equal(t, []string{"example.com"}, res.Hosts)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package urlprocessor
+
+import "context"
+
+func (r *repo) DeleteRuleSet(ctx context.Context, id string) error {
+	query := `DELETE FROM url_rule_sets WHERE id = $1`
+
+	if _, err := r.conn(ctx).ExecContext(ctx, query, id); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package urlprocessor
+
+import (
+	domain "booklib/internal/domain/url-processor"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+func (r *repo) GetRuleSetByID(ctx context.Context, id string) (*domain.RuleSet, error) {
+	var (
+		query = `SELECT ` + columns + ` FROM url_rule_sets WHERE id = $1`
+		rs    RuleSet
+	)
+
+	if err := r.conn(ctx).GetContext(ctx, &rs, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return rs.ToDomain()
+}
@@ -0,0 +1,75 @@
+package urlprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRuleSetByID(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMocks   func(mock sqlmock.Sqlmock)
+		expectedName string
+		expectedErr  string
+	}{
+		{
+			name: "found",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, name, hosts, priority, operations, active, created_at, updated_at FROM url_rule_sets WHERE id = \$1`).
+					WithArgs("rs-1").
+					WillReturnRows(sqlmock.NewRows(ruleSetColumns).
+						AddRow("rs-1", "shop", "{example.com}", 0, []byte(`{}`), true, time.Now(), time.Now()))
+			},
+			expectedName: "shop",
+		},
+		{
+			name: "not found",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, name, hosts, priority, operations, active, created_at, updated_at FROM url_rule_sets WHERE id = \$1`).
+					WithArgs("rs-1").
+					WillReturnRows(sqlmock.NewRows(ruleSetColumns))
+			},
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, name, hosts, priority, operations, active, created_at, updated_at FROM url_rule_sets`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			res, err := repo.GetRuleSetByID(context.Background(), "rs-1")
+
+			switch {
+			case tt.expectedErr != "":
+				assert.ErrorContains(t, err, tt.expectedErr)
+				assert.Nil(t, res)
+			case tt.expectedName == "":
+				assert.NoError(t, err)
+				assert.Nil(t, res)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedName, res.Name)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
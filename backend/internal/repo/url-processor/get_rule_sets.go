@@ -0,0 +1,28 @@
+package urlprocessor
+
+import (
+	domain "booklib/internal/domain/url-processor"
+	"context"
+)
+
+func (r *repo) GetRuleSets(ctx context.Context) ([]domain.RuleSet, error) {
+	var (
+		query  = `SELECT ` + columns + ` FROM url_rule_sets ORDER BY name, id`
+		result = []domain.RuleSet{}
+	)
+
+	var sets []RuleSet
+	if err := r.conn(ctx).SelectContext(ctx, &sets, query); err != nil {
+		return nil, err
+	}
+
+	for _, rs := range sets {
+		res, err := rs.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *res)
+	}
+
+	return result, nil
+}
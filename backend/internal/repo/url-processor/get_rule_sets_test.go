@@ -0,0 +1,84 @@
+package urlprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRuleSets(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMocks    func(mock sqlmock.Sqlmock)
+		expectedNames []string
+		expectedErr   string
+	}{
+		{
+			name: "rule sets by name",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, name, hosts, priority, operations, active, created_at, updated_at FROM url_rule_sets ORDER BY name, id`).
+					WillReturnRows(sqlmock.NewRows(ruleSetColumns).
+						AddRow("rs-1", "blog", "{*.example.com}", 0, []byte(`{}`), true, time.Now(), time.Now()).
+						AddRow("rs-2", "shop", "{example.com}", 0, []byte(`{}`), false, time.Now(), time.Now()))
+			},
+			expectedNames: []string{"blog", "shop"},
+		},
+		{
+			name: "no rule sets",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, name, hosts, priority, operations, active, created_at, updated_at FROM url_rule_sets`).
+					WillReturnRows(sqlmock.NewRows(ruleSetColumns))
+			},
+			expectedNames: []string{},
+		},
+		{
+			name: "invalid stored operations",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, name, hosts, priority, operations, active, created_at, updated_at FROM url_rule_sets`).
+					WillReturnRows(sqlmock.NewRows(ruleSetColumns).
+						AddRow("rs-1", "blog", "{*.example.com}", 0, []byte(`null}`), true, time.Now(), time.Now()))
+			},
+			expectedErr: "url rule set rs-1 has invalid operations",
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, name, hosts, priority, operations, active, created_at, updated_at FROM url_rule_sets`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			res, err := repo.GetRuleSets(context.Background())
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				names := []string{}
+				for _, rs := range res {
+					names = append(names, rs.Name)
+				}
+				assert.Equal(t, tt.expectedNames, names)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
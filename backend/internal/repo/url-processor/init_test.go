@@ -0,0 +1,26 @@
+package urlprocessor
+
+import (
+	"testing"
+
+	domain "booklib/internal/domain/url-processor"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var ruleSetColumns = []string{"id", "name", "hosts", "priority", "operations", "active", "created_at", "updated_at"}
+
+func TestNew(t *testing.T) {
+	t.Run("creates new repository with database connection", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		repo := New(sqlx.NewDb(db, "sqlmock"))
+
+		assert.NotNil(t, repo)
+		assert.Implements(t, (*domain.RuleSetRepository)(nil), repo)
+	})
+}
@@ -0,0 +1,57 @@
+package urlprocessor
+
+import (
+	domain "booklib/internal/domain/url-processor"
+	"booklib/internal/repo/dialect"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// columns are the columns of RuleSet, named rather than selected with * so that
+// a column added by a later migration does not break scanning.
+const columns = `id, name, hosts, priority, operations, active, created_at, updated_at`
+
+type RuleSet struct {
+	ID       string              `db:"id"`
+	Name     string              `db:"name"`
+	Hosts    dialect.StringArray `db:"hosts"`
+	Priority int                 `db:"priority"`
+	// Operations holds the JSON of domain.RuleSet.Operations
+	Operations []byte    `db:"operations"`
+	Active     bool      `db:"active"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+func (rs *RuleSet) ToDomain() (*domain.RuleSet, error) {
+	var operations map[string][]domain.Step
+	if err := json.Unmarshal(rs.Operations, &operations); err != nil {
+		return nil, fmt.Errorf("url rule set %s has invalid operations: %w", rs.ID, err)
+	}
+
+	hosts := []string(rs.Hosts)
+	if hosts == nil {
+		hosts = []string{}
+	}
+
+	return &domain.RuleSet{
+		ID:         rs.ID,
+		Name:       rs.Name,
+		Hosts:      hosts,
+		Priority:   rs.Priority,
+		Operations: operations,
+		Active:     rs.Active,
+		CreatedAt:  rs.CreatedAt,
+		UpdatedAt:  rs.UpdatedAt,
+	}, nil
+}
+
+// operations encodes the operations of rs for the operations column.
+func operations(rs *domain.RuleSet) (string, error) {
+	raw, err := json.Marshal(rs.Operations)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
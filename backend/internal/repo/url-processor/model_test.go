@@ -0,0 +1,53 @@
+package urlprocessor
+
+import (
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/url-processor"
+	"booklib/internal/repo/dialect"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleSet_ToDomain(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		model       RuleSet
+		expected    *domain.RuleSet
+		expectedErr string
+	}{
+		{
+			name: "maps every field",
+			model: RuleSet{
+				ID: "rs-1", Name: "shop", Hosts: dialect.StringArray{"*.example.com"}, Priority: 5,
+				Operations: []byte(`{"canonical":[{"type":"keep_query","params":["page"]}]}`), Active: true, CreatedAt: now, UpdatedAt: now,
+			},
+			expected: &domain.RuleSet{
+				ID: "rs-1", Name: "shop", Hosts: []string{"*.example.com"}, Priority: 5,
+				Operations: map[string][]domain.Step{"canonical": {{Type: domain.StepKeepQuery, Params: []string{"page"}}}},
+				Active:     true, CreatedAt: now, UpdatedAt: now,
+			},
+		},
+		{
+			name:        "invalid operations",
+			model:       RuleSet{ID: "rs-1", Operations: []byte(`[`)},
+			expectedErr: "url rule set rs-1 has invalid operations",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.model.ToDomain()
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
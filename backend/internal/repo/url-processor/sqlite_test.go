@@ -0,0 +1,47 @@
+package urlprocessor
+
+import (
+	"context"
+	"testing"
+
+	domain "booklib/internal/domain/url-processor"
+	"booklib/internal/repo/sqlitetest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepo_SQLite(t *testing.T) {
+	ctx := context.Background()
+
+	db := sqlitetest.New(t)
+	// a column added by a later migration is not selected
+	db.MustExec(`ALTER TABLE url_rule_sets ADD COLUMN note TEXT`)
+	repo := New(db)
+	ops := map[string][]domain.Step{"canonical": {{Type: domain.StepKeepQuery, Params: []string{"page"}}}}
+
+	rs, err := repo.AddRuleSet(ctx, &domain.RuleSet{ID: "rs-1", Name: "shop", Hosts: []string{"example.com", "*.example.com"}, Priority: 2, Operations: ops, Active: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com", "*.example.com"}, rs.Hosts)
+	assert.Equal(t, ops, rs.Operations)
+	assert.False(t, rs.CreatedAt.IsZero())
+
+	rs, err = repo.UpdateRuleSet(ctx, &domain.RuleSet{ID: "rs-1", Name: "shop", Hosts: []string{"*"}, Operations: map[string][]domain.Step{}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*"}, rs.Hosts)
+	assert.Equal(t, map[string][]domain.Step{}, rs.Operations)
+	assert.False(t, rs.Active)
+
+	rs, err = repo.UpdateRuleSet(ctx, &domain.RuleSet{ID: "missing", Name: "x", Hosts: []string{"*"}})
+	assert.NoError(t, err)
+	assert.Nil(t, rs)
+
+	sets, err := repo.GetRuleSets(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, sets, 1)
+
+	assert.NoError(t, repo.DeleteRuleSet(ctx, "rs-1"))
+	rs, err = repo.GetRuleSetByID(ctx, "rs-1")
+	assert.NoError(t, err)
+	assert.Nil(t, rs)
+}
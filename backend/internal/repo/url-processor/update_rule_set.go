@@ -0,0 +1,29 @@
+package urlprocessor
+
+import (
+	domain "booklib/internal/domain/url-processor"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+func (r *repo) UpdateRuleSet(ctx context.Context, rs *domain.RuleSet) (*domain.RuleSet, error) {
+	var (
+		query = `UPDATE url_rule_sets SET name = $1, hosts = $2, priority = $3, operations = $4, active = $5, updated_at = ` + r.dialect.Now() + ` WHERE id = $6 RETURNING ` + columns
+		res   RuleSet
+	)
+
+	ops, err := operations(rs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.conn(ctx).GetContext(ctx, &res, query, rs.Name, r.dialect.Array(rs.Hosts), rs.Priority, ops, rs.Active, rs.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return res.ToDomain()
+}
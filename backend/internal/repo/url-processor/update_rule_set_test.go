@@ -0,0 +1,83 @@
+package urlprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/url-processor"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateRuleSet(t *testing.T) {
+	rs := &domain.RuleSet{
+		ID: "rs-1", Name: "shop", Hosts: []string{"example.com"}, Priority: 1,
+		Operations: map[string][]domain.Step{"clean": {{Type: domain.StepStripTracking}}},
+	}
+	ops := `{"clean":[{"type":"strip_tracking"}]}`
+
+	tests := []struct {
+		name        string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedNil bool
+		expectedErr string
+	}{
+		{
+			name: "successful update rule set",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE url_rule_sets SET name = \$1, hosts = \$2, priority = \$3, operations = \$4, active = \$5, updated_at = NOW\(\) WHERE id = \$6 RETURNING id, name, hosts, priority, operations, active, created_at, updated_at`).
+					WithArgs("shop", pq.Array([]string{"example.com"}), 1, ops, false, "rs-1").
+					WillReturnRows(sqlmock.NewRows(ruleSetColumns).
+						AddRow("rs-1", "shop", "{example.com}", 1, []byte(ops), false, time.Now(), time.Now()))
+			},
+		},
+		{
+			name: "not found",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE url_rule_sets`).
+					WillReturnRows(sqlmock.NewRows(ruleSetColumns))
+			},
+			expectedNil: true,
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE url_rule_sets`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			res, err := repo.UpdateRuleSet(context.Background(), rs)
+
+			switch {
+			case tt.expectedErr != "":
+				assert.ErrorContains(t, err, tt.expectedErr)
+				assert.Nil(t, res)
+			case tt.expectedNil:
+				assert.NoError(t, err)
+				assert.Nil(t, res)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, rs.Operations, res.Operations)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
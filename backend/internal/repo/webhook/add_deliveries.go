@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	domain "booklib/internal/domain/webhook"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// AddDeliveries skips the deliveries of an event already queued for the
+// subscription, leaving their ID zero; a retried event queues nothing twice.
+func (r *repo) AddDeliveries(ctx context.Context, deliveries []*domain.Delivery) error {
+	for start := 0; start < len(deliveries); start += maxRowsPerStatement {
+		end := min(start+maxRowsPerStatement, len(deliveries))
+
+		var (
+			chunk  = deliveries[start:end]
+			values = make([]string, 0, len(chunk))
+			args   = make([]interface{}, 0, len(chunk)*6)
+		)
+		for i, d := range chunk {
+			n := i * 6
+			values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6))
+			args = append(args, d.SubscriptionID, d.EventID, d.EventType, []byte(d.Payload), d.Status, r.dialect.Time(d.NextAttemptAt))
+		}
+
+		query := `INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, next_attempt_at) VALUES ` +
+			strings.Join(values, ", ") + ` ON CONFLICT (subscription_id, event_id) DO NOTHING RETURNING id, subscription_id, event_id`
+
+		var inserted []struct {
+			ID             int64  `db:"id"`
+			SubscriptionID string `db:"subscription_id"`
+			EventID        int64  `db:"event_id"`
+		}
+		if err := r.conn(ctx).SelectContext(ctx, &inserted, query, args...); err != nil {
+			return err
+		}
+
+		ids := make(map[deliveryKey]int64, len(inserted))
+		for _, row := range inserted {
+			ids[deliveryKey{row.SubscriptionID, row.EventID}] = row.ID
+		}
+		for _, d := range chunk {
+			key := deliveryKey{d.SubscriptionID, d.EventID}
+			d.ID = ids[key]
+			// a delivery repeated within the chunk was inserted once
+			delete(ids, key)
+		}
+	}
+
+	return nil
+}
+
+type deliveryKey struct {
+	subscriptionID string
+	eventID        int64
+}
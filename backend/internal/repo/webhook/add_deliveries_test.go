@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/webhook"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddDeliveries(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		deliveries  []*domain.Delivery
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedIDs []int64
+		expectedErr string
+	}{
+		{
+			name: "inserts deliveries and sets their ids",
+			deliveries: []*domain.Delivery{
+				{SubscriptionID: "sub-1", EventID: 1, EventType: "book.created", Payload: json.RawMessage(`{}`), Status: domain.DeliveryStatusPending, NextAttemptAt: now},
+				{SubscriptionID: "sub-2", EventID: 1, EventType: "book.created", Payload: json.RawMessage(`{}`), Status: domain.DeliveryStatusPending, NextAttemptAt: now},
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO webhook_deliveries \(subscription_id, event_id, event_type, payload, status, next_attempt_at\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\), \(\$7, \$8, \$9, \$10, \$11, \$12\) ON CONFLICT \(subscription_id, event_id\) DO NOTHING RETURNING id, subscription_id, event_id`).
+					WithArgs(
+						"sub-1", int64(1), "book.created", []byte(`{}`), domain.DeliveryStatusPending, now,
+						"sub-2", int64(1), "book.created", []byte(`{}`), domain.DeliveryStatusPending, now,
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "subscription_id", "event_id"}).AddRow(10, "sub-1", 1).AddRow(11, "sub-2", 1))
+			},
+			expectedIDs: []int64{10, 11},
+		},
+		{
+			name: "leaves the id of an already queued delivery zero",
+			deliveries: []*domain.Delivery{
+				{SubscriptionID: "sub-1", EventID: 1, EventType: "book.created", Payload: json.RawMessage(`{}`), Status: domain.DeliveryStatusPending, NextAttemptAt: now},
+				{SubscriptionID: "sub-2", EventID: 1, EventType: "book.created", Payload: json.RawMessage(`{}`), Status: domain.DeliveryStatusPending, NextAttemptAt: now},
+			},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO webhook_deliveries .* ON CONFLICT \(subscription_id, event_id\) DO NOTHING`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "subscription_id", "event_id"}).AddRow(12, "sub-2", 1))
+			},
+			expectedIDs: []int64{0, 12},
+		},
+		{
+			name:        "no deliveries",
+			deliveries:  []*domain.Delivery{},
+			setupMocks:  func(mock sqlmock.Sqlmock) {},
+			expectedIDs: []int64{},
+		},
+		{
+			name:       "database error",
+			deliveries: []*domain.Delivery{{SubscriptionID: "sub-1", NextAttemptAt: now}},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO webhook_deliveries`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			err = repo.AddDeliveries(context.Background(), tt.deliveries)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				ids := make([]int64, 0, len(tt.deliveries))
+				for _, d := range tt.deliveries {
+					ids = append(ids, d.ID)
+				}
+				assert.Equal(t, tt.expectedIDs, ids)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package webhook
+
+import (
+	domain "booklib/internal/domain/webhook"
+	"context"
+)
+
+func (r *repo) AddSubscription(ctx context.Context, sub *domain.Subscription) (*domain.Subscription, error) {
+	var (
+		query = `INSERT INTO webhook_subscriptions (id, url, event_types, secret, active) VALUES ($1, $2, $3, $4, $5) RETURNING ` + subscriptionFields
+		res   Subscription
+	)
+
+	if err := r.conn(ctx).GetContext(ctx, &res, query, sub.ID, sub.URL, r.dialect.Array(sub.EventTypes), sub.Secret, sub.Active); err != nil {
+		return nil, err
+	}
+
+	return res.ToDomain(), nil
+}
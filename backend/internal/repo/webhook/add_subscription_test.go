@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/webhook"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddSubscription(t *testing.T) {
+	sub := &domain.Subscription{
+		ID: "sub-1", URL: "https://example.com/hook", EventTypes: []string{"book.created"}, Secret: "secret", Active: true,
+	}
+
+	tests := []struct {
+		name        string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedErr string
+	}{
+		{
+			name: "successful add subscription",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO webhook_subscriptions \(id, url, event_types, secret, active\) VALUES \(\$1, \$2, \$3, \$4, \$5\) RETURNING id, url, event_types, secret, active, created_at, updated_at`).
+					WithArgs("sub-1", "https://example.com/hook", pq.Array([]string{"book.created"}), "secret", true).
+					WillReturnRows(sqlmock.NewRows(subscriptionColumns).
+						AddRow("sub-1", "https://example.com/hook", "{book.created}", "secret", true, time.Now(), time.Now()))
+			},
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO webhook_subscriptions`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			res, err := repo.AddSubscription(context.Background(), sub)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, sub.ID, res.ID)
+				assert.Equal(t, sub.EventTypes, res.EventTypes)
+				assert.False(t, res.CreatedAt.IsZero())
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
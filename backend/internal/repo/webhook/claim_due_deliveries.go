@@ -0,0 +1,28 @@
+package webhook
+
+import (
+	domain "booklib/internal/domain/webhook"
+	"context"
+	"time"
+)
+
+func (r *repo) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.Delivery, error) {
+	var (
+		// SKIP LOCKED lets several workers claim disjoint sets of deliveries
+		query = `UPDATE webhook_deliveries SET next_attempt_at = $1, updated_at = ` + r.dialect.Now() + ` WHERE id IN (` +
+			`SELECT id FROM webhook_deliveries WHERE status = $2 AND next_attempt_at <= $3 ` +
+			`ORDER BY next_attempt_at, id LIMIT $4` + r.dialect.SkipLocked() + `) RETURNING ` + deliveryFields
+		result = []domain.Delivery{}
+	)
+
+	var deliveries []Delivery
+	if err := r.conn(ctx).SelectContext(ctx, &deliveries, query, r.dialect.Time(leaseUntil), domain.DeliveryStatusPending, r.dialect.Time(now), limit); err != nil {
+		return nil, err
+	}
+
+	for _, d := range deliveries {
+		result = append(result, *d.ToDomain())
+	}
+
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/webhook"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimDueDeliveries(t *testing.T) {
+	var (
+		now   = time.Now()
+		lease = now.Add(time.Minute)
+	)
+
+	tests := []struct {
+		name        string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedIDs []int64
+		expectedErr string
+	}{
+		{
+			name: "claims due pending deliveries",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE webhook_deliveries SET next_attempt_at = \$1, updated_at = NOW\(\) WHERE id IN \(SELECT id FROM webhook_deliveries WHERE status = \$2 AND next_attempt_at <= \$3 ORDER BY next_attempt_at, id LIMIT \$4 FOR UPDATE SKIP LOCKED\) RETURNING id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_error, response_status, created_at, updated_at`).
+					WithArgs(lease, domain.DeliveryStatusPending, now, 10).
+					WillReturnRows(sqlmock.NewRows(deliveryColumns).
+						AddRow(1, "sub-1", 5, "book.created", []byte(`{}`), "pending", 0, lease, "", 0, now, now))
+			},
+			expectedIDs: []int64{1},
+		},
+		{
+			name: "nothing due",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE webhook_deliveries`).
+					WillReturnRows(sqlmock.NewRows(deliveryColumns))
+			},
+			expectedIDs: []int64{},
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE webhook_deliveries`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			deliveries, err := repo.ClaimDueDeliveries(context.Background(), now, lease, 10)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, deliveries)
+			} else {
+				assert.NoError(t, err)
+				ids := make([]int64, 0, len(deliveries))
+				for _, d := range deliveries {
+					ids = append(ids, d.ID)
+				}
+				assert.Equal(t, tt.expectedIDs, ids)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package webhook
+
+import "context"
+
+func (r *repo) DeleteSubscription(ctx context.Context, id string) error {
+	query := `DELETE FROM webhook_subscriptions WHERE id = $1`
+
+	if _, err := r.conn(ctx).ExecContext(ctx, query, id); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	domain "booklib/internal/domain/webhook"
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (r *repo) GetDeliveries(ctx context.Context, filter domain.DeliveryFilter) ([]domain.Delivery, error) {
+	var (
+		query      = `SELECT ` + deliveryFields + ` FROM webhook_deliveries`
+		conditions []string
+		args       []interface{}
+		result     = []domain.Delivery{}
+	)
+
+	if filter.SubscriptionID != "" {
+		args = append(args, filter.SubscriptionID)
+		conditions = append(conditions, fmt.Sprintf("subscription_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultDeliveriesLimit
+	}
+	args = append(args, limit)
+	// newest first, which is what a delivery log is read for
+	query += fmt.Sprintf(` ORDER BY id DESC LIMIT $%d`, len(args))
+
+	var deliveries []Delivery
+	if err := r.conn(ctx).SelectContext(ctx, &deliveries, query, args...); err != nil {
+		return nil, err
+	}
+
+	for _, d := range deliveries {
+		result = append(result, *d.ToDomain())
+	}
+
+	return result, nil
+}
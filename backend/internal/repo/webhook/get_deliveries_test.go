@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/webhook"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDeliveries(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      domain.DeliveryFilter
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedIDs []int64
+		expectedErr string
+	}{
+		{
+			name: "without filter",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_error, response_status, created_at, updated_at FROM webhook_deliveries ORDER BY id DESC LIMIT \$1`).
+					WithArgs(defaultDeliveriesLimit).
+					WillReturnRows(sqlmock.NewRows(deliveryColumns).
+						AddRow(2, "sub-1", 5, "book.created", []byte(`{}`), "succeeded", 1, time.Now(), "", 200, time.Now(), time.Now()).
+						AddRow(1, "sub-1", 4, "book.created", []byte(`{}`), "dead", 8, time.Now(), "timeout", 0, time.Now(), time.Now()))
+			},
+			expectedIDs: []int64{2, 1},
+		},
+		{
+			name:   "by subscription and status",
+			filter: domain.DeliveryFilter{SubscriptionID: "sub-1", Status: domain.DeliveryStatusDead, Limit: 5},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_error, response_status, created_at, updated_at FROM webhook_deliveries WHERE subscription_id = \$1 AND status = \$2 ORDER BY id DESC LIMIT \$3`).
+					WithArgs("sub-1", domain.DeliveryStatusDead, 5).
+					WillReturnRows(sqlmock.NewRows(deliveryColumns).
+						AddRow(1, "sub-1", 4, "book.created", []byte(`{}`), "dead", 8, time.Now(), "timeout", 0, time.Now(), time.Now()))
+			},
+			expectedIDs: []int64{1},
+		},
+		{
+			name:   "by status only",
+			filter: domain.DeliveryFilter{Status: domain.DeliveryStatusDead},
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_error, response_status, created_at, updated_at FROM webhook_deliveries WHERE status = \$1 ORDER BY id DESC LIMIT \$2`).
+					WithArgs(domain.DeliveryStatusDead, defaultDeliveriesLimit).
+					WillReturnRows(sqlmock.NewRows(deliveryColumns))
+			},
+			expectedIDs: []int64{},
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_error, response_status, created_at, updated_at FROM webhook_deliveries`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			deliveries, err := repo.GetDeliveries(context.Background(), tt.filter)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, deliveries)
+			} else {
+				assert.NoError(t, err)
+				ids := make([]int64, 0, len(deliveries))
+				for _, d := range deliveries {
+					ids = append(ids, d.ID)
+				}
+				assert.Equal(t, tt.expectedIDs, ids)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
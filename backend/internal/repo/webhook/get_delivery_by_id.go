@@ -0,0 +1,24 @@
+package webhook
+
+import (
+	domain "booklib/internal/domain/webhook"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+func (r *repo) GetDeliveryByID(ctx context.Context, id int64) (*domain.Delivery, error) {
+	var (
+		query = `SELECT ` + deliveryFields + ` FROM webhook_deliveries WHERE id = $1`
+		d     Delivery
+	)
+
+	if err := r.conn(ctx).GetContext(ctx, &d, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return d.ToDomain(), nil
+}
@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDeliveryByID(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedNil bool
+		expectedErr string
+	}{
+		{
+			name: "successful get delivery",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_error, response_status, created_at, updated_at FROM webhook_deliveries WHERE id = \$1`).
+					WithArgs(int64(7)).
+					WillReturnRows(sqlmock.NewRows(deliveryColumns).
+						AddRow(7, "sub-1", 5, "book.created", []byte(`{}`), "dead", 8, time.Now(), "timeout", 0, time.Now(), time.Now()))
+			},
+		},
+		{
+			name: "delivery not found",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_error, response_status, created_at, updated_at FROM webhook_deliveries`).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedNil: true,
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_error, response_status, created_at, updated_at FROM webhook_deliveries`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			d, err := repo.GetDeliveryByID(context.Background(), 7)
+
+			switch {
+			case tt.expectedErr != "":
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, d)
+			case tt.expectedNil:
+				assert.NoError(t, err)
+				assert.Nil(t, d)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, int64(7), d.ID)
+				assert.Equal(t, "dead", d.Status)
+				assert.Equal(t, 8, d.Attempts)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
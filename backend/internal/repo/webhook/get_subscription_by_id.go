@@ -0,0 +1,24 @@
+package webhook
+
+import (
+	domain "booklib/internal/domain/webhook"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+func (r *repo) GetSubscriptionByID(ctx context.Context, id string) (*domain.Subscription, error) {
+	var (
+		query = `SELECT ` + subscriptionFields + ` FROM webhook_subscriptions WHERE id = $1`
+		sub   Subscription
+	)
+
+	if err := r.conn(ctx).GetContext(ctx, &sub, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return sub.ToDomain(), nil
+}
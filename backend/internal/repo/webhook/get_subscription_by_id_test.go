@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSubscriptionByID(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedNil bool
+		expectedErr string
+	}{
+		{
+			name: "successful get subscription",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, url, event_types, secret, active, created_at, updated_at FROM webhook_subscriptions WHERE id = \$1`).
+					WithArgs("sub-1").
+					WillReturnRows(sqlmock.NewRows(subscriptionColumns).
+						AddRow("sub-1", "https://example.com", "{book.created}", "secret", true, time.Now(), time.Now()))
+			},
+		},
+		{
+			name: "subscription not found",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, url, event_types, secret, active, created_at, updated_at FROM webhook_subscriptions WHERE id = \$1`).
+					WithArgs("sub-1").
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedNil: true,
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, url, event_types, secret, active, created_at, updated_at FROM webhook_subscriptions`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			sub, err := repo.GetSubscriptionByID(context.Background(), "sub-1")
+
+			switch {
+			case tt.expectedErr != "":
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, sub)
+			case tt.expectedNil:
+				assert.NoError(t, err)
+				assert.Nil(t, sub)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, "sub-1", sub.ID)
+				assert.Equal(t, "secret", sub.Secret)
+				assert.Equal(t, []string{"book.created"}, sub.EventTypes)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
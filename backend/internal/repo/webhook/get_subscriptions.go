@@ -0,0 +1,24 @@
+package webhook
+
+import (
+	domain "booklib/internal/domain/webhook"
+	"context"
+)
+
+func (r *repo) GetSubscriptions(ctx context.Context) ([]domain.Subscription, error) {
+	var (
+		query  = `SELECT ` + subscriptionFields + ` FROM webhook_subscriptions ORDER BY created_at, id`
+		result = []domain.Subscription{}
+	)
+
+	var subs []Subscription
+	if err := r.conn(ctx).SelectContext(ctx, &subs, query); err != nil {
+		return nil, err
+	}
+
+	for _, sub := range subs {
+		result = append(result, *sub.ToDomain())
+	}
+
+	return result, nil
+}
@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSubscriptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedIDs []string
+		expectedErr string
+	}{
+		{
+			name: "returns subscriptions oldest first",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, url, event_types, secret, active, created_at, updated_at FROM webhook_subscriptions ORDER BY created_at, id`).
+					WillReturnRows(sqlmock.NewRows(subscriptionColumns).
+						AddRow("sub-1", "https://a.example.com", "{}", "s1", true, time.Now(), time.Now()).
+						AddRow("sub-2", "https://b.example.com", "{book.deleted}", "s2", false, time.Now(), time.Now()))
+			},
+			expectedIDs: []string{"sub-1", "sub-2"},
+		},
+		{
+			name: "no subscriptions",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, url, event_types, secret, active, created_at, updated_at FROM webhook_subscriptions`).
+					WillReturnRows(sqlmock.NewRows(subscriptionColumns))
+			},
+			expectedIDs: []string{},
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, url, event_types, secret, active, created_at, updated_at FROM webhook_subscriptions`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			subs, err := repo.GetSubscriptions(context.Background())
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, subs)
+			} else {
+				assert.NoError(t, err)
+				ids := make([]string, 0, len(subs))
+				for _, s := range subs {
+					ids = append(ids, s.ID)
+				}
+				assert.Equal(t, tt.expectedIDs, ids)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
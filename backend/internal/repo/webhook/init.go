@@ -0,0 +1,35 @@
+package webhook
+
+import (
+	"context"
+
+	domain "booklib/internal/domain/webhook"
+	"booklib/internal/repo/dialect"
+	"booklib/internal/repo/sqltx"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// maxRowsPerStatement keeps bulk statements well below the postgres limit
+	// of 65535 bind parameters.
+	maxRowsPerStatement = 1000
+
+	defaultDeliveriesLimit = 100
+)
+
+type repo struct {
+	db      *sqlx.DB
+	dialect dialect.Dialect
+}
+
+func New(db *sqlx.DB) domain.Repository {
+	return &repo{
+		db:      db,
+		dialect: dialect.Of(db),
+	}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repo) conn(ctx context.Context) sqltx.Queryer {
+	return sqltx.Conn(ctx, r.db)
+}
@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	"testing"
+
+	domain "booklib/internal/domain/webhook"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	subscriptionColumns = []string{"id", "url", "event_types", "secret", "active", "created_at", "updated_at"}
+	deliveryColumns     = []string{
+		"id", "subscription_id", "event_id", "event_type", "payload", "status", "attempts",
+		"next_attempt_at", "last_error", "response_status", "created_at", "updated_at",
+	}
+)
+
+func TestNew(t *testing.T) {
+	t.Run("creates new repository with database connection", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		sqlxDB := sqlx.NewDb(db, "sqlmock")
+		repo := New(sqlxDB)
+
+		assert.NotNil(t, repo)
+		assert.Implements(t, (*domain.Repository)(nil), repo)
+	})
+}
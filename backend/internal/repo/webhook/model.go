@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	domain "booklib/internal/domain/webhook"
+	"booklib/internal/repo/dialect"
+	"encoding/json"
+	"time"
+)
+
+// subscriptionFields and deliveryFields are the columns of Subscription and
+// Delivery, named rather than selected with * so that a column added by a
+// later migration does not break scanning.
+const (
+	subscriptionFields = `id, url, event_types, secret, active, created_at, updated_at`
+	deliveryFields     = `id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, ` +
+		`last_error, response_status, created_at, updated_at`
+)
+
+type Subscription struct {
+	ID         string              `db:"id"`
+	URL        string              `db:"url"`
+	EventTypes dialect.StringArray `db:"event_types"`
+	Secret     string              `db:"secret"`
+	Active     bool                `db:"active"`
+	CreatedAt  time.Time           `db:"created_at"`
+	UpdatedAt  time.Time           `db:"updated_at"`
+}
+
+func (s *Subscription) ToDomain() *domain.Subscription {
+	eventTypes := []string(s.EventTypes)
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	return &domain.Subscription{
+		ID:         s.ID,
+		URL:        s.URL,
+		EventTypes: eventTypes,
+		Secret:     s.Secret,
+		Active:     s.Active,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+}
+
+type Delivery struct {
+	ID             int64     `db:"id"`
+	SubscriptionID string    `db:"subscription_id"`
+	EventID        int64     `db:"event_id"`
+	EventType      string    `db:"event_type"`
+	Payload        []byte    `db:"payload"`
+	Status         string    `db:"status"`
+	Attempts       int       `db:"attempts"`
+	NextAttemptAt  time.Time `db:"next_attempt_at"`
+	LastError      string    `db:"last_error"`
+	ResponseStatus int       `db:"response_status"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+func (d *Delivery) ToDomain() *domain.Delivery {
+	return &domain.Delivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        json.RawMessage(d.Payload),
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastError:      d.LastError,
+		ResponseStatus: d.ResponseStatus,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/webhook"
+	"booklib/internal/repo/dialect"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscription_ToDomain(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		model    Subscription
+		expected *domain.Subscription
+	}{
+		{
+			name: "maps every field",
+			model: Subscription{
+				ID: "sub-1", URL: "https://example.com/hook", EventTypes: dialect.StringArray{"book.created"},
+				Secret: "secret", Active: true, CreatedAt: now, UpdatedAt: now,
+			},
+			expected: &domain.Subscription{
+				ID: "sub-1", URL: "https://example.com/hook", EventTypes: []string{"book.created"},
+				Secret: "secret", Active: true, CreatedAt: now, UpdatedAt: now,
+			},
+		},
+		{
+			name:     "null event types become empty",
+			model:    Subscription{ID: "sub-2"},
+			expected: &domain.Subscription{ID: "sub-2", EventTypes: []string{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.model.ToDomain())
+		})
+	}
+}
+
+func TestDelivery_ToDomain(t *testing.T) {
+	now := time.Now()
+
+	model := Delivery{
+		ID: 1, SubscriptionID: "sub-1", EventID: 2, EventType: "book.created", Payload: []byte(`{"id":2}`),
+		Status: domain.DeliveryStatusPending, Attempts: 3, NextAttemptAt: now, LastError: "timeout",
+		ResponseStatus: 502, CreatedAt: now, UpdatedAt: now,
+	}
+
+	assert.Equal(t, &domain.Delivery{
+		ID: 1, SubscriptionID: "sub-1", EventID: 2, EventType: "book.created", Payload: json.RawMessage(`{"id":2}`),
+		Status: domain.DeliveryStatusPending, Attempts: 3, NextAttemptAt: now, LastError: "timeout",
+		ResponseStatus: 502, CreatedAt: now, UpdatedAt: now,
+	}, model.ToDomain())
+}
@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/webhook"
+	"booklib/internal/repo/sqlitetest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepo_SQLite(t *testing.T) {
+	ctx := context.Background()
+
+	db := sqlitetest.New(t)
+	// columns added by a later migration are not selected
+	db.MustExec(`ALTER TABLE webhook_subscriptions ADD COLUMN note TEXT`)
+	db.MustExec(`ALTER TABLE webhook_deliveries ADD COLUMN note TEXT`)
+	repo := New(db)
+
+	sub, err := repo.AddSubscription(ctx, &domain.Subscription{ID: "sub-1", URL: "https://example.com/hook", EventTypes: []string{"book.created"}, Secret: "secret", Active: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"book.created"}, sub.EventTypes)
+	assert.True(t, sub.Active)
+
+	sub, err = repo.UpdateSubscription(ctx, &domain.Subscription{ID: "sub-1", URL: "https://example.com/new", EventTypes: []string{}, Secret: "secret"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, sub.EventTypes)
+	assert.False(t, sub.Active)
+
+	subs, err := repo.GetSubscriptions(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, subs, 1)
+
+	now := time.Now()
+	deliveries := []*domain.Delivery{
+		{SubscriptionID: "sub-1", EventID: 1, EventType: "book.created", Payload: json.RawMessage(`{"id":1}`), Status: domain.DeliveryStatusPending, NextAttemptAt: now},
+		{SubscriptionID: "sub-1", EventID: 2, EventType: "book.created", Payload: json.RawMessage(`{"id":2}`), Status: domain.DeliveryStatusPending, NextAttemptAt: now.Add(time.Hour)},
+	}
+	assert.NoError(t, repo.AddDeliveries(ctx, deliveries))
+	// a retried event is queued once per subscription
+	again := []*domain.Delivery{{SubscriptionID: "sub-1", EventID: 1, EventType: "book.created", Payload: json.RawMessage(`{"id":1}`), Status: domain.DeliveryStatusPending, NextAttemptAt: now}}
+	assert.NoError(t, repo.AddDeliveries(ctx, again))
+	assert.Zero(t, again[0].ID)
+	assert.Error(t, repo.AddDeliveries(ctx, []*domain.Delivery{{SubscriptionID: "missing", Payload: json.RawMessage(`{}`)}}))
+
+	claimed, err := repo.ClaimDueDeliveries(ctx, now, now.Add(time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, deliveries[0].ID, claimed[0].ID)
+	assert.JSONEq(t, `{"id":1}`, string(claimed[0].Payload))
+
+	d := claimed[0]
+	d.Status, d.Attempts, d.ResponseStatus = domain.DeliveryStatusSucceeded, 1, 204
+	assert.NoError(t, repo.UpdateDelivery(ctx, &d))
+
+	got, err := repo.GetDeliveryByID(ctx, d.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DeliveryStatusSucceeded, got.Status)
+	assert.Equal(t, 204, got.ResponseStatus)
+
+	list, err := repo.GetDeliveries(ctx, domain.DeliveryFilter{Status: domain.DeliveryStatusPending})
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, deliveries[1].ID, list[0].ID)
+
+	// deliveries go with their subscription
+	assert.NoError(t, repo.DeleteSubscription(ctx, "sub-1"))
+	got, err = repo.GetDeliveryByID(ctx, d.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,17 @@
+package webhook
+
+import (
+	domain "booklib/internal/domain/webhook"
+	"context"
+)
+
+func (r *repo) UpdateDelivery(ctx context.Context, d *domain.Delivery) error {
+	query := `UPDATE webhook_deliveries SET status = $1, attempts = $2, next_attempt_at = $3, last_error = $4, ` +
+		`response_status = $5, updated_at = ` + r.dialect.Now() + ` WHERE id = $6`
+
+	if _, err := r.conn(ctx).ExecContext(ctx, query, d.Status, d.Attempts, r.dialect.Time(d.NextAttemptAt), d.LastError, d.ResponseStatus, d.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/webhook"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateDelivery(t *testing.T) {
+	d := &domain.Delivery{
+		ID: 7, Status: domain.DeliveryStatusPending, Attempts: 2, NextAttemptAt: time.Now(),
+		LastError: "unexpected status 502", ResponseStatus: 502,
+	}
+
+	tests := []struct {
+		name        string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedErr string
+	}{
+		{
+			name: "successful update delivery",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE webhook_deliveries SET status = \$1, attempts = \$2, next_attempt_at = \$3, last_error = \$4, response_status = \$5, updated_at = NOW\(\) WHERE id = \$6`).
+					WithArgs(d.Status, d.Attempts, d.NextAttemptAt, d.LastError, d.ResponseStatus, d.ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE webhook_deliveries`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			err = repo.UpdateDelivery(context.Background(), d)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
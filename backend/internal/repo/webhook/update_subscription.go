@@ -0,0 +1,24 @@
+package webhook
+
+import (
+	domain "booklib/internal/domain/webhook"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+func (r *repo) UpdateSubscription(ctx context.Context, sub *domain.Subscription) (*domain.Subscription, error) {
+	var (
+		query = `UPDATE webhook_subscriptions SET url = $1, event_types = $2, secret = $3, active = $4, updated_at = ` + r.dialect.Now() + ` WHERE id = $5 RETURNING ` + subscriptionFields
+		res   Subscription
+	)
+
+	if err := r.conn(ctx).GetContext(ctx, &res, query, sub.URL, r.dialect.Array(sub.EventTypes), sub.Secret, sub.Active, sub.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return res.ToDomain(), nil
+}
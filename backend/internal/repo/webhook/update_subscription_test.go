@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/webhook"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateSubscription(t *testing.T) {
+	sub := &domain.Subscription{
+		ID: "sub-1", URL: "https://example.com/new", EventTypes: []string{}, Secret: "secret", Active: false,
+	}
+
+	tests := []struct {
+		name        string
+		setupMocks  func(mock sqlmock.Sqlmock)
+		expectedNil bool
+		expectedErr string
+	}{
+		{
+			name: "successful update subscription",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE webhook_subscriptions SET url = \$1, event_types = \$2, secret = \$3, active = \$4, updated_at = NOW\(\) WHERE id = \$5 RETURNING id, url, event_types, secret, active, created_at, updated_at`).
+					WithArgs("https://example.com/new", pq.Array([]string{}), "secret", false, "sub-1").
+					WillReturnRows(sqlmock.NewRows(subscriptionColumns).
+						AddRow("sub-1", "https://example.com/new", "{}", "secret", false, time.Now(), time.Now()))
+			},
+		},
+		{
+			name: "subscription deleted before update",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE webhook_subscriptions`).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedNil: true,
+		},
+		{
+			name: "database error",
+			setupMocks: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`UPDATE webhook_subscriptions`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := New(sqlx.NewDb(db, "sqlmock"))
+			tt.setupMocks(mock)
+
+			res, err := repo.UpdateSubscription(context.Background(), sub)
+
+			switch {
+			case tt.expectedErr != "":
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, res)
+			case tt.expectedNil:
+				assert.NoError(t, err)
+				assert.Nil(t, res)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, sub.URL, res.URL)
+				assert.False(t, res.Active)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package backup
+
+import (
+	"archive/tar"
+	domain "booklib/internal/domain/backup"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// maxArchiveSize bounds the decompressed size of an archive read for a
+// restore, which is held in memory, so that a small upload cannot inflate
+// into an endless one.
+const maxArchiveSize = 1 << 30
+
+// An archive is a gzipped tar holding, in this order, the manifest, one JSON
+// Lines file per entity and a SHA256SUMS file covering all of them, so it can
+// also be checked with `sha256sum -c` once extracted.
+type archive struct {
+	manifest domain.Manifest
+	// files holds the content of every file, by name
+	files map[string][]byte
+}
+
+func invalidArchive(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", domain.ErrInvalidArchive, fmt.Sprintf(format, args...))
+}
+
+func entityFile(entity string) string {
+	return entity + ".jsonl"
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// lines splits a JSON Lines file into its records.
+func lines(data []byte) [][]byte {
+	var result [][]byte
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) > 0 {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+func writeArchive(w io.Writer, a *archive) error {
+	manifest, err := json.MarshalIndent(a.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	var (
+		names = []string{domain.ManifestFile}
+		files = map[string][]byte{domain.ManifestFile: manifest}
+		sums  strings.Builder
+	)
+	for _, ef := range a.manifest.Entities {
+		names = append(names, ef.File)
+		files[ef.File] = a.files[ef.File]
+	}
+	for _, name := range names {
+		_, _ = fmt.Fprintf(&sums, "%s  %s\n", checksum(files[name]), name)
+	}
+	names = append(names, domain.ChecksumsFile)
+	files[domain.ChecksumsFile] = []byte(sums.String())
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, name := range names {
+		hdr := &tar.Header{
+			Name:    name,
+			Mode:    0o644,
+			Size:    int64(len(files[name])),
+			ModTime: a.manifest.CreatedAt,
+		}
+		if err = tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err = tw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	if err = tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// readArchive reads an archive of up to maxSize bytes once decompressed and
+// checks it is complete and intact: every checksum matches, the manifest is of
+// a known format and every entity file it lists is there with the announced
+// number of records.
+func readArchive(r io.Reader, maxSize int64) (*archive, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, invalidArchive("not a gzip file: %v", err)
+	}
+	defer gz.Close()
+
+	var (
+		files = map[string][]byte{}
+		// one byte over maxSize tells a larger archive from one of maxSize
+		lr       = &io.LimitedReader{R: gz, N: maxSize + 1}
+		tooLarge = func() error {
+			return invalidArchive("the archive is larger than %d bytes once decompressed", maxSize)
+		}
+		tr = tar.NewReader(lr)
+	)
+	for {
+		hdr, err := tr.Next()
+		if lr.N == 0 {
+			return nil, tooLarge()
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, invalidArchive("corrupt tar file: %v", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if _, ok := files[hdr.Name]; ok {
+			return nil, invalidArchive("%s appears twice", hdr.Name)
+		}
+		if hdr.Size >= lr.N {
+			return nil, tooLarge()
+		}
+		if files[hdr.Name], err = io.ReadAll(tr); err != nil {
+			if lr.N == 0 {
+				return nil, tooLarge()
+			}
+			return nil, invalidArchive("corrupt tar file: %v", err)
+		}
+	}
+
+	if err = verifyChecksums(files); err != nil {
+		return nil, err
+	}
+
+	a := &archive{files: files}
+	raw, ok := files[domain.ManifestFile]
+	if !ok {
+		return nil, invalidArchive("%s is missing", domain.ManifestFile)
+	}
+	if err = json.Unmarshal(raw, &a.manifest); err != nil {
+		return nil, invalidArchive("%s: %v", domain.ManifestFile, err)
+	}
+
+	if err = verifyManifest(&a.manifest, files); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func verifyChecksums(files map[string][]byte) error {
+	sums, ok := files[domain.ChecksumsFile]
+	if !ok {
+		return invalidArchive("%s is missing", domain.ChecksumsFile)
+	}
+
+	listed := map[string]bool{}
+	sc := bufio.NewScanner(bytes.NewReader(sums))
+	for sc.Scan() {
+		sum, name, ok := strings.Cut(sc.Text(), "  ")
+		if !ok {
+			return invalidArchive("malformed %s line %q", domain.ChecksumsFile, sc.Text())
+		}
+		data, ok := files[name]
+		if !ok {
+			return invalidArchive("%s is missing", name)
+		}
+		if checksum(data) != sum {
+			return invalidArchive("checksum mismatch for %s", name)
+		}
+		listed[name] = true
+	}
+
+	for name := range files {
+		if name != domain.ChecksumsFile && !listed[name] {
+			return invalidArchive("%s has no checksum", name)
+		}
+	}
+	return nil
+}
+
+func verifyManifest(m *domain.Manifest, files map[string][]byte) error {
+	if m.FormatVersion < 1 || m.FormatVersion > domain.FormatVersion {
+		return invalidArchive("format version %d is not supported, this build reads up to %d", m.FormatVersion, domain.FormatVersion)
+	}
+	if m.SchemaVersion == "" {
+		return invalidArchive("manifest has no schema version")
+	}
+
+	seen := map[string]bool{}
+	for _, ef := range m.Entities {
+		if !slices.Contains(domain.Entities, ef.Name) {
+			return invalidArchive("unknown entity %q", ef.Name)
+		}
+		if seen[ef.Name] {
+			return invalidArchive("entity %q is listed twice", ef.Name)
+		}
+		seen[ef.Name] = true
+
+		data, ok := files[ef.File]
+		if !ok {
+			return invalidArchive("%s is missing", ef.File)
+		}
+		if checksum(data) != ef.SHA256 {
+			return invalidArchive("checksum mismatch for %s", ef.File)
+		}
+		if n := len(lines(data)); n != ef.Records {
+			return invalidArchive("%s has %d records, the manifest announces %d", ef.File, n, ef.Records)
+		}
+	}
+	return nil
+}
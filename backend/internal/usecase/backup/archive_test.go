@@ -0,0 +1,243 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/backup"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newArchive builds an archive of the given entity files, keyed by entity.
+func newArchive(t *testing.T, schemaVersion string, entityFiles map[string]string) *archive {
+	a := &archive{
+		manifest: domain.Manifest{
+			FormatVersion: domain.FormatVersion,
+			SchemaVersion: schemaVersion,
+			CreatedAt:     time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC),
+		},
+		files: map[string][]byte{},
+	}
+	for _, name := range domain.Entities {
+		content, ok := entityFiles[name]
+		if !ok {
+			continue
+		}
+		file := entityFile(name)
+		a.files[file] = []byte(content)
+		a.manifest.Entities = append(a.manifest.Entities, domain.EntityFile{
+			Name: name, File: file, Records: len(lines([]byte(content))), SHA256: checksum([]byte(content)),
+		})
+	}
+	return a
+}
+
+func encodeArchive(t *testing.T, a *archive) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, writeArchive(&buf, a))
+	return buf.Bytes()
+}
+
+// rawArchive writes the given files into a gzipped tar as they are.
+func rawArchive(t *testing.T, files [][2]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, f := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: f[0], Mode: 0o644, Size: int64(len(f[1]))}))
+		_, err := tw.Write([]byte(f[1]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func sums(files ...[2]string) string {
+	var sb strings.Builder
+	for _, f := range files {
+		_, _ = fmt.Fprintf(&sb, "%s  %s\n", checksum([]byte(f[1])), f[0])
+	}
+	return sb.String()
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	books := `{"id":"a","title":"Emma"}` + "\n" + `{"id":"b","title":"Dune"}` + "\n"
+	a := newArchive(t, "20261019_04", map[string]string{domain.EntityBooks: books, domain.EntityEvents: ""})
+
+	raw := encodeArchive(t, a)
+
+	t.Run("files are in order", func(t *testing.T) {
+		gz, err := gzip.NewReader(bytes.NewReader(raw))
+		require.NoError(t, err)
+		tr := tar.NewReader(gz)
+
+		var names []string
+		for {
+			hdr, err := tr.Next()
+			if err != nil {
+				break
+			}
+			names = append(names, hdr.Name)
+		}
+		assert.Equal(t, []string{"manifest.json", "books.jsonl", "events.jsonl", "SHA256SUMS"}, names)
+	})
+
+	t.Run("reads back what was written", func(t *testing.T) {
+		read, err := readArchive(bytes.NewReader(raw), maxArchiveSize)
+		require.NoError(t, err)
+
+		assert.Equal(t, a.manifest, read.manifest)
+		assert.Equal(t, books, string(read.files["books.jsonl"]))
+		assert.Empty(t, read.files["events.jsonl"])
+	})
+}
+
+func TestReadArchive(t *testing.T) {
+	var (
+		books    = [2]string{"books.jsonl", `{"id":"a"}` + "\n"}
+		manifest = func(format int, schema, sha string, records int) [2]string {
+			return [2]string{"manifest.json", fmt.Sprintf(
+				`{"format_version":%d,"schema_version":%q,"entities":[{"name":"books","file":"books.jsonl","records":%d,"sha256":%q}]}`,
+				format, schema, records, sha)}
+		}
+		valid = manifest(1, "20261019_04", checksum([]byte(books[1])), 1)
+		// decompressedSize is the size of the tar inside a gzipped archive
+		decompressedSize = func(raw []byte) int64 {
+			gz, err := gzip.NewReader(bytes.NewReader(raw))
+			require.NoError(t, err)
+			n, err := io.Copy(io.Discard, gz)
+			require.NoError(t, err)
+			return n
+		}
+		validArchive = rawArchive(t, [][2]string{valid, books, {"SHA256SUMS", sums(valid, books)}})
+		bomb         = func() []byte {
+			// a megabyte of zeros gzips into a few kilobytes
+			zeros := [2]string{"books.jsonl", strings.Repeat("\x00", 1<<20)}
+			m := manifest(1, "20261019_04", checksum([]byte(zeros[1])), 0)
+			return rawArchive(t, [][2]string{m, zeros, {"SHA256SUMS", sums(m, zeros)}})
+		}()
+	)
+
+	tests := []struct {
+		name  string
+		input []byte
+		// maxSize defaults to maxArchiveSize
+		maxSize     int64
+		expectedErr string
+	}{
+		{
+			name:    "archive of the largest size",
+			input:   validArchive,
+			maxSize: decompressedSize(validArchive),
+		},
+		{
+			name:        "archive over the largest size",
+			input:       validArchive,
+			maxSize:     decompressedSize(validArchive) - 1,
+			expectedErr: fmt.Sprintf("the archive is larger than %d bytes once decompressed", decompressedSize(validArchive)-1),
+		},
+		{
+			name:        "file over the largest size",
+			input:       bomb,
+			maxSize:     64 << 10,
+			expectedErr: "the archive is larger than 65536 bytes once decompressed",
+		},
+		{
+			name:  "valid archive",
+			input: rawArchive(t, [][2]string{valid, books, {"SHA256SUMS", sums(valid, books)}}),
+		},
+		{
+			name:        "not gzipped",
+			input:       []byte("plain text"),
+			expectedErr: "not a gzip file",
+		},
+		{
+			name:        "without checksums",
+			input:       rawArchive(t, [][2]string{valid, books}),
+			expectedErr: "SHA256SUMS is missing",
+		},
+		{
+			name:        "checksum mismatch",
+			input:       rawArchive(t, [][2]string{valid, {"books.jsonl", `{"id":"b"}` + "\n"}, {"SHA256SUMS", sums(valid, books)}}),
+			expectedErr: "checksum mismatch for books.jsonl",
+		},
+		{
+			name:        "file without checksum",
+			input:       rawArchive(t, [][2]string{valid, books, {"notes.txt", "hi"}, {"SHA256SUMS", sums(valid, books)}}),
+			expectedErr: "notes.txt has no checksum",
+		},
+		{
+			name:        "without manifest",
+			input:       rawArchive(t, [][2]string{books, {"SHA256SUMS", sums(books)}}),
+			expectedErr: "manifest.json is missing",
+		},
+		{
+			name: "newer format",
+			input: func() []byte {
+				m := manifest(domain.FormatVersion+1, "20261019_04", checksum([]byte(books[1])), 1)
+				return rawArchive(t, [][2]string{m, books, {"SHA256SUMS", sums(m, books)}})
+			}(),
+			expectedErr: fmt.Sprintf("format version %d is not supported, this build reads up to %d", domain.FormatVersion+1, domain.FormatVersion),
+		},
+		{
+			name: "without schema version",
+			input: func() []byte {
+				m := manifest(1, "", checksum([]byte(books[1])), 1)
+				return rawArchive(t, [][2]string{m, books, {"SHA256SUMS", sums(m, books)}})
+			}(),
+			expectedErr: "manifest has no schema version",
+		},
+		{
+			name: "record count mismatch",
+			input: func() []byte {
+				m := manifest(1, "20261019_04", checksum([]byte(books[1])), 3)
+				return rawArchive(t, [][2]string{m, books, {"SHA256SUMS", sums(m, books)}})
+			}(),
+			expectedErr: "books.jsonl has 1 records, the manifest announces 3",
+		},
+		{
+			name: "manifest checksum of another file",
+			input: func() []byte {
+				m := manifest(1, "20261019_04", checksum([]byte("other")), 1)
+				return rawArchive(t, [][2]string{m, books, {"SHA256SUMS", sums(m, books)}})
+			}(),
+			expectedErr: "checksum mismatch for books.jsonl",
+		},
+		{
+			name: "unknown entity",
+			input: func() []byte {
+				m := [2]string{"manifest.json", `{"format_version":1,"schema_version":"20261019_04","entities":[{"name":"loans","file":"loans.jsonl"}]}`}
+				return rawArchive(t, [][2]string{m, {"SHA256SUMS", sums(m)}})
+			}(),
+			expectedErr: `unknown entity "loans"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxSize := tt.maxSize
+			if maxSize == 0 {
+				maxSize = maxArchiveSize
+			}
+
+			_, err := readArchive(bytes.NewReader(tt.input), maxSize)
+
+			if tt.expectedErr != "" {
+				assert.True(t, errors.Is(err, domain.ErrInvalidArchive))
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
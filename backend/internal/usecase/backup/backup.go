@@ -0,0 +1,57 @@
+package backup
+
+import (
+	domain "booklib/internal/domain/backup"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+)
+
+func (u usecase) Backup(ctx context.Context, w io.Writer) (*domain.Manifest, error) {
+	a := &archive{
+		manifest: domain.Manifest{
+			FormatVersion: domain.FormatVersion,
+			CreatedAt:     u.now().UTC(),
+		},
+		files: map[string][]byte{},
+	}
+
+	// one transaction, so every entity is read as of the same moment
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.repo.Snapshot(ctx); err != nil {
+			return err
+		}
+
+		var err error
+		if a.manifest.SchemaVersion, err = u.repo.SchemaVersion(ctx); err != nil {
+			return err
+		}
+
+		for _, ent := range entities {
+			var buf bytes.Buffer
+			n, err := ent.dump(ctx, u.repo, json.NewEncoder(&buf))
+			if err != nil {
+				return err
+			}
+
+			file := entityFile(ent.name)
+			a.files[file] = buf.Bytes()
+			a.manifest.Entities = append(a.manifest.Entities, domain.EntityFile{
+				Name:    ent.name,
+				File:    file,
+				Records: n,
+				SHA256:  checksum(buf.Bytes()),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = writeArchive(w, a); err != nil {
+		return nil, err
+	}
+	return &a.manifest, nil
+}
@@ -0,0 +1,131 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/backup"
+	"booklib/internal/domain/backup/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var backupTime = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+// expectEmptyDump expects every entity but books to be read once, empty.
+func expectEmptyDump(repo *mocks.Repository) {
+	repo.On("GetEvents", mock.Anything, int64(0), pageSize).Return(nil, nil).Once()
+	repo.On("GetOutboxMessages", mock.Anything, int64(0), pageSize).Return(nil, nil).Once()
+	repo.On("GetSubscriptions", mock.Anything, "", pageSize).Return(nil, nil).Once()
+	repo.On("GetDeliveries", mock.Anything, int64(0), pageSize).Return(nil, nil).Once()
+	repo.On("GetLinks", mock.Anything, "", pageSize).Return(nil, nil).Once()
+	repo.On("GetLinkClicks", mock.Anything, domain.LinkClicksKey{}, pageSize).Return(nil, nil).Once()
+	repo.On("GetRuleSets", mock.Anything, "", pageSize).Return(nil, nil).Once()
+}
+
+func TestUsecase_Backup(t *testing.T) {
+	ctx := context.Background()
+	dbErr := errors.New("database error")
+
+	books := []domain.Book{
+		{ID: "a", Title: "Emma", Author: "Jane Austen", Year: 1815, CreatedAt: backupTime, UpdatedAt: backupTime},
+		{ID: "b", Title: "Dune", Author: "Frank Herbert", Year: 1965, CreatedAt: backupTime, UpdatedAt: backupTime},
+	}
+
+	tests := []struct {
+		name          string
+		mockSetup     func(repo *mocks.Repository)
+		expectedErr   error
+		expectedCount map[string]int
+	}{
+		{
+			name: "backs up every entity",
+			mockSetup: func(repo *mocks.Repository) {
+				repo.On("Snapshot", mock.Anything).Return(nil).Once()
+				repo.On("SchemaVersion", mock.Anything).Return("20261019_04", nil).Once()
+				repo.On("GetBooks", mock.Anything, "", pageSize).Return(books, nil).Once()
+				expectEmptyDump(repo)
+			},
+			expectedCount: map[string]int{domain.EntityBooks: 2},
+		},
+		{
+			name: "pages through large entities",
+			mockSetup: func(repo *mocks.Repository) {
+				full := make([]domain.Book, pageSize)
+				for i := range full {
+					full[i] = domain.Book{ID: fmt.Sprintf("%04d", i), CreatedAt: backupTime, UpdatedAt: backupTime}
+				}
+				repo.On("Snapshot", mock.Anything).Return(nil).Once()
+				repo.On("SchemaVersion", mock.Anything).Return("20261019_04", nil).Once()
+				repo.On("GetBooks", mock.Anything, "", pageSize).Return(full, nil).Once()
+				repo.On("GetBooks", mock.Anything, full[pageSize-1].ID, pageSize).Return(books, nil).Once()
+				expectEmptyDump(repo)
+			},
+			expectedCount: map[string]int{domain.EntityBooks: pageSize + 2},
+		},
+		{
+			name: "snapshot fails",
+			mockSetup: func(repo *mocks.Repository) {
+				repo.On("Snapshot", mock.Anything).Return(dbErr).Once()
+			},
+			expectedErr: dbErr,
+		},
+		{
+			name: "database was never migrated",
+			mockSetup: func(repo *mocks.Repository) {
+				repo.On("Snapshot", mock.Anything).Return(nil).Once()
+				repo.On("SchemaVersion", mock.Anything).Return("", domain.ErrNoSchemaVersion).Once()
+			},
+			expectedErr: domain.ErrNoSchemaVersion,
+		},
+		{
+			name: "reading an entity fails",
+			mockSetup: func(repo *mocks.Repository) {
+				repo.On("Snapshot", mock.Anything).Return(nil).Once()
+				repo.On("SchemaVersion", mock.Anything).Return("20261019_04", nil).Once()
+				repo.On("GetBooks", mock.Anything, "", pageSize).Return(books, nil).Once()
+				repo.On("GetEvents", mock.Anything, int64(0), pageSize).Return(nil, dbErr).Once()
+			},
+			expectedErr: dbErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			tt.mockSetup(repo)
+
+			uc := &usecase{repo: repo, tx: passthroughTx{}, now: func() time.Time { return backupTime }}
+
+			var buf bytes.Buffer
+			manifest, err := uc.Backup(ctx, &buf)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, manifest)
+				assert.Zero(t, buf.Len())
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, domain.FormatVersion, manifest.FormatVersion)
+			assert.Equal(t, "20261019_04", manifest.SchemaVersion)
+			assert.Equal(t, backupTime, manifest.CreatedAt)
+			require.Len(t, manifest.Entities, len(domain.Entities))
+			for i, ef := range manifest.Entities {
+				assert.Equal(t, domain.Entities[i], ef.Name)
+				assert.Equal(t, tt.expectedCount[ef.Name], ef.Records)
+			}
+
+			read, err := readArchive(&buf, maxArchiveSize)
+			require.NoError(t, err)
+			assert.Equal(t, *manifest, read.manifest)
+		})
+	}
+}
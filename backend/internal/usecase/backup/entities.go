@@ -0,0 +1,154 @@
+package backup
+
+import (
+	domain "booklib/internal/domain/backup"
+	"context"
+	"encoding/json"
+)
+
+// entity tells how to back up and restore one entity of domain.Entities.
+type entity struct {
+	name string
+	// since is the schema version that introduced the entity, archives of
+	// older schemas do not have it
+	since string
+	// format is the archive format that added the entity, archives of older
+	// formats do not have it either
+	format  int
+	dump    func(ctx context.Context, repo domain.Repository, enc *json.Encoder) (int, error)
+	restore func(ctx context.Context, repo domain.Repository, records [][]byte) error
+}
+
+var entities = []entity{
+	{
+		name: domain.EntityBooks,
+		dump: func(ctx context.Context, repo domain.Repository, enc *json.Encoder) (int, error) {
+			return dump(ctx, enc, repo.GetBooks, func(b domain.Book) string { return b.ID })
+		},
+		restore: func(ctx context.Context, repo domain.Repository, records [][]byte) error {
+			return restore(ctx, domain.EntityBooks, records, repo.AddBooks)
+		},
+	},
+	{
+		name:  domain.EntityEvents,
+		since: "20261019_02",
+		dump: func(ctx context.Context, repo domain.Repository, enc *json.Encoder) (int, error) {
+			return dump(ctx, enc, repo.GetEvents, func(e domain.Event) int64 { return e.ID })
+		},
+		restore: func(ctx context.Context, repo domain.Repository, records [][]byte) error {
+			return restore(ctx, domain.EntityEvents, records, repo.AddEvents)
+		},
+	},
+	{
+		name:  domain.EntityOutbox,
+		since: "20261019_04",
+		dump: func(ctx context.Context, repo domain.Repository, enc *json.Encoder) (int, error) {
+			return dump(ctx, enc, repo.GetOutboxMessages, func(m domain.OutboxMessage) int64 { return m.ID })
+		},
+		restore: func(ctx context.Context, repo domain.Repository, records [][]byte) error {
+			return restore(ctx, domain.EntityOutbox, records, repo.AddOutboxMessages)
+		},
+	},
+	{
+		name:  domain.EntityWebhookSubscriptions,
+		since: "20261019_03",
+		dump: func(ctx context.Context, repo domain.Repository, enc *json.Encoder) (int, error) {
+			return dump(ctx, enc, repo.GetSubscriptions, func(s domain.Subscription) string { return s.ID })
+		},
+		restore: func(ctx context.Context, repo domain.Repository, records [][]byte) error {
+			return restore(ctx, domain.EntityWebhookSubscriptions, records, repo.AddSubscriptions)
+		},
+	},
+	{
+		name:  domain.EntityWebhookDeliveries,
+		since: "20261019_03",
+		dump: func(ctx context.Context, repo domain.Repository, enc *json.Encoder) (int, error) {
+			return dump(ctx, enc, repo.GetDeliveries, func(d domain.Delivery) int64 { return d.ID })
+		},
+		restore: func(ctx context.Context, repo domain.Repository, records [][]byte) error {
+			return restore(ctx, domain.EntityWebhookDeliveries, records, repo.AddDeliveries)
+		},
+	},
+	{
+		name:   domain.EntityLinks,
+		since:  "20261019_05",
+		format: 2,
+		dump: func(ctx context.Context, repo domain.Repository, enc *json.Encoder) (int, error) {
+			return dump(ctx, enc, repo.GetLinks, func(l domain.Link) string { return l.Code })
+		},
+		restore: func(ctx context.Context, repo domain.Repository, records [][]byte) error {
+			return restore(ctx, domain.EntityLinks, records, repo.AddLinks)
+		},
+	},
+	{
+		name:   domain.EntityLinkClicks,
+		since:  "20261019_05",
+		format: 2,
+		dump: func(ctx context.Context, repo domain.Repository, enc *json.Encoder) (int, error) {
+			return dump(ctx, enc, repo.GetLinkClicks, func(c domain.LinkClicks) domain.LinkClicksKey {
+				return domain.LinkClicksKey{Code: c.Code, Day: c.Day}
+			})
+		},
+		restore: func(ctx context.Context, repo domain.Repository, records [][]byte) error {
+			return restore(ctx, domain.EntityLinkClicks, records, repo.AddLinkClicks)
+		},
+	},
+	{
+		name:   domain.EntityURLRuleSets,
+		since:  "20261019_06",
+		format: 3,
+		dump: func(ctx context.Context, repo domain.Repository, enc *json.Encoder) (int, error) {
+			return dump(ctx, enc, repo.GetRuleSets, func(rs domain.RuleSet) string { return rs.ID })
+		},
+		restore: func(ctx context.Context, repo domain.Repository, records [][]byte) error {
+			return restore(ctx, domain.EntityURLRuleSets, records, repo.AddRuleSets)
+		},
+	},
+}
+
+// dump pages through an entity and writes every record to enc, one per line.
+func dump[T any, K comparable](
+	ctx context.Context,
+	enc *json.Encoder,
+	get func(ctx context.Context, after K, limit int) ([]T, error),
+	key func(T) K,
+) (int, error) {
+	var (
+		after K
+		n     int
+	)
+	for {
+		page, err := get(ctx, after, pageSize)
+		if err != nil {
+			return n, err
+		}
+		for _, rec := range page {
+			if err = enc.Encode(rec); err != nil {
+				return n, err
+			}
+		}
+		n += len(page)
+
+		if len(page) < pageSize {
+			return n, nil
+		}
+		after = key(page[len(page)-1])
+	}
+}
+
+// restore decodes the records of an entity and adds them in batches.
+func restore[T any](ctx context.Context, name string, records [][]byte, add func(ctx context.Context, records []T) error) error {
+	decoded := make([]T, len(records))
+	for i, raw := range records {
+		if err := json.Unmarshal(raw, &decoded[i]); err != nil {
+			return invalidArchive("%s record %d: %v", name, i+1, err)
+		}
+	}
+
+	for start := 0; start < len(decoded); start += pageSize {
+		if err := add(ctx, decoded[start:min(start+pageSize, len(decoded))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
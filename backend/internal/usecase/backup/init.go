@@ -0,0 +1,26 @@
+package backup
+
+import (
+	domain "booklib/internal/domain/backup"
+	"booklib/internal/domain/transaction"
+	"time"
+)
+
+const (
+	// pageSize is how many records are read or written per statement.
+	pageSize = 1000
+)
+
+type usecase struct {
+	repo domain.Repository
+	tx   transaction.Manager
+	now  func() time.Time
+}
+
+func New(repo domain.Repository, tx transaction.Manager) UseCase {
+	return &usecase{
+		repo: repo,
+		tx:   tx,
+		now:  time.Now,
+	}
+}
@@ -0,0 +1,26 @@
+package backup
+
+import (
+	"context"
+	"testing"
+
+	"booklib/internal/domain/backup/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// passthroughTx runs a unit of work without a transaction.
+type passthroughTx struct{}
+
+func (passthroughTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestNew(t *testing.T) {
+	t.Run("creates new usecase with repository", func(t *testing.T) {
+		uc := New(mocks.NewRepository(t), passthroughTx{})
+
+		assert.NotNil(t, uc)
+		assert.Implements(t, (*UseCase)(nil), uc)
+	})
+}
@@ -0,0 +1,34 @@
+package backup
+
+import (
+	domain "booklib/internal/domain/backup"
+	"context"
+	"io"
+)
+
+type RestoreInput struct {
+	// DryRun validates the archive and restores it in a transaction that is
+	// rolled back, so the database is left as it was.
+	DryRun bool
+}
+
+type RestoreOutput struct {
+	Manifest domain.Manifest
+	// SchemaVersion is the schema of the database the archive was restored
+	// into; records of an older archive are upgraded to it.
+	SchemaVersion string
+	Upgraded      bool
+	// Restored counts the restored records of every entity.
+	Restored map[string]int
+	DryRun   bool
+}
+
+//go:generate mockery --name=UseCase --output=./mocks
+type UseCase interface {
+	// Backup writes an archive of every entity to w.
+	Backup(ctx context.Context, w io.Writer) (*domain.Manifest, error)
+	// Restore replaces every entity with the content of the archive read from
+	// r, in a single transaction. Invalid archives are refused with an error
+	// wrapping domain.ErrInvalidArchive before anything is changed.
+	Restore(ctx context.Context, r io.Reader, in RestoreInput) (*RestoreOutput, error)
+}
@@ -0,0 +1,93 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	backup "booklib/internal/domain/backup"
+	context "context"
+
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+
+	usecasebackup "booklib/internal/usecase/backup"
+)
+
+// UseCase is an autogenerated mock type for the UseCase type
+type UseCase struct {
+	mock.Mock
+}
+
+// Backup provides a mock function with given fields: ctx, w
+func (_m *UseCase) Backup(ctx context.Context, w io.Writer) (*backup.Manifest, error) {
+	ret := _m.Called(ctx, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Backup")
+	}
+
+	var r0 *backup.Manifest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Writer) (*backup.Manifest, error)); ok {
+		return rf(ctx, w)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, io.Writer) *backup.Manifest); ok {
+		r0 = rf(ctx, w)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*backup.Manifest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, io.Writer) error); ok {
+		r1 = rf(ctx, w)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, r, in
+func (_m *UseCase) Restore(ctx context.Context, r io.Reader, in usecasebackup.RestoreInput) (*usecasebackup.RestoreOutput, error) {
+	ret := _m.Called(ctx, r, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 *usecasebackup.RestoreOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader, usecasebackup.RestoreInput) (*usecasebackup.RestoreOutput, error)); ok {
+		return rf(ctx, r, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader, usecasebackup.RestoreInput) *usecasebackup.RestoreOutput); ok {
+		r0 = rf(ctx, r, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecasebackup.RestoreOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, io.Reader, usecasebackup.RestoreInput) error); ok {
+		r1 = rf(ctx, r, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUseCase creates a new instance of UseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UseCase {
+	mock := &UseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,74 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	// errDryRun rolls back the transaction of a dry run.
+	errDryRun = errors.New("dry run")
+)
+
+func (u usecase) Restore(ctx context.Context, r io.Reader, in RestoreInput) (*RestoreOutput, error) {
+	a, err := readArchive(r, maxArchiveSize)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &RestoreOutput{
+		Manifest: a.manifest,
+		Restored: make(map[string]int, len(entities)),
+		DryRun:   in.DryRun,
+	}
+
+	err = u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if out.SchemaVersion, err = u.repo.SchemaVersion(ctx); err != nil {
+			return err
+		}
+		if a.manifest.SchemaVersion > out.SchemaVersion {
+			return invalidArchive("the archive schema %s is newer than the database schema %s, migrate the database first",
+				a.manifest.SchemaVersion, out.SchemaVersion)
+		}
+		out.Upgraded = a.manifest.SchemaVersion < out.SchemaVersion
+
+		if err = u.repo.Clear(ctx); err != nil {
+			return err
+		}
+
+		for _, ent := range entities {
+			ef, ok := a.manifest.Entity(ent.name)
+			if !ok {
+				// entities newer than the archive are left empty
+				if a.manifest.SchemaVersion < ent.since || a.manifest.FormatVersion < ent.format {
+					continue
+				}
+				return invalidArchive("%s is missing from the manifest", ent.name)
+			}
+
+			records, err := upgradeRecords(&a.manifest, ent.name, lines(a.files[ef.File]))
+			if err != nil {
+				return err
+			}
+			if err = ent.restore(ctx, u.repo, records); err != nil {
+				return err
+			}
+			out.Restored[ent.name] = len(records)
+		}
+
+		if err = u.repo.ResetSequences(ctx); err != nil {
+			return err
+		}
+
+		if in.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,273 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/backup"
+	"booklib/internal/domain/backup/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsecase_Restore(t *testing.T) {
+	ctx := context.Background()
+	dbErr := errors.New("database error")
+
+	var (
+		bookLine  = `{"id":"a","title":"Emma","author":"Jane Austen","year":1815,"created_at":"2026-10-19T10:00:00Z","updated_at":"2026-10-19T10:00:00Z"}` + "\n"
+		eventLine = `{"id":7,"type":"book.created","aggregate_type":"book","aggregate_id":"a","occurred_at":"2026-10-19T10:00:00Z"}` + "\n"
+		current   = map[string]string{
+			domain.EntityBooks:                bookLine,
+			domain.EntityEvents:               eventLine,
+			domain.EntityOutbox:               "",
+			domain.EntityWebhookSubscriptions: "",
+			domain.EntityWebhookDeliveries:    "",
+		}
+		book = domain.Book{ID: "a", Title: "Emma", Author: "Jane Austen", Year: 1815, CreatedAt: backupTime, UpdatedAt: backupTime}
+
+		linkLine   = `{"code":"abc123","target_url":"https://example.com/a","custom":false,"expires_at":null,"created_at":"2026-10-19T10:00:00Z"}` + "\n"
+		clicksLine = `{"code":"abc123","day":"2026-10-19T00:00:00Z","clicks":3}` + "\n"
+		ruleLine   = `{"id":"rs-1","name":"shop","hosts":["shop.example.com"],"priority":10,"operations":{"canonical":[]},"active":true,"created_at":"2026-10-19T10:00:00Z","updated_at":"2026-10-19T10:00:00Z"}` + "\n"
+		withLinks  = map[string]string{
+			domain.EntityBooks:                bookLine,
+			domain.EntityEvents:               "",
+			domain.EntityOutbox:               "",
+			domain.EntityWebhookSubscriptions: "",
+			domain.EntityWebhookDeliveries:    "",
+			domain.EntityLinks:                linkLine,
+			domain.EntityLinkClicks:           clicksLine,
+			domain.EntityURLRuleSets:          ruleLine,
+		}
+	)
+
+	// expectRestore expects a full restore of the current archive
+	expectRestore := func(repo *mocks.Repository) {
+		repo.On("SchemaVersion", mock.Anything).Return("20261019_04", nil).Once()
+		repo.On("Clear", mock.Anything).Return(nil).Once()
+		repo.On("AddBooks", mock.Anything, []domain.Book{book}).Return(nil).Once()
+		repo.On("AddEvents", mock.Anything, mock.MatchedBy(func(events []domain.Event) bool {
+			return len(events) == 1 && events[0].ID == 7 && events[0].AggregateID == "a"
+		})).Return(nil).Once()
+		repo.On("ResetSequences", mock.Anything).Return(nil).Once()
+	}
+
+	tests := []struct {
+		name             string
+		archive          []byte
+		input            RestoreInput
+		mockSetup        func(repo *mocks.Repository)
+		expectedErr      error
+		expectedErrMsg   string
+		expectedRestored map[string]int
+		expectedUpgraded bool
+		// expectedSchema defaults to 20261019_04
+		expectedSchema string
+	}{
+		{
+			name:             "restores every entity",
+			archive:          encodeArchive(t, newArchive(t, "20261019_04", current)),
+			mockSetup:        expectRestore,
+			expectedRestored: map[string]int{"books": 1, "events": 1, "outbox": 0, "webhook_subscriptions": 0, "webhook_deliveries": 0},
+		},
+		{
+			name:             "dry run",
+			archive:          encodeArchive(t, newArchive(t, "20261019_04", current)),
+			input:            RestoreInput{DryRun: true},
+			mockSetup:        expectRestore,
+			expectedRestored: map[string]int{"books": 1, "events": 1, "outbox": 0, "webhook_subscriptions": 0, "webhook_deliveries": 0},
+		},
+		{
+			name: "upgrades archives of older schemas",
+			archive: encodeArchive(t, newArchive(t, "20250101_01", map[string]string{
+				domain.EntityBooks: `{"id":"a","title":"Emma","author":"Jane Austen","year":1815,"created_at":null,"updated_at":null}` + "\n",
+			})),
+			mockSetup: func(repo *mocks.Repository) {
+				repo.On("SchemaVersion", mock.Anything).Return("20261019_04", nil).Once()
+				repo.On("Clear", mock.Anything).Return(nil).Once()
+				repo.On("AddBooks", mock.Anything, []domain.Book{book}).Return(nil).Once()
+				repo.On("ResetSequences", mock.Anything).Return(nil).Once()
+			},
+			expectedRestored: map[string]int{"books": 1},
+			expectedUpgraded: true,
+		},
+		{
+			name:    "restores short links and url rule sets",
+			archive: encodeArchive(t, newArchive(t, "20261019_08", withLinks)),
+			mockSetup: func(repo *mocks.Repository) {
+				repo.On("SchemaVersion", mock.Anything).Return("20261019_08", nil).Once()
+				repo.On("Clear", mock.Anything).Return(nil).Once()
+				repo.On("AddBooks", mock.Anything, []domain.Book{book}).Return(nil).Once()
+				repo.On("AddLinks", mock.Anything, []domain.Link{
+					{Code: "abc123", TargetURL: "https://example.com/a", CreatedAt: backupTime},
+				}).Return(nil).Once()
+				repo.On("AddLinkClicks", mock.Anything, []domain.LinkClicks{
+					{Code: "abc123", Day: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Clicks: 3},
+				}).Return(nil).Once()
+				repo.On("AddRuleSets", mock.Anything, mock.MatchedBy(func(ruleSets []domain.RuleSet) bool {
+					return len(ruleSets) == 1 && ruleSets[0].ID == "rs-1" && string(ruleSets[0].Operations) == `{"canonical":[]}`
+				})).Return(nil).Once()
+				repo.On("ResetSequences", mock.Anything).Return(nil).Once()
+			},
+			expectedRestored: map[string]int{
+				"books": 1, "events": 0, "outbox": 0, "webhook_subscriptions": 0, "webhook_deliveries": 0,
+				"links": 1, "link_clicks": 1, "url_rule_sets": 1,
+			},
+			expectedSchema: "20261019_08",
+		},
+		{
+			name: "archives of the first format have no short links nor url rule sets",
+			archive: func() []byte {
+				a := newArchive(t, "20261019_07", current)
+				a.manifest.FormatVersion = 1
+				return encodeArchive(t, a)
+			}(),
+			mockSetup: func(repo *mocks.Repository) {
+				repo.On("SchemaVersion", mock.Anything).Return("20261019_08", nil).Once()
+				repo.On("Clear", mock.Anything).Return(nil).Once()
+				repo.On("AddBooks", mock.Anything, []domain.Book{book}).Return(nil).Once()
+				repo.On("AddEvents", mock.Anything, mock.Anything).Return(nil).Once()
+				repo.On("ResetSequences", mock.Anything).Return(nil).Once()
+			},
+			expectedRestored: map[string]int{"books": 1, "events": 1, "outbox": 0, "webhook_subscriptions": 0, "webhook_deliveries": 0},
+			expectedUpgraded: true,
+			expectedSchema:   "20261019_08",
+		},
+		{
+			name: "archives of the second format have no url rule sets",
+			archive: func() []byte {
+				files := map[string]string{}
+				for name, content := range withLinks {
+					if name != domain.EntityURLRuleSets {
+						files[name] = content
+					}
+				}
+				a := newArchive(t, "20261019_08", files)
+				a.manifest.FormatVersion = 2
+				return encodeArchive(t, a)
+			}(),
+			mockSetup: func(repo *mocks.Repository) {
+				repo.On("SchemaVersion", mock.Anything).Return("20261019_08", nil).Once()
+				repo.On("Clear", mock.Anything).Return(nil).Once()
+				repo.On("AddBooks", mock.Anything, []domain.Book{book}).Return(nil).Once()
+				repo.On("AddLinks", mock.Anything, mock.Anything).Return(nil).Once()
+				repo.On("AddLinkClicks", mock.Anything, mock.Anything).Return(nil).Once()
+				repo.On("ResetSequences", mock.Anything).Return(nil).Once()
+			},
+			expectedRestored: map[string]int{
+				"books": 1, "events": 0, "outbox": 0, "webhook_subscriptions": 0, "webhook_deliveries": 0,
+				"links": 1, "link_clicks": 1,
+			},
+			expectedSchema: "20261019_08",
+		},
+		{
+			name:           "invalid archive",
+			archive:        []byte("not an archive"),
+			mockSetup:      func(repo *mocks.Repository) {},
+			expectedErr:    domain.ErrInvalidArchive,
+			expectedErrMsg: "not a gzip file",
+		},
+		{
+			name:    "archive of a newer schema",
+			archive: encodeArchive(t, newArchive(t, "20261019_04", current)),
+			mockSetup: func(repo *mocks.Repository) {
+				repo.On("SchemaVersion", mock.Anything).Return("20261019_02", nil).Once()
+			},
+			expectedErr:    domain.ErrInvalidArchive,
+			expectedErrMsg: "the archive schema 20261019_04 is newer than the database schema 20261019_02",
+		},
+		{
+			name: "entity missing from the manifest",
+			archive: encodeArchive(t, newArchive(t, "20261019_04", map[string]string{
+				domain.EntityBooks: bookLine,
+			})),
+			mockSetup: func(repo *mocks.Repository) {
+				repo.On("SchemaVersion", mock.Anything).Return("20261019_04", nil).Once()
+				repo.On("Clear", mock.Anything).Return(nil).Once()
+				repo.On("AddBooks", mock.Anything, []domain.Book{book}).Return(nil).Once()
+			},
+			expectedErr:    domain.ErrInvalidArchive,
+			expectedErrMsg: "events is missing from the manifest",
+		},
+		{
+			name: "malformed record",
+			archive: encodeArchive(t, newArchive(t, "20261019_04", map[string]string{
+				domain.EntityBooks: `{"id":1}` + "\n",
+			})),
+			mockSetup: func(repo *mocks.Repository) {
+				repo.On("SchemaVersion", mock.Anything).Return("20261019_04", nil).Once()
+				repo.On("Clear", mock.Anything).Return(nil).Once()
+			},
+			expectedErr:    domain.ErrInvalidArchive,
+			expectedErrMsg: "books record 1",
+		},
+		{
+			name:    "adding records fails",
+			archive: encodeArchive(t, newArchive(t, "20261019_04", current)),
+			mockSetup: func(repo *mocks.Repository) {
+				repo.On("SchemaVersion", mock.Anything).Return("20261019_04", nil).Once()
+				repo.On("Clear", mock.Anything).Return(nil).Once()
+				repo.On("AddBooks", mock.Anything, mock.Anything).Return(dbErr).Once()
+			},
+			expectedErr: dbErr,
+		},
+		{
+			name:    "clearing fails",
+			archive: encodeArchive(t, newArchive(t, "20261019_04", current)),
+			mockSetup: func(repo *mocks.Repository) {
+				repo.On("SchemaVersion", mock.Anything).Return("20261019_04", nil).Once()
+				repo.On("Clear", mock.Anything).Return(dbErr).Once()
+			},
+			expectedErr: dbErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			tt.mockSetup(repo)
+
+			tx := &recordingTx{}
+			uc := &usecase{repo: repo, tx: tx, now: time.Now}
+
+			out, err := uc.Restore(ctx, bytes.NewReader(tt.archive), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				if tt.expectedErrMsg != "" {
+					assert.ErrorContains(t, err, tt.expectedErrMsg)
+				}
+				assert.Nil(t, out)
+				return
+			}
+			require.NoError(t, err)
+
+			expectedSchema := tt.expectedSchema
+			if expectedSchema == "" {
+				expectedSchema = "20261019_04"
+			}
+			assert.Equal(t, expectedSchema, out.SchemaVersion)
+			assert.Equal(t, tt.expectedRestored, out.Restored)
+			assert.Equal(t, tt.expectedUpgraded, out.Upgraded)
+			assert.Equal(t, tt.input.DryRun, out.DryRun)
+			// a dry run fails the transaction so nothing is kept
+			assert.Equal(t, tt.input.DryRun, tx.err != nil)
+		})
+	}
+}
+
+// recordingTx runs a unit of work without a transaction and records how it
+// ended.
+type recordingTx struct {
+	err error
+}
+
+func (r *recordingTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	r.err = fn(ctx)
+	return r.err
+}
@@ -0,0 +1,67 @@
+package backup
+
+import (
+	domain "booklib/internal/domain/backup"
+	"encoding/json"
+)
+
+// upgrade rewrites the records of archives taken before a migration changed
+// the shape of an entity, the way the migration rewrote the rows.
+type upgrade struct {
+	version string
+	entity  string
+	apply   func(m *domain.Manifest, rec map[string]json.RawMessage)
+}
+
+var upgrades = []upgrade{
+	{
+		// 20261019_01_index_books_updated_at made the book timestamps NOT
+		// NULL, defaulting created_at to the migration time and updated_at to
+		// created_at
+		version: "20261019_01",
+		entity:  domain.EntityBooks,
+		apply: func(m *domain.Manifest, rec map[string]json.RawMessage) {
+			if isNull(rec["created_at"]) {
+				rec["created_at"], _ = json.Marshal(m.CreatedAt)
+			}
+			if isNull(rec["updated_at"]) {
+				rec["updated_at"] = rec["created_at"]
+			}
+		},
+	},
+}
+
+func isNull(raw json.RawMessage) bool {
+	return len(raw) == 0 || string(raw) == "null"
+}
+
+// upgradeRecords applies the upgrades of entity newer than the schema of the
+// archive, in order, and returns the rewritten records.
+func upgradeRecords(m *domain.Manifest, entity string, records [][]byte) ([][]byte, error) {
+	var pending []upgrade
+	for _, up := range upgrades {
+		if up.entity == entity && m.SchemaVersion < up.version {
+			pending = append(pending, up)
+		}
+	}
+	if len(pending) == 0 {
+		return records, nil
+	}
+
+	result := make([][]byte, len(records))
+	for i, raw := range records {
+		var rec map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &rec); err != nil {
+			return nil, invalidArchive("%s record %d: %v", entity, i+1, err)
+		}
+		for _, up := range pending {
+			up.apply(m, rec)
+		}
+
+		var err error
+		if result[i], err = json.Marshal(rec); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,81 @@
+package backup
+
+import (
+	"errors"
+	"testing"
+
+	domain "booklib/internal/domain/backup"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeRecords(t *testing.T) {
+	tests := []struct {
+		name          string
+		schemaVersion string
+		entity        string
+		records       []string
+		expected      []string
+		expectedErr   error
+	}{
+		{
+			name:          "fills missing book timestamps",
+			schemaVersion: "20250101_01",
+			entity:        domain.EntityBooks,
+			records: []string{
+				`{"id":"a","created_at":null,"updated_at":null}`,
+				`{"id":"b","created_at":"2020-01-01T00:00:00Z","updated_at":null}`,
+				`{"id":"c","created_at":"2020-01-01T00:00:00Z","updated_at":"2021-01-01T00:00:00Z"}`,
+			},
+			expected: []string{
+				`{"created_at":"2026-10-19T10:00:00Z","id":"a","updated_at":"2026-10-19T10:00:00Z"}`,
+				`{"created_at":"2020-01-01T00:00:00Z","id":"b","updated_at":"2020-01-01T00:00:00Z"}`,
+				`{"created_at":"2020-01-01T00:00:00Z","id":"c","updated_at":"2021-01-01T00:00:00Z"}`,
+			},
+		},
+		{
+			name:          "leaves records of the same schema as they are",
+			schemaVersion: "20261019_01",
+			entity:        domain.EntityBooks,
+			records:       []string{`{"id":"a","created_at":null}`},
+			expected:      []string{`{"id":"a","created_at":null}`},
+		},
+		{
+			name:          "leaves other entities as they are",
+			schemaVersion: "20250101_01",
+			entity:        domain.EntityEvents,
+			records:       []string{`{"id":1}`},
+			expected:      []string{`{"id":1}`},
+		},
+		{
+			name:          "malformed record",
+			schemaVersion: "20250101_01",
+			entity:        domain.EntityBooks,
+			records:       []string{`{"id":`},
+			expectedErr:   domain.ErrInvalidArchive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &domain.Manifest{SchemaVersion: tt.schemaVersion, CreatedAt: backupTime}
+			records := make([][]byte, len(tt.records))
+			for i, rec := range tt.records {
+				records[i] = []byte(rec)
+			}
+
+			result, err := upgradeRecords(m, tt.entity, records)
+
+			if tt.expectedErr != nil {
+				assert.True(t, errors.Is(err, tt.expectedErr))
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result, len(tt.expected))
+			for i, rec := range result {
+				assert.Equal(t, tt.expected[i], string(rec))
+			}
+		})
+	}
+}
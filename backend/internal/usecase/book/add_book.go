@@ -2,6 +2,7 @@ package book
 
 import (
 	"booklib/internal/domain/book"
+	"booklib/internal/domain/event"
 	"context"
 )
 
@@ -11,11 +12,22 @@ type AddBookInput struct {
 	Year   int
 }
 
-func (u usecase) AddBook(ctx context.Context, in AddBookInput) error {
+func (u usecase) AddBook(ctx context.Context, in AddBookInput) (*book.Book, error) {
 	bk, err := book.NewBook(in.Title, in.Author, in.Year)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return u.repo.AddBook(ctx, bk)
+	var res *book.Book
+	err = u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if res, err = u.repo.AddBook(ctx, bk); err != nil {
+			return err
+		}
+		return u.recordEvents(ctx, bookEvent{eventType: event.TypeBookCreated, id: res.ID, after: res})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
 }
@@ -7,6 +7,8 @@ import (
 
 	domain "booklib/internal/domain/book"
 	"booklib/internal/domain/book/mocks"
+	"booklib/internal/domain/event"
+	eventmocks "booklib/internal/domain/event/mocks"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -14,10 +16,11 @@ import (
 
 func TestAddBook(t *testing.T) {
 	tests := []struct {
-		name        string
-		input       AddBookInput
-		setupMocks  func(*mocks.Repository)
-		expectedErr string
+		name         string
+		input        AddBookInput
+		setupMocks   func(*mocks.Repository, *eventmocks.Repository)
+		expectedBook *domain.Book
+		expectedErr  string
 	}{
 		{
 			name: "successful add book",
@@ -26,10 +29,18 @@ func TestAddBook(t *testing.T) {
 				Author: "Test Author",
 				Year:   2023,
 			},
-			setupMocks: func(repo *mocks.Repository) {
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
 				repo.On("AddBook", mock.Anything, mock.MatchedBy(func(book *domain.Book) bool {
 					return book.Title == "Test Book" && book.Author == "Test Author" && book.Year == 2023
-				})).Return(nil)
+				})).Return(func(_ context.Context, book *domain.Book) (*domain.Book, error) {
+					return book, nil
+				})
+				events.On("AddEvents", mock.Anything, eventTypes(event.TypeBookCreated)).Return(nil)
+			},
+			expectedBook: &domain.Book{
+				Title:  "Test Book",
+				Author: "Test Author",
+				Year:   2023,
 			},
 			expectedErr: "",
 		},
@@ -40,7 +51,7 @@ func TestAddBook(t *testing.T) {
 				Author: "Test Author",
 				Year:   2023,
 			},
-			setupMocks:  func(repo *mocks.Repository) {},
+			setupMocks:  func(repo *mocks.Repository, events *eventmocks.Repository) {},
 			expectedErr: "title cannot be empty",
 		},
 		{
@@ -50,7 +61,7 @@ func TestAddBook(t *testing.T) {
 				Author: "",
 				Year:   2023,
 			},
-			setupMocks:  func(repo *mocks.Repository) {},
+			setupMocks:  func(repo *mocks.Repository, events *eventmocks.Repository) {},
 			expectedErr: "author cannot be empty",
 		},
 		{
@@ -60,27 +71,48 @@ func TestAddBook(t *testing.T) {
 				Author: "Test Author",
 				Year:   2023,
 			},
-			setupMocks: func(repo *mocks.Repository) {
-				repo.On("AddBook", mock.Anything, mock.Anything).Return(errors.New("repository error"))
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("AddBook", mock.Anything, mock.Anything).Return(nil, errors.New("repository error"))
 			},
 			expectedErr: "repository error",
 		},
+		{
+			name: "event error rolls back the book",
+			input: AddBookInput{
+				Title:  "Test Book",
+				Author: "Test Author",
+				Year:   2023,
+			},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("AddBook", mock.Anything, mock.Anything).Return(func(_ context.Context, book *domain.Book) (*domain.Book, error) {
+					return book, nil
+				})
+				events.On("AddEvents", mock.Anything, mock.Anything).Return(errors.New("event error"))
+			},
+			expectedErr: "event error",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := mocks.NewRepository(t)
-			tt.setupMocks(repo)
+			events := eventmocks.NewRepository(t)
+			tt.setupMocks(repo, events)
 
-			uc := New(repo)
-			err := uc.AddBook(context.Background(), tt.input)
+			uc := New(repo, events, passthroughTx{})
+			book, err := uc.AddBook(context.Background(), tt.input)
 
 			if tt.expectedErr != "" {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, book)
 			} else {
 				assert.NoError(t, err)
+				assert.NotEmpty(t, book.ID)
+				assert.Equal(t, tt.expectedBook.Title, book.Title)
+				assert.Equal(t, tt.expectedBook.Author, book.Author)
+				assert.Equal(t, tt.expectedBook.Year, book.Year)
 			}
 		})
 	}
-}
\ No newline at end of file
+}
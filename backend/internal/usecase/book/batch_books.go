@@ -0,0 +1,372 @@
+package book
+
+import (
+	domain "booklib/internal/domain/book"
+	"booklib/internal/domain/event"
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	BatchOpCreate = "create"
+	BatchOpUpdate = "update"
+	BatchOpDelete = "delete"
+
+	BatchStatusSuccess = "success"
+	BatchStatusError   = "error"
+	// BatchStatusAborted marks operations that were valid but not applied
+	// because another operation of an atomic batch failed.
+	BatchStatusAborted = "aborted"
+
+	MaxBatchOperations = 1000
+)
+
+var (
+	ErrBatchEmpty    = errors.New("batch must contain at least one operation")
+	ErrBatchTooLarge = fmt.Errorf("batch cannot contain more than %d operations", MaxBatchOperations)
+
+	errBatchRollback = errors.New("batch rolled back")
+)
+
+type BatchOperation struct {
+	Op string
+	// ID is optional for creates: the book keeps it when given, and gets a
+	// new one otherwise.
+	ID     string
+	Title  string
+	Author string
+	Year   int
+}
+
+type BatchInput struct {
+	Operations []BatchOperation
+	// BestEffort applies every operation on its own instead of in a single
+	// transaction, so a failing operation does not undo the others.
+	BestEffort bool
+}
+
+type BatchResult struct {
+	Op     string
+	ID     string
+	Status string
+	Book   *domain.Book
+	Error  string
+}
+
+type BatchOutput struct {
+	// Results has one entry per operation, in input order.
+	Results []BatchResult
+	Failed  int
+}
+
+func (u usecase) BatchBooks(ctx context.Context, in BatchInput) (*BatchOutput, error) {
+	if len(in.Operations) == 0 {
+		return nil, ErrBatchEmpty
+	}
+	if len(in.Operations) > MaxBatchOperations {
+		return nil, ErrBatchTooLarge
+	}
+
+	books, results := prepareBatch(in.Operations)
+
+	if in.BestEffort {
+		u.applyBatchBestEffort(ctx, books, results)
+		return newBatchOutput(results), nil
+	}
+
+	if err := u.applyBatchAtomic(ctx, books, results); err != nil {
+		return nil, err
+	}
+
+	return newBatchOutput(results), nil
+}
+
+// prepareBatch validates every operation and builds the books to write. Invalid
+// operations already carry their error in the returned results.
+func prepareBatch(ops []BatchOperation) ([]*domain.Book, []BatchResult) {
+	var (
+		books   = make([]*domain.Book, len(ops))
+		results = make([]BatchResult, len(ops))
+	)
+
+	for i, op := range ops {
+		results[i] = BatchResult{Op: op.Op, ID: op.ID}
+
+		var (
+			bk  *domain.Book
+			id  string
+			err error
+		)
+		switch op.Op {
+		case BatchOpCreate:
+			if bk, err = domain.NewBook(op.Title, op.Author, op.Year); err != nil || op.ID == "" {
+				break
+			}
+			bk.ID, err = domain.ParseID(op.ID)
+		case BatchOpUpdate:
+			if id, err = domain.ParseID(op.ID); err != nil {
+				break
+			}
+			bk = &domain.Book{ID: id, Title: op.Title, Author: op.Author, Year: op.Year}
+			err = bk.Validate()
+		case BatchOpDelete:
+			if id, err = domain.ParseID(op.ID); err == nil {
+				results[i].ID = id
+			}
+		default:
+			err = fmt.Errorf("invalid operation %q", op.Op)
+		}
+
+		if err != nil {
+			results[i].Status = BatchStatusError
+			results[i].Error = err.Error()
+			continue
+		}
+		if bk != nil {
+			results[i].ID = bk.ID
+		}
+		books[i] = bk
+	}
+
+	return books, results
+}
+
+func (u usecase) applyBatchBestEffort(ctx context.Context, books []*domain.Book, results []BatchResult) {
+	for i := range results {
+		if results[i].Status == BatchStatusError {
+			continue
+		}
+
+		// every operation commits on its own, together with its event
+		err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+			before, res, err := u.applyBatchOperation(ctx, books[i], results[i])
+			if err != nil {
+				return err
+			}
+			results[i].Book = res
+
+			if ch, ok := newBatchEvent(results[i], before); ok {
+				return u.recordEvents(ctx, ch)
+			}
+			return nil
+		})
+		if err != nil {
+			results[i].Status = BatchStatusError
+			results[i].Error = err.Error()
+			results[i].Book = nil
+			continue
+		}
+		results[i].Status = BatchStatusSuccess
+	}
+}
+
+// applyBatchOperation applies a single operation and returns the book before
+// and after it.
+func (u usecase) applyBatchOperation(ctx context.Context, bk *domain.Book, res BatchResult) (*domain.Book, *domain.Book, error) {
+	switch res.Op {
+	case BatchOpCreate:
+		after, err := u.repo.AddBook(ctx, bk)
+		return nil, after, err
+
+	case BatchOpUpdate:
+		before, err := u.lockBook(ctx, res.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if before == nil {
+			return nil, nil, domain.ErrNotFound
+		}
+		after, err := u.repo.UpdateBook(ctx, bk)
+		if err != nil {
+			return nil, nil, err
+		}
+		if after == nil {
+			return nil, nil, domain.ErrNotFound
+		}
+		return before, after, nil
+
+	case BatchOpDelete:
+		before, err := u.lockBook(ctx, res.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return before, nil, u.repo.DeleteBook(ctx, res.ID)
+	}
+
+	return nil, nil, fmt.Errorf("invalid operation %q", res.Op)
+}
+
+func (u usecase) applyBatchAtomic(ctx context.Context, books []*domain.Book, results []BatchResult) error {
+	checkBatchRuns(results)
+	for _, res := range results {
+		if res.Status == BatchStatusError {
+			abortBatch(results)
+			return nil
+		}
+	}
+
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var changes []bookEvent
+		// consecutive operations of the same kind are written with one bulk
+		// call, which keeps the input order meaningful for dependent operations
+		for start := 0; start < len(results); {
+			end := start + 1
+			for end < len(results) && results[end].Op == results[start].Op {
+				end++
+			}
+
+			runChanges, err := u.applyBatchRun(ctx, books[start:end], results[start:end])
+			if err != nil {
+				return err
+			}
+			changes = append(changes, runChanges...)
+			start = end
+		}
+		return u.recordEvents(ctx, changes...)
+	})
+	if errors.Is(err, errBatchRollback) {
+		abortBatch(results)
+		return nil
+	}
+
+	return err
+}
+
+// checkBatchRuns fails the operations repeating an id within a run of
+// operations, which are written with one bulk call: it would insert or update
+// a row twice in one statement, or record its deletion twice.
+func checkBatchRuns(results []BatchResult) {
+	var seen map[string]int
+	for i := range results {
+		if i == 0 || results[i].Op != results[i-1].Op {
+			seen = make(map[string]int)
+		}
+		if results[i].Status == BatchStatusError {
+			continue
+		}
+		if j, ok := seen[results[i].ID]; ok {
+			results[i].Status = BatchStatusError
+			results[i].Error = fmt.Sprintf("id %q is already at index %d of the same run of %s operations", results[i].ID, j, results[i].Op)
+			continue
+		}
+		seen[results[i].ID] = i
+	}
+}
+
+func (u usecase) applyBatchRun(ctx context.Context, books []*domain.Book, results []BatchResult) ([]bookEvent, error) {
+	// updates and deletes need the current rows as before snapshots
+	befores := make(map[string]*domain.Book)
+	if results[0].Op != BatchOpCreate {
+		ids := make([]string, len(results))
+		for i := range results {
+			ids[i] = results[i].ID
+		}
+		rows, err := u.repo.GetBooksByIDsForUpdate(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for i := range rows {
+			befores[rows[i].ID] = &rows[i]
+		}
+	}
+
+	switch results[0].Op {
+	case BatchOpCreate:
+		rows, err := u.repo.AddBooks(ctx, books)
+		if err != nil {
+			return nil, err
+		}
+		setBatchRows(rows, results)
+
+	case BatchOpUpdate:
+		rows, err := u.repo.UpdateBooks(ctx, books)
+		if err != nil {
+			return nil, err
+		}
+		setBatchRows(rows, results)
+
+		for i := range results {
+			if results[i].Status != BatchStatusSuccess {
+				results[i].Status = BatchStatusError
+				results[i].Error = domain.ErrNotFound.Error()
+				return nil, errBatchRollback
+			}
+		}
+
+	case BatchOpDelete:
+		ids := make([]string, len(results))
+		for i := range results {
+			ids[i] = results[i].ID
+		}
+		if _, err := u.repo.DeleteBooks(ctx, ids); err != nil {
+			return nil, err
+		}
+		for i := range results {
+			results[i].Status = BatchStatusSuccess
+		}
+	}
+
+	var changes []bookEvent
+	for i := range results {
+		if ch, ok := newBatchEvent(results[i], befores[results[i].ID]); ok {
+			changes = append(changes, ch)
+		}
+	}
+
+	return changes, nil
+}
+
+// newBatchEvent describes a successful batch operation. Deleting a book that
+// did not exist changes nothing and produces no event.
+func newBatchEvent(res BatchResult, before *domain.Book) (bookEvent, bool) {
+	switch res.Op {
+	case BatchOpCreate:
+		return bookEvent{eventType: event.TypeBookCreated, id: res.ID, after: res.Book}, true
+	case BatchOpUpdate:
+		return bookEvent{eventType: event.TypeBookUpdated, id: res.ID, before: before, after: res.Book}, true
+	case BatchOpDelete:
+		if before == nil {
+			return bookEvent{}, false
+		}
+		return bookEvent{eventType: event.TypeBookDeleted, id: res.ID, before: before}, true
+	}
+
+	return bookEvent{}, false
+}
+
+func setBatchRows(rows []domain.Book, results []BatchResult) {
+	byID := make(map[string]*domain.Book, len(rows))
+	for i := range rows {
+		byID[rows[i].ID] = &rows[i]
+	}
+
+	for i := range results {
+		if bk, ok := byID[results[i].ID]; ok {
+			results[i].Status = BatchStatusSuccess
+			results[i].Book = bk
+		}
+	}
+}
+
+// abortBatch marks every operation that did not fail itself as aborted.
+func abortBatch(results []BatchResult) {
+	for i := range results {
+		if results[i].Status == BatchStatusError {
+			continue
+		}
+		results[i].Status = BatchStatusAborted
+		results[i].Book = nil
+	}
+}
+
+func newBatchOutput(results []BatchResult) *BatchOutput {
+	out := &BatchOutput{Results: results}
+	for _, res := range results {
+		if res.Status != BatchStatusSuccess {
+			out.Failed++
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,322 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "booklib/internal/domain/book"
+	"booklib/internal/domain/book/mocks"
+	"booklib/internal/domain/event"
+	eventmocks "booklib/internal/domain/event/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBatchBooks(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            BatchInput
+		setupMocks       func(*mocks.Repository, *eventmocks.Repository)
+		expectedStatuses []string
+		expectedIDs      []string
+		expectedFailed   int
+		expectedErr      string
+	}{
+		{
+			name:        "empty batch",
+			input:       BatchInput{},
+			setupMocks:  func(repo *mocks.Repository, events *eventmocks.Repository) {},
+			expectedErr: ErrBatchEmpty.Error(),
+		},
+		{
+			name:        "too many operations",
+			input:       BatchInput{Operations: make([]BatchOperation, MaxBatchOperations+1)},
+			setupMocks:  func(repo *mocks.Repository, events *eventmocks.Repository) {},
+			expectedErr: ErrBatchTooLarge.Error(),
+		},
+		{
+			name: "atomic batch groups consecutive operations",
+			input: BatchInput{
+				Operations: []BatchOperation{
+					{Op: BatchOpCreate, Title: "Book 1", Author: "Author 1", Year: 2021},
+					{Op: BatchOpCreate, Title: "Book 2", Author: "Author 2", Year: 2022},
+					{Op: BatchOpUpdate, ID: "00000000-0000-0000-0000-000000000003", Title: "Book 3", Author: "Author 3", Year: 2023},
+					{Op: BatchOpDelete, ID: "00000000-0000-0000-0000-000000000004"},
+					{Op: BatchOpDelete, ID: "00000000-0000-0000-0000-000000000005"},
+				},
+			},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("AddBooks", mock.Anything, mock.MatchedBy(func(books []*domain.Book) bool {
+					return len(books) == 2 && books[0].Title == "Book 1" && books[1].Title == "Book 2"
+				})).Return(func(_ context.Context, books []*domain.Book) ([]domain.Book, error) {
+					return []domain.Book{*books[0], *books[1]}, nil
+				}).Once()
+				repo.On("GetBooksByIDsForUpdate", mock.Anything, []string{"00000000-0000-0000-0000-000000000003"}).
+					Return([]domain.Book{{ID: "00000000-0000-0000-0000-000000000003", Title: "Old Book 3", Author: "Author 3", Year: 2023}}, nil).Once()
+				repo.On("UpdateBooks", mock.Anything, mock.MatchedBy(func(books []*domain.Book) bool {
+					return len(books) == 1 && books[0].ID == "00000000-0000-0000-0000-000000000003"
+				})).Return([]domain.Book{{ID: "00000000-0000-0000-0000-000000000003", Title: "Book 3", Author: "Author 3", Year: 2023}}, nil).Once()
+				repo.On("GetBooksByIDsForUpdate", mock.Anything, []string{"00000000-0000-0000-0000-000000000004", "00000000-0000-0000-0000-000000000005"}).
+					Return([]domain.Book{{ID: "00000000-0000-0000-0000-000000000004", Title: "Book 4", Author: "Author 4", Year: 2024}}, nil).Once()
+				repo.On("DeleteBooks", mock.Anything, []string{"00000000-0000-0000-0000-000000000004", "00000000-0000-0000-0000-000000000005"}).
+					Return([]string{"00000000-0000-0000-0000-000000000004"}, nil).Once()
+				// id-5 did not exist, so its delete records no event
+				events.On("AddEvents", mock.Anything, eventTypes(
+					event.TypeBookCreated, event.TypeBookCreated, event.TypeBookUpdated, event.TypeBookDeleted,
+				)).Return(nil).Once()
+			},
+			expectedStatuses: []string{
+				BatchStatusSuccess, BatchStatusSuccess, BatchStatusSuccess, BatchStatusSuccess, BatchStatusSuccess,
+			},
+		},
+		{
+			name: "atomic batch fails when its events cannot be recorded",
+			input: BatchInput{
+				Operations: []BatchOperation{
+					{Op: BatchOpCreate, Title: "Book 1", Author: "Author 1", Year: 2021},
+				},
+			},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("AddBooks", mock.Anything, mock.Anything).
+					Return(func(_ context.Context, books []*domain.Book) ([]domain.Book, error) {
+						return []domain.Book{*books[0]}, nil
+					}).Once()
+				events.On("AddEvents", mock.Anything, mock.Anything).Return(errors.New("repository error")).Once()
+			},
+			expectedErr: "repository error",
+		},
+		{
+			name: "atomic batch is rolled back when an update target is missing",
+			input: BatchInput{
+				Operations: []BatchOperation{
+					{Op: BatchOpCreate, Title: "Book 1", Author: "Author 1", Year: 2021},
+					{Op: BatchOpUpdate, ID: "00000000-0000-0000-0000-0000000000ff", Title: "Book 2", Author: "Author 2", Year: 2022},
+				},
+			},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("AddBooks", mock.Anything, mock.Anything).
+					Return(func(_ context.Context, books []*domain.Book) ([]domain.Book, error) {
+						return []domain.Book{*books[0]}, nil
+					}).Once()
+				repo.On("GetBooksByIDsForUpdate", mock.Anything, []string{"00000000-0000-0000-0000-0000000000ff"}).Return([]domain.Book{}, nil).Once()
+				repo.On("UpdateBooks", mock.Anything, mock.Anything).Return([]domain.Book{}, nil).Once()
+			},
+			expectedStatuses: []string{BatchStatusAborted, BatchStatusError},
+			expectedFailed:   2,
+		},
+		{
+			name: "atomic batch is not applied when an operation is invalid",
+			input: BatchInput{
+				Operations: []BatchOperation{
+					{Op: BatchOpCreate, Title: "Book 1", Author: "Author 1", Year: 2021},
+					{Op: BatchOpCreate, Title: "", Author: "Author 2", Year: 2022},
+					{Op: "upsert", ID: "00000000-0000-0000-0000-000000000003"},
+				},
+			},
+			setupMocks:       func(repo *mocks.Repository, events *eventmocks.Repository) {},
+			expectedStatuses: []string{BatchStatusAborted, BatchStatusError, BatchStatusError},
+			expectedFailed:   3,
+		},
+		{
+			name: "atomic batch is not applied when an id is not a UUID",
+			input: BatchInput{
+				Operations: []BatchOperation{
+					{Op: BatchOpCreate, Title: "Book 1", Author: "Author 1", Year: 2021},
+					{Op: BatchOpUpdate, ID: "not-a-uuid", Title: "Book 2", Author: "Author 2", Year: 2022},
+					{Op: BatchOpDelete, ID: "{00000000-0000-0000-0000-000000000003}"},
+				},
+			},
+			setupMocks:       func(repo *mocks.Repository, events *eventmocks.Repository) {},
+			expectedStatuses: []string{BatchStatusAborted, BatchStatusError, BatchStatusError},
+			expectedFailed:   3,
+		},
+		{
+			name: "atomic batch matches uppercase ids with the stored ones",
+			input: BatchInput{
+				Operations: []BatchOperation{
+					{Op: BatchOpUpdate, ID: "ABCDEF00-0000-0000-0000-000000000003", Title: "Book 3", Author: "Author 3", Year: 2023},
+					{Op: BatchOpDelete, ID: "ABCDEF00-0000-0000-0000-000000000004"},
+				},
+			},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("GetBooksByIDsForUpdate", mock.Anything, []string{"abcdef00-0000-0000-0000-000000000003"}).
+					Return([]domain.Book{{ID: "abcdef00-0000-0000-0000-000000000003", Title: "Old Book 3", Author: "Author 3", Year: 2023}}, nil).Once()
+				repo.On("UpdateBooks", mock.Anything, mock.MatchedBy(func(books []*domain.Book) bool {
+					return len(books) == 1 && books[0].ID == "abcdef00-0000-0000-0000-000000000003"
+				})).Return([]domain.Book{{ID: "abcdef00-0000-0000-0000-000000000003", Title: "Book 3", Author: "Author 3", Year: 2023}}, nil).Once()
+				repo.On("GetBooksByIDsForUpdate", mock.Anything, []string{"abcdef00-0000-0000-0000-000000000004"}).
+					Return([]domain.Book{{ID: "abcdef00-0000-0000-0000-000000000004", Title: "Book 4", Author: "Author 4", Year: 2024}}, nil).Once()
+				repo.On("DeleteBooks", mock.Anything, []string{"abcdef00-0000-0000-0000-000000000004"}).
+					Return([]string{"abcdef00-0000-0000-0000-000000000004"}, nil).Once()
+				events.On("AddEvents", mock.Anything, eventTypes(event.TypeBookUpdated, event.TypeBookDeleted)).Return(nil).Once()
+			},
+			expectedStatuses: []string{BatchStatusSuccess, BatchStatusSuccess},
+			expectedIDs:      []string{"abcdef00-0000-0000-0000-000000000003", "abcdef00-0000-0000-0000-000000000004"},
+		},
+		{
+			name: "atomic batch is not applied when a run repeats an id",
+			input: BatchInput{
+				Operations: []BatchOperation{
+					{Op: BatchOpUpdate, ID: "abcdef00-0000-0000-0000-000000000003", Title: "Book 3", Author: "Author 3", Year: 2023},
+					{Op: BatchOpUpdate, ID: "ABCDEF00-0000-0000-0000-000000000003", Title: "Book 3b", Author: "Author 3", Year: 2023},
+					{Op: BatchOpDelete, ID: "abcdef00-0000-0000-0000-000000000003"},
+				},
+			},
+			setupMocks:       func(repo *mocks.Repository, events *eventmocks.Repository) {},
+			expectedStatuses: []string{BatchStatusAborted, BatchStatusError, BatchStatusAborted},
+			expectedFailed:   3,
+		},
+		{
+			name: "atomic batch creates books with the given ids",
+			input: BatchInput{
+				Operations: []BatchOperation{
+					{Op: BatchOpCreate, ID: "ABCDEF00-0000-0000-0000-000000000001", Title: "Book 1", Author: "Author 1", Year: 2021},
+					{Op: BatchOpCreate, Title: "Book 2", Author: "Author 2", Year: 2022},
+				},
+			},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("AddBooks", mock.Anything, mock.MatchedBy(func(books []*domain.Book) bool {
+					return len(books) == 2 && books[0].ID == "abcdef00-0000-0000-0000-000000000001" && books[1].ID != ""
+				})).Return(func(_ context.Context, books []*domain.Book) ([]domain.Book, error) {
+					return []domain.Book{*books[0], *books[1]}, nil
+				}).Once()
+				events.On("AddEvents", mock.Anything, eventTypes(event.TypeBookCreated, event.TypeBookCreated)).Return(nil).Once()
+			},
+			expectedStatuses: []string{BatchStatusSuccess, BatchStatusSuccess},
+			expectedIDs:      []string{"abcdef00-0000-0000-0000-000000000001"},
+		},
+		{
+			name: "atomic batch is not applied when a create has an invalid or repeated id",
+			input: BatchInput{
+				Operations: []BatchOperation{
+					{Op: BatchOpCreate, ID: "abcdef00-0000-0000-0000-000000000001", Title: "Book 1", Author: "Author 1", Year: 2021},
+					{Op: BatchOpCreate, ID: "ABCDEF00-0000-0000-0000-000000000001", Title: "Book 2", Author: "Author 2", Year: 2022},
+					{Op: BatchOpCreate, ID: "not-a-uuid", Title: "Book 3", Author: "Author 3", Year: 2023},
+				},
+			},
+			setupMocks:       func(repo *mocks.Repository, events *eventmocks.Repository) {},
+			expectedStatuses: []string{BatchStatusAborted, BatchStatusError, BatchStatusError},
+			expectedFailed:   3,
+		},
+		{
+			name: "atomic batch repository error",
+			input: BatchInput{
+				Operations: []BatchOperation{
+					{Op: BatchOpDelete, ID: "00000000-0000-0000-0000-000000000001"},
+				},
+			},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("GetBooksByIDsForUpdate", mock.Anything, []string{"00000000-0000-0000-0000-000000000001"}).Return([]domain.Book{{ID: "00000000-0000-0000-0000-000000000001"}}, nil)
+				repo.On("DeleteBooks", mock.Anything, []string{"00000000-0000-0000-0000-000000000001"}).Return(nil, errors.New("repository error"))
+			},
+			expectedErr: "repository error",
+		},
+		{
+			name: "best effort batch applies operations one by one",
+			input: BatchInput{
+				BestEffort: true,
+				Operations: []BatchOperation{
+					{Op: BatchOpCreate, Title: "Book 1", Author: "Author 1", Year: 2021},
+					{Op: BatchOpUpdate, ID: "00000000-0000-0000-0000-0000000000ff", Title: "Book 2", Author: "Author 2", Year: 2022},
+					{Op: BatchOpDelete, ID: "00000000-0000-0000-0000-000000000003"},
+					{Op: BatchOpDelete, ID: ""},
+					{Op: BatchOpCreate, Title: "Book 5", Author: "Author 5", Year: 2025},
+				},
+			},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("AddBook", mock.Anything, mock.MatchedBy(func(book *domain.Book) bool {
+					return book.Title == "Book 1"
+				})).Return(func(_ context.Context, book *domain.Book) (*domain.Book, error) {
+					return book, nil
+				}).Once()
+				repo.On("GetBooksByIDsForUpdate", mock.Anything, []string{"00000000-0000-0000-0000-0000000000ff"}).Return([]domain.Book{}, nil).Once()
+				repo.On("GetBooksByIDsForUpdate", mock.Anything, []string{"00000000-0000-0000-0000-000000000003"}).
+					Return([]domain.Book{{ID: "00000000-0000-0000-0000-000000000003"}}, nil).Once()
+				repo.On("DeleteBook", mock.Anything, "00000000-0000-0000-0000-000000000003").Return(nil).Once()
+				repo.On("AddBook", mock.Anything, mock.MatchedBy(func(book *domain.Book) bool {
+					return book.Title == "Book 5"
+				})).Return(nil, errors.New("repository error")).Once()
+				// every operation records its event in its own transaction
+				events.On("AddEvents", mock.Anything, eventTypes(event.TypeBookCreated)).Return(nil).Once()
+				events.On("AddEvents", mock.Anything, eventTypes(event.TypeBookDeleted)).Return(nil).Once()
+			},
+			expectedStatuses: []string{
+				BatchStatusSuccess, BatchStatusError, BatchStatusSuccess, BatchStatusError, BatchStatusError,
+			},
+			expectedFailed: 3,
+		},
+		{
+			name: "best effort batch matches uppercase ids with the stored ones",
+			input: BatchInput{
+				BestEffort: true,
+				Operations: []BatchOperation{
+					{Op: BatchOpDelete, ID: "ABCDEF00-0000-0000-0000-000000000004"},
+				},
+			},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("GetBooksByIDsForUpdate", mock.Anything, []string{"abcdef00-0000-0000-0000-000000000004"}).
+					Return([]domain.Book{{ID: "abcdef00-0000-0000-0000-000000000004"}}, nil).Once()
+				repo.On("DeleteBook", mock.Anything, "abcdef00-0000-0000-0000-000000000004").Return(nil).Once()
+				events.On("AddEvents", mock.Anything, eventTypes(event.TypeBookDeleted)).Return(nil).Once()
+			},
+			expectedStatuses: []string{BatchStatusSuccess},
+			expectedIDs:      []string{"abcdef00-0000-0000-0000-000000000004"},
+		},
+		{
+			name: "best effort operation fails when its event cannot be recorded",
+			input: BatchInput{
+				BestEffort: true,
+				Operations: []BatchOperation{
+					{Op: BatchOpCreate, Title: "Book 1", Author: "Author 1", Year: 2021},
+				},
+			},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("AddBook", mock.Anything, mock.Anything).
+					Return(func(_ context.Context, book *domain.Book) (*domain.Book, error) {
+						return book, nil
+					}).Once()
+				events.On("AddEvents", mock.Anything, mock.Anything).Return(errors.New("repository error")).Once()
+			},
+			expectedStatuses: []string{BatchStatusError},
+			expectedFailed:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			events := eventmocks.NewRepository(t)
+			tt.setupMocks(repo, events)
+
+			uc := New(repo, events, passthroughTx{})
+			out, err := uc.BatchBooks(context.Background(), tt.input)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, out)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedFailed, out.Failed)
+			assert.Len(t, out.Results, len(tt.expectedStatuses))
+			for i, status := range tt.expectedStatuses {
+				assert.Equal(t, status, out.Results[i].Status, "result %d", i)
+				assert.Equal(t, tt.input.Operations[i].Op, out.Results[i].Op)
+				if status == BatchStatusSuccess && out.Results[i].Op != BatchOpDelete {
+					assert.NotNil(t, out.Results[i].Book)
+					assert.Equal(t, out.Results[i].ID, out.Results[i].Book.ID)
+				}
+				if status == BatchStatusAborted {
+					assert.Nil(t, out.Results[i].Book)
+				}
+			}
+			for i, id := range tt.expectedIDs {
+				assert.Equal(t, id, out.Results[i].ID, "result %d", i)
+			}
+		})
+	}
+}
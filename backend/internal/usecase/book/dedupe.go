@@ -0,0 +1,317 @@
+package book
+
+import (
+	domain "booklib/internal/domain/book"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Duplicates are found by scoring pairs of books on their normalised title,
+// author names and year. Books carry no ISBN, which would otherwise settle
+// most pairs on its own.
+const (
+	titleWeight  = 0.55
+	authorWeight = 0.35
+	yearWeight   = 0.10
+
+	// initialScore is how much an initial matches the name it abbreviates,
+	// as J against John.
+	initialScore = 0.8
+	// minTokenScore is the lowest similarity at which two name tokens are
+	// taken for spelling variants of each other.
+	minTokenScore = 0.75
+)
+
+// Similarity scores how much two books look alike, from 0 to 1.
+type Similarity struct {
+	Title  float64
+	Author float64
+	// Year is nil when either book has no year; Score then ignores it.
+	Year  *float64
+	Score float64
+}
+
+// DuplicatePair is two books scoring at least the requested minimum.
+type DuplicatePair struct {
+	BookIDs    [2]string
+	Similarity Similarity
+}
+
+// DuplicateGroup is a set of books linked by duplicate pairs.
+type DuplicateGroup struct {
+	// Books are oldest first, the first one being the natural one to keep.
+	Books []domain.Book
+	// Pairs are highest score first.
+	Pairs []DuplicatePair
+	// Score is the highest score of the pairs.
+	Score float64
+}
+
+var leadingArticles = []string{"the", "a", "an"}
+
+// normalizeTitle lowercases title, drops punctuation and a leading article,
+// so "The Lord of the Rings." and "lord of the rings" are the same.
+func normalizeTitle(title string) string {
+	words := tokens(strings.ReplaceAll(title, "&", " and "))
+	if len(words) > 1 && slices.Contains(leadingArticles, words[0]) {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// authorTokens returns the sorted name parts of author, so "Austen, Jane" and
+// "Jane Austen" are the same and "J.R.R. Tolkien" gives its three initials.
+func authorTokens(author string) []string {
+	names := tokens(author)
+	slices.Sort(names)
+	return names
+}
+
+// tokens lowercases s and splits it on everything but letters and digits.
+func tokens(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// compareBooks scores a pair of books.
+func compareBooks(a, b domain.Book) Similarity {
+	sim := Similarity{
+		Title:  titleSimilarity(normalizeTitle(a.Title), normalizeTitle(b.Title)),
+		Author: authorSimilarity(authorTokens(a.Author), authorTokens(b.Author)),
+	}
+
+	if a.Year == 0 || b.Year == 0 {
+		sim.Score = (titleWeight*sim.Title + authorWeight*sim.Author) / (titleWeight + authorWeight)
+		return sim
+	}
+
+	var year float64
+	switch diff := a.Year - b.Year; {
+	case diff == 0:
+		year = 1
+	case diff == 1 || diff == -1:
+		// a reprint or a year typed off by one
+		year = 0.5
+	}
+	sim.Year = &year
+	sim.Score = titleWeight*sim.Title + authorWeight*sim.Author + yearWeight*year
+	return sim
+}
+
+func titleSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	return editSimilarity(a, b)
+}
+
+// authorSimilarity matches every name part of the shorter name to the most
+// similar unused part of the other. Unmatched parts count half, so a missing
+// first name lowers the score without ruling the pair out.
+func authorSimilarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	var (
+		used  = make([]bool, len(b))
+		total float64
+	)
+	for _, x := range a {
+		best, bestIdx := 0.0, -1
+		for i, y := range b {
+			if used[i] {
+				continue
+			}
+			if s := tokenSimilarity(x, y); s > best {
+				best, bestIdx = s, i
+			}
+		}
+		if bestIdx >= 0 {
+			used[bestIdx] = true
+			total += best
+		}
+	}
+
+	return (total/float64(len(a)) + total/float64(len(b))) / 2
+}
+
+func tokenSimilarity(a, b string) float64 {
+	switch {
+	case a == b:
+		return 1
+	case isInitialOf(a, b) || isInitialOf(b, a):
+		return initialScore
+	}
+	if s := editSimilarity(a, b); s >= minTokenScore {
+		return s
+	}
+	return 0
+}
+
+func isInitialOf(initial, name string) bool {
+	return len([]rune(initial)) == 1 && strings.HasPrefix(name, initial)
+}
+
+// editSimilarity is 1 minus the edit distance of a and b relative to the
+// longest of them.
+func editSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// findDuplicates scores the books sharing a title word or a name part and
+// groups those scoring at least minScore. Books sharing no word are never
+// compared, which keeps large libraries far from comparing every pair.
+func findDuplicates(books []domain.Book, minScore float64) []DuplicateGroup {
+	// block books by the words of their title and author
+	blocks := map[string][]int{}
+	for i, bk := range books {
+		words := append(strings.Fields(normalizeTitle(bk.Title)), authorTokens(bk.Author)...)
+		slices.Sort(words)
+		for _, w := range slices.Compact(words) {
+			if len([]rune(w)) > 1 {
+				blocks[w] = append(blocks[w], i)
+			}
+		}
+	}
+
+	var (
+		compared = map[[2]int]bool{}
+		pairs    []DuplicatePair
+		groups   = newUnionFind(len(books))
+	)
+	for _, block := range blocks {
+		for x := 0; x < len(block); x++ {
+			for y := x + 1; y < len(block); y++ {
+				i, j := block[x], block[y]
+				if compared[[2]int{i, j}] {
+					continue
+				}
+				compared[[2]int{i, j}] = true
+
+				sim := compareBooks(books[i], books[j])
+				if sim.Score < minScore {
+					continue
+				}
+				pairs = append(pairs, DuplicatePair{BookIDs: [2]string{books[i].ID, books[j].ID}, Similarity: sim})
+				groups.union(i, j)
+			}
+		}
+	}
+
+	return newDuplicateGroups(books, pairs, groups)
+}
+
+func newDuplicateGroups(books []domain.Book, pairs []DuplicatePair, uf *unionFind) []DuplicateGroup {
+	index := make(map[string]int, len(books))
+	for i, bk := range books {
+		index[bk.ID] = i
+	}
+
+	byRoot := map[int]*DuplicateGroup{}
+	var result []*DuplicateGroup
+	group := func(i int) *DuplicateGroup {
+		root := uf.find(i)
+		g, ok := byRoot[root]
+		if !ok {
+			g = &DuplicateGroup{}
+			byRoot[root] = g
+			result = append(result, g)
+		}
+		return g
+	}
+
+	for _, p := range pairs {
+		g := group(index[p.BookIDs[0]])
+		g.Pairs = append(g.Pairs, p)
+		g.Score = max(g.Score, p.Similarity.Score)
+	}
+	for i, bk := range books {
+		if g, ok := byRoot[uf.find(i)]; ok {
+			g.Books = append(g.Books, bk)
+		}
+	}
+
+	groups := make([]DuplicateGroup, len(result))
+	for i, g := range result {
+		slices.SortStableFunc(g.Books, func(a, b domain.Book) int {
+			if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+				return c
+			}
+			return strings.Compare(a.ID, b.ID)
+		})
+		slices.SortStableFunc(g.Pairs, func(a, b DuplicatePair) int {
+			return compareScores(a.Similarity.Score, b.Similarity.Score, a.BookIDs[0], b.BookIDs[0])
+		})
+		groups[i] = *g
+	}
+	slices.SortStableFunc(groups, func(a, b DuplicateGroup) int {
+		return compareScores(a.Score, b.Score, a.Books[0].ID, b.Books[0].ID)
+	})
+	return groups
+}
+
+// compareScores orders by descending score, then by id for a stable report.
+func compareScores(a, b float64, idA, idB string) int {
+	switch {
+	case a > b:
+		return -1
+	case a < b:
+		return 1
+	}
+	return strings.Compare(idA, idB)
+}
+
+type unionFind struct {
+	parent []int
+}
+
+func newUnionFind(n int) *unionFind {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	return &unionFind{parent: parent}
+}
+
+func (u *unionFind) find(i int) int {
+	for u.parent[i] != i {
+		u.parent[i] = u.parent[u.parent[i]]
+		i = u.parent[i]
+	}
+	return i
+}
+
+func (u *unionFind) union(i, j int) {
+	u.parent[u.find(i)] = u.find(j)
+}
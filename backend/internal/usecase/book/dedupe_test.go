@@ -0,0 +1,156 @@
+package book
+
+import (
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/book"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{title: "The Lord of the Rings.", expected: "lord of the rings"},
+		{title: "  PRIDE & prejudice ", expected: "pride and prejudice"},
+		{title: "A Tale of Two Cities", expected: "tale of two cities"},
+		{title: "The", expected: "the"},
+		{title: "Nineteen Eighty-Four", expected: "nineteen eighty four"},
+		{title: "Cien años de soledad", expected: "cien años de soledad"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizeTitle(tt.title))
+		})
+	}
+}
+
+func TestCompareBooks(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     domain.Book
+		minScore float64
+		maxScore float64
+		noYear   bool
+	}{
+		{
+			name:     "casing, punctuation and name order",
+			a:        domain.Book{Title: "Pride and Prejudice", Author: "Jane Austen", Year: 1813},
+			b:        domain.Book{Title: "pride & prejudice.", Author: "Austen, Jane", Year: 1813},
+			minScore: 1,
+			maxScore: 1,
+		},
+		{
+			name:     "initials",
+			a:        domain.Book{Title: "The Hobbit", Author: "J.R.R. Tolkien", Year: 1937},
+			b:        domain.Book{Title: "Hobbit", Author: "John Ronald Reuel Tolkien", Year: 1937},
+			minScore: 0.9,
+			maxScore: 0.95,
+		},
+		{
+			name:     "typo in the title",
+			a:        domain.Book{Title: "The Great Gatsby", Author: "F. Scott Fitzgerald", Year: 1925},
+			b:        domain.Book{Title: "The Great Gatsbey", Author: "F. Scott Fitzgerald", Year: 1925},
+			minScore: 0.95,
+			maxScore: 0.99,
+		},
+		{
+			name:     "missing year is ignored",
+			a:        domain.Book{Title: "Dune", Author: "Frank Herbert", Year: 1965},
+			b:        domain.Book{Title: "Dune", Author: "Frank Herbert"},
+			minScore: 1,
+			maxScore: 1,
+			noYear:   true,
+		},
+		{
+			name:     "surname only",
+			a:        domain.Book{Title: "Emma", Author: "Jane Austen", Year: 1815},
+			b:        domain.Book{Title: "Emma", Author: "Austen", Year: 1815},
+			minScore: 0.9,
+			maxScore: 0.92,
+		},
+		{
+			name:     "other book of the same author",
+			a:        domain.Book{Title: "Emma", Author: "Jane Austen", Year: 1815},
+			b:        domain.Book{Title: "Persuasion", Author: "Jane Austen", Year: 1817},
+			minScore: 0.4,
+			maxScore: 0.5,
+		},
+		{
+			name:     "same title of another author",
+			a:        domain.Book{Title: "Persuasion", Author: "Jane Austen", Year: 1817},
+			b:        domain.Book{Title: "Persuasion", Author: "Robert Cialdini", Year: 1984},
+			minScore: 0.55,
+			maxScore: 0.55,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim := compareBooks(tt.a, tt.b)
+
+			assert.GreaterOrEqual(t, sim.Score, tt.minScore-1e-9)
+			assert.LessOrEqual(t, sim.Score, tt.maxScore+1e-9)
+			assert.Equal(t, tt.noYear, sim.Year == nil)
+			assert.Equal(t, sim, compareBooks(tt.b, tt.a), "scores must not depend on the order")
+		})
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	at := func(day int) time.Time { return time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC) }
+	books := []domain.Book{
+		{ID: "hobbit-2", Title: "Hobbit", Author: "Tolkien, J. R. R.", Year: 1937, CreatedAt: at(3)},
+		{ID: "emma", Title: "Emma", Author: "Jane Austen", Year: 1815, CreatedAt: at(1)},
+		{ID: "hobbit-1", Title: "The Hobbit", Author: "J.R.R. Tolkien", Year: 1937, CreatedAt: at(2)},
+		{ID: "persuasion", Title: "Persuasion", Author: "Jane Austen", Year: 1817, CreatedAt: at(1)},
+		{ID: "dune-1", Title: "Dune", Author: "Frank Herbert", Year: 1965, CreatedAt: at(5)},
+		{ID: "dune-2", Title: "DUNE", Author: "Herbert, Frank", CreatedAt: at(4)},
+		{ID: "hobbit-3", Title: "The Hobbit.", Author: "JRR Tolkien", Year: 1937, CreatedAt: at(4)},
+	}
+
+	t.Run("groups duplicates, best group first", func(t *testing.T) {
+		groups := findDuplicates(books, DefaultMinDuplicateScore)
+
+		require.Len(t, groups, 2)
+
+		assert.Equal(t, 1.0, groups[0].Score)
+		assert.Equal(t, []string{"dune-2", "dune-1"}, bookIDs(groups[0].Books))
+		require.Len(t, groups[0].Pairs, 1)
+
+		// the oldest book comes first
+		assert.Equal(t, []string{"hobbit-1", "hobbit-2", "hobbit-3"}, bookIDs(groups[1].Books))
+		require.NotEmpty(t, groups[1].Pairs)
+		for i := 1; i < len(groups[1].Pairs); i++ {
+			assert.GreaterOrEqual(t, groups[1].Pairs[i-1].Similarity.Score, groups[1].Pairs[i].Similarity.Score)
+		}
+	})
+
+	t.Run("a lower minimum reports more", func(t *testing.T) {
+		groups := findDuplicates(books, 0.3)
+
+		var ids []string
+		for _, g := range groups {
+			ids = append(ids, bookIDs(g.Books)...)
+		}
+		assert.ElementsMatch(t, []string{"emma", "persuasion", "hobbit-1", "hobbit-2", "hobbit-3", "dune-1", "dune-2"}, ids)
+	})
+
+	t.Run("no duplicates", func(t *testing.T) {
+		assert.Empty(t, findDuplicates(books[1:2], DefaultMinDuplicateScore))
+		assert.Empty(t, findDuplicates(nil, DefaultMinDuplicateScore))
+	})
+}
+
+func bookIDs(books []domain.Book) []string {
+	ids := make([]string, len(books))
+	for i, bk := range books {
+		ids[i] = bk.ID
+	}
+	return ids
+}
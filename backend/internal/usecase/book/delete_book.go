@@ -1,9 +1,25 @@
 package book
 
 import (
+	"booklib/internal/domain/event"
 	"context"
 )
 
 func (u usecase) DeleteBook(ctx context.Context, id string) error {
-	return u.repo.DeleteBook(ctx, id)
+	return u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// the current state is kept as the before snapshot of the event
+		bk, err := u.lockBook(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = u.repo.DeleteBook(ctx, id); err != nil {
+			return err
+		}
+
+		if bk == nil {
+			return nil
+		}
+		return u.recordEvents(ctx, bookEvent{eventType: event.TypeBookDeleted, id: id, before: bk})
+	})
 }
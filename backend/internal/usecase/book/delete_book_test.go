@@ -5,38 +5,57 @@ import (
 	"errors"
 	"testing"
 
+	domain "booklib/internal/domain/book"
 	"booklib/internal/domain/book/mocks"
+	"booklib/internal/domain/event"
+	eventmocks "booklib/internal/domain/event/mocks"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestDeleteBook(t *testing.T) {
 	tests := []struct {
 		name        string
 		bookID      string
-		setupMocks  func(*mocks.Repository)
+		setupMocks  func(*mocks.Repository, *eventmocks.Repository)
 		expectedErr string
 	}{
 		{
 			name:   "successful delete book",
 			bookID: "test-id",
-			setupMocks: func(repo *mocks.Repository) {
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("GetBooksByIDsForUpdate", context.Background(), []string{"test-id"}).Return([]domain.Book{{ID: "test-id", Title: "Test Book"}}, nil)
 				repo.On("DeleteBook", context.Background(), "test-id").Return(nil)
+				events.On("AddEvents", context.Background(), mock.MatchedBy(func(evs []*event.Event) bool {
+					return len(evs) == 1 && evs[0].Type == event.TypeBookDeleted &&
+						evs[0].AggregateID == "test-id" && len(evs[0].Before) > 0 && len(evs[0].After) == 0
+				})).Return(nil)
 			},
 			expectedErr: "",
 		},
 		{
-			name:   "book not found for delete",
+			name:   "deleting missing book records no event",
 			bookID: "non-existent-id",
-			setupMocks: func(repo *mocks.Repository) {
-				repo.On("DeleteBook", context.Background(), "non-existent-id").Return(errors.New("book not found"))
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("GetBooksByIDsForUpdate", context.Background(), []string{"non-existent-id"}).Return([]domain.Book{}, nil)
+				repo.On("DeleteBook", context.Background(), "non-existent-id").Return(nil)
 			},
-			expectedErr: "book not found",
+			expectedErr: "",
+		},
+		{
+			name:   "get book error",
+			bookID: "test-id",
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("GetBooksByIDsForUpdate", context.Background(), []string{"test-id"}).Return(nil, errors.New("repository error"))
+			},
+			expectedErr: "repository error",
 		},
 		{
 			name:   "repository error during delete",
 			bookID: "test-id",
-			setupMocks: func(repo *mocks.Repository) {
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("GetBooksByIDsForUpdate", context.Background(), []string{"test-id"}).Return([]domain.Book{{ID: "test-id"}}, nil)
 				repo.On("DeleteBook", context.Background(), "test-id").Return(errors.New("repository error"))
 			},
 			expectedErr: "repository error",
@@ -46,9 +65,10 @@ func TestDeleteBook(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := mocks.NewRepository(t)
-			tt.setupMocks(repo)
+			events := eventmocks.NewRepository(t)
+			tt.setupMocks(repo, events)
 
-			uc := New(repo)
+			uc := New(repo, events, passthroughTx{})
 			err := uc.DeleteBook(context.Background(), tt.bookID)
 
 			if tt.expectedErr != "" {
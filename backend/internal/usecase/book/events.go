@@ -0,0 +1,35 @@
+package book
+
+import (
+	domain "booklib/internal/domain/book"
+	"booklib/internal/domain/event"
+	"context"
+)
+
+// bookEvent describes a single change; before is nil for creations and after
+// is nil for deletions.
+type bookEvent struct {
+	eventType string
+	id        string
+	before    *domain.Book
+	after     *domain.Book
+}
+
+// recordEvents appends the changes to the event log and the outbox. It is
+// called with the ctx of the transaction that made the changes, so a change is
+// never committed without its events.
+func (u usecase) recordEvents(ctx context.Context, changes ...bookEvent) error {
+	events := make([]*event.Event, 0, len(changes))
+	for _, ch := range changes {
+		ev, err := event.NewEvent(ch.eventType, event.AggregateBook, ch.id, ch.before, ch.after)
+		if err != nil {
+			return err
+		}
+		events = append(events, ev)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	return u.events.AddEvents(ctx, events)
+}
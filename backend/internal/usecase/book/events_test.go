@@ -0,0 +1,100 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "booklib/internal/domain/book"
+	"booklib/internal/domain/event"
+	eventmocks "booklib/internal/domain/event/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// eventTypes matches an AddEvents call whose events have exactly the given types.
+func eventTypes(types ...string) interface{} {
+	return mock.MatchedBy(func(events []*event.Event) bool {
+		if len(events) != len(types) {
+			return false
+		}
+		for i, ev := range events {
+			if ev.Type != types[i] || ev.AggregateType != event.AggregateBook {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestRecordEvents(t *testing.T) {
+	var (
+		before = &domain.Book{ID: "id-1", Title: "Old Title", Author: "Author", Year: 2020}
+		after  = &domain.Book{ID: "id-1", Title: "New Title", Author: "Author", Year: 2021}
+	)
+
+	tests := []struct {
+		name        string
+		changes     []bookEvent
+		setupMocks  func(*eventmocks.Repository)
+		assertFn    func(*testing.T, []*event.Event)
+		expectedErr string
+	}{
+		{
+			name:    "records snapshots",
+			changes: []bookEvent{{eventType: event.TypeBookUpdated, id: "id-1", before: before, after: after}},
+			setupMocks: func(events *eventmocks.Repository) {
+				events.On("AddEvents", mock.Anything, eventTypes(event.TypeBookUpdated)).Return(nil)
+			},
+			assertFn: func(t *testing.T, events []*event.Event) {
+				assert.Equal(t, "id-1", events[0].AggregateID)
+				assert.Contains(t, string(events[0].Before), `"title":"Old Title"`)
+				assert.Contains(t, string(events[0].After), `"title":"New Title"`)
+			},
+		},
+		{
+			name:    "missing snapshot is left empty",
+			changes: []bookEvent{{eventType: event.TypeBookDeleted, id: "id-1", before: before}},
+			setupMocks: func(events *eventmocks.Repository) {
+				events.On("AddEvents", mock.Anything, eventTypes(event.TypeBookDeleted)).Return(nil)
+			},
+			assertFn: func(t *testing.T, events []*event.Event) {
+				assert.NotEmpty(t, events[0].Before)
+				assert.Empty(t, events[0].After)
+			},
+		},
+		{
+			name:    "append error is returned",
+			changes: []bookEvent{{eventType: event.TypeBookCreated, id: "id-1", after: after}},
+			setupMocks: func(events *eventmocks.Repository) {
+				events.On("AddEvents", mock.Anything, mock.Anything).Return(errors.New("repository error"))
+			},
+			expectedErr: "repository error",
+		},
+		{
+			name:       "nothing to record",
+			setupMocks: func(events *eventmocks.Repository) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := eventmocks.NewRepository(t)
+			tt.setupMocks(events)
+
+			uc := usecase{events: events}
+			err := uc.recordEvents(context.Background(), tt.changes...)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.assertFn != nil {
+				recorded := events.Calls[0].Arguments.Get(1).([]*event.Event)
+				tt.assertFn(t, recorded)
+			}
+		})
+	}
+}
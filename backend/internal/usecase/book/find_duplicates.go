@@ -0,0 +1,41 @@
+package book
+
+import (
+	domain "booklib/internal/domain/book"
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	// DefaultMinDuplicateScore reports pairs that differ in casing,
+	// punctuation, name order or initials but not different books of the
+	// same author.
+	DefaultMinDuplicateScore = 0.85
+)
+
+var (
+	ErrInvalidMinScore = errors.New("min_score must be greater than 0 and at most 1")
+)
+
+type FindDuplicatesInput struct {
+	// MinScore is the lowest score reported, DefaultMinDuplicateScore when 0.
+	MinScore float64
+}
+
+func (u usecase) FindDuplicates(ctx context.Context, in FindDuplicatesInput) ([]DuplicateGroup, error) {
+	minScore := in.MinScore
+	if minScore == 0 {
+		minScore = DefaultMinDuplicateScore
+	}
+	if minScore < 0 || minScore > 1 {
+		return nil, fmt.Errorf("%w, got %v", ErrInvalidMinScore, minScore)
+	}
+
+	books, err := u.repo.GetAllBooks(ctx, domain.Filter{})
+	if err != nil {
+		return nil, err
+	}
+
+	return findDuplicates(books, minScore), nil
+}
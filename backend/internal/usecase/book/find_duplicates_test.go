@@ -0,0 +1,78 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "booklib/internal/domain/book"
+	"booklib/internal/domain/book/mocks"
+	eventmocks "booklib/internal/domain/event/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDuplicatesUseCase(t *testing.T) {
+	books := []domain.Book{
+		{ID: "dune-1", Title: "Dune", Author: "Frank Herbert", Year: 1965},
+		{ID: "dune-2", Title: "Dune.", Author: "Herbert, Frank", Year: 1965},
+		{ID: "emma", Title: "Emma", Author: "Jane Austen", Year: 1815},
+	}
+
+	tests := []struct {
+		name           string
+		input          FindDuplicatesInput
+		setupMocks     func(*mocks.Repository)
+		expectedGroups int
+		expectedErr    error
+	}{
+		{
+			name:  "default minimum score",
+			input: FindDuplicatesInput{},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetAllBooks", context.Background(), domain.Filter{}).Return(books, nil)
+			},
+			expectedGroups: 1,
+		},
+		{
+			name:  "high minimum score",
+			input: FindDuplicatesInput{MinScore: 1},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetAllBooks", context.Background(), domain.Filter{}).Return(books[1:], nil)
+			},
+			expectedGroups: 0,
+		},
+		{
+			name:        "minimum score out of range",
+			input:       FindDuplicatesInput{MinScore: 1.5},
+			setupMocks:  func(repo *mocks.Repository) {},
+			expectedErr: ErrInvalidMinScore,
+		},
+		{
+			name:  "repository error",
+			input: FindDuplicatesInput{},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetAllBooks", context.Background(), domain.Filter{}).Return(nil, errors.New("repository error"))
+			},
+			expectedErr: errors.New("repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			tt.setupMocks(repo)
+
+			uc := New(repo, eventmocks.NewRepository(t), passthroughTx{})
+			groups, err := uc.FindDuplicates(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorContains(t, err, tt.expectedErr.Error())
+				assert.Nil(t, groups)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, groups, tt.expectedGroups)
+		})
+	}
+}
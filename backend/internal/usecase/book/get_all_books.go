@@ -3,8 +3,15 @@ package book
 import (
 	domain "booklib/internal/domain/book"
 	"context"
+	"time"
 )
 
-func (u usecase) GetAllBooks(ctx context.Context) ([]domain.Book, error) {
-	return u.repo.GetAllBooks(ctx)
+type GetAllBooksInput struct {
+	UpdatedSince *time.Time
+}
+
+func (u usecase) GetAllBooks(ctx context.Context, in GetAllBooksInput) ([]domain.Book, error) {
+	return u.repo.GetAllBooks(ctx, domain.Filter{
+		UpdatedSince: in.UpdatedSince,
+	})
 }
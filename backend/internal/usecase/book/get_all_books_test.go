@@ -4,16 +4,21 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	domain "booklib/internal/domain/book"
 	"booklib/internal/domain/book/mocks"
+	eventmocks "booklib/internal/domain/event/mocks"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestGetAllBooks(t *testing.T) {
+	updatedSince := time.Date(2025, 8, 7, 0, 0, 0, 0, time.UTC)
+
 	tests := []struct {
 		name          string
+		input         GetAllBooksInput
 		setupMocks    func(*mocks.Repository)
 		expectedBooks []domain.Book
 		expectedErr   string
@@ -35,7 +40,7 @@ func TestGetAllBooks(t *testing.T) {
 						Year:   2022,
 					},
 				}
-				repo.On("GetAllBooks", context.Background()).Return(books, nil)
+				repo.On("GetAllBooks", context.Background(), domain.Filter{}).Return(books, nil)
 			},
 			expectedBooks: []domain.Book{
 				{
@@ -56,7 +61,7 @@ func TestGetAllBooks(t *testing.T) {
 		{
 			name: "empty result",
 			setupMocks: func(repo *mocks.Repository) {
-				repo.On("GetAllBooks", context.Background()).Return([]domain.Book{}, nil)
+				repo.On("GetAllBooks", context.Background(), domain.Filter{}).Return([]domain.Book{}, nil)
 			},
 			expectedBooks: []domain.Book{},
 			expectedErr:   "",
@@ -64,11 +69,37 @@ func TestGetAllBooks(t *testing.T) {
 		{
 			name: "repository error",
 			setupMocks: func(repo *mocks.Repository) {
-				repo.On("GetAllBooks", context.Background()).Return(nil, errors.New("repository error"))
+				repo.On("GetAllBooks", context.Background(), domain.Filter{}).Return(nil, errors.New("repository error"))
 			},
 			expectedBooks: nil,
 			expectedErr:   "repository error",
 		},
+		{
+			name:  "filter by updated since",
+			input: GetAllBooksInput{UpdatedSince: &updatedSince},
+			setupMocks: func(repo *mocks.Repository) {
+				books := []domain.Book{
+					{
+						ID:        "1",
+						Title:     "Book 1",
+						Author:    "Author 1",
+						Year:      2021,
+						UpdatedAt: updatedSince.Add(time.Hour),
+					},
+				}
+				repo.On("GetAllBooks", context.Background(), domain.Filter{UpdatedSince: &updatedSince}).Return(books, nil)
+			},
+			expectedBooks: []domain.Book{
+				{
+					ID:        "1",
+					Title:     "Book 1",
+					Author:    "Author 1",
+					Year:      2021,
+					UpdatedAt: updatedSince.Add(time.Hour),
+				},
+			},
+			expectedErr: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -76,8 +107,8 @@ func TestGetAllBooks(t *testing.T) {
 			repo := mocks.NewRepository(t)
 			tt.setupMocks(repo)
 
-			uc := New(repo)
-			books, err := uc.GetAllBooks(context.Background())
+			uc := New(repo, eventmocks.NewRepository(t), passthroughTx{})
+			books, err := uc.GetAllBooks(context.Background(), tt.input)
 
 			if tt.expectedErr != "" {
 				assert.Error(t, err)
@@ -89,4 +120,4 @@ func TestGetAllBooks(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
@@ -8,3 +8,15 @@ import (
 func (u usecase) GetBook(ctx context.Context, id string) (*domain.Book, error) {
 	return u.repo.GetBookByID(ctx, id)
 }
+
+// lockBook reads the book within the transaction carried by ctx and locks it
+// until the transaction ends, so the before snapshot of an event is the state
+// the write replaces. It returns nil when there is no such book.
+func (u usecase) lockBook(ctx context.Context, id string) (*domain.Book, error) {
+	books, err := u.repo.GetBooksByIDsForUpdate(ctx, []string{id})
+	if err != nil || len(books) == 0 {
+		return nil, err
+	}
+
+	return &books[0], nil
+}
@@ -7,6 +7,7 @@ import (
 
 	domain "booklib/internal/domain/book"
 	"booklib/internal/domain/book/mocks"
+	eventmocks "booklib/internal/domain/event/mocks"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -64,7 +65,7 @@ func TestGetBook(t *testing.T) {
 			repo := mocks.NewRepository(t)
 			tt.setupMocks(repo)
 
-			uc := New(repo)
+			uc := New(repo, eventmocks.NewRepository(t), passthroughTx{})
 			book, err := uc.GetBook(context.Background(), tt.bookID)
 
 			if tt.expectedErr != "" {
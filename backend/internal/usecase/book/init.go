@@ -2,14 +2,22 @@ package book
 
 import (
 	domain "booklib/internal/domain/book"
+	"booklib/internal/domain/event"
+	"booklib/internal/domain/transaction"
 )
 
 type usecase struct {
-	repo domain.Repository
+	repo   domain.Repository
+	events event.Repository
+	tx     transaction.Manager
 }
 
-func New(repo domain.Repository) UseCase {
+// New builds the book use case. Every change is recorded in events within the
+// transaction of the change, run by tx.
+func New(repo domain.Repository, events event.Repository, tx transaction.Manager) UseCase {
 	return &usecase{
-		repo: repo,
+		repo:   repo,
+		events: events,
+		tx:     tx,
 	}
 }
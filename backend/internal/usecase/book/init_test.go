@@ -1,18 +1,27 @@
 package book
 
 import (
+	"context"
 	"testing"
 
 	"booklib/internal/domain/book/mocks"
+	eventmocks "booklib/internal/domain/event/mocks"
 
 	"github.com/stretchr/testify/assert"
 )
 
+// passthroughTx runs a unit of work without a transaction.
+type passthroughTx struct{}
+
+func (passthroughTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 func TestNew(t *testing.T) {
 	t.Run("creates new usecase with repository", func(t *testing.T) {
 		repo := mocks.NewRepository(t)
 		
-		uc := New(repo)
+		uc := New(repo, eventmocks.NewRepository(t), passthroughTx{})
 		
 		assert.NotNil(t, uc)
 		assert.Implements(t, (*UseCase)(nil), uc)
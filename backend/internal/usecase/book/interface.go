@@ -9,8 +9,13 @@ import (
 //go:generate mockery --name=UseCase --output=./mocks
 type UseCase interface {
 	GetBook(ctx context.Context, id string) (*domain.Book, error)
-	GetAllBooks(ctx context.Context) ([]domain.Book, error)
-	AddBook(ctx context.Context, in AddBookInput) error
-	UpdateBook(ctx context.Context, id string, in UpdateBookInput) error
+	GetAllBooks(ctx context.Context, in GetAllBooksInput) ([]domain.Book, error)
+	AddBook(ctx context.Context, in AddBookInput) (*domain.Book, error)
+	UpdateBook(ctx context.Context, id string, in UpdateBookInput) (*domain.Book, error)
 	DeleteBook(ctx context.Context, id string) error
+	BatchBooks(ctx context.Context, in BatchInput) (*BatchOutput, error)
+	// FindDuplicates groups the books that look like the same book.
+	FindDuplicates(ctx context.Context, in FindDuplicatesInput) ([]DuplicateGroup, error)
+	// MergeBooks keeps the book id and removes the given duplicates of it.
+	MergeBooks(ctx context.Context, id string, in MergeBooksInput) (*MergeBooksOutput, error)
 }
@@ -0,0 +1,112 @@
+package book
+
+import (
+	domain "booklib/internal/domain/book"
+	"booklib/internal/domain/event"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrMergeEmpty    = errors.New("duplicate_ids must contain at least one id")
+	ErrMergeTooLarge = fmt.Errorf("duplicate_ids cannot contain more than %d ids", MaxBatchOperations)
+	ErrMergeSelf     = errors.New("a book cannot be merged into itself")
+)
+
+type MergeBooksInput struct {
+	// DuplicateIDs are the books merged into the kept one and removed.
+	DuplicateIDs []string
+}
+
+type MergeBooksOutput struct {
+	// Book is the kept book after the merge.
+	Book *domain.Book
+	// Merged are the removed duplicates as they were.
+	Merged []domain.Book
+}
+
+// MergeBooks keeps the book id and removes its duplicates in one transaction.
+// The kept book keeps its fields, taking the year of a duplicate when it has
+// none. Every duplicate is recorded as a book.merged event pointing to the
+// kept book, so its history leads to where it went.
+func (u usecase) MergeBooks(ctx context.Context, id string, in MergeBooksInput) (*MergeBooksOutput, error) {
+	var ids []string
+	for _, dupID := range in.DuplicateIDs {
+		if !slices.Contains(ids, dupID) {
+			ids = append(ids, dupID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, ErrMergeEmpty
+	}
+	if len(ids) > MaxBatchOperations {
+		return nil, ErrMergeTooLarge
+	}
+	if slices.Contains(ids, id) {
+		return nil, ErrMergeSelf
+	}
+
+	var out *MergeBooksOutput
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		found, err := u.repo.GetBooksByIDsForUpdate(ctx, append([]string{id}, ids...))
+		if err != nil {
+			return err
+		}
+		byID := make(map[string]domain.Book, len(found))
+		for _, bk := range found {
+			byID[bk.ID] = bk
+		}
+
+		kept, ok := byID[id]
+		if !ok {
+			return domain.ErrNotFound
+		}
+		merged := make([]domain.Book, len(ids))
+		for i, dupID := range ids {
+			if merged[i], ok = byID[dupID]; !ok {
+				return fmt.Errorf("%w: %s", domain.ErrNotFound, dupID)
+			}
+		}
+
+		var (
+			before  = kept
+			changes []bookEvent
+		)
+		for _, dup := range merged {
+			if kept.Year == 0 && dup.Year != 0 {
+				kept.Year = dup.Year
+			}
+		}
+		if kept != before {
+			res, err := u.repo.UpdateBook(ctx, &kept)
+			if err != nil {
+				return err
+			}
+			if res == nil {
+				return domain.ErrNotFound
+			}
+			kept = *res
+			changes = append(changes, bookEvent{eventType: event.TypeBookUpdated, id: id, before: &before, after: &kept})
+		}
+
+		if _, err = u.repo.DeleteBooks(ctx, ids); err != nil {
+			return err
+		}
+		for i := range merged {
+			changes = append(changes, bookEvent{eventType: event.TypeBookMerged, id: merged[i].ID, before: &merged[i], after: &kept})
+		}
+		if err = u.recordEvents(ctx, changes...); err != nil {
+			return err
+		}
+
+		out = &MergeBooksOutput{Book: &kept, Merged: merged}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
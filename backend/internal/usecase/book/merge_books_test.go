@@ -0,0 +1,152 @@
+package book
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	domain "booklib/internal/domain/book"
+	"booklib/internal/domain/book/mocks"
+	"booklib/internal/domain/event"
+	eventmocks "booklib/internal/domain/event/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeBooks(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		kept = domain.Book{ID: "kept", Title: "Dune", Author: "Frank Herbert"}
+		dupA = domain.Book{ID: "dup-a", Title: "DUNE", Author: "Herbert, Frank", Year: 1965}
+		dupB = domain.Book{ID: "dup-b", Title: "Dune.", Author: "F. Herbert", Year: 1966}
+	)
+
+	// mergedInto checks a book.merged event of id pointing to the kept book
+	mergedInto := func(ev *event.Event, id string) bool {
+		var after domain.Book
+		return ev.Type == event.TypeBookMerged && ev.AggregateID == id &&
+			len(ev.Before) > 0 && json.Unmarshal(ev.After, &after) == nil && after.ID == "kept"
+	}
+
+	tests := []struct {
+		name           string
+		id             string
+		input          MergeBooksInput
+		setupMocks     func(*mocks.Repository, *eventmocks.Repository)
+		expectedYear   int
+		expectedMerged []string
+		expectedErr    error
+	}{
+		{
+			name:  "keeps the book and takes the first known year",
+			id:    "kept",
+			input: MergeBooksInput{DuplicateIDs: []string{"dup-a", "dup-b", "dup-a"}},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("GetBooksByIDsForUpdate", ctx, []string{"kept", "dup-a", "dup-b"}).Return([]domain.Book{dupB, kept, dupA}, nil)
+				repo.On("UpdateBook", ctx, mock.MatchedBy(func(bk *domain.Book) bool {
+					return bk.ID == "kept" && bk.Year == 1965 && bk.Title == "Dune"
+				})).Return(func(_ context.Context, bk *domain.Book) *domain.Book {
+					res := *bk
+					return &res
+				}, nil)
+				repo.On("DeleteBooks", ctx, []string{"dup-a", "dup-b"}).Return([]string{"dup-a", "dup-b"}, nil)
+				events.On("AddEvents", ctx, mock.MatchedBy(func(evs []*event.Event) bool {
+					return len(evs) == 3 &&
+						evs[0].Type == event.TypeBookUpdated && evs[0].AggregateID == "kept" &&
+						mergedInto(evs[1], "dup-a") && mergedInto(evs[2], "dup-b")
+				})).Return(nil)
+			},
+			expectedYear:   1965,
+			expectedMerged: []string{"dup-a", "dup-b"},
+		},
+		{
+			name:  "kept book unchanged",
+			id:    "dup-a",
+			input: MergeBooksInput{DuplicateIDs: []string{"dup-b"}},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("GetBooksByIDsForUpdate", ctx, []string{"dup-a", "dup-b"}).Return([]domain.Book{dupA, dupB}, nil)
+				repo.On("DeleteBooks", ctx, []string{"dup-b"}).Return([]string{"dup-b"}, nil)
+				events.On("AddEvents", ctx, mock.MatchedBy(func(evs []*event.Event) bool {
+					return len(evs) == 1 && evs[0].Type == event.TypeBookMerged && evs[0].AggregateID == "dup-b"
+				})).Return(nil)
+			},
+			expectedYear:   1965,
+			expectedMerged: []string{"dup-b"},
+		},
+		{
+			name:        "no duplicates",
+			id:          "kept",
+			setupMocks:  func(repo *mocks.Repository, events *eventmocks.Repository) {},
+			expectedErr: ErrMergeEmpty,
+		},
+		{
+			name:        "merging a book into itself",
+			id:          "kept",
+			input:       MergeBooksInput{DuplicateIDs: []string{"dup-a", "kept"}},
+			setupMocks:  func(repo *mocks.Repository, events *eventmocks.Repository) {},
+			expectedErr: ErrMergeSelf,
+		},
+		{
+			name:  "kept book not found",
+			id:    "kept",
+			input: MergeBooksInput{DuplicateIDs: []string{"dup-a"}},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("GetBooksByIDsForUpdate", ctx, []string{"kept", "dup-a"}).Return([]domain.Book{dupA}, nil)
+			},
+			expectedErr: domain.ErrNotFound,
+		},
+		{
+			name:  "duplicate not found",
+			id:    "kept",
+			input: MergeBooksInput{DuplicateIDs: []string{"dup-a"}},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("GetBooksByIDsForUpdate", ctx, []string{"kept", "dup-a"}).Return([]domain.Book{kept}, nil)
+			},
+			expectedErr: domain.ErrNotFound,
+		},
+		{
+			name:  "repository error",
+			id:    "kept",
+			input: MergeBooksInput{DuplicateIDs: []string{"dup-a"}},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("GetBooksByIDsForUpdate", ctx, []string{"kept", "dup-a"}).Return(nil, errors.New("repository error"))
+			},
+			expectedErr: errors.New("repository error"),
+		},
+		{
+			name:  "event error",
+			id:    "dup-a",
+			input: MergeBooksInput{DuplicateIDs: []string{"dup-b"}},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("GetBooksByIDsForUpdate", ctx, []string{"dup-a", "dup-b"}).Return([]domain.Book{dupA, dupB}, nil)
+				repo.On("DeleteBooks", ctx, []string{"dup-b"}).Return([]string{"dup-b"}, nil)
+				events.On("AddEvents", ctx, mock.Anything).Return(errors.New("event error"))
+			},
+			expectedErr: errors.New("event error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			events := eventmocks.NewRepository(t)
+			tt.setupMocks(repo, events)
+
+			uc := New(repo, events, passthroughTx{})
+			out, err := uc.MergeBooks(ctx, tt.id, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorContains(t, err, tt.expectedErr.Error())
+				assert.Nil(t, out)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.id, out.Book.ID)
+			assert.Equal(t, tt.expectedYear, out.Book.Year)
+			assert.Equal(t, tt.expectedMerged, bookIDs(out.Merged))
+		})
+	}
+}
@@ -17,21 +17,63 @@ type UseCase struct {
 }
 
 // AddBook provides a mock function with given fields: ctx, in
-func (_m *UseCase) AddBook(ctx context.Context, in book.AddBookInput) error {
+func (_m *UseCase) AddBook(ctx context.Context, in book.AddBookInput) (*domainbook.Book, error) {
 	ret := _m.Called(ctx, in)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddBook")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, book.AddBookInput) error); ok {
+	var r0 *domainbook.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, book.AddBookInput) (*domainbook.Book, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, book.AddBookInput) *domainbook.Book); ok {
 		r0 = rf(ctx, in)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domainbook.Book)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, book.AddBookInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BatchBooks provides a mock function with given fields: ctx, in
+func (_m *UseCase) BatchBooks(ctx context.Context, in book.BatchInput) (*book.BatchOutput, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchBooks")
+	}
+
+	var r0 *book.BatchOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, book.BatchInput) (*book.BatchOutput, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, book.BatchInput) *book.BatchOutput); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*book.BatchOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, book.BatchInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // DeleteBook provides a mock function with given fields: ctx, id
@@ -52,9 +94,39 @@ func (_m *UseCase) DeleteBook(ctx context.Context, id string) error {
 	return r0
 }
 
-// GetAllBooks provides a mock function with given fields: ctx
-func (_m *UseCase) GetAllBooks(ctx context.Context) ([]domainbook.Book, error) {
-	ret := _m.Called(ctx)
+// FindDuplicates provides a mock function with given fields: ctx, in
+func (_m *UseCase) FindDuplicates(ctx context.Context, in book.FindDuplicatesInput) ([]book.DuplicateGroup, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDuplicates")
+	}
+
+	var r0 []book.DuplicateGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, book.FindDuplicatesInput) ([]book.DuplicateGroup, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, book.FindDuplicatesInput) []book.DuplicateGroup); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]book.DuplicateGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, book.FindDuplicatesInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllBooks provides a mock function with given fields: ctx, in
+func (_m *UseCase) GetAllBooks(ctx context.Context, in book.GetAllBooksInput) ([]domainbook.Book, error) {
+	ret := _m.Called(ctx, in)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllBooks")
@@ -62,19 +134,19 @@ func (_m *UseCase) GetAllBooks(ctx context.Context) ([]domainbook.Book, error) {
 
 	var r0 []domainbook.Book
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]domainbook.Book, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, book.GetAllBooksInput) ([]domainbook.Book, error)); ok {
+		return rf(ctx, in)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []domainbook.Book); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, book.GetAllBooksInput) []domainbook.Book); ok {
+		r0 = rf(ctx, in)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domainbook.Book)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, book.GetAllBooksInput) error); ok {
+		r1 = rf(ctx, in)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -112,22 +184,64 @@ func (_m *UseCase) GetBook(ctx context.Context, id string) (*domainbook.Book, er
 	return r0, r1
 }
 
+// MergeBooks provides a mock function with given fields: ctx, id, in
+func (_m *UseCase) MergeBooks(ctx context.Context, id string, in book.MergeBooksInput) (*book.MergeBooksOutput, error) {
+	ret := _m.Called(ctx, id, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeBooks")
+	}
+
+	var r0 *book.MergeBooksOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, book.MergeBooksInput) (*book.MergeBooksOutput, error)); ok {
+		return rf(ctx, id, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, book.MergeBooksInput) *book.MergeBooksOutput); ok {
+		r0 = rf(ctx, id, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*book.MergeBooksOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, book.MergeBooksInput) error); ok {
+		r1 = rf(ctx, id, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateBook provides a mock function with given fields: ctx, id, in
-func (_m *UseCase) UpdateBook(ctx context.Context, id string, in book.UpdateBookInput) error {
+func (_m *UseCase) UpdateBook(ctx context.Context, id string, in book.UpdateBookInput) (*domainbook.Book, error) {
 	ret := _m.Called(ctx, id, in)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateBook")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, book.UpdateBookInput) error); ok {
+	var r0 *domainbook.Book
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, book.UpdateBookInput) (*domainbook.Book, error)); ok {
+		return rf(ctx, id, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, book.UpdateBookInput) *domainbook.Book); ok {
 		r0 = rf(ctx, id, in)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domainbook.Book)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, book.UpdateBookInput) error); ok {
+		r1 = rf(ctx, id, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewUseCase creates a new instance of UseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -1,6 +1,8 @@
 package book
 
 import (
+	domain "booklib/internal/domain/book"
+	"booklib/internal/domain/event"
 	"context"
 )
 
@@ -10,15 +12,35 @@ type UpdateBookInput struct {
 	Year   int
 }
 
-func (u usecase) UpdateBook(ctx context.Context, id string, in UpdateBookInput) error {
-	bk, err := u.GetBook(ctx, id)
+func (u usecase) UpdateBook(ctx context.Context, id string, in UpdateBookInput) (*domain.Book, error) {
+	var res *domain.Book
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// read in the transaction, so a concurrent update cannot slip in
+		// between the before snapshot and the write
+		before, err := u.lockBook(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return domain.ErrNotFound
+		}
+
+		bk := *before
+		bk.Title = in.Title
+		bk.Author = in.Author
+		bk.Year = in.Year
+
+		if res, err = u.repo.UpdateBook(ctx, &bk); err != nil {
+			return err
+		}
+		if res == nil {
+			return domain.ErrNotFound
+		}
+		return u.recordEvents(ctx, bookEvent{eventType: event.TypeBookUpdated, id: res.ID, before: before, after: res})
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	bk.Title = in.Title
-	bk.Author = in.Author
-	bk.Year = in.Year
-
-	return u.repo.UpdateBook(ctx, bk)
+	return res, nil
 }
@@ -3,10 +3,13 @@ package book
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	domain "booklib/internal/domain/book"
 	"booklib/internal/domain/book/mocks"
+	"booklib/internal/domain/event"
+	eventmocks "booklib/internal/domain/event/mocks"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -14,11 +17,12 @@ import (
 
 func TestUpdateBook(t *testing.T) {
 	tests := []struct {
-		name        string
-		bookID      string
-		input       UpdateBookInput
-		setupMocks  func(*mocks.Repository)
-		expectedErr string
+		name         string
+		bookID       string
+		input        UpdateBookInput
+		setupMocks   func(*mocks.Repository, *eventmocks.Repository)
+		expectedBook *domain.Book
+		expectedErr  string
 	}{
 		{
 			name:   "successful update book",
@@ -28,19 +32,32 @@ func TestUpdateBook(t *testing.T) {
 				Author: "Updated Author",
 				Year:   2024,
 			},
-			setupMocks: func(repo *mocks.Repository) {
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
 				existingBook := &domain.Book{
 					ID:     "test-id",
 					Title:  "Old Book",
 					Author: "Old Author",
 					Year:   2020,
 				}
-				repo.On("GetBookByID", context.Background(), "test-id").Return(existingBook, nil)
+				repo.On("GetBooksByIDsForUpdate", context.Background(), []string{"test-id"}).Return([]domain.Book{*existingBook}, nil)
 				repo.On("UpdateBook", context.Background(), mock.MatchedBy(func(book *domain.Book) bool {
-					return book.ID == "test-id" && book.Title == "Updated Book" && 
-						   book.Author == "Updated Author" && book.Year == 2024
+					return book.ID == "test-id" && book.Title == "Updated Book" &&
+						book.Author == "Updated Author" && book.Year == 2024
+				})).Return(func(_ context.Context, book *domain.Book) (*domain.Book, error) {
+					return book, nil
+				})
+				events.On("AddEvents", context.Background(), mock.MatchedBy(func(evs []*event.Event) bool {
+					return len(evs) == 1 && evs[0].Type == event.TypeBookUpdated &&
+						strings.Contains(string(evs[0].Before), `"title":"Old Book"`) &&
+						strings.Contains(string(evs[0].After), `"title":"Updated Book"`)
 				})).Return(nil)
 			},
+			expectedBook: &domain.Book{
+				ID:     "test-id",
+				Title:  "Updated Book",
+				Author: "Updated Author",
+				Year:   2024,
+			},
 			expectedErr: "",
 		},
 		{
@@ -51,8 +68,8 @@ func TestUpdateBook(t *testing.T) {
 				Author: "Updated Author",
 				Year:   2024,
 			},
-			setupMocks: func(repo *mocks.Repository) {
-				repo.On("GetBookByID", context.Background(), "non-existent-id").Return(nil, errors.New("book not found"))
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("GetBooksByIDsForUpdate", context.Background(), []string{"non-existent-id"}).Return(nil, errors.New("book not found"))
 			},
 			expectedErr: "book not found",
 		},
@@ -64,8 +81,8 @@ func TestUpdateBook(t *testing.T) {
 				Author: "Updated Author",
 				Year:   2024,
 			},
-			setupMocks: func(repo *mocks.Repository) {
-				repo.On("GetBookByID", context.Background(), "test-id").Return(nil, errors.New("repository error"))
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("GetBooksByIDsForUpdate", context.Background(), []string{"test-id"}).Return(nil, errors.New("repository error"))
 			},
 			expectedErr: "repository error",
 		},
@@ -77,34 +94,98 @@ func TestUpdateBook(t *testing.T) {
 				Author: "Updated Author",
 				Year:   2024,
 			},
-			setupMocks: func(repo *mocks.Repository) {
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
 				existingBook := &domain.Book{
 					ID:     "test-id",
 					Title:  "Old Book",
 					Author: "Old Author",
 					Year:   2020,
 				}
-				repo.On("GetBookByID", context.Background(), "test-id").Return(existingBook, nil)
-				repo.On("UpdateBook", context.Background(), mock.Anything).Return(errors.New("update failed"))
+				repo.On("GetBooksByIDsForUpdate", context.Background(), []string{"test-id"}).Return([]domain.Book{*existingBook}, nil)
+				repo.On("UpdateBook", context.Background(), mock.Anything).Return(nil, errors.New("update failed"))
 			},
 			expectedErr: "update failed",
 		},
+		{
+			name:   "book does not exist",
+			bookID: "missing-id",
+			input: UpdateBookInput{
+				Title:  "Updated Book",
+				Author: "Updated Author",
+				Year:   2024,
+			},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				repo.On("GetBooksByIDsForUpdate", context.Background(), []string{"missing-id"}).Return([]domain.Book{}, nil)
+			},
+			expectedErr: domain.ErrNotFound.Error(),
+		},
+		{
+			name:   "book deleted before update",
+			bookID: "test-id",
+			input: UpdateBookInput{
+				Title:  "Updated Book",
+				Author: "Updated Author",
+				Year:   2024,
+			},
+			setupMocks: func(repo *mocks.Repository, events *eventmocks.Repository) {
+				existingBook := &domain.Book{
+					ID:     "test-id",
+					Title:  "Old Book",
+					Author: "Old Author",
+					Year:   2020,
+				}
+				repo.On("GetBooksByIDsForUpdate", context.Background(), []string{"test-id"}).Return([]domain.Book{*existingBook}, nil)
+				repo.On("UpdateBook", context.Background(), mock.Anything).Return(nil, nil)
+			},
+			expectedErr: domain.ErrNotFound.Error(),
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := mocks.NewRepository(t)
-			tt.setupMocks(repo)
+			events := eventmocks.NewRepository(t)
+			tt.setupMocks(repo, events)
 
-			uc := New(repo)
-			err := uc.UpdateBook(context.Background(), tt.bookID, tt.input)
+			uc := New(repo, events, passthroughTx{})
+			book, err := uc.UpdateBook(context.Background(), tt.bookID, tt.input)
 
 			if tt.expectedErr != "" {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, book)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBook, book)
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+type inTxKey struct{}
+
+// markingTx marks the context of its unit of work, so a test can tell what
+// ran inside the transaction.
+type markingTx struct{}
+
+func (markingTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, inTxKey{}, true))
+}
+
+func TestUpdateBook_ReadsBeforeWithinTx(t *testing.T) {
+	inTx := mock.MatchedBy(func(ctx context.Context) bool { return ctx.Value(inTxKey{}) != nil })
+
+	repo := mocks.NewRepository(t)
+	events := eventmocks.NewRepository(t)
+	repo.On("GetBooksByIDsForUpdate", inTx, []string{"test-id"}).
+		Return([]domain.Book{{ID: "test-id", Title: "Old Book", Author: "Old Author", Year: 2020}}, nil).Once()
+	repo.On("UpdateBook", inTx, mock.Anything).Return(func(_ context.Context, book *domain.Book) (*domain.Book, error) {
+		return book, nil
+	}).Once()
+	events.On("AddEvents", inTx, mock.Anything).Return(nil).Once()
+
+	uc := New(repo, events, markingTx{})
+	_, err := uc.UpdateBook(context.Background(), "test-id", UpdateBookInput{Title: "New Book", Author: "Old Author", Year: 2020})
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,36 @@
+package event
+
+import (
+	domain "booklib/internal/domain/event"
+	"context"
+	"errors"
+)
+
+const (
+	DefaultEventsLimit = 100
+	MaxEventsLimit     = 1000
+)
+
+type GetEventsInput struct {
+	AggregateType string
+	// AfterID returns only events newer than this id; zero reads from the start.
+	AfterID int64
+	Limit   int
+}
+
+func (u usecase) GetEvents(ctx context.Context, in GetEventsInput) ([]domain.Event, error) {
+	if in.AggregateType == "" {
+		return nil, errors.New("aggregate type cannot be empty")
+	}
+	if in.AfterID < 0 {
+		return nil, errors.New("after id cannot be negative")
+	}
+
+	limit := in.Limit
+	if limit <= 0 {
+		limit = DefaultEventsLimit
+	}
+	limit = min(limit, MaxEventsLimit)
+
+	return u.repo.GetEventsAfter(ctx, in.AggregateType, in.AfterID, limit)
+}
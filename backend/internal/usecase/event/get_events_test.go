@@ -0,0 +1,90 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "booklib/internal/domain/event"
+	"booklib/internal/domain/event/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEvents(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          GetEventsInput
+		setupMocks     func(*mocks.Repository)
+		expectedEvents []domain.Event
+		expectedErr    string
+	}{
+		{
+			name:  "reads events after the given id",
+			input: GetEventsInput{AggregateType: domain.AggregateBook, AfterID: 3, Limit: 10},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetEventsAfter", context.Background(), domain.AggregateBook, int64(3), 10).
+					Return([]domain.Event{{ID: 4}, {ID: 5}}, nil)
+			},
+			expectedEvents: []domain.Event{{ID: 4}, {ID: 5}},
+		},
+		{
+			name:  "default limit",
+			input: GetEventsInput{AggregateType: domain.AggregateBook},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetEventsAfter", context.Background(), domain.AggregateBook, int64(0), DefaultEventsLimit).
+					Return([]domain.Event{}, nil)
+			},
+			expectedEvents: []domain.Event{},
+		},
+		{
+			name:  "limit is capped",
+			input: GetEventsInput{AggregateType: domain.AggregateBook, Limit: MaxEventsLimit + 1},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetEventsAfter", context.Background(), domain.AggregateBook, int64(0), MaxEventsLimit).
+					Return([]domain.Event{}, nil)
+			},
+			expectedEvents: []domain.Event{},
+		},
+		{
+			name:        "empty aggregate type",
+			input:       GetEventsInput{},
+			setupMocks:  func(repo *mocks.Repository) {},
+			expectedErr: "aggregate type cannot be empty",
+		},
+		{
+			name:        "negative after id",
+			input:       GetEventsInput{AggregateType: domain.AggregateBook, AfterID: -1},
+			setupMocks:  func(repo *mocks.Repository) {},
+			expectedErr: "after id cannot be negative",
+		},
+		{
+			name:  "repository error",
+			input: GetEventsInput{AggregateType: domain.AggregateBook},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetEventsAfter", context.Background(), domain.AggregateBook, int64(0), DefaultEventsLimit).
+					Return(nil, errors.New("repository error"))
+			},
+			expectedErr: "repository error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			tt.setupMocks(repo)
+
+			uc := New(repo)
+			events, err := uc.GetEvents(context.Background(), tt.input)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, events)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedEvents, events)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package event
+
+import (
+	"context"
+)
+
+func (u usecase) GetLatestEventID(ctx context.Context, aggregateType string) (int64, error) {
+	return u.repo.GetLatestEventID(ctx, aggregateType)
+}
@@ -0,0 +1,54 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "booklib/internal/domain/event"
+	"booklib/internal/domain/event/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLatestEventID(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMocks  func(*mocks.Repository)
+		expectedID  int64
+		expectedErr string
+	}{
+		{
+			name: "returns the newest id",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetLatestEventID", context.Background(), domain.AggregateBook).Return(int64(42), nil)
+			},
+			expectedID: 42,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetLatestEventID", context.Background(), domain.AggregateBook).Return(int64(0), errors.New("repository error"))
+			},
+			expectedErr: "repository error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			tt.setupMocks(repo)
+
+			uc := New(repo)
+			id, err := uc.GetLatestEventID(context.Background(), domain.AggregateBook)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedID, id)
+			}
+		})
+	}
+}
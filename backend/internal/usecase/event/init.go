@@ -0,0 +1,15 @@
+package event
+
+import (
+	domain "booklib/internal/domain/event"
+)
+
+type usecase struct {
+	repo domain.Repository
+}
+
+func New(repo domain.Repository) UseCase {
+	return &usecase{
+		repo: repo,
+	}
+}
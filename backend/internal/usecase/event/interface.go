@@ -0,0 +1,13 @@
+package event
+
+import (
+	"context"
+
+	domain "booklib/internal/domain/event"
+)
+
+//go:generate mockery --name=UseCase --output=./mocks
+type UseCase interface {
+	GetEvents(ctx context.Context, in GetEventsInput) ([]domain.Event, error)
+	GetLatestEventID(ctx context.Context, aggregateType string) (int64, error)
+}
@@ -0,0 +1,88 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	domainevent "booklib/internal/domain/event"
+	event "booklib/internal/usecase/event"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UseCase is an autogenerated mock type for the UseCase type
+type UseCase struct {
+	mock.Mock
+}
+
+// GetEvents provides a mock function with given fields: ctx, in
+func (_m *UseCase) GetEvents(ctx context.Context, in event.GetEventsInput) ([]domainevent.Event, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEvents")
+	}
+
+	var r0 []domainevent.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, event.GetEventsInput) ([]domainevent.Event, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, event.GetEventsInput) []domainevent.Event); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domainevent.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, event.GetEventsInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestEventID provides a mock function with given fields: ctx, aggregateType
+func (_m *UseCase) GetLatestEventID(ctx context.Context, aggregateType string) (int64, error) {
+	ret := _m.Called(ctx, aggregateType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestEventID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, aggregateType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, aggregateType)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, aggregateType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUseCase creates a new instance of UseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UseCase {
+	mock := &UseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
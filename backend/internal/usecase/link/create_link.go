@@ -0,0 +1,161 @@
+package link
+
+import (
+	domain "booklib/internal/domain/link"
+	processor "booklib/internal/domain/url-processor"
+	urlprocessor "booklib/internal/usecase/url-processor"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+const codeAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+var aliasPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{3,64}$`)
+
+type CreateLinkInput struct {
+	URL string
+	// Alias is the code to use, a random one is generated when empty.
+	Alias     string
+	ExpiresAt *time.Time
+}
+
+type CreateLinkOutput struct {
+	Link *domain.Link
+	// Created is false when an existing link was returned.
+	Created bool
+}
+
+func (u *usecase) CreateLink(ctx context.Context, in CreateLinkInput) (*CreateLinkOutput, error) {
+	target, err := u.canonicalise(ctx, in.URL)
+	if err != nil {
+		return nil, err
+	}
+	if in.Alias != "" && !aliasPattern.MatchString(in.Alias) {
+		return nil, domain.ErrInvalidAlias
+	}
+	if in.ExpiresAt != nil && !in.ExpiresAt.After(u.now()) {
+		return nil, domain.ErrInvalidExpiry
+	}
+
+	l := &domain.Link{TargetURL: target, Custom: in.Alias != "", ExpiresAt: in.ExpiresAt}
+	if l.Custom {
+		return u.addCustomLink(ctx, l, in.Alias)
+	}
+
+	if l.Shared() {
+		existing, err := u.repo.GetSharedLink(ctx, target)
+		if err != nil || existing != nil {
+			return existingLink(existing, err)
+		}
+	}
+
+	for range maxCodeAttempts {
+		if l.Code, err = u.newCode(); err != nil {
+			return nil, err
+		}
+
+		res, err := u.repo.AddLink(ctx, l)
+		if err != nil {
+			return nil, err
+		}
+		if res != nil {
+			return &CreateLinkOutput{Link: res, Created: true}, nil
+		}
+
+		// the conflict may be another request sharing the same target first
+		if l.Shared() {
+			existing, err := u.repo.GetSharedLink(ctx, target)
+			if err != nil || existing != nil {
+				return existingLink(existing, err)
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no free link code after %d attempts", maxCodeAttempts)
+}
+
+// addCustomLink stores l under alias. Asking again for the same alias, target
+// and expiry returns the existing link.
+func (u *usecase) addCustomLink(ctx context.Context, l *domain.Link, alias string) (*CreateLinkOutput, error) {
+	l.Code = alias
+	res, err := u.repo.AddLink(ctx, l)
+	if err != nil {
+		return nil, err
+	}
+	if res != nil {
+		return &CreateLinkOutput{Link: res, Created: true}, nil
+	}
+
+	existing, err := u.repo.GetLinkByCode(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil || !existing.Custom || existing.TargetURL != l.TargetURL || !sameExpiry(existing.ExpiresAt, l.ExpiresAt) {
+		return nil, domain.ErrCodeTaken
+	}
+
+	return &CreateLinkOutput{Link: existing}, nil
+}
+
+// canonicalise runs the configured URL processor operation on rawURL, which
+// has to be an absolute http or https URL before and after.
+func (u *usecase) canonicalise(ctx context.Context, rawURL string) (string, error) {
+	if !isWebURL(rawURL) {
+		return "", domain.ErrInvalidURL
+	}
+
+	out, err := u.urls.CleanURL(ctx, urlprocessor.CleanURLInput{Profile: u.conf.Profile, Operation: u.conf.Operation, URL: rawURL})
+	if errors.Is(err, processor.ErrInvalidURL) || errors.Is(err, processor.ErrUnresolvable) {
+		return "", fmt.Errorf("%w: %v", domain.ErrInvalidURL, err)
+	}
+	if err != nil {
+		return "", err
+	}
+	if !isWebURL(out.URL) {
+		return "", domain.ErrInvalidURL
+	}
+
+	return out.URL, nil
+}
+
+func existingLink(l *domain.Link, err error) (*CreateLinkOutput, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &CreateLinkOutput{Link: l}, nil
+}
+
+func isWebURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func sameExpiry(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// newCode returns a random base62 code of codeLength characters.
+func newCode() (string, error) {
+	b := make([]byte, codeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		// 248 is the largest multiple of 62 in a byte, dropped to stay uniform
+		for b[i] >= 248 {
+			if _, err := rand.Read(b[i : i+1]); err != nil {
+				return "", err
+			}
+		}
+		b[i] = codeAlphabet[b[i]%62]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,255 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/link"
+	"booklib/internal/domain/link/mocks"
+	processor "booklib/internal/domain/url-processor"
+	urlprocessor "booklib/internal/usecase/url-processor"
+	urlmocks "booklib/internal/usecase/url-processor/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateLink(t *testing.T) {
+	var (
+		now       = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+		future    = now.Add(24 * time.Hour)
+		past      = now.Add(-time.Hour)
+		target    = "https://example.com/a?utm_source=x"
+		canonical = "https://example.com/a?utm_source=x&id=1"
+	)
+
+	cleaned := func(urls *urlmocks.UseCase) {
+		urls.On("CleanURL", mock.Anything, urlprocessor.CleanURLInput{Operation: DefaultOperation, URL: target}).
+			Return(&urlprocessor.CleanURLOutput{URL: canonical}, nil)
+	}
+	added := func(_ context.Context, l *domain.Link) (*domain.Link, error) {
+		res := *l
+		res.CreatedAt = now
+		return &res, nil
+	}
+
+	tests := []struct {
+		name            string
+		input           CreateLinkInput
+		codes           []string
+		setupMocks      func(*mocks.Repository, *urlmocks.UseCase)
+		expected        *CreateLinkOutput
+		expectedErr     error
+		expectedErrText string
+	}{
+		{
+			name:  "generates a code for a new target",
+			input: CreateLinkInput{URL: target},
+			codes: []string{"aB3dE5f"},
+			setupMocks: func(repo *mocks.Repository, urls *urlmocks.UseCase) {
+				cleaned(urls)
+				repo.On("GetSharedLink", mock.Anything, canonical).Return(nil, nil)
+				repo.On("AddLink", mock.Anything, &domain.Link{Code: "aB3dE5f", TargetURL: canonical}).Return(added)
+			},
+			expected: &CreateLinkOutput{
+				Link:    &domain.Link{Code: "aB3dE5f", TargetURL: canonical, CreatedAt: now},
+				Created: true,
+			},
+		},
+		{
+			name:  "reuses the shared link of a target",
+			input: CreateLinkInput{URL: target},
+			setupMocks: func(repo *mocks.Repository, urls *urlmocks.UseCase) {
+				cleaned(urls)
+				repo.On("GetSharedLink", mock.Anything, canonical).Return(&domain.Link{Code: "old1234", TargetURL: canonical}, nil)
+			},
+			expected: &CreateLinkOutput{Link: &domain.Link{Code: "old1234", TargetURL: canonical}},
+		},
+		{
+			name:  "retries a taken code",
+			input: CreateLinkInput{URL: target},
+			codes: []string{"taken00", "aB3dE5f"},
+			setupMocks: func(repo *mocks.Repository, urls *urlmocks.UseCase) {
+				cleaned(urls)
+				repo.On("GetSharedLink", mock.Anything, canonical).Return(nil, nil)
+				repo.On("AddLink", mock.Anything, &domain.Link{Code: "taken00", TargetURL: canonical}).Return(nil, nil).Once()
+				repo.On("AddLink", mock.Anything, &domain.Link{Code: "aB3dE5f", TargetURL: canonical}).Return(added).Once()
+			},
+			expected: &CreateLinkOutput{
+				Link:    &domain.Link{Code: "aB3dE5f", TargetURL: canonical, CreatedAt: now},
+				Created: true,
+			},
+		},
+		{
+			name:  "returns the link shared meanwhile",
+			input: CreateLinkInput{URL: target},
+			codes: []string{"aB3dE5f"},
+			setupMocks: func(repo *mocks.Repository, urls *urlmocks.UseCase) {
+				cleaned(urls)
+				repo.On("GetSharedLink", mock.Anything, canonical).Return(nil, nil).Once()
+				repo.On("AddLink", mock.Anything, mock.Anything).Return(nil, nil).Once()
+				repo.On("GetSharedLink", mock.Anything, canonical).Return(&domain.Link{Code: "other12", TargetURL: canonical}, nil).Once()
+			},
+			expected: &CreateLinkOutput{Link: &domain.Link{Code: "other12", TargetURL: canonical}},
+		},
+		{
+			name:  "expiring links are not shared",
+			input: CreateLinkInput{URL: target, ExpiresAt: &future},
+			codes: []string{"aB3dE5f"},
+			setupMocks: func(repo *mocks.Repository, urls *urlmocks.UseCase) {
+				cleaned(urls)
+				repo.On("AddLink", mock.Anything, &domain.Link{Code: "aB3dE5f", TargetURL: canonical, ExpiresAt: &future}).Return(added)
+			},
+			expected: &CreateLinkOutput{
+				Link:    &domain.Link{Code: "aB3dE5f", TargetURL: canonical, ExpiresAt: &future, CreatedAt: now},
+				Created: true,
+			},
+		},
+		{
+			name:  "no free code",
+			input: CreateLinkInput{URL: target, ExpiresAt: &future},
+			codes: []string{"a000000", "a000001", "a000002", "a000003", "a000004"},
+			setupMocks: func(repo *mocks.Repository, urls *urlmocks.UseCase) {
+				cleaned(urls)
+				repo.On("AddLink", mock.Anything, mock.Anything).Return(nil, nil).Times(maxCodeAttempts)
+			},
+			expectedErrText: "no free link code after 5 attempts",
+		},
+		{
+			name:  "custom alias",
+			input: CreateLinkInput{URL: target, Alias: "dune-2"},
+			setupMocks: func(repo *mocks.Repository, urls *urlmocks.UseCase) {
+				cleaned(urls)
+				repo.On("AddLink", mock.Anything, &domain.Link{Code: "dune-2", TargetURL: canonical, Custom: true}).Return(added)
+			},
+			expected: &CreateLinkOutput{
+				Link:    &domain.Link{Code: "dune-2", TargetURL: canonical, Custom: true, CreatedAt: now},
+				Created: true,
+			},
+		},
+		{
+			name:  "same custom alias again",
+			input: CreateLinkInput{URL: target, Alias: "dune-2"},
+			setupMocks: func(repo *mocks.Repository, urls *urlmocks.UseCase) {
+				cleaned(urls)
+				repo.On("AddLink", mock.Anything, mock.Anything).Return(nil, nil)
+				repo.On("GetLinkByCode", mock.Anything, "dune-2").Return(&domain.Link{Code: "dune-2", TargetURL: canonical, Custom: true}, nil)
+			},
+			expected: &CreateLinkOutput{Link: &domain.Link{Code: "dune-2", TargetURL: canonical, Custom: true}},
+		},
+		{
+			name:  "custom alias taken by another target",
+			input: CreateLinkInput{URL: target, Alias: "dune-2"},
+			setupMocks: func(repo *mocks.Repository, urls *urlmocks.UseCase) {
+				cleaned(urls)
+				repo.On("AddLink", mock.Anything, mock.Anything).Return(nil, nil)
+				repo.On("GetLinkByCode", mock.Anything, "dune-2").Return(&domain.Link{Code: "dune-2", TargetURL: "https://example.com/b", Custom: true}, nil)
+			},
+			expectedErr: domain.ErrCodeTaken,
+		},
+		{
+			name:  "custom alias taken with another expiry",
+			input: CreateLinkInput{URL: target, Alias: "dune-2", ExpiresAt: &future},
+			setupMocks: func(repo *mocks.Repository, urls *urlmocks.UseCase) {
+				cleaned(urls)
+				repo.On("AddLink", mock.Anything, mock.Anything).Return(nil, nil)
+				repo.On("GetLinkByCode", mock.Anything, "dune-2").Return(&domain.Link{Code: "dune-2", TargetURL: canonical, Custom: true}, nil)
+			},
+			expectedErr: domain.ErrCodeTaken,
+		},
+		{
+			name:  "invalid alias",
+			input: CreateLinkInput{URL: target, Alias: "a/b"},
+			setupMocks: func(repo *mocks.Repository, urls *urlmocks.UseCase) {
+				cleaned(urls)
+			},
+			expectedErr: domain.ErrInvalidAlias,
+		},
+		{
+			name:  "expiry in the past",
+			input: CreateLinkInput{URL: target, ExpiresAt: &past},
+			setupMocks: func(repo *mocks.Repository, urls *urlmocks.UseCase) {
+				cleaned(urls)
+			},
+			expectedErr: domain.ErrInvalidExpiry,
+		},
+		{
+			name:        "relative url",
+			input:       CreateLinkInput{URL: "/a"},
+			setupMocks:  func(repo *mocks.Repository, urls *urlmocks.UseCase) {},
+			expectedErr: domain.ErrInvalidURL,
+		},
+		{
+			name:  "url the processor rejects",
+			input: CreateLinkInput{URL: "https://xn--a.com/"},
+			setupMocks: func(repo *mocks.Repository, urls *urlmocks.UseCase) {
+				urls.On("CleanURL", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: bad host", processor.ErrInvalidURL))
+			},
+			expectedErr: domain.ErrInvalidURL,
+		},
+		{
+			name:  "processor error",
+			input: CreateLinkInput{URL: target},
+			setupMocks: func(repo *mocks.Repository, urls *urlmocks.UseCase) {
+				urls.On("CleanURL", mock.Anything, mock.Anything).Return(nil, processor.ErrInvalidOperation)
+			},
+			expectedErr: processor.ErrInvalidOperation,
+		},
+		{
+			name:  "repository error",
+			input: CreateLinkInput{URL: target},
+			setupMocks: func(repo *mocks.Repository, urls *urlmocks.UseCase) {
+				cleaned(urls)
+				repo.On("GetSharedLink", mock.Anything, canonical).Return(nil, errors.New("repository error"))
+			},
+			expectedErrText: "repository error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			urls := urlmocks.NewUseCase(t)
+			tt.setupMocks(repo, urls)
+
+			uc := New(repo, urls, Config{}).(*usecase)
+			uc.now = func() time.Time { return now }
+			codes := tt.codes
+			uc.newCode = func() (string, error) {
+				require.NotEmpty(t, codes, "unexpected code generation")
+				code := codes[0]
+				codes = codes[1:]
+				return code, nil
+			}
+
+			out, err := uc.CreateLink(context.Background(), tt.input)
+
+			if tt.expectedErr != nil || tt.expectedErrText != "" {
+				if tt.expectedErr != nil {
+					assert.ErrorIs(t, err, tt.expectedErr)
+				}
+				assert.ErrorContains(t, err, tt.expectedErrText)
+				assert.Nil(t, out)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestNewCode(t *testing.T) {
+	seen := map[string]bool{}
+	for range 100 {
+		code, err := newCode()
+
+		require.NoError(t, err)
+		assert.Regexp(t, `^[0-9A-Za-z]{7}$`, code)
+		seen[code] = true
+	}
+	assert.Len(t, seen, 100)
+}
@@ -0,0 +1,35 @@
+package link
+
+import (
+	domain "booklib/internal/domain/link"
+	"context"
+)
+
+type GetLinkOutput struct {
+	Link *domain.Link
+	// Clicks counts the redirects per UTC day, oldest first, leaving out days
+	// without any.
+	Clicks      []domain.DailyClicks
+	TotalClicks int64
+}
+
+func (u *usecase) GetLink(ctx context.Context, code string) (*GetLinkOutput, error) {
+	l, err := u.repo.GetLinkByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if l == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	clicks, err := u.repo.GetClicks(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &GetLinkOutput{Link: l, Clicks: clicks}
+	for _, c := range clicks {
+		out.TotalClicks += c.Clicks
+	}
+	return out, nil
+}
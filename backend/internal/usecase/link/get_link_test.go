@@ -0,0 +1,82 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/link"
+	"booklib/internal/domain/link/mocks"
+	urlmocks "booklib/internal/usecase/url-processor/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetLink(t *testing.T) {
+	var (
+		link   = &domain.Link{Code: "aB3dE5f", TargetURL: "https://example.com/a"}
+		clicks = []domain.DailyClicks{
+			{Day: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), Clicks: 3},
+			{Day: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Clicks: 4},
+		}
+	)
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mocks.Repository)
+		expected    *GetLinkOutput
+		expectedErr string
+	}{
+		{
+			name: "link with clicks",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetLinkByCode", mock.Anything, "aB3dE5f").Return(link, nil)
+				repo.On("GetClicks", mock.Anything, "aB3dE5f").Return(clicks, nil)
+			},
+			expected: &GetLinkOutput{Link: link, Clicks: clicks, TotalClicks: 7},
+		},
+		{
+			name: "link never visited",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetLinkByCode", mock.Anything, "aB3dE5f").Return(link, nil)
+				repo.On("GetClicks", mock.Anything, "aB3dE5f").Return([]domain.DailyClicks{}, nil)
+			},
+			expected: &GetLinkOutput{Link: link, Clicks: []domain.DailyClicks{}},
+		},
+		{
+			name: "not found",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetLinkByCode", mock.Anything, "aB3dE5f").Return(nil, nil)
+			},
+			expectedErr: domain.ErrNotFound.Error(),
+		},
+		{
+			name: "clicks error",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetLinkByCode", mock.Anything, "aB3dE5f").Return(link, nil)
+				repo.On("GetClicks", mock.Anything, "aB3dE5f").Return(nil, errors.New("repository error"))
+			},
+			expectedErr: "repository error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			tt.setupMocks(repo)
+
+			uc := New(repo, urlmocks.NewUseCase(t), Config{})
+			res, err := uc.GetLink(context.Background(), "aB3dE5f")
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, res)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
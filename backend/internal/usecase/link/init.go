@@ -0,0 +1,47 @@
+package link
+
+import (
+	domain "booklib/internal/domain/link"
+	urlprocessor "booklib/internal/usecase/url-processor"
+	"time"
+)
+
+const (
+	// DefaultOperation canonicalises targets without dropping their query,
+	// so campaign params survive shortening.
+	DefaultOperation = "normalize"
+
+	codeLength      = 7
+	maxCodeAttempts = 5
+)
+
+// Config picks how targets are canonicalised.
+type Config struct {
+	// Profile holds Operation, the default profile of the URL processor when
+	// empty.
+	Profile string
+	// Operation is DefaultOperation when empty.
+	Operation string
+}
+
+type usecase struct {
+	repo    domain.Repository
+	urls    urlprocessor.UseCase
+	conf    Config
+	now     func() time.Time
+	newCode func() (string, error)
+}
+
+func New(repo domain.Repository, urls urlprocessor.UseCase, conf Config) UseCase {
+	if conf.Operation == "" {
+		conf.Operation = DefaultOperation
+	}
+
+	return &usecase{
+		repo:    repo,
+		urls:    urls,
+		conf:    conf,
+		now:     time.Now,
+		newCode: newCode,
+	}
+}
@@ -0,0 +1,29 @@
+package link
+
+import (
+	"testing"
+
+	"booklib/internal/domain/link/mocks"
+	urlmocks "booklib/internal/usecase/url-processor/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("creates new usecase with defaults", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		urls := urlmocks.NewUseCase(t)
+
+		uc := New(repo, urls, Config{})
+
+		assert.NotNil(t, uc)
+		assert.Implements(t, (*UseCase)(nil), uc)
+		assert.Equal(t, DefaultOperation, uc.(*usecase).conf.Operation)
+	})
+
+	t.Run("keeps the given operation", func(t *testing.T) {
+		uc := New(mocks.NewRepository(t), urlmocks.NewUseCase(t), Config{Profile: "shop", Operation: "clean"})
+
+		assert.Equal(t, Config{Profile: "shop", Operation: "clean"}, uc.(*usecase).conf)
+	})
+}
@@ -0,0 +1,18 @@
+package link
+
+import (
+	domain "booklib/internal/domain/link"
+	"context"
+)
+
+//go:generate mockery --name=UseCase --output=./mocks
+type UseCase interface {
+	// CreateLink shortens the canonical form of a URL. Links without alias
+	// nor expiry are shared: shortening the same target again returns the
+	// existing link.
+	CreateLink(ctx context.Context, in CreateLinkInput) (*CreateLinkOutput, error)
+	// VisitLink returns the link a code redirects to and counts the click.
+	VisitLink(ctx context.Context, code string) (*domain.Link, error)
+	// GetLink returns a link with its clicks per day.
+	GetLink(ctx context.Context, code string) (*GetLinkOutput, error)
+}
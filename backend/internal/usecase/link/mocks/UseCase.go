@@ -0,0 +1,120 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	domainlink "booklib/internal/domain/link"
+	link "booklib/internal/usecase/link"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UseCase is an autogenerated mock type for the UseCase type
+type UseCase struct {
+	mock.Mock
+}
+
+// CreateLink provides a mock function with given fields: ctx, in
+func (_m *UseCase) CreateLink(ctx context.Context, in link.CreateLinkInput) (*link.CreateLinkOutput, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLink")
+	}
+
+	var r0 *link.CreateLinkOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, link.CreateLinkInput) (*link.CreateLinkOutput, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, link.CreateLinkInput) *link.CreateLinkOutput); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*link.CreateLinkOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, link.CreateLinkInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLink provides a mock function with given fields: ctx, code
+func (_m *UseCase) GetLink(ctx context.Context, code string) (*link.GetLinkOutput, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLink")
+	}
+
+	var r0 *link.GetLinkOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*link.GetLinkOutput, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *link.GetLinkOutput); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*link.GetLinkOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VisitLink provides a mock function with given fields: ctx, code
+func (_m *UseCase) VisitLink(ctx context.Context, code string) (*domainlink.Link, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VisitLink")
+	}
+
+	var r0 *domainlink.Link
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domainlink.Link, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domainlink.Link); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domainlink.Link)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUseCase creates a new instance of UseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UseCase {
+	mock := &UseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
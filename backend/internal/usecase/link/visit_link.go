@@ -0,0 +1,30 @@
+package link
+
+import (
+	domain "booklib/internal/domain/link"
+	"context"
+
+	"github.com/rizanw/go-log"
+)
+
+func (u *usecase) VisitLink(ctx context.Context, code string) (*domain.Link, error) {
+	l, err := u.repo.GetLinkByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if l == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	now := u.now()
+	if l.Expired(now) {
+		return nil, domain.ErrExpired
+	}
+
+	// a click that cannot be counted does not stop the redirect
+	if err = u.repo.AddClick(ctx, code, now.UTC()); err != nil {
+		log.Error(ctx, err, nil, "failed to count link click")
+	}
+
+	return l, nil
+}
@@ -0,0 +1,87 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/link"
+	"booklib/internal/domain/link/mocks"
+	urlmocks "booklib/internal/usecase/url-processor/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVisitLink(t *testing.T) {
+	var (
+		now     = time.Date(2026, 10, 19, 10, 0, 0, 0, time.FixedZone("WIB", 7*3600))
+		expired = now.Add(-time.Minute)
+		link    = &domain.Link{Code: "aB3dE5f", TargetURL: "https://example.com/a"}
+	)
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mocks.Repository)
+		expected    *domain.Link
+		expectedErr string
+	}{
+		{
+			name: "counts the click on the UTC day",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetLinkByCode", mock.Anything, "aB3dE5f").Return(link, nil)
+				repo.On("AddClick", mock.Anything, "aB3dE5f", now.UTC()).Return(nil)
+			},
+			expected: link,
+		},
+		{
+			name: "redirects when the click is not counted",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetLinkByCode", mock.Anything, "aB3dE5f").Return(link, nil)
+				repo.On("AddClick", mock.Anything, "aB3dE5f", mock.Anything).Return(errors.New("repository error"))
+			},
+			expected: link,
+		},
+		{
+			name: "not found",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetLinkByCode", mock.Anything, "aB3dE5f").Return(nil, nil)
+			},
+			expectedErr: domain.ErrNotFound.Error(),
+		},
+		{
+			name: "expired",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetLinkByCode", mock.Anything, "aB3dE5f").Return(&domain.Link{Code: "aB3dE5f", ExpiresAt: &expired}, nil)
+			},
+			expectedErr: domain.ErrExpired.Error(),
+		},
+		{
+			name: "repository error",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetLinkByCode", mock.Anything, "aB3dE5f").Return(nil, errors.New("repository error"))
+			},
+			expectedErr: "repository error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			tt.setupMocks(repo)
+
+			uc := New(repo, urlmocks.NewUseCase(t), Config{}).(*usecase)
+			uc.now = func() time.Time { return now }
+			res, err := uc.VisitLink(context.Background(), "aB3dE5f")
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, res)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package outbox
+
+import (
+	"time"
+
+	domain "booklib/internal/domain/outbox"
+)
+
+const (
+	DefaultBatchSize      = 100
+	DefaultLease          = 30 * time.Second
+	DefaultInitialBackoff = time.Second
+	DefaultMaxBackoff     = time.Minute
+)
+
+// Config tunes the relay. Zero values fall back to the defaults above.
+type Config struct {
+	BatchSize int
+	// Lease is how long a claimed message is held before another relay may
+	// pick it up, it should outlive a single publish
+	Lease          time.Duration
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+type usecase struct {
+	repo      domain.Repository
+	publisher domain.EventPublisher
+	conf      Config
+	now       func() time.Time
+}
+
+func New(repo domain.Repository, publisher domain.EventPublisher, conf Config) UseCase {
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = DefaultBatchSize
+	}
+	if conf.Lease <= 0 {
+		conf.Lease = DefaultLease
+	}
+	if conf.InitialBackoff <= 0 {
+		conf.InitialBackoff = DefaultInitialBackoff
+	}
+	if conf.MaxBackoff <= 0 {
+		conf.MaxBackoff = DefaultMaxBackoff
+	}
+
+	return &usecase{
+		repo:      repo,
+		publisher: publisher,
+		conf:      conf,
+		now:       time.Now,
+	}
+}
@@ -0,0 +1,24 @@
+package outbox
+
+import (
+	"testing"
+
+	"booklib/internal/domain/outbox/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("creates new usecase with defaults", func(t *testing.T) {
+		uc := New(mocks.NewRepository(t), mocks.NewEventPublisher(t), Config{})
+
+		assert.NotNil(t, uc)
+		assert.Implements(t, (*UseCase)(nil), uc)
+
+		u := uc.(*usecase)
+		assert.Equal(t, DefaultBatchSize, u.conf.BatchSize)
+		assert.Equal(t, DefaultLease, u.conf.Lease)
+		assert.Equal(t, DefaultInitialBackoff, u.conf.InitialBackoff)
+		assert.Equal(t, DefaultMaxBackoff, u.conf.MaxBackoff)
+	})
+}
@@ -0,0 +1,10 @@
+package outbox
+
+import "context"
+
+//go:generate mockery --name=UseCase --output=./mocks
+type UseCase interface {
+	// Relay publishes the outbox messages that are due and returns how many
+	// were attempted.
+	Relay(ctx context.Context) (int, error)
+}
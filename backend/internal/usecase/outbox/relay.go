@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/rizanw/go-log"
+)
+
+func (u usecase) Relay(ctx context.Context) (int, error) {
+	now := u.now()
+
+	messages, err := u.repo.ClaimPending(ctx, now, now.Add(u.conf.Lease), u.conf.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// a message is only marked once it has been published, so a relay that
+	// dies in between publishes it again after the lease: at least once
+	published := make([]int64, 0, len(messages))
+	for i := range messages {
+		m := &messages[i]
+
+		if err = u.publisher.Publish(ctx, &m.Event); err != nil {
+			// events are never dropped, a failing message is retried until
+			// the publisher recovers and holds back the rest of its aggregate
+			retryAt := u.now().Add(u.backoff(m.Attempts + 1))
+			if err = u.repo.MarkFailed(ctx, m.ID, err.Error(), retryAt); err != nil {
+				log.Error(ctx, err, nil, "failed to record outbox publish failure")
+			}
+			continue
+		}
+		published = append(published, m.ID)
+	}
+
+	if len(published) > 0 {
+		if err = u.repo.MarkPublished(ctx, published, u.now()); err != nil {
+			return len(messages), err
+		}
+	}
+
+	return len(messages), nil
+}
+
+// backoff doubles the wait after every failed attempt, capped at MaxBackoff.
+func (u usecase) backoff(attempts int) time.Duration {
+	wait := u.conf.InitialBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= u.conf.MaxBackoff {
+			return u.conf.MaxBackoff
+		}
+	}
+
+	return wait
+}
@@ -0,0 +1,142 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"booklib/internal/domain/event"
+	domain "booklib/internal/domain/outbox"
+	"booklib/internal/domain/outbox/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRelay(t *testing.T) {
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+	message := func(id int64, aggregateID string, attempts int) domain.Message {
+		return domain.Message{
+			ID:       id,
+			Attempts: attempts,
+			Event:    event.Event{ID: id + 10, Type: event.TypeBookCreated, AggregateType: event.AggregateBook, AggregateID: aggregateID},
+		}
+	}
+	eventOf := func(aggregateID string) interface{} {
+		return mock.MatchedBy(func(ev *event.Event) bool { return ev.AggregateID == aggregateID })
+	}
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mocks.Repository, *mocks.EventPublisher)
+		expectedN   int
+		expectedErr string
+	}{
+		{
+			name: "publishes and marks every message",
+			setupMocks: func(repo *mocks.Repository, pub *mocks.EventPublisher) {
+				repo.On("ClaimPending", mock.Anything, now, now.Add(time.Minute), 10).
+					Return([]domain.Message{message(1, "id-1", 0), message(2, "id-2", 0)}, nil)
+				pub.On("Publish", mock.Anything, eventOf("id-1")).Return(nil).Once()
+				pub.On("Publish", mock.Anything, eventOf("id-2")).Return(nil).Once()
+				repo.On("MarkPublished", mock.Anything, []int64{1, 2}, now).Return(nil)
+			},
+			expectedN: 2,
+		},
+		{
+			name: "failed publish is retried with backoff",
+			setupMocks: func(repo *mocks.Repository, pub *mocks.EventPublisher) {
+				repo.On("ClaimPending", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return([]domain.Message{message(1, "id-1", 2), message(2, "id-2", 0)}, nil)
+				pub.On("Publish", mock.Anything, eventOf("id-1")).Return(errors.New("broker unavailable")).Once()
+				pub.On("Publish", mock.Anything, eventOf("id-2")).Return(nil).Once()
+				repo.On("MarkFailed", mock.Anything, int64(1), "broker unavailable", now.Add(4*time.Second)).Return(nil)
+				repo.On("MarkPublished", mock.Anything, []int64{2}, now).Return(nil)
+			},
+			expectedN: 2,
+		},
+		{
+			name: "nothing published",
+			setupMocks: func(repo *mocks.Repository, pub *mocks.EventPublisher) {
+				repo.On("ClaimPending", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return([]domain.Message{message(1, "id-1", 0)}, nil)
+				pub.On("Publish", mock.Anything, mock.Anything).Return(errors.New("broker unavailable"))
+				repo.On("MarkFailed", mock.Anything, int64(1), mock.Anything, mock.Anything).Return(errors.New("repository error"))
+			},
+			expectedN: 1,
+		},
+		{
+			name: "nothing pending",
+			setupMocks: func(repo *mocks.Repository, pub *mocks.EventPublisher) {
+				repo.On("ClaimPending", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return([]domain.Message{}, nil)
+			},
+			expectedN: 0,
+		},
+		{
+			name: "claim error",
+			setupMocks: func(repo *mocks.Repository, pub *mocks.EventPublisher) {
+				repo.On("ClaimPending", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("repository error"))
+			},
+			expectedErr: "repository error",
+		},
+		{
+			name: "mark published error",
+			setupMocks: func(repo *mocks.Repository, pub *mocks.EventPublisher) {
+				repo.On("ClaimPending", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return([]domain.Message{message(1, "id-1", 0)}, nil)
+				pub.On("Publish", mock.Anything, mock.Anything).Return(nil)
+				repo.On("MarkPublished", mock.Anything, []int64{1}, mock.Anything).Return(errors.New("repository error"))
+			},
+			expectedN:   1,
+			expectedErr: "repository error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			pub := mocks.NewEventPublisher(t)
+			tt.setupMocks(repo, pub)
+
+			uc := New(repo, pub, Config{
+				BatchSize:      10,
+				Lease:          time.Minute,
+				InitialBackoff: time.Second,
+				MaxBackoff:     time.Minute,
+			}).(*usecase)
+			uc.now = func() time.Time { return now }
+
+			n, err := uc.Relay(context.Background())
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedN, n)
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	uc := New(nil, nil, Config{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}).(*usecase)
+
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 1, expected: time.Second},
+		{attempts: 2, expected: 2 * time.Second},
+		{attempts: 3, expected: 4 * time.Second},
+		{attempts: 5, expected: 10 * time.Second},
+		{attempts: 50, expected: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, uc.backoff(tt.attempts), "attempts %d", tt.attempts)
+	}
+}
@@ -1,44 +1,177 @@
 package urlprocessor
 
 import (
+	domain "booklib/internal/domain/url-processor"
 	"context"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 )
 
-func (u *usecase) CleanURL(ctx context.Context, operation, uri string) (string, error) {
-	parsed, err := url.Parse(uri)
+type CleanURLInput struct {
+	// Profile holds the operation, the default profile when empty.
+	Profile   string
+	Operation string
+	URL       string
+	// Explain records what every step did in CleanURLOutput.Steps.
+	Explain bool
+}
+
+type CleanURLOutput struct {
+	URL string
+	// DisplayURL is URL with its punycode host in Unicode, empty when the
+	// host is not an IDN.
+	DisplayURL string
+	// RemovedParams are the names of the query params the operation removed,
+	// each once, in the order they appeared.
+	RemovedParams []string
+	// Steps explains every step run, in order, when asked to.
+	Steps []AppliedStep
+	// Chain lists the requests made by resolve steps, in order.
+	Chain []Hop
+	// CanonicalURL is the canonical link of the page the last resolve step
+	// reached, empty when it had none.
+	CanonicalURL string
+	// RuleSet is the stored rule set whose operation ran, nil when the
+	// operation of the profile did.
+	RuleSet *RuleSetRef
+}
+
+// RuleSetRef names a stored rule set.
+type RuleSetRef struct {
+	ID   string
+	Name string
+}
+
+func (u *usecase) CleanURL(ctx context.Context, in CleanURLInput) (*CleanURLOutput, error) {
+	sets, err := u.ruleSets.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.clean(ctx, sets, in.Profile, in.Operation, in.URL, in.Explain)
+}
+
+// clean runs operation on raw with the steps of the rule set of sets
+// applying to it, or else of the profile.
+func (u *usecase) clean(ctx context.Context, sets []*ruleSet, profile, operation, raw string, explain bool) (*CleanURLOutput, error) {
+	parsed, err := url.Parse(raw)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidURL, err)
 	}
 
-	switch strings.ToLower(operation) {
-	case "canonical":
-		applyCanonical(parsed)
-	case "redirection":
-		applyRedirection(parsed)
-	case "all":
-		applyCanonical(parsed)
-		applyRedirection(parsed)
-	default:
-		return "", fmt.Errorf("invalid operation")
+	steps, rs, err := u.pipeline(sets, profile, operation, parsed.Hostname())
+	if err != nil {
+		return nil, err
 	}
 
-	return parsed.String(), nil
+	out, err := u.apply(ctx, steps, parsed, explain)
+	if err != nil {
+		return nil, err
+	}
+	if rs != nil {
+		out.RuleSet = rs.ref()
+	}
+	return out, nil
 }
 
-func applyCanonical(u *url.URL) {
-	// remove query parameters
-	u.RawQuery = ""
-	// remove trailing slash from the path
-	u.Path = strings.TrimSuffix(u.Path, "/")
+// apply runs steps on parsed, recording each of them when explain is set.
+func (u *usecase) apply(ctx context.Context, steps []step, parsed *url.URL, explain bool) (*CleanURLOutput, error) {
+	out := &CleanURLOutput{}
+	for _, s := range steps {
+		var (
+			before string
+			parts  components
+		)
+		if explain {
+			before, parts = parsed.String(), newComponents(parsed)
+		}
+
+		var removed []string
+		if s.typ == domain.StepResolve {
+			res, err := u.resolver.resolve(ctx, parsed)
+			if err != nil {
+				return nil, err
+			}
+			out.Chain = append(out.Chain, res.chain...)
+			out.CanonicalURL = res.canonical
+		} else {
+			removed = s.apply(parsed)
+		}
+		for _, name := range removed {
+			if !slices.Contains(out.RemovedParams, name) {
+				out.RemovedParams = append(out.RemovedParams, name)
+			}
+		}
+
+		if explain {
+			out.Steps = append(out.Steps, AppliedStep{
+				Type:          s.typ,
+				Operation:     s.operation,
+				Before:        before,
+				After:         parsed.String(),
+				Changed:       parts.changed(newComponents(parsed)),
+				RemovedParams: removed,
+			})
+		}
+	}
+	out.URL = parsed.String()
+	out.DisplayURL = displayURL(parsed)
+
+	return out, nil
+}
+
+// profile returns the compiled operations of a profile, the default one
+// when profile is empty.
+func (u *usecase) profile(profile string) (map[string][]step, error) {
+	if profile == "" {
+		profile = u.defaultProfile
+	}
+	ops, ok := u.profiles[strings.ToLower(profile)]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", domain.ErrUnknownProfile, profile)
+	}
+	return ops, nil
 }
 
-func applyRedirection(u *url.URL) {
-	// force domain redirect to www.byfood.com
-	u.Host = "www.byfood.com"
-	u.Scheme = "https"
-	// lowercase entire URL parts
-	u.Path = strings.ToLower(u.Path)
+// pipeline returns the steps running operation on a URL of host: those of
+// the rule set of sets applying to host, which it returns as well, or else
+// those of the profile.
+func (u *usecase) pipeline(sets []*ruleSet, profile, operation, host string) ([]step, *ruleSet, error) {
+	ops, err := u.profile(profile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	name := strings.ToLower(operation)
+	if rs := matchRuleSet(sets, host, name); rs != nil {
+		return rs.operations[name], rs, nil
+	}
+	steps, ok := ops[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w %q", domain.ErrInvalidOperation, operation)
+	}
+	return steps, nil, nil
+}
+
+// checkOperation fails when the profile is unknown or neither it nor any
+// rule set of sets defines operation.
+func (u *usecase) checkOperation(sets []*ruleSet, profile, operation string) error {
+	ops, err := u.profile(profile)
+	if err != nil {
+		return err
+	}
+
+	name := strings.ToLower(operation)
+	if _, ok := ops[name]; ok {
+		return nil
+	}
+	if slices.ContainsFunc(sets, func(rs *ruleSet) bool {
+		_, ok := rs.operations[name]
+		return ok
+	}) {
+		return nil
+	}
+	return fmt.Errorf("%w %q", domain.ErrInvalidOperation, operation)
 }
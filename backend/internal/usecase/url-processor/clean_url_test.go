@@ -4,7 +4,10 @@ import (
 	"context"
 	"testing"
 
+	domain "booklib/internal/domain/url-processor"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCleanURL(t *testing.T) {
@@ -103,15 +106,16 @@ func TestCleanURL(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			uc := New()
-			result, err := uc.CleanURL(context.Background(), tt.operation, tt.url)
+			uc, err := New(noRuleSets(t), Config{})
+			require.NoError(t, err)
+			result, err := uc.CleanURL(context.Background(), CleanURLInput{Operation: tt.operation, URL: tt.url})
 
 			if tt.expectedErr != "" {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErr)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected, result)
+				assert.Equal(t, tt.expected, result.URL)
 			}
 		})
 	}
@@ -165,16 +169,186 @@ func TestCleanURLEdgeCases(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			uc := New()
-			result, err := uc.CleanURL(context.Background(), tt.operation, tt.url)
+			uc, err := New(noRuleSets(t), Config{})
+			require.NoError(t, err)
+			result, err := uc.CleanURL(context.Background(), CleanURLInput{Operation: tt.operation, URL: tt.url})
 
 			if tt.expectedErr != "" {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErr)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected, result)
+				assert.Equal(t, tt.expected, result.URL)
+			}
+		})
+	}
+}
+
+func TestCleanURLProfiles(t *testing.T) {
+	uc, err := New(noRuleSets(t), Config{Profiles: map[string]domain.Profile{
+		"shop": {Operations: map[string][]domain.Step{
+			"canonical": {
+				{Type: domain.StepStripQuery, Params: []string{"utm_*", "ref"}},
+				{Type: domain.StepTrailingSlash, Policy: domain.TrailingSlashAdd},
+			},
+			"redirection": {
+				{Type: domain.StepSetHost, Host: "shop.example.com"},
+				{Type: domain.StepForceScheme, Scheme: "https"},
+			},
+			"all": {
+				{Type: domain.StepStripTracking},
+				{Type: domain.StepOperation, Operation: "canonical"},
+				{Type: domain.StepOperation, Operation: "redirection"},
+			},
+		}},
+	}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		in              CleanURLInput
+		expected        string
+		expectedDisplay string
+		expectedRemoved []string
+		expectedErr     error
+	}{
+		{
+			name:            "operation of a profile",
+			in:              CleanURLInput{Profile: "shop", Operation: "canonical", URL: "http://example.com/Item?id=1&utm_source=mail&ref=x"},
+			expected:        "http://example.com/Item/?id=1",
+			expectedRemoved: []string{"utm_source", "ref"},
+		},
+		{
+			name:     "profile names are case-insensitive",
+			in:       CleanURLInput{Profile: "SHOP", Operation: "redirection", URL: "http://example.com/Item?id=1"},
+			expected: "https://shop.example.com/Item?id=1",
+		},
+		{
+			name:            "default profile",
+			in:              CleanURLInput{Operation: "all", URL: "http://example.com/Item/?id=1"},
+			expected:        "https://www.byfood.com/item",
+			expectedRemoved: []string{"id"},
+		},
+		{
+			name:            "removed params are reported once",
+			in:              CleanURLInput{Profile: "shop", Operation: "all", URL: "http://example.com/Item?utm_source=a&utm_source=b&fbclid=x"},
+			expected:        "https://shop.example.com/Item/",
+			expectedRemoved: []string{"utm_source", "fbclid"},
+		},
+		{
+			name:            "clean only strips tracking params",
+			in:              CleanURLInput{Operation: "clean", URL: "https://Example.com/Item/?id=1&utm_medium=mail&gclid=x&page=2"},
+			expected:        "https://Example.com/Item/?id=1&page=2",
+			expectedRemoved: []string{"utm_medium", "gclid"},
+		},
+		{
+			name:            "normalize reports the display URL of an IDN",
+			in:              CleanURLInput{Operation: "normalize", URL: "HTTP://Bücher.Example:80/a/./%7eb?utm_source=x"},
+			expected:        "http://xn--bcher-kva.example/a/~b?utm_source=x",
+			expectedDisplay: "http://bücher.example/a/~b?utm_source=x",
+		},
+		{
+			name:        "operation missing from the profile",
+			in:          CleanURLInput{Profile: "shop", Operation: "redirect", URL: "http://example.com"},
+			expectedErr: domain.ErrInvalidOperation,
+		},
+		{
+			name:        "unknown profile",
+			in:          CleanURLInput{Profile: "blog", Operation: "all", URL: "http://example.com"},
+			expectedErr: domain.ErrUnknownProfile,
+		},
+		{
+			name:        "invalid url",
+			in:          CleanURLInput{Operation: "all", URL: "://example.com"},
+			expectedErr: domain.ErrInvalidURL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := uc.CleanURL(context.Background(), tt.in)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
 			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.URL)
+			assert.Equal(t, tt.expectedDisplay, result.DisplayURL)
+			assert.Equal(t, tt.expectedRemoved, result.RemovedParams)
 		})
 	}
 }
+
+func TestCleanURLExplain(t *testing.T) {
+	uc, err := New(noRuleSets(t), Config{})
+	require.NoError(t, err)
+
+	t.Run("explains every step", func(t *testing.T) {
+		result, err := uc.CleanURL(context.Background(), CleanURLInput{
+			Operation: "all",
+			URL:       "https://BYFOOD.com/food-EXPeriences?query=abc/",
+			Explain:   true,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://www.byfood.com/food-experiences", result.URL)
+		assert.Equal(t, []AppliedStep{
+			{
+				Type:          domain.StepStripQuery,
+				Operation:     "canonical",
+				Before:        "https://BYFOOD.com/food-EXPeriences?query=abc/",
+				After:         "https://BYFOOD.com/food-EXPeriences",
+				Changed:       []string{ComponentQuery},
+				RemovedParams: []string{"query"},
+			},
+			{
+				Type:      domain.StepTrailingSlash,
+				Operation: "canonical",
+				Before:    "https://BYFOOD.com/food-EXPeriences",
+				After:     "https://BYFOOD.com/food-EXPeriences",
+				Changed:   []string{},
+			},
+			{
+				Type:      domain.StepSetHost,
+				Operation: "redirection",
+				Before:    "https://BYFOOD.com/food-EXPeriences",
+				After:     "https://www.byfood.com/food-EXPeriences",
+				Changed:   []string{ComponentHost},
+			},
+			{
+				Type:      domain.StepForceScheme,
+				Operation: "redirection",
+				Before:    "https://www.byfood.com/food-EXPeriences",
+				After:     "https://www.byfood.com/food-EXPeriences",
+				Changed:   []string{},
+			},
+			{
+				Type:      domain.StepLowercasePath,
+				Operation: "redirection",
+				Before:    "https://www.byfood.com/food-EXPeriences",
+				After:     "https://www.byfood.com/food-experiences",
+				Changed:   []string{ComponentPath},
+			},
+		}, result.Steps)
+	})
+
+	t.Run("explains several changes of one step", func(t *testing.T) {
+		result, err := uc.CleanURL(context.Background(), CleanURLInput{
+			Operation: "normalize",
+			URL:       "HTTP://User@Example.COM:80/a/../b?%7e=1#%7e",
+			Explain:   true,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Steps, 1)
+		assert.Equal(t, []string{ComponentHost, ComponentPath, ComponentQuery, ComponentFragment}, result.Steps[0].Changed)
+	})
+
+	t.Run("without explain", func(t *testing.T) {
+		result, err := uc.CleanURL(context.Background(), CleanURLInput{Operation: "all", URL: "https://example.com/a/"})
+
+		require.NoError(t, err)
+		assert.Nil(t, result.Steps)
+	})
+}
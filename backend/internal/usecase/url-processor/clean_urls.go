@@ -0,0 +1,102 @@
+package urlprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	DefaultBatchWorkers = 8
+	DefaultMaxBatchURLs = 10000
+)
+
+var (
+	ErrBatchEmpty    = errors.New("batch must contain at least one url")
+	ErrBatchTooLarge = errors.New("batch contains too many urls")
+)
+
+type CleanURLsInput struct {
+	// Profile holds the operation, the default profile when empty.
+	Profile   string
+	Operation string
+	URLs      []string
+	// Explain records what every step did for every URL.
+	Explain bool
+}
+
+// CleanURLsResult is the outcome of one URL of a batch; Err is set instead
+// of Output when the URL could not be processed.
+type CleanURLsResult struct {
+	Index  int
+	URL    string
+	Output *CleanURLOutput
+	Err    error
+}
+
+func (u *usecase) CleanURLs(ctx context.Context, in CleanURLsInput, emit func(CleanURLsResult) error) error {
+	if len(in.URLs) == 0 {
+		return ErrBatchEmpty
+	}
+	if len(in.URLs) > u.maxBatchURLs {
+		return fmt.Errorf("%w, the maximum is %d", ErrBatchTooLarge, u.maxBatchURLs)
+	}
+
+	sets, err := u.ruleSets.get(ctx)
+	if err != nil {
+		return err
+	}
+	// an unknown profile or operation fails the batch rather than every URL
+	if err := u.checkOperation(sets, in.Profile, in.Operation); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		workers = min(u.batchWorkers, len(in.URLs))
+		// pending holds the results in input order; its capacity bounds how
+		// many finished results wait behind a slow one
+		pending = make(chan chan CleanURLsResult, workers)
+		sem     = make(chan struct{}, workers)
+	)
+	go func() {
+		defer close(pending)
+		for i, raw := range in.URLs {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			res := make(chan CleanURLsResult, 1)
+			go func() {
+				defer func() { <-sem }()
+				res <- u.cleanBatchURL(ctx, sets, in, i, raw)
+			}()
+
+			select {
+			case pending <- res:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for res := range pending {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := emit(<-res); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func (u *usecase) cleanBatchURL(ctx context.Context, sets []*ruleSet, in CleanURLsInput, index int, raw string) CleanURLsResult {
+	res := CleanURLsResult{Index: index, URL: raw}
+	res.Output, res.Err = u.clean(ctx, sets, in.Profile, in.Operation, raw, in.Explain)
+	return res
+}
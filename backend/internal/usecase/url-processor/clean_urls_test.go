@@ -0,0 +1,192 @@
+package urlprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	domain "booklib/internal/domain/url-processor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanURLs(t *testing.T) {
+	uc, err := New(noRuleSets(t), Config{BatchWorkers: 3, MaxBatchURLs: 5})
+	require.NoError(t, err)
+
+	type result struct {
+		url      string
+		output   string
+		removed  []string
+		errorMsg string
+	}
+
+	tests := []struct {
+		name        string
+		in          CleanURLsInput
+		expected    []result
+		expectedErr error
+	}{
+		{
+			name: "results in input order",
+			in: CleanURLsInput{Operation: "canonical", URLs: []string{
+				"https://example.com/a/?q=1",
+				"://bad",
+				"https://example.com/b",
+				"https://example.com/c/?utm_source=x",
+			}},
+			expected: []result{
+				{url: "https://example.com/a/?q=1", output: "https://example.com/a", removed: []string{"q"}},
+				{url: "://bad", errorMsg: `invalid url: parse "://bad": missing protocol scheme`},
+				{url: "https://example.com/b", output: "https://example.com/b"},
+				{url: "https://example.com/c/?utm_source=x", output: "https://example.com/c", removed: []string{"utm_source"}},
+			},
+		},
+		{
+			name:     "operation of a profile",
+			in:       CleanURLsInput{Profile: "DEFAULT", Operation: "clean", URLs: []string{"https://example.com/?id=1&fbclid=x"}},
+			expected: []result{{url: "https://example.com/?id=1&fbclid=x", output: "https://example.com/?id=1", removed: []string{"fbclid"}}},
+		},
+		{
+			name:        "empty batch",
+			in:          CleanURLsInput{Operation: "all"},
+			expectedErr: ErrBatchEmpty,
+		},
+		{
+			name:        "batch too large",
+			in:          CleanURLsInput{Operation: "all", URLs: make([]string, 6)},
+			expectedErr: ErrBatchTooLarge,
+		},
+		{
+			name:        "unknown operation",
+			in:          CleanURLsInput{Operation: "shout", URLs: []string{"https://example.com"}},
+			expectedErr: domain.ErrInvalidOperation,
+		},
+		{
+			name:        "unknown profile",
+			in:          CleanURLsInput{Profile: "blog", Operation: "all", URLs: []string{"https://example.com"}},
+			expectedErr: domain.ErrUnknownProfile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var results []CleanURLsResult
+			err := uc.CleanURLs(context.Background(), tt.in, func(res CleanURLsResult) error {
+				results = append(results, res)
+				return nil
+			})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, results)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, results, len(tt.expected))
+
+			for i, res := range results {
+				assert.Equal(t, i, res.Index)
+				assert.Equal(t, tt.expected[i].url, res.URL)
+				if tt.expected[i].errorMsg != "" {
+					assert.EqualError(t, res.Err, tt.expected[i].errorMsg)
+					assert.True(t, errors.Is(res.Err, domain.ErrInvalidURL))
+					assert.Nil(t, res.Output)
+					continue
+				}
+				require.NoError(t, res.Err)
+				assert.Equal(t, tt.expected[i].output, res.Output.URL)
+				assert.Equal(t, tt.expected[i].removed, res.Output.RemovedParams)
+			}
+		})
+	}
+}
+
+func TestCleanURLsOrder(t *testing.T) {
+	uc, err := New(noRuleSets(t), Config{BatchWorkers: 4})
+	require.NoError(t, err)
+
+	urls := make([]string, 2000)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://example.com/%d/?n=%d", i, i)
+	}
+
+	var n int
+	err = uc.CleanURLs(context.Background(), CleanURLsInput{Operation: "canonical", URLs: urls}, func(res CleanURLsResult) error {
+		assert.Equal(t, n, res.Index)
+		assert.Equal(t, fmt.Sprintf("https://example.com/%d", n), res.Output.URL)
+		n++
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, len(urls), n)
+}
+
+func TestCleanURLsStops(t *testing.T) {
+	uc, err := New(noRuleSets(t), Config{BatchWorkers: 2})
+	require.NoError(t, err)
+
+	urls := make([]string, 100)
+	for i := range urls {
+		urls[i] = "https://example.com/"
+	}
+	in := CleanURLsInput{Operation: "all", URLs: urls}
+
+	t.Run("at the first error of emit", func(t *testing.T) {
+		writeErr := errors.New("client went away")
+
+		var n int
+		err := uc.CleanURLs(context.Background(), in, func(res CleanURLsResult) error {
+			n++
+			if n == 3 {
+				return writeErr
+			}
+			return nil
+		})
+
+		assert.ErrorIs(t, err, writeErr)
+		assert.Equal(t, 3, n)
+	})
+
+	t.Run("when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var n int
+		err := uc.CleanURLs(ctx, in, func(res CleanURLsResult) error {
+			n++
+			if n == 3 {
+				cancel()
+			}
+			return nil
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 3, n)
+	})
+}
+
+func TestCleanURLsExplain(t *testing.T) {
+	uc, err := New(noRuleSets(t), Config{})
+	require.NoError(t, err)
+
+	for _, explain := range []bool{false, true} {
+		t.Run(fmt.Sprintf("explain=%t", explain), func(t *testing.T) {
+			in := CleanURLsInput{Operation: "canonical", URLs: []string{"https://example.com/a/?q=1"}, Explain: explain}
+
+			err := uc.CleanURLs(context.Background(), in, func(res CleanURLsResult) error {
+				require.NoError(t, res.Err)
+				if explain {
+					assert.Len(t, res.Output.Steps, 2)
+				} else {
+					assert.Nil(t, res.Output.Steps)
+				}
+				return nil
+			})
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package urlprocessor
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// linkAttrs are the attributes holding the links of an HTML document, by
+// element.
+var linkAttrs = map[string]string{
+	"a":    "href",
+	"link": "href",
+	"img":  "src",
+}
+
+// docLink is a link found in a document: its value is written at
+// doc[start:end].
+type docLink struct {
+	element   string
+	attribute string
+	line      int
+	url       string
+	start     int
+	end       int
+	// encode writes a URL as the value of the link
+	encode func(u string) []byte
+}
+
+// htmlLinks returns the links of an HTML document, in document order. Only
+// the first of repeated attributes counts, as for browsers.
+func htmlLinks(doc []byte) ([]docLink, error) {
+	var (
+		links  []docLink
+		offset int
+		lines  = newLineCounter(doc)
+		z      = html.NewTokenizer(bytes.NewReader(doc))
+	)
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if err := z.Err(); !errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+			}
+			return links, nil
+		}
+		// the raw tokens put back together are the document
+		rawLen := len(z.Raw())
+		start := offset
+		offset += rawLen
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+
+		raw := doc[start : start+rawLen]
+		name, _ := z.TagName()
+		attr, ok := linkAttrs[string(name)]
+		if !ok {
+			continue
+		}
+		span, ok := findAttr(raw, attr)
+		if !ok {
+			continue
+		}
+
+		links = append(links, docLink{
+			element:   string(name),
+			attribute: attr,
+			line:      lines.line(start + span.start),
+			url:       strings.TrimSpace(html.UnescapeString(string(raw[span.start:span.end]))),
+			start:     start + span.start,
+			end:       start + span.end,
+			encode:    span.encode,
+		})
+	}
+}
+
+// attrSpan is where the value of an attribute is written in a raw tag.
+type attrSpan struct {
+	start, end int
+	// quote is the quote around the value, 0 when unquoted
+	quote byte
+}
+
+// encode escapes u for the place of the value, keeping its quotes; an
+// unquoted value gets double quotes.
+func (s attrSpan) encode(u string) []byte {
+	escaped := html.EscapeString(u)
+	if s.quote == 0 {
+		return []byte(`"` + escaped + `"`)
+	}
+	return []byte(escaped)
+}
+
+// findAttr scans a raw start tag for the value of the named attribute,
+// following the attribute syntax of HTML.
+func findAttr(raw []byte, name string) (attrSpan, bool) {
+	isSpace := func(c byte) bool {
+		return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+	}
+
+	// skip "<" and the tag name
+	i := 1
+	for i < len(raw) && !isSpace(raw[i]) && raw[i] != '/' && raw[i] != '>' {
+		i++
+	}
+	for i < len(raw) {
+		for i < len(raw) && (isSpace(raw[i]) || raw[i] == '/') {
+			i++
+		}
+		if i >= len(raw) || raw[i] == '>' {
+			return attrSpan{}, false
+		}
+
+		nameStart := i
+		i++ // a name may start with "="
+		for i < len(raw) && !isSpace(raw[i]) && raw[i] != '/' && raw[i] != '>' && raw[i] != '=' {
+			i++
+		}
+		attr := strings.ToLower(string(raw[nameStart:i]))
+		for i < len(raw) && isSpace(raw[i]) {
+			i++
+		}
+		if i >= len(raw) || raw[i] != '=' {
+			continue
+		}
+		i++
+		for i < len(raw) && isSpace(raw[i]) {
+			i++
+		}
+
+		var span attrSpan
+		if i < len(raw) && (raw[i] == '"' || raw[i] == '\'') {
+			span.quote = raw[i]
+			i++
+			span.start = i
+			for i < len(raw) && raw[i] != span.quote {
+				i++
+			}
+			span.end = i
+			i++
+		} else {
+			span.start = i
+			for i < len(raw) && !isSpace(raw[i]) && raw[i] != '>' {
+				i++
+			}
+			span.end = i
+		}
+		if attr == name {
+			return span, true
+		}
+	}
+	return attrSpan{}, false
+}
+
+// sitemapLinks returns the <loc> links of a sitemap or sitemap index, image
+// and video locations included, in document order.
+func sitemapLinks(doc []byte) ([]docLink, error) {
+	var (
+		links []docLink
+		lines = newLineCounter(doc)
+		d     = xml.NewDecoder(bytes.NewReader(doc))
+		// loc is the link being read, nil outside <loc>
+		loc  *docLink
+		text strings.Builder
+	)
+	for {
+		start := int(d.InputOffset())
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			return links, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "loc" && loc == nil {
+				offset := int(d.InputOffset())
+				loc = &docLink{element: "loc", line: lines.line(offset), start: offset}
+				text.Reset()
+			}
+		case xml.CharData:
+			if loc != nil {
+				text.Write(t)
+			}
+		case xml.EndElement:
+			if t.Name.Local != "loc" || loc == nil {
+				continue
+			}
+			// the value runs up to the end tag
+			loc.end = start
+			loc.url = strings.TrimSpace(text.String())
+			loc.encode = xmlEncoder(doc[loc.start:loc.end])
+			links = append(links, *loc)
+			loc = nil
+		}
+	}
+}
+
+// xmlEncoder escapes a URL as the text of an element, keeping the
+// whitespace around the current text.
+func xmlEncoder(raw []byte) func(u string) []byte {
+	const space = " \t\r\n"
+	lead := raw[:len(raw)-len(bytes.TrimLeft(raw, space))]
+	trail := raw[len(bytes.TrimRight(raw, space)):]
+	if len(lead) == len(raw) {
+		trail = nil
+	}
+
+	return func(u string) []byte {
+		var b bytes.Buffer
+		b.Write(lead)
+		_ = xml.EscapeText(&b, []byte(u))
+		b.Write(trail)
+		return b.Bytes()
+	}
+}
+
+// isWebURL reports whether raw is an absolute http or https URL, the only
+// links rewritten: relative links and other schemes are left as they are.
+func isWebURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	return scheme == "http" || scheme == "https"
+}
+
+// lineCounter returns the lines of increasing offsets of a document.
+type lineCounter struct {
+	doc    []byte
+	offset int
+	count  int
+}
+
+func newLineCounter(doc []byte) *lineCounter {
+	return &lineCounter{doc: doc, count: 1}
+}
+
+// line returns the 1-based line of offset, which may not be lower than the
+// last one asked for.
+func (c *lineCounter) line(offset int) int {
+	c.count += bytes.Count(c.doc[c.offset:offset], []byte("\n"))
+	c.offset = offset
+	return c.count
+}
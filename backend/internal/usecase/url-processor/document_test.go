@@ -0,0 +1,155 @@
+package urlprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTMLLinks(t *testing.T) {
+	type link struct {
+		element, attribute string
+		line               int
+		url, raw           string
+	}
+
+	tests := []struct {
+		name     string
+		doc      string
+		expected []link
+	}{
+		{
+			name: "links of a, link and img",
+			doc: "<html><head><link rel=\"canonical\" href=\"https://example.com/\"></head>\n" +
+				"<body><a class=x HREF='https://example.com/a?x=1&amp;y=2'>a</a>\n" +
+				"<img alt=\"\" src=https://example.com/i.png /><p data-href=\"https://example.com/p\">p</p></body></html>",
+			expected: []link{
+				{"link", "href", 1, "https://example.com/", "https://example.com/"},
+				{"a", "href", 2, "https://example.com/a?x=1&y=2", "https://example.com/a?x=1&amp;y=2"},
+				{"img", "src", 3, "https://example.com/i.png", "https://example.com/i.png"},
+			},
+		},
+		{
+			name: "first of repeated attributes",
+			doc:  `<a href="https://example.com/1" href="https://example.com/2">a</a>`,
+			expected: []link{
+				{"a", "href", 1, "https://example.com/1", "https://example.com/1"},
+			},
+		},
+		{
+			name: "value around spaces",
+			doc:  `<a title="a > b" href = " https://example.com/a ">a</a>`,
+			expected: []link{
+				{"a", "href", 1, "https://example.com/a", " https://example.com/a "},
+			},
+		},
+		{
+			name: "ignores text, comments, scripts and attributes without value",
+			doc: `<!-- <a href="https://example.com/c"> --><script>var a = '<a href="https://example.com/s">';</script>` +
+				`<a href>x</a><p>&lt;a href="https://example.com/t"&gt;</p>`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links, err := htmlLinks([]byte(tt.doc))
+
+			require.NoError(t, err)
+			var got []link
+			for _, l := range links {
+				got = append(got, link{l.element, l.attribute, l.line, l.url, tt.doc[l.start:l.end]})
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestFindAttr(t *testing.T) {
+	tests := []struct {
+		raw           string
+		expectedValue string
+		expectedQuote byte
+		expectedFound bool
+	}{
+		{raw: `<a href="x">`, expectedValue: "x", expectedQuote: '"', expectedFound: true},
+		{raw: `<a href='x'>`, expectedValue: "x", expectedQuote: '\'', expectedFound: true},
+		{raw: `<a href=x>`, expectedValue: "x", expectedQuote: 0, expectedFound: true},
+		{raw: `<a href=x/>`, expectedValue: "x/", expectedQuote: 0, expectedFound: true},
+		{raw: `<a hreflang="en" href="x">`, expectedValue: "x", expectedQuote: '"', expectedFound: true},
+		{raw: `<a download href="x">`, expectedValue: "x", expectedQuote: '"', expectedFound: true},
+		{raw: `<a data-x="href=y" href="x">`, expectedValue: "x", expectedQuote: '"', expectedFound: true},
+		{raw: `<a/href="x">`, expectedValue: "x", expectedQuote: '"', expectedFound: true},
+		{raw: `<a href>`},
+		{raw: `<a hreflang="en">`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			span, ok := findAttr([]byte(tt.raw), "href")
+
+			assert.Equal(t, tt.expectedFound, ok)
+			if ok {
+				assert.Equal(t, tt.expectedValue, tt.raw[span.start:span.end])
+				assert.Equal(t, tt.expectedQuote, span.quote)
+			}
+		})
+	}
+}
+
+func TestSitemapLinks(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:image="http://www.google.com/schemas/sitemap-image/1.1">
+  <url>
+    <loc>https://example.com/a?x=1&amp;y=2</loc>
+    <lastmod>2026-10-19</lastmod>
+    <image:image><image:loc>
+      https://example.com/i.png
+    </image:loc></image:image>
+  </url>
+  <url><loc><![CDATA[https://example.com/b]]></loc></url>
+</urlset>`
+
+	links, err := sitemapLinks([]byte(doc))
+
+	require.NoError(t, err)
+	require.Len(t, links, 3)
+	assert.Equal(t, "https://example.com/a?x=1&y=2", links[0].url)
+	assert.Equal(t, "https://example.com/a?x=1&amp;y=2", doc[links[0].start:links[0].end])
+	assert.Equal(t, 4, links[0].line)
+	assert.Equal(t, "https://example.com/i.png", links[1].url)
+	assert.Equal(t, 6, links[1].line)
+	assert.Equal(t, "\n      https://example.com/x?a=1&amp;b=2\n    ", string(links[1].encode("https://example.com/x?a=1&b=2")))
+	assert.Equal(t, "https://example.com/b", links[2].url)
+	assert.Equal(t, "<![CDATA[https://example.com/b]]>", doc[links[2].start:links[2].end])
+
+	t.Run("invalid xml", func(t *testing.T) {
+		_, err := sitemapLinks([]byte("<urlset><url><loc>https://example.com</url></urlset>"))
+
+		assert.ErrorIs(t, err, ErrInvalidDocument)
+	})
+}
+
+func TestIsWebURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected bool
+	}{
+		{url: "https://example.com/a", expected: true},
+		{url: "HTTP://example.com", expected: true},
+		{url: "/a"},
+		{url: "//cdn.example.com/a.js"},
+		{url: "#top"},
+		{url: "mailto:a@example.com"},
+		{url: "javascript:void(0)"},
+		{url: "ftp://example.com/a"},
+		{url: "https://exa mple.com/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isWebURL(tt.url))
+		})
+	}
+}
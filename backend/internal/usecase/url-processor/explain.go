@@ -0,0 +1,72 @@
+package urlprocessor
+
+import (
+	"net/url"
+)
+
+// URL components an applied step may change.
+const (
+	ComponentScheme   = "scheme"
+	ComponentUserinfo = "userinfo"
+	ComponentHost     = "host"
+	ComponentPath     = "path"
+	ComponentQuery    = "query"
+	ComponentFragment = "fragment"
+)
+
+// AppliedStep explains what one step of an operation did to the URL.
+type AppliedStep struct {
+	Type string
+	// Operation is the operation defining the step, which differs from the
+	// requested one for steps of included operations.
+	Operation string
+	Before    string
+	After     string
+	// Changed lists the components the step changed, in URL order; it is
+	// empty when the step left the URL as it was.
+	Changed       []string
+	RemovedParams []string
+}
+
+// components are the parts of a URL as they are written in it.
+type components struct {
+	scheme, userinfo, host, path, query, fragment string
+}
+
+func newComponents(u *url.URL) components {
+	c := components{
+		scheme:   u.Scheme,
+		host:     u.Host,
+		path:     u.EscapedPath(),
+		query:    u.RawQuery,
+		fragment: u.EscapedFragment(),
+	}
+	if u.Opaque != "" {
+		c.path = u.Opaque
+	}
+	if u.User != nil {
+		c.userinfo = u.User.String()
+	}
+	return c
+}
+
+// changed lists the components differing between c and after.
+func (c components) changed(after components) []string {
+	changed := []string{}
+	for _, cmp := range []struct {
+		name          string
+		before, after string
+	}{
+		{ComponentScheme, c.scheme, after.scheme},
+		{ComponentUserinfo, c.userinfo, after.userinfo},
+		{ComponentHost, c.host, after.host},
+		{ComponentPath, c.path, after.path},
+		{ComponentQuery, c.query, after.query},
+		{ComponentFragment, c.fragment, after.fragment},
+	} {
+		if cmp.before != cmp.after {
+			changed = append(changed, cmp.name)
+		}
+	}
+	return changed
+}
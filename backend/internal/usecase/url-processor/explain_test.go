@@ -0,0 +1,66 @@
+package urlprocessor
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentsChanged(t *testing.T) {
+	tests := []struct {
+		name     string
+		before   string
+		after    string
+		expected []string
+	}{
+		{
+			name:     "nothing changed",
+			before:   "https://example.com/a?b=c#d",
+			after:    "https://example.com/a?b=c#d",
+			expected: []string{},
+		},
+		{
+			name:     "every component changed",
+			before:   "http://u@example.com/a?b=c#d",
+			after:    "https://v@example.org/A?b=C#D",
+			expected: []string{ComponentScheme, ComponentUserinfo, ComponentHost, ComponentPath, ComponentQuery, ComponentFragment},
+		},
+		{
+			name:     "port change is a host change",
+			before:   "https://example.com:443/",
+			after:    "https://example.com/",
+			expected: []string{ComponentHost},
+		},
+		{
+			name:     "escaping change is a path change",
+			before:   "https://example.com/%7Ea",
+			after:    "https://example.com/~a",
+			expected: []string{ComponentPath},
+		},
+		{
+			name:     "userinfo removed",
+			before:   "https://u:p@example.com/",
+			after:    "https://example.com/",
+			expected: []string{ComponentUserinfo},
+		},
+		{
+			name:     "opaque part",
+			before:   "mailto:a@example.com",
+			after:    "mailto:b@example.com",
+			expected: []string{ComponentPath},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, err := url.Parse(tt.before)
+			require.NoError(t, err)
+			after, err := url.Parse(tt.after)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, newComponents(before).changed(newComponents(after)))
+		})
+	}
+}
@@ -1,8 +1,102 @@
 package urlprocessor
 
+import (
+	domain "booklib/internal/domain/url-processor"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	// DefaultProfile serves the requests naming no profile,
+	// domain.DefaultProfile when empty.
+	DefaultProfile string
+	// Profiles are served next to the built-in default profile; one named
+	// like it replaces it.
+	Profiles map[string]domain.Profile
+	// TrackingParams are removed by every strip_tracking step on top of
+	// domain.TrackingParams.
+	TrackingParams []string
+	// BatchWorkers is how many URLs of a batch are processed at once,
+	// DefaultBatchWorkers when unset.
+	BatchWorkers int
+	// MaxBatchURLs is the most URLs a batch may hold, DefaultMaxBatchURLs
+	// when unset.
+	MaxBatchURLs int
+	// MaxDocumentLinks is the most distinct links a document may have
+	// processed, DefaultMaxDocumentLinks when unset.
+	MaxDocumentLinks int
+	// Client fetches the URLs of resolve steps; its redirect policy is
+	// replaced to follow redirects one by one. A plain client is used when
+	// nil.
+	Client *http.Client
+	// ResolveMaxHops is how many redirects a resolve step follows,
+	// DefaultResolveMaxHops when unset.
+	ResolveMaxHops int
+	// ResolveTimeout bounds a whole resolve step, DefaultResolveTimeout when
+	// unset.
+	ResolveTimeout time.Duration
+	// RuleSetsRefresh is how long the stored rule sets are cached,
+	// DefaultRuleSetsRefresh when unset.
+	RuleSetsRefresh time.Duration
+}
+
 type usecase struct {
+	defaultProfile string
+	// profiles holds the compiled operations of every profile
+	profiles     map[string]map[string][]step
+	batchWorkers int
+	maxBatchURLs int
+	// maxDocumentLinks bounds the links of a document, which are processed
+	// maxBatchURLs at a time
+	maxDocumentLinks int
+	resolver         *resolver
+	repo             domain.RuleSetRepository
+	ruleSets         *ruleSets
 }
 
-func New() UseCase {
-	return &usecase{}
+// New compiles the profiles of conf and fails on the first invalid one. The
+// rule sets of repo are loaded when first needed.
+func New(repo domain.RuleSetRepository, conf Config) (UseCase, error) {
+	defs := map[string]domain.Profile{domain.DefaultProfile: domain.NewDefaultProfile()}
+	for name, p := range conf.Profiles {
+		defs[strings.ToLower(name)] = p
+	}
+
+	u := &usecase{
+		defaultProfile:   strings.ToLower(conf.DefaultProfile),
+		profiles:         make(map[string]map[string][]step, len(defs)),
+		batchWorkers:     conf.BatchWorkers,
+		maxBatchURLs:     conf.MaxBatchURLs,
+		maxDocumentLinks: conf.MaxDocumentLinks,
+		resolver:         newResolver(conf.Client, conf.ResolveMaxHops, conf.ResolveTimeout),
+		repo:             repo,
+		ruleSets:         newRuleSets(repo, conf.TrackingParams, conf.RuleSetsRefresh),
+	}
+	if u.defaultProfile == "" {
+		u.defaultProfile = domain.DefaultProfile
+	}
+	if u.batchWorkers <= 0 {
+		u.batchWorkers = DefaultBatchWorkers
+	}
+	if u.maxBatchURLs <= 0 {
+		u.maxBatchURLs = DefaultMaxBatchURLs
+	}
+	if u.maxDocumentLinks <= 0 {
+		u.maxDocumentLinks = DefaultMaxDocumentLinks
+	}
+
+	for name, p := range defs {
+		ops, err := compileProfile(p, conf.TrackingParams)
+		if err != nil {
+			return nil, fmt.Errorf("url processor profile %q: %w", name, err)
+		}
+		u.profiles[name] = ops
+	}
+	if _, ok := u.profiles[u.defaultProfile]; !ok {
+		return nil, fmt.Errorf("url processor default profile %q is not defined", u.defaultProfile)
+	}
+
+	return u, nil
 }
@@ -1,17 +1,108 @@
 package urlprocessor
 
 import (
+	"maps"
+	"slices"
 	"testing"
 
+	domain "booklib/internal/domain/url-processor"
+	"booklib/internal/domain/url-processor/mocks"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
+// noRuleSets returns a repository without any rule set.
+func noRuleSets(t *testing.T) *mocks.RuleSetRepository {
+	repo := mocks.NewRuleSetRepository(t)
+	repo.On("GetRuleSets", mock.Anything).Return([]domain.RuleSet{}, nil).Maybe()
+	return repo
+}
+
 func TestNew(t *testing.T) {
 	t.Run("creates new usecase", func(t *testing.T) {
 
-		uc := New()
+		uc, err := New(noRuleSets(t), Config{})
 
+		assert.NoError(t, err)
 		assert.NotNil(t, uc)
 		assert.Implements(t, (*UseCase)(nil), uc)
 	})
+
+	t.Run("defaults batch limits and rule sets refresh", func(t *testing.T) {
+		uc, err := New(noRuleSets(t), Config{})
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultBatchWorkers, uc.(*usecase).batchWorkers)
+		assert.Equal(t, DefaultMaxBatchURLs, uc.(*usecase).maxBatchURLs)
+		assert.Equal(t, DefaultRuleSetsRefresh, uc.(*usecase).ruleSets.refresh)
+	})
+}
+
+func TestNewProfiles(t *testing.T) {
+	shop := domain.Profile{Operations: map[string][]domain.Step{
+		"canonical": {{Type: domain.StepKeepQuery, Params: []string{"id"}}},
+	}}
+
+	tests := []struct {
+		name             string
+		conf             Config
+		expectedProfiles []string
+		expectedDefault  string
+		expectedErr      string
+	}{
+		{
+			name:             "built-in default profile",
+			conf:             Config{},
+			expectedProfiles: []string{"default"},
+			expectedDefault:  "default",
+		},
+		{
+			name:             "configured profiles",
+			conf:             Config{DefaultProfile: "Shop", Profiles: map[string]domain.Profile{"SHOP": shop}},
+			expectedProfiles: []string{"default", "shop"},
+			expectedDefault:  "shop",
+		},
+		{
+			name:             "replaced default profile",
+			conf:             Config{Profiles: map[string]domain.Profile{"default": shop}},
+			expectedProfiles: []string{"default"},
+			expectedDefault:  "default",
+		},
+		{
+			name:        "undefined default profile",
+			conf:        Config{DefaultProfile: "shop"},
+			expectedErr: `url processor default profile "shop" is not defined`,
+		},
+		{
+			name: "invalid profile",
+			conf: Config{Profiles: map[string]domain.Profile{"shop": {Operations: map[string][]domain.Step{
+				"canonical": {{Type: "shout"}},
+			}}}},
+			expectedErr: `url processor profile "shop": operation "canonical" step 1: unknown step type "shout"`,
+		},
+		{
+			name:        "invalid tracking params",
+			conf:        Config{TrackingParams: []string{"ref", ""}},
+			expectedErr: `url processor profile "default": operation "clean" step 1: strip_tracking: params cannot contain an empty name`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, err := New(noRuleSets(t), tt.conf)
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				assert.Nil(t, uc)
+				return
+			}
+			require.NoError(t, err)
+
+			u := uc.(*usecase)
+			assert.Equal(t, tt.expectedDefault, u.defaultProfile)
+			assert.ElementsMatch(t, tt.expectedProfiles, slices.Collect(maps.Keys(u.profiles)))
+		})
+	}
 }
@@ -1,8 +1,33 @@
 package urlprocessor
 
-import "context"
+import (
+	domain "booklib/internal/domain/url-processor"
+	"context"
+)
 
 //go:generate mockery --name=UseCase --output=./mocks
 type UseCase interface {
-	CleanURL(ctx context.Context, operation, url string) (string, error)
+	// CleanURL runs an operation of a profile on a URL and reports the query
+	// params it removed.
+	CleanURL(ctx context.Context, in CleanURLInput) (*CleanURLOutput, error)
+	// CleanURLs runs an operation on every URL of a batch, several at once,
+	// and passes the results to emit in input order. It stops at the first
+	// error of emit and returns it.
+	CleanURLs(ctx context.Context, in CleanURLsInput, emit func(CleanURLsResult) error) error
+	// RewriteDocument runs an operation on every link of an HTML or sitemap
+	// document and rewrites the links it changed, leaving the rest of the
+	// document as it was.
+	RewriteDocument(ctx context.Context, in RewriteDocumentInput) (*RewriteDocumentOutput, error)
+
+	// AddRuleSet validates and stores a rule set; its operations take
+	// precedence over those of the profiles for the URLs of its hosts.
+	AddRuleSet(ctx context.Context, in RuleSetInput) (*domain.RuleSet, error)
+	GetRuleSets(ctx context.Context) ([]domain.RuleSet, error)
+	GetRuleSet(ctx context.Context, id string) (*domain.RuleSet, error)
+	UpdateRuleSet(ctx context.Context, id string, in RuleSetInput) (*domain.RuleSet, error)
+	DeleteRuleSet(ctx context.Context, id string) error
+	// TestRuleSet runs an operation on a few URLs with the active rule sets
+	// and again with a draft in place of the one it replaces, and compares
+	// the results.
+	TestRuleSet(ctx context.Context, in TestRuleSetInput) ([]TestRuleSetResult, error)
 }
@@ -3,9 +3,12 @@
 package mocks
 
 import (
+	url_processor "booklib/internal/domain/url-processor"
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	urlprocessor "booklib/internal/usecase/url-processor"
 )
 
 // UseCase is an autogenerated mock type for the UseCase type
@@ -13,27 +16,245 @@ type UseCase struct {
 	mock.Mock
 }
 
-// CleanURL provides a mock function with given fields: ctx, operation, url
-func (_m *UseCase) CleanURL(ctx context.Context, operation string, url string) (string, error) {
-	ret := _m.Called(ctx, operation, url)
+// AddRuleSet provides a mock function with given fields: ctx, in
+func (_m *UseCase) AddRuleSet(ctx context.Context, in urlprocessor.RuleSetInput) (*url_processor.RuleSet, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddRuleSet")
+	}
+
+	var r0 *url_processor.RuleSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, urlprocessor.RuleSetInput) (*url_processor.RuleSet, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, urlprocessor.RuleSetInput) *url_processor.RuleSet); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*url_processor.RuleSet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, urlprocessor.RuleSetInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CleanURL provides a mock function with given fields: ctx, in
+func (_m *UseCase) CleanURL(ctx context.Context, in urlprocessor.CleanURLInput) (*urlprocessor.CleanURLOutput, error) {
+	ret := _m.Called(ctx, in)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CleanURL")
 	}
 
-	var r0 string
+	var r0 *urlprocessor.CleanURLOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, urlprocessor.CleanURLInput) (*urlprocessor.CleanURLOutput, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, urlprocessor.CleanURLInput) *urlprocessor.CleanURLOutput); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*urlprocessor.CleanURLOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, urlprocessor.CleanURLInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CleanURLs provides a mock function with given fields: ctx, in, emit
+func (_m *UseCase) CleanURLs(ctx context.Context, in urlprocessor.CleanURLsInput, emit func(urlprocessor.CleanURLsResult) error) error {
+	ret := _m.Called(ctx, in, emit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CleanURLs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, urlprocessor.CleanURLsInput, func(urlprocessor.CleanURLsResult) error) error); ok {
+		r0 = rf(ctx, in, emit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRuleSet provides a mock function with given fields: ctx, id
+func (_m *UseCase) DeleteRuleSet(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRuleSet")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRuleSet provides a mock function with given fields: ctx, id
+func (_m *UseCase) GetRuleSet(ctx context.Context, id string) (*url_processor.RuleSet, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRuleSet")
+	}
+
+	var r0 *url_processor.RuleSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*url_processor.RuleSet, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *url_processor.RuleSet); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*url_processor.RuleSet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRuleSets provides a mock function with given fields: ctx
+func (_m *UseCase) GetRuleSets(ctx context.Context) ([]url_processor.RuleSet, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRuleSets")
+	}
+
+	var r0 []url_processor.RuleSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]url_processor.RuleSet, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []url_processor.RuleSet); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]url_processor.RuleSet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RewriteDocument provides a mock function with given fields: ctx, in
+func (_m *UseCase) RewriteDocument(ctx context.Context, in urlprocessor.RewriteDocumentInput) (*urlprocessor.RewriteDocumentOutput, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RewriteDocument")
+	}
+
+	var r0 *urlprocessor.RewriteDocumentOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, urlprocessor.RewriteDocumentInput) (*urlprocessor.RewriteDocumentOutput, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, urlprocessor.RewriteDocumentInput) *urlprocessor.RewriteDocumentOutput); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*urlprocessor.RewriteDocumentOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, urlprocessor.RewriteDocumentInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TestRuleSet provides a mock function with given fields: ctx, in
+func (_m *UseCase) TestRuleSet(ctx context.Context, in urlprocessor.TestRuleSetInput) ([]urlprocessor.TestRuleSetResult, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TestRuleSet")
+	}
+
+	var r0 []urlprocessor.TestRuleSetResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, urlprocessor.TestRuleSetInput) ([]urlprocessor.TestRuleSetResult, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, urlprocessor.TestRuleSetInput) []urlprocessor.TestRuleSetResult); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]urlprocessor.TestRuleSetResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, urlprocessor.TestRuleSetInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRuleSet provides a mock function with given fields: ctx, id, in
+func (_m *UseCase) UpdateRuleSet(ctx context.Context, id string, in urlprocessor.RuleSetInput) (*url_processor.RuleSet, error) {
+	ret := _m.Called(ctx, id, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRuleSet")
+	}
+
+	var r0 *url_processor.RuleSet
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return rf(ctx, operation, url)
+	if rf, ok := ret.Get(0).(func(context.Context, string, urlprocessor.RuleSetInput) (*url_processor.RuleSet, error)); ok {
+		return rf(ctx, id, in)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = rf(ctx, operation, url)
+	if rf, ok := ret.Get(0).(func(context.Context, string, urlprocessor.RuleSetInput) *url_processor.RuleSet); ok {
+		r0 = rf(ctx, id, in)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*url_processor.RuleSet)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, operation, url)
+	if rf, ok := ret.Get(1).(func(context.Context, string, urlprocessor.RuleSetInput) error); ok {
+		r1 = rf(ctx, id, in)
 	} else {
 		r1 = ret.Error(1)
 	}
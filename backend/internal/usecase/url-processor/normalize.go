@@ -0,0 +1,240 @@
+package urlprocessor
+
+import (
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// defaultPorts are the ports a URL of the scheme connects to when it names
+// none, dropped by normalisation.
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+	"ws":    "80",
+	"wss":   "443",
+	"ftp":   "21",
+}
+
+// normalizeURL applies the syntax- and scheme-based normalisations of RFC 3986
+// section 6.2 that never change what the URL points to: lowercase scheme and
+// host, IDN hosts in punycode, no default port, percent-encoding in uppercase
+// with unreserved characters decoded, and no dot-segments. sortQuery also
+// sorts the query params by name, keeping the order of repeated params.
+func normalizeURL(u *url.URL, sortQuery bool) {
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Opaque != "" {
+		// mailto:, urn: and the like have no hierarchy to normalise
+		return
+	}
+
+	u.Host = normalizeHost(u.Host, u.Scheme)
+
+	path := normalizePercentEncoding(u.EscapedPath())
+	if u.Host != "" || strings.HasPrefix(path, "/") {
+		// dot-segments of relative references are resolved against a base
+		path = removeDotSegments(path)
+	}
+	setEscapedPath(u, path)
+
+	u.RawQuery = normalizePercentEncoding(u.RawQuery)
+	if sortQuery {
+		u.RawQuery = sortQueryParams(u.RawQuery)
+	}
+
+	fragment := normalizePercentEncoding(u.EscapedFragment())
+	if unescaped, err := url.PathUnescape(fragment); err == nil {
+		u.Fragment, u.RawFragment = unescaped, fragment
+	}
+}
+
+// normalizeHost lowercases host, converts an IDN to punycode and drops the
+// default port of scheme.
+func normalizeHost(host, scheme string) string {
+	if host == "" {
+		return ""
+	}
+
+	name, port, err := net.SplitHostPort(host)
+	if err != nil {
+		// no port
+		name, port = strings.TrimSuffix(strings.TrimSuffix(host, ":"), "]"), ""
+		name = strings.TrimPrefix(name, "[")
+	}
+
+	name = strings.ToLower(name)
+	if !isASCII(name) {
+		// a host idna refuses is left as it is rather than made up
+		if ascii, err := idna.Lookup.ToASCII(name); err == nil {
+			name = ascii
+		}
+	}
+
+	if port == defaultPorts[scheme] {
+		port = ""
+	}
+	switch {
+	case port != "":
+		return net.JoinHostPort(name, port)
+	case strings.Contains(name, ":"):
+		// IPv6 literal
+		return "[" + name + "]"
+	}
+	return name
+}
+
+// normalizePercentEncoding uppercases the hex digits of percent-encoded
+// octets and decodes those of unreserved characters, which never need
+// encoding. Malformed escapes are left as they are.
+func normalizePercentEncoding(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' || i+2 >= len(s) || !isHex(s[i+1]) || !isHex(s[i+2]) {
+			sb.WriteByte(s[i])
+			continue
+		}
+
+		c := unhex(s[i+1])<<4 | unhex(s[i+2])
+		if isUnreserved(c) {
+			sb.WriteByte(c)
+		} else {
+			sb.WriteString(strings.ToUpper(s[i : i+3]))
+		}
+		i += 2
+	}
+	return sb.String()
+}
+
+// removeDotSegments is the algorithm of RFC 3986 section 5.2.4.
+func removeDotSegments(path string) string {
+	var (
+		in  = path
+		out strings.Builder
+	)
+	// popSegment removes the last segment of the output and its preceding
+	// slash
+	popSegment := func() {
+		s := out.String()
+		out.Reset()
+		if i := strings.LastIndexByte(s, '/'); i > 0 {
+			out.WriteString(s[:i])
+		}
+	}
+
+	for in != "" {
+		switch {
+		case strings.HasPrefix(in, "../"):
+			in = in[3:]
+		case strings.HasPrefix(in, "./"):
+			in = in[2:]
+		case strings.HasPrefix(in, "/./"):
+			in = in[2:]
+		case in == "/.":
+			in = "/"
+		case strings.HasPrefix(in, "/../"):
+			in = in[3:]
+			popSegment()
+		case in == "/..":
+			in = "/"
+			popSegment()
+		case in == "." || in == "..":
+			in = ""
+		default:
+			// move the first segment, its leading slash included
+			end := strings.IndexByte(in[1:], '/') + 1
+			if end == 0 {
+				end = len(in)
+			}
+			out.WriteString(in[:end])
+			in = in[end:]
+		}
+	}
+	return out.String()
+}
+
+// setEscapedPath sets the path of u from its escaped form, keeping the
+// escaping when it differs from the one url.URL would choose.
+func setEscapedPath(u *url.URL, escaped string) {
+	path, err := url.PathUnescape(escaped)
+	if err != nil {
+		return
+	}
+	u.Path, u.RawPath = path, ""
+	if u.EscapedPath() != escaped {
+		u.RawPath = escaped
+	}
+}
+
+// sortQueryParams sorts the params of a raw query by name, the stable sort
+// keeping repeated params in order.
+func sortQueryParams(rawQuery string) string {
+	params := slices.DeleteFunc(strings.Split(rawQuery, "&"), func(p string) bool { return p == "" })
+	slices.SortStableFunc(params, func(a, b string) int {
+		nameA, _, _ := strings.Cut(a, "=")
+		nameB, _, _ := strings.Cut(b, "=")
+		return strings.Compare(nameA, nameB)
+	})
+	return strings.Join(params, "&")
+}
+
+// displayURL returns u with its punycode host in Unicode, for showing to
+// people, or "" when the host has no punycode label.
+func displayURL(u *url.URL) string {
+	host := u.Hostname()
+	if !strings.Contains(host, "xn--") {
+		return ""
+	}
+	display, err := idna.Display.ToUnicode(host)
+	if err != nil || display == host {
+		return ""
+	}
+
+	// url.URL escapes non-ASCII hosts, so the host is swapped in the string
+	authority := "//" + host
+	if u.User != nil {
+		authority = "//" + u.User.String() + "@" + host
+	}
+	s := u.String()
+	i := strings.Index(s, authority)
+	if i < 0 {
+		return ""
+	}
+	return s[:i+len(authority)-len(host)] + display + s[i+len(authority):]
+}
+
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func unhex(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	}
+	return c - 'A' + 10
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,236 @@
+package urlprocessor
+
+import (
+	domain "booklib/internal/domain/url-processor"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+const (
+	DefaultResolveMaxHops = 10
+	DefaultResolveTimeout = 10 * time.Second
+
+	// maxPageBytes bounds how much of the final page is read looking for its
+	// canonical link
+	maxPageBytes = 1 << 20
+	userAgent    = "booklib-url-processor/1.0"
+)
+
+// Hop is one request made resolving a URL.
+type Hop struct {
+	URL    string
+	Method string
+	Status int
+	// Location is the redirect target resolved against URL, empty for the
+	// last hop.
+	Location string
+}
+
+// resolution is what resolving a URL found.
+type resolution struct {
+	chain []Hop
+	// canonical is the canonical link of the final page, empty when it has
+	// none
+	canonical string
+}
+
+type resolver struct {
+	client  *http.Client
+	maxHops int
+	timeout time.Duration
+}
+
+func newResolver(client *http.Client, maxHops int, timeout time.Duration) *resolver {
+	if maxHops <= 0 {
+		maxHops = DefaultResolveMaxHops
+	}
+	if timeout <= 0 {
+		timeout = DefaultResolveTimeout
+	}
+
+	// redirects are followed one by one to record them
+	c := &http.Client{}
+	if client != nil {
+		*c = *client
+	}
+	c.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return &resolver{client: c, maxHops: maxHops, timeout: timeout}
+}
+
+// resolve follows the redirects of u and moves it to the canonical link of
+// the page reached, or to that page when it has none. Every hop is asked
+// with HEAD first and with GET when HEAD is refused or the page has to be
+// read.
+func (r *resolver) resolve(ctx context.Context, u *url.URL) (resolution, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var (
+		res     resolution
+		current = *u
+		visited = []string{}
+	)
+	for {
+		if current.Scheme != "http" && current.Scheme != "https" {
+			return res, fmt.Errorf("%w: %s is not an http or https url", domain.ErrUnresolvable, current.String())
+		}
+		visited = append(visited, current.String())
+
+		hop, resp, err := r.fetch(ctx, &current)
+		if err != nil {
+			return res, err
+		}
+
+		location := redirectLocation(resp, &current)
+		if location == nil {
+			res.canonical = canonicalLink(resp, &current)
+			resp.Body.Close()
+			res.chain = append(res.chain, hop)
+			break
+		}
+		resp.Body.Close()
+
+		hop.Location = location.String()
+		res.chain = append(res.chain, hop)
+		if slices.Contains(visited, hop.Location) {
+			return res, fmt.Errorf("%w: redirect loop back to %s", domain.ErrUnresolvable, hop.Location)
+		}
+		if len(res.chain) > r.maxHops {
+			return res, fmt.Errorf("%w: more than %d redirects", domain.ErrUnresolvable, r.maxHops)
+		}
+		current = *location
+	}
+
+	*u = current
+	if res.canonical != "" {
+		if canonical, err := url.Parse(res.canonical); err == nil {
+			*u = *canonical
+		}
+	}
+	return res, nil
+}
+
+// fetch asks for u with HEAD, and again with GET when HEAD fails, is refused
+// or the answer is a page to read. The body of the response is left open.
+func (r *resolver) fetch(ctx context.Context, u *url.URL) (Hop, *http.Response, error) {
+	resp, err := r.do(ctx, http.MethodHead, u)
+	if err == nil && (isRedirect(resp) || (resp.StatusCode < http.StatusBadRequest && !isHTML(resp))) {
+		return Hop{URL: u.String(), Method: http.MethodHead, Status: resp.StatusCode}, resp, nil
+	}
+	if err == nil {
+		resp.Body.Close()
+	}
+
+	resp, err = r.do(ctx, http.MethodGet, u)
+	if err != nil {
+		return Hop{}, nil, fmt.Errorf("%w: %v", domain.ErrUnresolvable, err)
+	}
+	return Hop{URL: u.String(), Method: http.MethodGet, Status: resp.StatusCode}, resp, nil
+}
+
+func (r *resolver) do(ctx context.Context, method string, u *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,*/*;q=0.8")
+
+	return r.client.Do(req)
+}
+
+func isRedirect(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return resp.Header.Get("Location") != ""
+	}
+	return false
+}
+
+func isHTML(resp *http.Response) bool {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
+
+// redirectLocation returns where resp redirects to, resolved against base,
+// or nil when it is not a redirect.
+func redirectLocation(resp *http.Response, base *url.URL) *url.URL {
+	if !isRedirect(resp) {
+		return nil
+	}
+	location, err := base.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return nil
+	}
+	// the fragment of the redirected URL survives a location without one
+	if location.Fragment == "" {
+		location.Fragment, location.RawFragment = base.Fragment, base.RawFragment
+	}
+	return location
+}
+
+// canonicalLink returns the <link rel="canonical"> of the head of an HTML
+// response, resolved against base, or "" when it has none.
+func canonicalLink(resp *http.Response, base *url.URL) string {
+	if resp.Request.Method != http.MethodGet || resp.StatusCode >= http.StatusBadRequest || !isHTML(resp) {
+		return ""
+	}
+
+	z := html.NewTokenizer(io.LimitReader(resp.Body, maxPageBytes))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return ""
+
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "head" {
+				return ""
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			if string(name) == "body" {
+				return ""
+			}
+			if string(name) != "link" {
+				continue
+			}
+
+			var rel, href string
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = z.TagAttr()
+				switch string(key) {
+				case "rel":
+					rel = string(val)
+				case "href":
+					href = strings.TrimSpace(string(val))
+				}
+			}
+			if href == "" || !slices.ContainsFunc(strings.Fields(rel), func(r string) bool {
+				return strings.EqualFold(r, "canonical")
+			}) {
+				continue
+			}
+
+			canonical, err := base.Parse(href)
+			if err != nil || (canonical.Scheme != "http" && canonical.Scheme != "https") {
+				return ""
+			}
+			return canonical.String()
+		}
+	}
+}
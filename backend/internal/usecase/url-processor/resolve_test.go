@@ -0,0 +1,172 @@
+package urlprocessor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/url-processor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newResolveServer(t *testing.T) *httptest.Server {
+	redirect := func(to string, status int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Location", to)
+			w.WriteHeader(status)
+		}
+	}
+	page := func(head string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprintf(w, "<!doctype html><html><head><title>t</title>%s</head><body>"+
+				`<link rel="canonical" href="/in-body"></body></html>`, head)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/chain", redirect("/page", http.StatusFound))
+	mux.Handle("/page", page(`<link rel="alternate canonical" href="/canonical/page">`))
+	mux.Handle("/plain", page(""))
+	mux.Handle("/file.pdf", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+	}))
+	mux.Handle("/no-head", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		redirect("/plain", http.StatusSeeOther)(w, r)
+	}))
+	mux.Handle("/loop-a", redirect("/loop-b", http.StatusTemporaryRedirect))
+	mux.Handle("/loop-b", redirect("/loop-a", http.StatusPermanentRedirect))
+	mux.Handle("/hops/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirect(r.URL.Path+"x", http.StatusFound)(w, r)
+	}))
+	mux.Handle("/slow", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestResolve(t *testing.T) {
+	srv := newResolveServer(t)
+	uc, err := New(noRuleSets(t), Config{ResolveMaxHops: 3, ResolveTimeout: 200 * time.Millisecond})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		path              string
+		expected          string
+		expectedChain     []string
+		expectedCanonical string
+		expectedErr       string
+	}{
+		{
+			name:     "follows redirects to the canonical link",
+			path:     "/chain?id=1",
+			expected: srv.URL + "/canonical/page",
+			expectedChain: []string{
+				"HEAD " + srv.URL + "/chain?id=1 302 " + srv.URL + "/page",
+				"GET " + srv.URL + "/page 200",
+			},
+			expectedCanonical: srv.URL + "/canonical/page",
+		},
+		{
+			name:          "page without canonical link",
+			path:          "/plain",
+			expected:      srv.URL + "/plain",
+			expectedChain: []string{"GET " + srv.URL + "/plain 200"},
+		},
+		{
+			name:          "does not download other documents",
+			path:          "/file.pdf",
+			expected:      srv.URL + "/file.pdf",
+			expectedChain: []string{"HEAD " + srv.URL + "/file.pdf 200"},
+		},
+		{
+			name:     "falls back to GET when HEAD is refused",
+			path:     "/no-head#top",
+			expected: srv.URL + "/plain#top",
+			expectedChain: []string{
+				"GET " + srv.URL + "/no-head#top 303 " + srv.URL + "/plain#top",
+				"GET " + srv.URL + "/plain#top 200",
+			},
+		},
+		{
+			name:     "ends on an error page",
+			path:     "/missing",
+			expected: srv.URL + "/missing",
+			expectedChain: []string{
+				"GET " + srv.URL + "/missing 404",
+			},
+		},
+		{
+			name:        "redirect loop",
+			path:        "/loop-a",
+			expectedErr: "url cannot be resolved: redirect loop back to " + srv.URL + "/loop-a",
+		},
+		{
+			name:        "too many redirects",
+			path:        "/hops/",
+			expectedErr: "url cannot be resolved: more than 3 redirects",
+		},
+		{
+			name:        "timeout",
+			path:        "/slow",
+			expectedErr: "context deadline exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := uc.CleanURL(context.Background(), CleanURLInput{Operation: "resolve", URL: srv.URL + tt.path})
+
+			if tt.expectedErr != "" {
+				assert.ErrorIs(t, err, domain.ErrUnresolvable)
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out.URL)
+			assert.Equal(t, tt.expectedCanonical, out.CanonicalURL)
+
+			chain := make([]string, 0, len(out.Chain))
+			for _, hop := range out.Chain {
+				chain = append(chain, strings.TrimSpace(fmt.Sprintf("%s %s %d %s", hop.Method, hop.URL, hop.Status, hop.Location)))
+			}
+			assert.Equal(t, tt.expectedChain, chain)
+		})
+	}
+
+	t.Run("only http urls", func(t *testing.T) {
+		_, err := uc.CleanURL(context.Background(), CleanURLInput{Operation: "resolve", URL: "ftp://example.com/a"})
+		assert.ErrorIs(t, err, domain.ErrUnresolvable)
+	})
+
+	t.Run("runs inside a pipeline", func(t *testing.T) {
+		uc, err := New(noRuleSets(t), Config{Profiles: map[string]domain.Profile{"shop": {Operations: map[string][]domain.Step{
+			"expand": {{Type: domain.StepResolve}, {Type: domain.StepStripTracking}},
+		}}}})
+		require.NoError(t, err)
+
+		out, err := uc.CleanURL(context.Background(), CleanURLInput{Profile: "shop", Operation: "expand", URL: srv.URL + "/chain?utm_source=x", Explain: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, srv.URL+"/canonical/page", out.URL)
+		require.Len(t, out.Steps, 2)
+		assert.Equal(t, []string{ComponentPath, ComponentQuery}, out.Steps[0].Changed)
+	})
+}
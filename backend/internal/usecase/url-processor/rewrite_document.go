@@ -0,0 +1,150 @@
+package urlprocessor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	FormatHTML    = "html"
+	FormatSitemap = "sitemap"
+
+	// DefaultMaxDocumentLinks is the most URLs a sitemap may list.
+	DefaultMaxDocumentLinks = 50000
+)
+
+var (
+	ErrUnknownFormat   = errors.New("format must be html or sitemap")
+	ErrInvalidDocument = errors.New("invalid document")
+	ErrTooManyLinks    = errors.New("document contains too many links")
+)
+
+type RewriteDocumentInput struct {
+	// Format is FormatHTML or FormatSitemap.
+	Format   string
+	Document []byte
+	// Profile holds the operation, the default profile when empty.
+	Profile   string
+	Operation string
+}
+
+type RewriteDocumentOutput struct {
+	// Document is the input with the changed links rewritten; everything
+	// else is left byte for byte as it was.
+	Document []byte
+	// Links lists the links the operation changed or failed on, in document
+	// order.
+	Links []DocumentLink
+	// Total counts the links processed, Changed and Failed those of Links.
+	Total   int
+	Changed int
+	Failed  int
+	// Skipped counts the links left out because they are not absolute http
+	// or https URLs, such as relative links and mailto: links.
+	Skipped int
+}
+
+// DocumentLink is a link of a document the operation changed or failed on.
+type DocumentLink struct {
+	// Element holds the link: a, link, img or loc.
+	Element string
+	// Attribute holds the link in Element, empty for the text of loc.
+	Attribute string
+	Line      int
+	URL       string
+	// RewrittenURL replaces URL in the document, empty when Err is set.
+	RewrittenURL string
+	Err          error
+}
+
+func (u *usecase) RewriteDocument(ctx context.Context, in RewriteDocumentInput) (*RewriteDocumentOutput, error) {
+	var (
+		links []docLink
+		err   error
+	)
+	switch in.Format {
+	case FormatHTML:
+		links, err = htmlLinks(in.Document)
+	case FormatSitemap:
+		links, err = sitemapLinks(in.Document)
+	default:
+		return nil, fmt.Errorf("%w, got %q", ErrUnknownFormat, in.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out := &RewriteDocumentOutput{}
+	// a URL linked several times is processed once
+	var (
+		urls    []string
+		indexes = map[string]int{}
+	)
+	for _, l := range links {
+		if !isWebURL(l.url) {
+			out.Skipped++
+			continue
+		}
+		out.Total++
+		if _, ok := indexes[l.url]; !ok {
+			indexes[l.url] = len(urls)
+			urls = append(urls, l.url)
+		}
+	}
+	if len(urls) == 0 {
+		out.Document = in.Document
+		return out, nil
+	}
+	if len(urls) > u.maxDocumentLinks {
+		return nil, fmt.Errorf("%w, the maximum is %d", ErrTooManyLinks, u.maxDocumentLinks)
+	}
+
+	// a sitemap alone holds up to 50,000 URLs, so the links are cleaned in
+	// batches of the size CleanURLs takes
+	results := make([]CleanURLsResult, len(urls))
+	for start := 0; start < len(urls); start += u.maxBatchURLs {
+		chunk := urls[start:min(start+u.maxBatchURLs, len(urls))]
+		err = u.CleanURLs(ctx, CleanURLsInput{Profile: in.Profile, Operation: in.Operation, URLs: chunk}, func(res CleanURLsResult) error {
+			results[start+res.Index] = res
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		doc  bytes.Buffer
+		last int
+	)
+	doc.Grow(len(in.Document))
+	for _, l := range links {
+		i, ok := indexes[l.url]
+		if !ok {
+			continue
+		}
+
+		res := results[i]
+		link := DocumentLink{Element: l.element, Attribute: l.attribute, Line: l.line, URL: l.url}
+		switch {
+		case res.Err != nil:
+			link.Err = res.Err
+			out.Failed++
+		case res.Output.URL != l.url:
+			link.RewrittenURL = res.Output.URL
+			out.Changed++
+			doc.Write(in.Document[last:l.start])
+			doc.Write(l.encode(res.Output.URL))
+			last = l.end
+		default:
+			continue
+		}
+		out.Links = append(out.Links, link)
+	}
+	doc.Write(in.Document[last:])
+	out.Document = doc.Bytes()
+
+	return out, nil
+}
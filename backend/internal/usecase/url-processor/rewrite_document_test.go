@@ -0,0 +1,200 @@
+package urlprocessor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	domain "booklib/internal/domain/url-processor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteDocument(t *testing.T) {
+	uc, err := New(noRuleSets(t), Config{Profiles: map[string]domain.Profile{
+		"shop": {Operations: map[string][]domain.Step{
+			"expand": {{Type: domain.StepResolve}},
+		}},
+	}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		in              RewriteDocumentInput
+		expected        string
+		expectedLinks   []DocumentLink
+		expectedFailed  []string
+		expectedTotal   int
+		expectedSkipped int
+		expectedErr     error
+	}{
+		{
+			name: "html",
+			in: RewriteDocumentInput{
+				Format:    FormatHTML,
+				Operation: "clean",
+				Document: []byte("<!DOCTYPE html>\n<HTML><head><link rel=stylesheet href=https://cdn.example.com/a.css?utm_source=x></head>\n" +
+					"<body class='a'>\n  <A HREF='https://example.com/a?id=1&amp;utm_medium=mail'>A &amp; B</A>\n" +
+					"  <img src=\"https://example.com/i.png\" alt=\"<b>\">\n  <a href=\"/relative?utm_source=x\">r</a>\n" +
+					"  <a href=\"mailto:a@example.com\">m</a><a href=\"https://example.com/a?id=1&utm_medium=mail\">again</a>\n</body></HTML>"),
+			},
+			expected: "<!DOCTYPE html>\n<HTML><head><link rel=stylesheet href=\"https://cdn.example.com/a.css\"></head>\n" +
+				"<body class='a'>\n  <A HREF='https://example.com/a?id=1'>A &amp; B</A>\n" +
+				"  <img src=\"https://example.com/i.png\" alt=\"<b>\">\n  <a href=\"/relative?utm_source=x\">r</a>\n" +
+				"  <a href=\"mailto:a@example.com\">m</a><a href=\"https://example.com/a?id=1\">again</a>\n</body></HTML>",
+			expectedLinks: []DocumentLink{
+				{Element: "link", Attribute: "href", Line: 2, URL: "https://cdn.example.com/a.css?utm_source=x", RewrittenURL: "https://cdn.example.com/a.css"},
+				{Element: "a", Attribute: "href", Line: 4, URL: "https://example.com/a?id=1&utm_medium=mail", RewrittenURL: "https://example.com/a?id=1"},
+				{Element: "a", Attribute: "href", Line: 7, URL: "https://example.com/a?id=1&utm_medium=mail", RewrittenURL: "https://example.com/a?id=1"},
+			},
+			expectedTotal:   4,
+			expectedSkipped: 2,
+		},
+		{
+			name: "sitemap",
+			in: RewriteDocumentInput{
+				Format:    FormatSitemap,
+				Operation: "canonical",
+				Document: []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<!-- generated -->\n" +
+					"<urlset xmlns=\"http://www.sitemaps.org/schemas/sitemap/0.9\">\n" +
+					"  <url>\n    <loc> https://example.com/a/?page=2&amp;x=1 </loc>\n    <priority>0.8</priority>\n  </url>\n" +
+					"  <url><loc>https://example.com/b</loc></url>\n</urlset>\n"),
+			},
+			expected: "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<!-- generated -->\n" +
+				"<urlset xmlns=\"http://www.sitemaps.org/schemas/sitemap/0.9\">\n" +
+				"  <url>\n    <loc> https://example.com/a </loc>\n    <priority>0.8</priority>\n  </url>\n" +
+				"  <url><loc>https://example.com/b</loc></url>\n</urlset>\n",
+			expectedLinks: []DocumentLink{
+				{Element: "loc", Line: 5, URL: "https://example.com/a/?page=2&x=1", RewrittenURL: "https://example.com/a"},
+			},
+			expectedTotal: 2,
+		},
+		{
+			name: "failing links are reported and kept",
+			in: RewriteDocumentInput{
+				Format:    FormatHTML,
+				Profile:   "shop",
+				Operation: "expand",
+				Document:  []byte(`<a href="http://example.com:99999/a">a</a>`),
+			},
+			expected:       `<a href="http://example.com:99999/a">a</a>`,
+			expectedFailed: []string{"http://example.com:99999/a"},
+			expectedTotal:  1,
+		},
+		{
+			name: "document without links",
+			in: RewriteDocumentInput{
+				Format:    FormatHTML,
+				Operation: "unknown",
+				Document:  []byte(`<p>nothing <a href="#top">here</a></p>`),
+			},
+			expected:        `<p>nothing <a href="#top">here</a></p>`,
+			expectedSkipped: 1,
+		},
+		{
+			name:        "unknown format",
+			in:          RewriteDocumentInput{Format: "pdf", Operation: "clean", Document: []byte("%PDF")},
+			expectedErr: ErrUnknownFormat,
+		},
+		{
+			name:        "invalid sitemap",
+			in:          RewriteDocumentInput{Format: FormatSitemap, Operation: "clean", Document: []byte("<urlset><loc>")},
+			expectedErr: ErrInvalidDocument,
+		},
+		{
+			name:        "unknown operation",
+			in:          RewriteDocumentInput{Format: FormatHTML, Operation: "shout", Document: []byte(`<a href="https://example.com">a</a>`)},
+			expectedErr: domain.ErrInvalidOperation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := uc.RewriteDocument(context.Background(), tt.in)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(out.Document))
+			assert.Equal(t, tt.expectedTotal, out.Total)
+			assert.Equal(t, tt.expectedSkipped, out.Skipped)
+
+			var (
+				changed []DocumentLink
+				failed  []string
+			)
+			for _, l := range out.Links {
+				if l.Err != nil {
+					failed = append(failed, l.URL)
+					assert.Empty(t, l.RewrittenURL)
+					continue
+				}
+				changed = append(changed, l)
+			}
+			assert.Equal(t, tt.expectedLinks, changed)
+			assert.Equal(t, tt.expectedFailed, failed)
+			assert.Equal(t, len(tt.expectedLinks), out.Changed)
+			assert.Equal(t, len(tt.expectedFailed), out.Failed)
+		})
+	}
+}
+
+func TestRewriteDocument_MoreLinksThanABatch(t *testing.T) {
+	uc, err := New(noRuleSets(t), Config{MaxBatchURLs: 3})
+	require.NoError(t, err)
+
+	var doc, expected strings.Builder
+	doc.WriteString("<urlset>")
+	expected.WriteString("<urlset>")
+	for i := range 8 {
+		fmt.Fprintf(&doc, "<url><loc>https://example.com/%d?utm_source=x</loc></url>", i)
+		fmt.Fprintf(&expected, "<url><loc>https://example.com/%d</loc></url>", i)
+	}
+	doc.WriteString("</urlset>")
+	expected.WriteString("</urlset>")
+
+	out, err := uc.RewriteDocument(context.Background(), RewriteDocumentInput{
+		Format: FormatSitemap, Operation: "clean", Document: []byte(doc.String()),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected.String(), string(out.Document))
+	assert.Equal(t, 8, out.Total)
+	assert.Equal(t, 8, out.Changed)
+	assert.Zero(t, out.Failed)
+	for i, l := range out.Links {
+		assert.Equal(t, fmt.Sprintf("https://example.com/%d", i), l.RewrittenURL)
+	}
+}
+
+func TestRewriteDocument_TooManyLinks(t *testing.T) {
+	uc, err := New(noRuleSets(t), Config{MaxBatchURLs: 3, MaxDocumentLinks: 4})
+	require.NoError(t, err)
+
+	rewrite := func(links int) (*RewriteDocumentOutput, error) {
+		var doc strings.Builder
+		doc.WriteString("<urlset>")
+		for i := range links {
+			// a link listed twice counts once
+			fmt.Fprintf(&doc, "<url><loc>https://example.com/%d</loc></url><url><loc>https://example.com/%d</loc></url>", i, i)
+		}
+		doc.WriteString("</urlset>")
+
+		return uc.RewriteDocument(context.Background(), RewriteDocumentInput{
+			Format: FormatSitemap, Operation: "clean", Document: []byte(doc.String()),
+		})
+	}
+
+	out, err := rewrite(4)
+	require.NoError(t, err)
+	assert.Equal(t, 8, out.Total)
+
+	out, err = rewrite(5)
+	assert.ErrorIs(t, err, ErrTooManyLinks)
+	assert.EqualError(t, err, "document contains too many links, the maximum is 4")
+	assert.Nil(t, out)
+}
@@ -0,0 +1,87 @@
+package urlprocessor
+
+import (
+	domain "booklib/internal/domain/url-processor"
+	"context"
+	"strings"
+)
+
+type RuleSetInput struct {
+	Name       string
+	Hosts      []string
+	Priority   int
+	Operations map[string][]domain.Step
+	Active     bool
+}
+
+func (u *usecase) AddRuleSet(ctx context.Context, in RuleSetInput) (*domain.RuleSet, error) {
+	rs, err := domain.NewRuleSet(in.Name, in.Hosts, in.Priority, in.Operations, in.Active)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = u.ruleSets.compile(*rs); err != nil {
+		return nil, err
+	}
+
+	res, err := u.repo.AddRuleSet(ctx, rs)
+	if err != nil {
+		return nil, err
+	}
+	u.ruleSets.invalidate()
+
+	return res, nil
+}
+
+func (u *usecase) GetRuleSets(ctx context.Context) ([]domain.RuleSet, error) {
+	return u.repo.GetRuleSets(ctx)
+}
+
+func (u *usecase) GetRuleSet(ctx context.Context, id string) (*domain.RuleSet, error) {
+	rs, err := u.repo.GetRuleSetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, domain.ErrRuleSetNotFound
+	}
+	return rs, nil
+}
+
+func (u *usecase) UpdateRuleSet(ctx context.Context, id string, in RuleSetInput) (*domain.RuleSet, error) {
+	rs, err := u.GetRuleSet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rs.Name = strings.TrimSpace(in.Name)
+	rs.Hosts = domain.NormalizeHosts(in.Hosts)
+	rs.Priority = in.Priority
+	rs.Operations = in.Operations
+	rs.Active = in.Active
+	if err = rs.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err = u.ruleSets.compile(*rs); err != nil {
+		return nil, err
+	}
+
+	res, err := u.repo.UpdateRuleSet(ctx, rs)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, domain.ErrRuleSetNotFound
+	}
+	u.ruleSets.invalidate()
+
+	return res, nil
+}
+
+func (u *usecase) DeleteRuleSet(ctx context.Context, id string) error {
+	if err := u.repo.DeleteRuleSet(ctx, id); err != nil {
+		return err
+	}
+	u.ruleSets.invalidate()
+
+	return nil
+}
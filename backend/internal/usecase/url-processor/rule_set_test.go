@@ -0,0 +1,272 @@
+package urlprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "booklib/internal/domain/url-processor"
+	"booklib/internal/domain/url-processor/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newRuleSetInput() RuleSetInput {
+	return RuleSetInput{
+		Name:       " Shop ",
+		Hosts:      []string{"Shop.Example.", "*.shop.example"},
+		Priority:   5,
+		Operations: map[string][]domain.Step{"canonical": {{Type: domain.StepStripQuery}}},
+		Active:     true,
+	}
+}
+
+func TestAddRuleSet(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          func() RuleSetInput
+		setupMocks  func(*mocks.RuleSetRepository)
+		expectedErr string
+	}{
+		{
+			name: "adds a normalised rule set",
+			in:   newRuleSetInput,
+			setupMocks: func(repo *mocks.RuleSetRepository) {
+				repo.On("AddRuleSet", mock.Anything, mock.MatchedBy(func(rs *domain.RuleSet) bool {
+					return rs.ID != "" && rs.Name == "Shop" && assert.ObjectsAreEqual([]string{"shop.example", "*.shop.example"}, rs.Hosts)
+				})).Return(func(_ context.Context, rs *domain.RuleSet) *domain.RuleSet { return rs }, nil).Once()
+			},
+		},
+		{
+			name: "invalid host",
+			in: func() RuleSetInput {
+				in := newRuleSetInput()
+				in.Hosts = []string{"shop.*.example"}
+				return in
+			},
+			expectedErr: `invalid url rule set: invalid host "shop.*.example"`,
+		},
+		{
+			name: "invalid step",
+			in: func() RuleSetInput {
+				in := newRuleSetInput()
+				in.Operations = map[string][]domain.Step{"canonical": {{Type: domain.StepOperation, Operation: "all"}}}
+				return in
+			},
+			expectedErr: `invalid url rule set: operation "canonical" step 1: invalid operation "all"`,
+		},
+		{
+			name: "repository error",
+			in:   newRuleSetInput,
+			setupMocks: func(repo *mocks.RuleSetRepository) {
+				repo.On("AddRuleSet", mock.Anything, mock.Anything).Return(nil, errors.New("database down")).Once()
+			},
+			expectedErr: "database down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRuleSetRepository(t)
+			if tt.setupMocks != nil {
+				tt.setupMocks(repo)
+			}
+			uc, err := New(repo, Config{})
+			require.NoError(t, err)
+
+			res, err := uc.AddRuleSet(context.Background(), tt.in())
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, res)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Shop", res.Name)
+		})
+	}
+
+	t.Run("applies at once", func(t *testing.T) {
+		repo := mocks.NewRuleSetRepository(t)
+		repo.On("GetRuleSets", mock.Anything).Return([]domain.RuleSet{}, nil).Once()
+		repo.On("AddRuleSet", mock.Anything, mock.Anything).Return(func(_ context.Context, rs *domain.RuleSet) *domain.RuleSet { return rs }, nil).Once()
+		uc, err := New(repo, Config{})
+		require.NoError(t, err)
+
+		out, err := uc.CleanURL(context.Background(), CleanURLInput{Operation: "canonical", URL: "https://shop.example/a/?id=1"})
+		require.NoError(t, err)
+		assert.Equal(t, "https://shop.example/a", out.URL)
+
+		in := newRuleSetInput()
+		in.Operations = map[string][]domain.Step{"canonical": {{Type: domain.StepKeepQuery, Params: []string{"id"}}}}
+		rs, err := uc.AddRuleSet(context.Background(), in)
+		require.NoError(t, err)
+		repo.On("GetRuleSets", mock.Anything).Return([]domain.RuleSet{*rs}, nil).Once()
+
+		out, err = uc.CleanURL(context.Background(), CleanURLInput{Operation: "canonical", URL: "https://shop.example/a/?id=1"})
+		require.NoError(t, err)
+		assert.Equal(t, "https://shop.example/a/?id=1", out.URL)
+		assert.Equal(t, &RuleSetRef{ID: rs.ID, Name: "Shop"}, out.RuleSet)
+	})
+}
+
+func TestGetRuleSet(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMocks  func(*mocks.RuleSetRepository)
+		expectedErr error
+	}{
+		{
+			name: "found",
+			setupMocks: func(repo *mocks.RuleSetRepository) {
+				repo.On("GetRuleSetByID", mock.Anything, "1").Return(&domain.RuleSet{ID: "1"}, nil).Once()
+			},
+		},
+		{
+			name: "not found",
+			setupMocks: func(repo *mocks.RuleSetRepository) {
+				repo.On("GetRuleSetByID", mock.Anything, "1").Return(nil, nil).Once()
+			},
+			expectedErr: domain.ErrRuleSetNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRuleSetRepository(t)
+			tt.setupMocks(repo)
+			uc, err := New(repo, Config{})
+			require.NoError(t, err)
+
+			res, err := uc.GetRuleSet(context.Background(), "1")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "1", res.ID)
+		})
+	}
+}
+
+func TestGetRuleSets(t *testing.T) {
+	repo := mocks.NewRuleSetRepository(t)
+	repo.On("GetRuleSets", mock.Anything).Return([]domain.RuleSet{{ID: "1"}, {ID: "2"}}, nil).Once()
+	uc, err := New(repo, Config{})
+	require.NoError(t, err)
+
+	res, err := uc.GetRuleSets(context.Background())
+
+	require.NoError(t, err)
+	assert.Len(t, res, 2)
+}
+
+func TestUpdateRuleSet(t *testing.T) {
+	stored := func() *domain.RuleSet {
+		return &domain.RuleSet{ID: "1", Name: "old", Hosts: []string{"*"},
+			Operations: map[string][]domain.Step{"clean": {{Type: domain.StepStripTracking}}}}
+	}
+
+	tests := []struct {
+		name        string
+		in          func() RuleSetInput
+		setupMocks  func(*mocks.RuleSetRepository)
+		expectedErr error
+	}{
+		{
+			name: "updates",
+			in:   newRuleSetInput,
+			setupMocks: func(repo *mocks.RuleSetRepository) {
+				repo.On("GetRuleSetByID", mock.Anything, "1").Return(stored(), nil).Once()
+				repo.On("UpdateRuleSet", mock.Anything, mock.MatchedBy(func(rs *domain.RuleSet) bool {
+					return rs.ID == "1" && rs.Name == "Shop" && rs.Priority == 5 && rs.Active
+				})).Return(func(_ context.Context, rs *domain.RuleSet) *domain.RuleSet { return rs }, nil).Once()
+			},
+		},
+		{
+			name: "not found",
+			in:   newRuleSetInput,
+			setupMocks: func(repo *mocks.RuleSetRepository) {
+				repo.On("GetRuleSetByID", mock.Anything, "1").Return(nil, nil).Once()
+			},
+			expectedErr: domain.ErrRuleSetNotFound,
+		},
+		{
+			name: "deleted meanwhile",
+			in:   newRuleSetInput,
+			setupMocks: func(repo *mocks.RuleSetRepository) {
+				repo.On("GetRuleSetByID", mock.Anything, "1").Return(stored(), nil).Once()
+				repo.On("UpdateRuleSet", mock.Anything, mock.Anything).Return(nil, nil).Once()
+			},
+			expectedErr: domain.ErrRuleSetNotFound,
+		},
+		{
+			name: "invalid",
+			in: func() RuleSetInput {
+				in := newRuleSetInput()
+				in.Name = " "
+				return in
+			},
+			setupMocks: func(repo *mocks.RuleSetRepository) {
+				repo.On("GetRuleSetByID", mock.Anything, "1").Return(stored(), nil).Once()
+			},
+			expectedErr: domain.ErrInvalidRuleSet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRuleSetRepository(t)
+			tt.setupMocks(repo)
+			uc, err := New(repo, Config{})
+			require.NoError(t, err)
+
+			res, err := uc.UpdateRuleSet(context.Background(), "1", tt.in())
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, res)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{"shop.example", "*.shop.example"}, res.Hosts)
+		})
+	}
+}
+
+func TestDeleteRuleSet(t *testing.T) {
+	tests := []struct {
+		name        string
+		repoErr     error
+		expectedErr string
+	}{
+		{
+			name: "deletes",
+		},
+		{
+			name:        "repository error",
+			repoErr:     errors.New("database down"),
+			expectedErr: "database down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRuleSetRepository(t)
+			repo.On("DeleteRuleSet", mock.Anything, "1").Return(tt.repoErr).Once()
+			uc, err := New(repo, Config{})
+			require.NoError(t, err)
+
+			err = uc.DeleteRuleSet(context.Background(), "1")
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package urlprocessor
+
+import (
+	domain "booklib/internal/domain/url-processor"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rizanw/go-log"
+)
+
+const (
+	DefaultRuleSetsRefresh = 30 * time.Second
+)
+
+// ruleSet is a compiled domain.RuleSet.
+type ruleSet struct {
+	def        domain.RuleSet
+	operations map[string][]step
+}
+
+func (rs *ruleSet) ref() *RuleSetRef {
+	return &RuleSetRef{ID: rs.def.ID, Name: rs.def.Name}
+}
+
+// ruleSets caches the compiled active rule sets of the repository, ordered
+// by precedence. The cache is reloaded once older than refresh, and right
+// after every change made through the usecase; changes made by other
+// instances thus apply within refresh.
+type ruleSets struct {
+	repo           domain.RuleSetRepository
+	trackingParams []string
+	refresh        time.Duration
+	now            func() time.Time
+
+	mu       sync.Mutex
+	active   []*ruleSet
+	loadedAt time.Time
+}
+
+func newRuleSets(repo domain.RuleSetRepository, trackingParams []string, refresh time.Duration) *ruleSets {
+	if refresh <= 0 {
+		refresh = DefaultRuleSetsRefresh
+	}
+	return &ruleSets{repo: repo, trackingParams: trackingParams, refresh: refresh, now: time.Now}
+}
+
+// get returns the active rule sets, highest precedence first. A stored rule
+// set that does not compile is logged and skipped rather than failing every
+// URL.
+func (c *ruleSets) get(ctx context.Context) ([]*ruleSet, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if !c.loadedAt.IsZero() && now.Sub(c.loadedAt) < c.refresh {
+		return c.active, nil
+	}
+
+	defs, err := c.repo.GetRuleSets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make([]*ruleSet, 0, len(defs))
+	for _, def := range defs {
+		if !def.Active {
+			continue
+		}
+		rs, err := c.compile(def)
+		if err != nil {
+			log.Error(ctx, err, log.KV{"rule_set_id": def.ID}, "skipping invalid url rule set")
+			continue
+		}
+		active = append(active, rs)
+	}
+	sortRuleSets(active)
+
+	c.active, c.loadedAt = active, now
+	return active, nil
+}
+
+// invalidate makes the next get reload the rule sets.
+func (c *ruleSets) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.loadedAt = time.Time{}
+}
+
+func (c *ruleSets) compile(def domain.RuleSet) (*ruleSet, error) {
+	ops, err := compileProfile(domain.Profile{Operations: def.Operations}, c.trackingParams)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidRuleSet, err)
+	}
+	return &ruleSet{def: def, operations: ops}, nil
+}
+
+// sortRuleSets orders sets by priority, highest first, then by name and ID
+// so that equal priorities resolve the same way every time.
+func sortRuleSets(sets []*ruleSet) {
+	slices.SortFunc(sets, func(a, b *ruleSet) int {
+		return cmp.Or(
+			cmp.Compare(b.def.Priority, a.def.Priority),
+			strings.Compare(a.def.Name, b.def.Name),
+			strings.Compare(a.def.ID, b.def.ID),
+		)
+	})
+}
+
+// matchRuleSet returns the rule set of sets, ordered by sortRuleSets, that
+// applies operation to a URL of host: among those matching host and defining
+// operation, the one of highest priority, then of most specific pattern. It
+// returns nil when none does.
+func matchRuleSet(sets []*ruleSet, host, operation string) *ruleSet {
+	var (
+		best            *ruleSet
+		bestSpecificity int
+	)
+	for _, rs := range sets {
+		if best != nil && rs.def.Priority < best.def.Priority {
+			break
+		}
+		if _, ok := rs.operations[operation]; !ok {
+			continue
+		}
+		specificity, ok := rs.def.Match(host)
+		if ok && (best == nil || specificity > bestSpecificity) {
+			best, bestSpecificity = rs, specificity
+		}
+	}
+	return best
+}
@@ -0,0 +1,211 @@
+package urlprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "booklib/internal/domain/url-processor"
+	"booklib/internal/domain/url-processor/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanURLRuleSets(t *testing.T) {
+	rules := []domain.RuleSet{
+		{
+			ID: "1", Name: "any host", Hosts: []string{"*"}, Priority: 0, Active: true,
+			Operations: map[string][]domain.Step{"canonical": {{Type: domain.StepStripQuery}}},
+		},
+		{
+			ID: "2", Name: "shop subdomains", Hosts: []string{"*.shop.example"}, Priority: 10, Active: true,
+			Operations: map[string][]domain.Step{
+				"canonical": {{Type: domain.StepKeepQuery, Params: []string{"id"}}},
+				"share":     {{Type: domain.StepOperation, Operation: "canonical"}, {Type: domain.StepForceScheme, Scheme: "https"}},
+			},
+		},
+		{
+			ID: "3", Name: "shop www", Hosts: []string{"www.shop.example"}, Priority: 10, Active: true,
+			Operations: map[string][]domain.Step{"canonical": {{Type: domain.StepKeepQuery, Params: []string{"sku"}}}},
+		},
+		{
+			ID: "4", Name: "shop api", Hosts: []string{"api.shop.example"}, Priority: 5, Active: true,
+			Operations: map[string][]domain.Step{"canonical": {{Type: domain.StepSetHost, Host: "shop.example"}}},
+		},
+		{
+			ID: "5", Name: "draft", Hosts: []string{"*"}, Priority: 100, Active: false,
+			Operations: map[string][]domain.Step{"canonical": {{Type: domain.StepSetHost, Host: "draft.example"}}},
+		},
+	}
+	repo := mocks.NewRuleSetRepository(t)
+	repo.On("GetRuleSets", mock.Anything).Return(rules, nil).Once()
+	uc, err := New(repo, Config{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		in              CleanURLInput
+		expected        string
+		expectedRuleSet *RuleSetRef
+		expectedErr     error
+	}{
+		{
+			name:            "most specific pattern at the highest priority",
+			in:              CleanURLInput{Operation: "canonical", URL: "http://WWW.Shop.Example/a?id=1&sku=2"},
+			expected:        "http://WWW.Shop.Example/a?sku=2",
+			expectedRuleSet: &RuleSetRef{ID: "3", Name: "shop www"},
+		},
+		{
+			name:            "wildcard pattern",
+			in:              CleanURLInput{Operation: "CANONICAL", URL: "http://m.shop.example/a?id=1&sku=2"},
+			expected:        "http://m.shop.example/a?id=1",
+			expectedRuleSet: &RuleSetRef{ID: "2", Name: "shop subdomains"},
+		},
+		{
+			name:            "higher priority wins over a more specific pattern",
+			in:              CleanURLInput{Operation: "canonical", URL: "http://api.shop.example/a?id=1&sku=2"},
+			expected:        "http://api.shop.example/a?id=1",
+			expectedRuleSet: &RuleSetRef{ID: "2", Name: "shop subdomains"},
+		},
+		{
+			name:            "any host",
+			in:              CleanURLInput{Operation: "canonical", URL: "http://example.com/a/?id=1"},
+			expected:        "http://example.com/a/",
+			expectedRuleSet: &RuleSetRef{ID: "1", Name: "any host"},
+		},
+		{
+			name:            "operation only defined by a rule set",
+			in:              CleanURLInput{Operation: "share", URL: "http://m.shop.example/a?id=1&sku=2"},
+			expected:        "https://m.shop.example/a?id=1",
+			expectedRuleSet: &RuleSetRef{ID: "2", Name: "shop subdomains"},
+		},
+		{
+			name:     "operation of the profile when no rule set defines it",
+			in:       CleanURLInput{Operation: "clean", URL: "http://m.shop.example/a?id=1&utm_source=x"},
+			expected: "http://m.shop.example/a?id=1",
+		},
+		{
+			name:        "operation of a rule set not matching the host",
+			in:          CleanURLInput{Operation: "share", URL: "http://example.com/a"},
+			expectedErr: domain.ErrInvalidOperation,
+		},
+		{
+			name:        "unknown profile",
+			in:          CleanURLInput{Profile: "blog", Operation: "canonical", URL: "http://example.com/a"},
+			expectedErr: domain.ErrUnknownProfile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := uc.CleanURL(context.Background(), tt.in)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out.URL)
+			assert.Equal(t, tt.expectedRuleSet, out.RuleSet)
+		})
+	}
+
+	t.Run("batch of an operation only defined by a rule set", func(t *testing.T) {
+		var results []CleanURLsResult
+		err := uc.CleanURLs(context.Background(), CleanURLsInput{
+			Operation: "share",
+			URLs:      []string{"http://m.shop.example/a?sku=1", "http://example.com/a"},
+		}, func(res CleanURLsResult) error {
+			results = append(results, res)
+			return nil
+		})
+
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "https://m.shop.example/a", results[0].Output.URL)
+		assert.ErrorIs(t, results[1].Err, domain.ErrInvalidOperation)
+	})
+
+	t.Run("batch of an operation defined nowhere", func(t *testing.T) {
+		err := uc.CleanURLs(context.Background(), CleanURLsInput{Operation: "expand", URLs: []string{"http://example.com/a"}},
+			func(CleanURLsResult) error { return nil })
+
+		assert.ErrorIs(t, err, domain.ErrInvalidOperation)
+	})
+}
+
+func TestRuleSetsGet(t *testing.T) {
+	valid := domain.RuleSet{ID: "1", Name: "b", Hosts: []string{"*"}, Active: true,
+		Operations: map[string][]domain.Step{"canonical": {{Type: domain.StepStripQuery}}}}
+	invalid := domain.RuleSet{ID: "2", Name: "a", Hosts: []string{"*"}, Active: true,
+		Operations: map[string][]domain.Step{"canonical": {{Type: "shout"}}}}
+	higher := domain.RuleSet{ID: "3", Name: "c", Hosts: []string{"*"}, Priority: 1, Active: true,
+		Operations: map[string][]domain.Step{"canonical": {{Type: domain.StepStripQuery}}}}
+
+	ids := func(sets []*ruleSet) []string {
+		res := []string{}
+		for _, rs := range sets {
+			res = append(res, rs.def.ID)
+		}
+		return res
+	}
+
+	t.Run("skips invalid rule sets and orders by precedence", func(t *testing.T) {
+		repo := mocks.NewRuleSetRepository(t)
+		repo.On("GetRuleSets", mock.Anything).Return([]domain.RuleSet{invalid, valid, higher}, nil).Once()
+		c := newRuleSets(repo, nil, 0)
+
+		sets, err := c.get(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"3", "1"}, ids(sets))
+	})
+
+	t.Run("caches until refresh", func(t *testing.T) {
+		repo := mocks.NewRuleSetRepository(t)
+		repo.On("GetRuleSets", mock.Anything).Return([]domain.RuleSet{valid}, nil).Once()
+		repo.On("GetRuleSets", mock.Anything).Return([]domain.RuleSet{valid, higher}, nil).Once()
+		c := newRuleSets(repo, nil, time.Minute)
+		now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+		c.now = func() time.Time { return now }
+
+		sets, err := c.get(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1"}, ids(sets))
+
+		now = now.Add(30 * time.Second)
+		sets, err = c.get(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1"}, ids(sets))
+
+		now = now.Add(30 * time.Second)
+		sets, err = c.get(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"3", "1"}, ids(sets))
+	})
+
+	t.Run("reloads once invalidated", func(t *testing.T) {
+		repo := mocks.NewRuleSetRepository(t)
+		repo.On("GetRuleSets", mock.Anything).Return([]domain.RuleSet{valid}, nil).Twice()
+		c := newRuleSets(repo, nil, time.Hour)
+
+		_, err := c.get(context.Background())
+		require.NoError(t, err)
+		c.invalidate()
+		_, err = c.get(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := mocks.NewRuleSetRepository(t)
+		repo.On("GetRuleSets", mock.Anything).Return(nil, errors.New("database down")).Once()
+		c := newRuleSets(repo, nil, 0)
+
+		_, err := c.get(context.Background())
+
+		assert.EqualError(t, err, "database down")
+	})
+}
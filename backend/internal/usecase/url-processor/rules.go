@@ -0,0 +1,252 @@
+package urlprocessor
+
+import (
+	domain "booklib/internal/domain/url-processor"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	schemePattern = regexp.MustCompile(`^[a-z][a-z0-9+.-]*$`)
+)
+
+// step is a compiled domain.Step.
+type step struct {
+	typ string
+	// operation is the operation the step is defined in
+	operation string
+	// apply changes u and returns the names of the query params it removed;
+	// it is nil for resolve steps, which the usecase runs with its resolver
+	apply func(u *url.URL) (removed []string)
+}
+
+// compileProfile turns the operations of a profile into flat pipelines, the
+// steps of referenced operations inlined. Operation names are case-insensitive.
+// trackingParams are the patterns strip_tracking steps remove on top of their
+// own params.
+func compileProfile(p domain.Profile, trackingParams []string) (map[string][]step, error) {
+	defs := make(map[string][]domain.Step, len(p.Operations))
+	for name, steps := range p.Operations {
+		key := strings.ToLower(name)
+		if key == "" {
+			return nil, errors.New("operation name cannot be empty")
+		}
+		if _, ok := defs[key]; ok {
+			return nil, fmt.Errorf("operation %q is defined twice", key)
+		}
+		defs[key] = steps
+	}
+
+	c := &compiler{defs: defs, trackingParams: trackingParams, compiled: map[string][]step{}}
+	for name := range defs {
+		if _, err := c.operation(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return c.compiled, nil
+}
+
+type compiler struct {
+	defs           map[string][]domain.Step
+	trackingParams []string
+	compiled       map[string][]step
+}
+
+// operation compiles the named operation; stack holds the operations being
+// compiled, to refuse cycles.
+func (c *compiler) operation(name string, stack []string) ([]step, error) {
+	if steps, ok := c.compiled[name]; ok {
+		return steps, nil
+	}
+	if slices.Contains(stack, name) {
+		return nil, fmt.Errorf("operation %q includes itself: %s", name, strings.Join(append(stack, name), " > "))
+	}
+	defs, ok := c.defs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", domain.ErrInvalidOperation, name)
+	}
+
+	steps := []step{}
+	for i, def := range defs {
+		if def.Type == domain.StepOperation {
+			included, err := c.operation(strings.ToLower(def.Operation), append(stack, name))
+			if err != nil {
+				return nil, fmt.Errorf("operation %q step %d: %w", name, i+1, err)
+			}
+			steps = append(steps, included...)
+			continue
+		}
+
+		s, err := compileStep(def, c.trackingParams)
+		if err != nil {
+			return nil, fmt.Errorf("operation %q step %d: %w", name, i+1, err)
+		}
+		s.operation = name
+		steps = append(steps, s)
+	}
+
+	c.compiled[name] = steps
+	return steps, nil
+}
+
+func compileStep(def domain.Step, trackingParams []string) (step, error) {
+	s := step{typ: def.Type}
+
+	switch def.Type {
+	case domain.StepSetHost:
+		host := strings.ToLower(def.Host)
+		if parsed, err := url.Parse("//" + host); host == "" || err != nil || parsed.Host != host {
+			return step{}, fmt.Errorf("%s needs a valid host, got %q", def.Type, def.Host)
+		}
+		s.apply = func(u *url.URL) []string {
+			u.Host = host
+			return nil
+		}
+
+	case domain.StepForceScheme:
+		scheme := strings.ToLower(def.Scheme)
+		if !schemePattern.MatchString(scheme) {
+			return step{}, fmt.Errorf("%s needs a valid scheme, got %q", def.Type, def.Scheme)
+		}
+		s.apply = func(u *url.URL) []string {
+			u.Scheme = scheme
+			return nil
+		}
+
+	case domain.StepLowercasePath:
+		s.apply = func(u *url.URL) []string {
+			u.Path = strings.ToLower(u.Path)
+			u.RawPath = strings.ToLower(u.RawPath)
+			return nil
+		}
+
+	case domain.StepStripQuery:
+		if len(def.Params) == 0 {
+			s.apply = func(u *url.URL) []string {
+				removed := filterQuery(u, func(string) bool { return false })
+				u.ForceQuery = false
+				return removed
+			}
+			break
+		}
+		match, err := compilePatterns(def.Params)
+		if err != nil {
+			return step{}, fmt.Errorf("%s: %w", def.Type, err)
+		}
+		s.apply = func(u *url.URL) []string { return filterQuery(u, func(name string) bool { return !match(name) }) }
+
+	case domain.StepKeepQuery:
+		if len(def.Params) == 0 {
+			return step{}, fmt.Errorf("%s needs params", def.Type)
+		}
+		match, err := compilePatterns(def.Params)
+		if err != nil {
+			return step{}, fmt.Errorf("%s: %w", def.Type, err)
+		}
+		s.apply = func(u *url.URL) []string { return filterQuery(u, match) }
+
+	case domain.StepStripTracking:
+		patterns := slices.Concat(domain.TrackingParams, trackingParams, def.Params)
+		match, err := compilePatterns(patterns)
+		if err != nil {
+			return step{}, fmt.Errorf("%s: %w", def.Type, err)
+		}
+		s.apply = func(u *url.URL) []string { return filterQuery(u, func(name string) bool { return !match(name) }) }
+
+	case domain.StepNormalize:
+		sortQuery := def.SortQuery
+		s.apply = func(u *url.URL) []string {
+			normalizeURL(u, sortQuery)
+			return nil
+		}
+
+	case domain.StepResolve:
+		// fetching needs the resolver of the usecase, see usecase.apply
+
+	case domain.StepTrailingSlash:
+		switch def.Policy {
+		case domain.TrailingSlashRemove:
+			s.apply = func(u *url.URL) []string {
+				u.Path = strings.TrimSuffix(u.Path, "/")
+				u.RawPath = strings.TrimSuffix(u.RawPath, "/")
+				return nil
+			}
+		case domain.TrailingSlashAdd:
+			s.apply = func(u *url.URL) []string {
+				if !strings.HasSuffix(u.Path, "/") {
+					u.Path += "/"
+					if u.RawPath != "" {
+						u.RawPath += "/"
+					}
+				}
+				return nil
+			}
+		case domain.TrailingSlashKeep:
+			s.apply = func(u *url.URL) []string { return nil }
+		default:
+			return step{}, fmt.Errorf("%s policy must be %s, %s or %s, got %q", def.Type,
+				domain.TrailingSlashRemove, domain.TrailingSlashAdd, domain.TrailingSlashKeep, def.Policy)
+		}
+
+	default:
+		return step{}, fmt.Errorf("unknown step type %q, expected one of %s", def.Type, strings.Join(domain.StepTypes, ", "))
+	}
+
+	return s, nil
+}
+
+// compilePatterns returns a matcher of parameter names against patterns where
+// `*` matches any run of characters, ignoring case.
+func compilePatterns(patterns []string) (func(name string) bool, error) {
+	res := make([]*regexp.Regexp, len(patterns))
+	for i, p := range patterns {
+		if p == "" {
+			return nil, errors.New("params cannot contain an empty name")
+		}
+		parts := strings.Split(p, "*")
+		for j := range parts {
+			parts[j] = regexp.QuoteMeta(parts[j])
+		}
+		res[i] = regexp.MustCompile(`(?i)^` + strings.Join(parts, ".*") + `$`)
+	}
+
+	return func(name string) bool {
+		return slices.ContainsFunc(res, func(re *regexp.Regexp) bool { return re.MatchString(name) })
+	}, nil
+}
+
+// filterQuery keeps the query parameters whose name satisfies keep, leaving
+// their order and encoding untouched. It returns the names it removed, each
+// once, in the order they appeared.
+func filterQuery(u *url.URL, keep func(name string) bool) (removed []string) {
+	if u.RawQuery == "" {
+		return nil
+	}
+
+	var kept []string
+	for _, param := range strings.Split(u.RawQuery, "&") {
+		if param == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(param, "=")
+		if unescaped, err := url.QueryUnescape(name); err == nil {
+			name = unescaped
+		}
+		switch {
+		case keep(name):
+			kept = append(kept, param)
+		case !slices.Contains(removed, name):
+			removed = append(removed, name)
+		}
+	}
+
+	u.RawQuery = strings.Join(kept, "&")
+	if u.RawQuery == "" {
+		u.ForceQuery = false
+	}
+	return removed
+}
@@ -0,0 +1,283 @@
+package urlprocessor
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	domain "booklib/internal/domain/url-processor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileStep(t *testing.T) {
+	tests := []struct {
+		name            string
+		step            domain.Step
+		trackingParams  []string
+		url             string
+		expected        string
+		expectedRemoved []string
+		expectedErr     string
+	}{
+		{
+			name:     "set host",
+			step:     domain.Step{Type: domain.StepSetHost, Host: "Example.org:8443"},
+			url:      "http://user@www.example.com:8080/a?b=c",
+			expected: "http://user@example.org:8443/a?b=c",
+		},
+		{
+			name:        "set host without host",
+			step:        domain.Step{Type: domain.StepSetHost},
+			expectedErr: `set_host needs a valid host, got ""`,
+		},
+		{
+			name:        "set host with a path",
+			step:        domain.Step{Type: domain.StepSetHost, Host: "example.org/path"},
+			expectedErr: `set_host needs a valid host, got "example.org/path"`,
+		},
+		{
+			name:     "force scheme",
+			step:     domain.Step{Type: domain.StepForceScheme, Scheme: "HTTPS"},
+			url:      "http://example.com/a",
+			expected: "https://example.com/a",
+		},
+		{
+			name:        "force invalid scheme",
+			step:        domain.Step{Type: domain.StepForceScheme, Scheme: "ht tp"},
+			expectedErr: `force_scheme needs a valid scheme, got "ht tp"`,
+		},
+		{
+			name:     "lowercase path",
+			step:     domain.Step{Type: domain.StepLowercasePath},
+			url:      "https://Example.com/A%2FB/C?Q=V",
+			expected: "https://Example.com/a%2fb/c?Q=V",
+		},
+		{
+			name:            "strip the whole query",
+			step:            domain.Step{Type: domain.StepStripQuery},
+			url:             "https://example.com/a?b=c&d=e&b=f",
+			expected:        "https://example.com/a",
+			expectedRemoved: []string{"b", "d"},
+		},
+		{
+			name:            "strip params by pattern",
+			step:            domain.Step{Type: domain.StepStripQuery, Params: []string{"utm_*", "FBCLID"}},
+			url:             "https://example.com/a?id=1&utm_source=x&fbclid=y&UTM_Medium=z&q=a%20b",
+			expected:        "https://example.com/a?id=1&q=a%20b",
+			expectedRemoved: []string{"utm_source", "fbclid", "UTM_Medium"},
+		},
+		{
+			name:            "strip every param by pattern",
+			step:            domain.Step{Type: domain.StepStripQuery, Params: []string{"*"}},
+			url:             "https://example.com/a?b=c",
+			expected:        "https://example.com/a",
+			expectedRemoved: []string{"b"},
+		},
+		{
+			name:            "strip encoded param names",
+			step:            domain.Step{Type: domain.StepStripQuery, Params: []string{"utm source"}},
+			url:             "https://example.com/a?utm%20source=x&id=1",
+			expected:        "https://example.com/a?id=1",
+			expectedRemoved: []string{"utm source"},
+		},
+		{
+			name:        "strip empty param name",
+			step:        domain.Step{Type: domain.StepStripQuery, Params: []string{""}},
+			expectedErr: "strip_query: params cannot contain an empty name",
+		},
+		{
+			name:            "keep params by pattern",
+			step:            domain.Step{Type: domain.StepKeepQuery, Params: []string{"id", "page*"}},
+			url:             "https://example.com/a?ref=x&id=1&pageSize=10&page=2",
+			expected:        "https://example.com/a?id=1&pageSize=10&page=2",
+			expectedRemoved: []string{"ref"},
+		},
+		{
+			name:        "keep without params",
+			step:        domain.Step{Type: domain.StepKeepQuery},
+			expectedErr: "keep_query needs params",
+		},
+		{
+			name:            "strip tracking params",
+			step:            domain.Step{Type: domain.StepStripTracking},
+			url:             "https://example.com/a?id=1&utm_source=x&FBCLID=y&page=2&mc_eid=z&igshid=w&q=a%20b#top",
+			expected:        "https://example.com/a?id=1&page=2&q=a%20b#top",
+			expectedRemoved: []string{"utm_source", "FBCLID", "mc_eid", "igshid"},
+		},
+		{
+			name:     "strip tracking params without any",
+			step:     domain.Step{Type: domain.StepStripTracking},
+			url:      "https://example.com/a?id=1",
+			expected: "https://example.com/a?id=1",
+		},
+		{
+			name:            "strip configured and step tracking params",
+			step:            domain.Step{Type: domain.StepStripTracking, Params: []string{"ref"}},
+			trackingParams:  []string{"campaign_*"},
+			url:             "https://example.com/a?campaign_id=1&ref=x&gclid=y&id=2",
+			expected:        "https://example.com/a?id=2",
+			expectedRemoved: []string{"campaign_id", "ref", "gclid"},
+		},
+		{
+			name:           "strip empty tracking param name",
+			step:           domain.Step{Type: domain.StepStripTracking},
+			trackingParams: []string{""},
+			expectedErr:    "strip_tracking: params cannot contain an empty name",
+		},
+		{
+			name:     "remove trailing slash",
+			step:     domain.Step{Type: domain.StepTrailingSlash, Policy: domain.TrailingSlashRemove},
+			url:      "https://example.com/a/",
+			expected: "https://example.com/a",
+		},
+		{
+			name:     "add trailing slash",
+			step:     domain.Step{Type: domain.StepTrailingSlash, Policy: domain.TrailingSlashAdd},
+			url:      "https://example.com/a?b=c",
+			expected: "https://example.com/a/?b=c",
+		},
+		{
+			name:     "add trailing slash to an empty path",
+			step:     domain.Step{Type: domain.StepTrailingSlash, Policy: domain.TrailingSlashAdd},
+			url:      "https://example.com",
+			expected: "https://example.com/",
+		},
+		{
+			name:     "keep trailing slash",
+			step:     domain.Step{Type: domain.StepTrailingSlash, Policy: domain.TrailingSlashKeep},
+			url:      "https://example.com/a/",
+			expected: "https://example.com/a/",
+		},
+		{
+			name:        "invalid trailing slash policy",
+			step:        domain.Step{Type: domain.StepTrailingSlash, Policy: "drop"},
+			expectedErr: `trailing_slash policy must be remove, add or keep, got "drop"`,
+		},
+		{
+			name:     "normalize",
+			step:     domain.Step{Type: domain.StepNormalize},
+			url:      "HTTPS://Example.com:443/a/../%7eb?z=1&a=2",
+			expected: "https://example.com/~b?z=1&a=2",
+		},
+		{
+			name:     "normalize and sort the query",
+			step:     domain.Step{Type: domain.StepNormalize, SortQuery: true},
+			url:      "HTTPS://Example.com:443/a/../%7eb?z=1&a=2",
+			expected: "https://example.com/~b?a=2&z=1",
+		},
+		{
+			name:        "unknown step",
+			step:        domain.Step{Type: "shout"},
+			expectedErr: `unknown step type "shout"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := compileStep(tt.step, tt.trackingParams)
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.step.Type, s.typ)
+
+			u, err := url.Parse(tt.url)
+			require.NoError(t, err)
+			removed := s.apply(u)
+			assert.Equal(t, tt.expected, u.String())
+			assert.Equal(t, tt.expectedRemoved, removed)
+		})
+	}
+}
+
+func TestCompileProfile(t *testing.T) {
+	include := func(name string) domain.Step {
+		return domain.Step{Type: domain.StepOperation, Operation: name}
+	}
+	lowercase := domain.Step{Type: domain.StepLowercasePath}
+	strip := domain.Step{Type: domain.StepStripQuery}
+
+	tests := []struct {
+		name        string
+		profile     domain.Profile
+		expected    map[string][]string
+		expectedErr string
+	}{
+		{
+			name: "inlines included operations",
+			profile: domain.Profile{Operations: map[string][]domain.Step{
+				"Clean": {strip},
+				"all":   {include("clean"), lowercase, include("CLEAN")},
+			}},
+			expected: map[string][]string{
+				"clean": {domain.StepStripQuery},
+				"all":   {domain.StepStripQuery, domain.StepLowercasePath, domain.StepStripQuery},
+			},
+		},
+		{
+			name:     "empty operation",
+			profile:  domain.Profile{Operations: map[string][]domain.Step{"noop": nil}},
+			expected: map[string][]string{"noop": {}},
+		},
+		{
+			name: "operation defined twice",
+			profile: domain.Profile{Operations: map[string][]domain.Step{
+				"clean": {strip},
+				"CLEAN": {strip},
+			}},
+			expectedErr: `operation "clean" is defined twice`,
+		},
+		{
+			name: "missing included operation",
+			profile: domain.Profile{Operations: map[string][]domain.Step{
+				"all": {include("canonical")},
+			}},
+			expectedErr: `operation "all" step 1: invalid operation "canonical"`,
+		},
+		{
+			name: "cycle",
+			profile: domain.Profile{Operations: map[string][]domain.Step{
+				"a": {lowercase, include("b")},
+				"b": {include("a")},
+			}},
+			expectedErr: "includes itself",
+		},
+		{
+			name: "invalid step",
+			profile: domain.Profile{Operations: map[string][]domain.Step{
+				"a": {lowercase, {Type: domain.StepSetHost}},
+			}},
+			expectedErr: `operation "a" step 2: set_host needs a valid host`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, err := compileProfile(tt.profile, nil)
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			types := make(map[string][]string, len(ops))
+			for name, steps := range ops {
+				types[name] = []string{}
+				for _, s := range steps {
+					types[name] = append(types[name], s.typ)
+				}
+			}
+			assert.Equal(t, tt.expected, types)
+		})
+	}
+
+	t.Run("missing operations wrap ErrInvalidOperation", func(t *testing.T) {
+		_, err := compileProfile(domain.Profile{Operations: map[string][]domain.Step{"all": {include("x")}}}, nil)
+		assert.True(t, errors.Is(err, domain.ErrInvalidOperation))
+	})
+}
@@ -0,0 +1,120 @@
+package urlprocessor
+
+import (
+	domain "booklib/internal/domain/url-processor"
+	"context"
+	"fmt"
+	"net/url"
+)
+
+const (
+	MaxTestURLs = 100
+
+	// draftName names a draft given without a name
+	draftName = "draft"
+)
+
+type TestRuleSetInput struct {
+	// ID is the stored rule set the draft replaces, empty for a new one.
+	ID string
+	// Draft is tested as if active, whatever its Active field.
+	Draft RuleSetInput
+	// Profile holds the operation, the default profile when empty.
+	Profile   string
+	Operation string
+	URLs      []string
+}
+
+// TestRuleSetResult compares what the active rule sets and the draft make
+// of one URL. Either output is nil when its error is set.
+type TestRuleSetResult struct {
+	URL       string
+	Active    *CleanURLOutput
+	ActiveErr error
+	Draft     *CleanURLOutput
+	DraftErr  error
+	// Changed lists the components of the URL the draft rewrites otherwise
+	// than the active rule sets, in URL order; it is empty when either
+	// failed.
+	Changed []string
+	// Differs is set when the draft gives another URL, or fails where the
+	// active rule sets do not or the other way around.
+	Differs bool
+}
+
+func (u *usecase) TestRuleSet(ctx context.Context, in TestRuleSetInput) ([]TestRuleSetResult, error) {
+	if len(in.URLs) == 0 {
+		return nil, ErrBatchEmpty
+	}
+	if len(in.URLs) > MaxTestURLs {
+		return nil, fmt.Errorf("%w, the maximum is %d", ErrBatchTooLarge, MaxTestURLs)
+	}
+
+	name := in.Draft.Name
+	if name == "" {
+		name = draftName
+	}
+	def, err := domain.NewRuleSet(name, in.Draft.Hosts, in.Draft.Priority, in.Draft.Operations, true)
+	if err != nil {
+		return nil, err
+	}
+	// a draft that replaces nothing has no ID, as it is not stored
+	def.ID = in.ID
+	if in.ID != "" {
+		if _, err = u.GetRuleSet(ctx, in.ID); err != nil {
+			return nil, err
+		}
+	}
+	draft, err := u.ruleSets.compile(*def)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := u.ruleSets.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	drafted := make([]*ruleSet, 0, len(active)+1)
+	for _, rs := range active {
+		if rs.def.ID != def.ID {
+			drafted = append(drafted, rs)
+		}
+	}
+	drafted = append(drafted, draft)
+	sortRuleSets(drafted)
+
+	if err = u.checkOperation(drafted, in.Profile, in.Operation); err != nil {
+		if u.checkOperation(active, in.Profile, in.Operation) != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]TestRuleSetResult, 0, len(in.URLs))
+	for _, raw := range in.URLs {
+		res := TestRuleSetResult{URL: raw}
+		res.Active, res.ActiveErr = u.clean(ctx, active, in.Profile, in.Operation, raw, false)
+		res.Draft, res.DraftErr = u.clean(ctx, drafted, in.Profile, in.Operation, raw, false)
+		res.Changed, res.Differs = compareOutcomes(res)
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+func compareOutcomes(res TestRuleSetResult) ([]string, bool) {
+	if res.ActiveErr != nil || res.DraftErr != nil {
+		if res.ActiveErr == nil || res.DraftErr == nil {
+			return []string{}, true
+		}
+		return []string{}, res.ActiveErr.Error() != res.DraftErr.Error()
+	}
+
+	before, err := url.Parse(res.Active.URL)
+	if err != nil {
+		return []string{}, res.Active.URL != res.Draft.URL
+	}
+	after, err := url.Parse(res.Draft.URL)
+	if err != nil {
+		return []string{}, res.Active.URL != res.Draft.URL
+	}
+	return newComponents(before).changed(newComponents(after)), res.Active.URL != res.Draft.URL
+}
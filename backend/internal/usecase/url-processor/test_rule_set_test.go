@@ -0,0 +1,185 @@
+package urlprocessor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	domain "booklib/internal/domain/url-processor"
+	"booklib/internal/domain/url-processor/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestRuleSet(t *testing.T) {
+	stored := domain.RuleSet{
+		ID: "1", Name: "shop", Hosts: []string{"shop.example"}, Active: true,
+		Operations: map[string][]domain.Step{"canonical": {{Type: domain.StepKeepQuery, Params: []string{"id"}}}},
+	}
+	urls := []string{"https://shop.example/a/?id=1&ref=x", "https://blog.example/a/?p=2", "https://shop.example/b#top"}
+
+	tests := []struct {
+		name            string
+		in              TestRuleSetInput
+		setupMocks      func(*mocks.RuleSetRepository)
+		expectedActive  []string
+		expectedDraft   []string
+		expectedChanged [][]string
+		expectedDiffers []bool
+		expectedErr     error
+	}{
+		{
+			name: "new rule set for another host",
+			in: TestRuleSetInput{
+				Draft: RuleSetInput{
+					Hosts:      []string{"blog.example"},
+					Operations: map[string][]domain.Step{"canonical": {{Type: domain.StepSetHost, Host: "www.blog.example"}}},
+				},
+				Operation: "canonical",
+				URLs:      urls,
+			},
+			setupMocks: func(repo *mocks.RuleSetRepository) {
+				repo.On("GetRuleSets", mock.Anything).Return([]domain.RuleSet{stored}, nil).Once()
+			},
+			expectedActive:  []string{"https://shop.example/a/?id=1", "https://blog.example/a", "https://shop.example/b#top"},
+			expectedDraft:   []string{"https://shop.example/a/?id=1", "https://www.blog.example/a/?p=2", "https://shop.example/b#top"},
+			expectedChanged: [][]string{{}, {ComponentHost, ComponentPath, ComponentQuery}, {}},
+			expectedDiffers: []bool{false, true, false},
+		},
+		{
+			name: "draft replacing a stored rule set",
+			in: TestRuleSetInput{
+				ID: "1",
+				Draft: RuleSetInput{
+					Name:       "shop v2",
+					Hosts:      []string{"shop.example"},
+					Operations: map[string][]domain.Step{"canonical": {{Type: domain.StepStripQuery}, {Type: domain.StepTrailingSlash, Policy: domain.TrailingSlashRemove}}},
+				},
+				Operation: "canonical",
+				URLs:      urls,
+			},
+			setupMocks: func(repo *mocks.RuleSetRepository) {
+				repo.On("GetRuleSetByID", mock.Anything, "1").Return(&stored, nil).Once()
+				repo.On("GetRuleSets", mock.Anything).Return([]domain.RuleSet{stored}, nil).Once()
+			},
+			expectedActive:  []string{"https://shop.example/a/?id=1", "https://blog.example/a", "https://shop.example/b#top"},
+			expectedDraft:   []string{"https://shop.example/a", "https://blog.example/a", "https://shop.example/b#top"},
+			expectedChanged: [][]string{{ComponentPath, ComponentQuery}, {}, {}},
+			expectedDiffers: []bool{true, false, false},
+		},
+		{
+			name: "unknown replaced rule set",
+			in: TestRuleSetInput{
+				ID:        "2",
+				Draft:     RuleSetInput{Hosts: []string{"*"}, Operations: map[string][]domain.Step{"canonical": {{Type: domain.StepStripQuery}}}},
+				Operation: "canonical",
+				URLs:      urls,
+			},
+			setupMocks: func(repo *mocks.RuleSetRepository) {
+				repo.On("GetRuleSetByID", mock.Anything, "2").Return(nil, nil).Once()
+			},
+			expectedErr: domain.ErrRuleSetNotFound,
+		},
+		{
+			name: "invalid draft",
+			in: TestRuleSetInput{
+				Draft:     RuleSetInput{Hosts: []string{"*"}, Operations: map[string][]domain.Step{"canonical": {{Type: "shout"}}}},
+				Operation: "canonical",
+				URLs:      urls,
+			},
+			expectedErr: domain.ErrInvalidRuleSet,
+		},
+		{
+			name: "operation defined nowhere",
+			in: TestRuleSetInput{
+				Draft:     RuleSetInput{Hosts: []string{"*"}, Operations: map[string][]domain.Step{"canonical": {{Type: domain.StepStripQuery}}}},
+				Operation: "expand",
+				URLs:      urls,
+			},
+			setupMocks: func(repo *mocks.RuleSetRepository) {
+				repo.On("GetRuleSets", mock.Anything).Return([]domain.RuleSet{stored}, nil).Once()
+			},
+			expectedErr: domain.ErrInvalidOperation,
+		},
+		{
+			name:        "no urls",
+			in:          TestRuleSetInput{Operation: "canonical"},
+			expectedErr: ErrBatchEmpty,
+		},
+		{
+			name:        "too many urls",
+			in:          TestRuleSetInput{Operation: "canonical", URLs: strings.Split(strings.Repeat("https://a.example/,", MaxTestURLs), ",")},
+			expectedErr: ErrBatchTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRuleSetRepository(t)
+			if tt.setupMocks != nil {
+				tt.setupMocks(repo)
+			}
+			uc, err := New(repo, Config{})
+			require.NoError(t, err)
+
+			results, err := uc.TestRuleSet(context.Background(), tt.in)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, results, len(tt.in.URLs))
+			for i, res := range results {
+				require.NoError(t, res.ActiveErr)
+				require.NoError(t, res.DraftErr)
+				assert.Equal(t, tt.in.URLs[i], res.URL)
+				assert.Equal(t, tt.expectedActive[i], res.Active.URL)
+				assert.Equal(t, tt.expectedDraft[i], res.Draft.URL)
+				assert.Equal(t, tt.expectedChanged[i], res.Changed)
+				assert.Equal(t, tt.expectedDiffers[i], res.Differs)
+			}
+		})
+	}
+
+	t.Run("draft failing where the active rule sets do not", func(t *testing.T) {
+		repo := mocks.NewRuleSetRepository(t)
+		repo.On("GetRuleSets", mock.Anything).Return([]domain.RuleSet{}, nil).Once()
+		uc, err := New(repo, Config{})
+		require.NoError(t, err)
+
+		results, err := uc.TestRuleSet(context.Background(), TestRuleSetInput{
+			Draft:     RuleSetInput{Name: "offline", Hosts: []string{"*"}, Operations: map[string][]domain.Step{"canonical": {{Type: domain.StepResolve}}}},
+			Operation: "canonical",
+			URLs:      []string{"ftp://shop.example/a"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.NoError(t, results[0].ActiveErr)
+		assert.ErrorIs(t, results[0].DraftErr, domain.ErrUnresolvable)
+		assert.Nil(t, results[0].Draft)
+		assert.True(t, results[0].Differs)
+	})
+
+	t.Run("draft replacing nothing has no ID", func(t *testing.T) {
+		repo := mocks.NewRuleSetRepository(t)
+		repo.On("GetRuleSets", mock.Anything).Return([]domain.RuleSet{}, nil).Once()
+		uc, err := New(repo, Config{})
+		require.NoError(t, err)
+
+		results, err := uc.TestRuleSet(context.Background(), TestRuleSetInput{
+			Draft:     RuleSetInput{Hosts: []string{"*"}, Operations: map[string][]domain.Step{"canonical": {{Type: domain.StepStripQuery}}}},
+			Operation: "canonical",
+			URLs:      []string{"https://shop.example/a/?id=1"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Nil(t, results[0].Active.RuleSet)
+		assert.Equal(t, &RuleSetRef{Name: "draft"}, results[0].Draft.RuleSet)
+		assert.Equal(t, "https://shop.example/a/", results[0].Draft.URL)
+	})
+}
@@ -0,0 +1,142 @@
+package webhook
+
+import (
+	domain "booklib/internal/domain/webhook"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rizanw/go-log"
+)
+
+const (
+	userAgent = "booklib-webhook/1.0"
+
+	// maxErrorBodySize bounds how much of a failed response is kept in the
+	// delivery log.
+	maxErrorBodySize = 256
+
+	// leaseMargin is added to the time a claimed batch may take to send, for
+	// the reads and writes around the attempts.
+	leaseMargin = time.Minute
+)
+
+var (
+	errSubscriptionGone     = errors.New("subscription no longer exists")
+	errSubscriptionInactive = errors.New("subscription is inactive")
+)
+
+func (u usecase) DeliverDue(ctx context.Context) (int, error) {
+	now := u.now()
+
+	// the deliveries of a batch are sent one after another, each for at most
+	// Timeout, so the lease covers the whole batch: no other worker claims
+	// one again before this one got to it. A worker that dies mid-batch only
+	// delays its deliveries instead of losing them.
+	lease := time.Duration(u.conf.BatchSize)*u.conf.Timeout + leaseMargin
+	deliveries, err := u.repo.ClaimDueDeliveries(ctx, now, now.Add(lease), u.conf.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	subs := make(map[string]*domain.Subscription)
+	for i := range deliveries {
+		d := &deliveries[i]
+
+		sub, ok := subs[d.SubscriptionID]
+		if !ok {
+			if sub, err = u.repo.GetSubscriptionByID(ctx, d.SubscriptionID); err != nil {
+				return i, err
+			}
+			subs[d.SubscriptionID] = sub
+		}
+
+		switch {
+		case sub == nil:
+			u.markDead(d, errSubscriptionGone)
+		case !sub.Active:
+			u.markDead(d, errSubscriptionInactive)
+		default:
+			u.attempt(ctx, sub, d)
+		}
+
+		if err = u.repo.UpdateDelivery(ctx, d); err != nil {
+			log.Error(ctx, err, nil, "failed to update webhook delivery")
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// attempt sends d once and records the outcome on it.
+func (u usecase) attempt(ctx context.Context, sub *domain.Subscription, d *domain.Delivery) {
+	d.Attempts++
+
+	status, err := u.send(ctx, sub, d)
+	d.ResponseStatus = status
+	if err == nil {
+		d.Status = domain.DeliveryStatusSucceeded
+		d.LastError = ""
+		return
+	}
+
+	if d.Attempts >= u.conf.MaxAttempts {
+		u.markDead(d, err)
+		return
+	}
+
+	d.LastError = err.Error()
+	d.NextAttemptAt = u.now().Add(u.backoff(d.Attempts))
+}
+
+func (u usecase) send(ctx context.Context, sub *domain.Subscription, d *domain.Delivery) (int, error) {
+	// bounded whatever the client, so the batch fits in its lease
+	ctx, cancel := context.WithTimeout(ctx, u.conf.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(domain.HeaderEvent, d.EventType)
+	req.Header.Set(domain.HeaderDelivery, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(domain.HeaderSignature, domain.Sign(sub.Secret, d.Payload))
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff doubles the wait after every failed attempt, capped at MaxBackoff.
+func (u usecase) backoff(attempts int) time.Duration {
+	wait := u.conf.InitialBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= u.conf.MaxBackoff {
+			return u.conf.MaxBackoff
+		}
+	}
+
+	return wait
+}
+
+func (u usecase) markDead(d *domain.Delivery, err error) {
+	d.Status = domain.DeliveryStatusDead
+	d.LastError = err.Error()
+}
@@ -0,0 +1,240 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"booklib/internal/domain/event"
+	domain "booklib/internal/domain/webhook"
+	"booklib/internal/domain/webhook/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// receiver is an httptest endpoint that checks every delivery it gets.
+type receiver struct {
+	t      *testing.T
+	secret string
+	status int
+	calls  int
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rc.calls++
+
+	body, err := io.ReadAll(r.Body)
+	assert.NoError(rc.t, err)
+	assert.Equal(rc.t, http.MethodPost, r.Method)
+	assert.Equal(rc.t, "application/json", r.Header.Get("Content-Type"))
+	assert.Equal(rc.t, event.TypeBookCreated, r.Header.Get(domain.HeaderEvent))
+	assert.Equal(rc.t, "7", r.Header.Get(domain.HeaderDelivery))
+	assert.True(rc.t, domain.VerifySignature(rc.secret, body, r.Header.Get(domain.HeaderSignature)))
+
+	w.WriteHeader(rc.status)
+	_, _ = w.Write([]byte("receiver says hi"))
+}
+
+func TestDeliverDue(t *testing.T) {
+	var (
+		now     = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+		payload = []byte(`{"id":1,"type":"book.created"}`)
+	)
+
+	delivery := func(attempts int) domain.Delivery {
+		return domain.Delivery{
+			ID: 7, SubscriptionID: "sub-1", EventID: 1, EventType: event.TypeBookCreated,
+			Payload: payload, Status: domain.DeliveryStatusPending, Attempts: attempts,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		status        int
+		active        bool
+		deleted       bool
+		attempts      int
+		expectedCalls int
+		assertFn      func(*testing.T, *domain.Delivery)
+	}{
+		{
+			name:          "successful delivery",
+			status:        http.StatusNoContent,
+			active:        true,
+			expectedCalls: 1,
+			assertFn: func(t *testing.T, d *domain.Delivery) {
+				assert.Equal(t, domain.DeliveryStatusSucceeded, d.Status)
+				assert.Equal(t, 1, d.Attempts)
+				assert.Equal(t, http.StatusNoContent, d.ResponseStatus)
+				assert.Empty(t, d.LastError)
+			},
+		},
+		{
+			name:          "failed delivery is retried with backoff",
+			status:        http.StatusBadGateway,
+			active:        true,
+			attempts:      2,
+			expectedCalls: 1,
+			assertFn: func(t *testing.T, d *domain.Delivery) {
+				assert.Equal(t, domain.DeliveryStatusPending, d.Status)
+				assert.Equal(t, 3, d.Attempts)
+				assert.Equal(t, http.StatusBadGateway, d.ResponseStatus)
+				assert.Equal(t, "unexpected status 502: receiver says hi", d.LastError)
+				assert.Equal(t, now.Add(4*time.Second), d.NextAttemptAt)
+			},
+		},
+		{
+			name:          "last failed attempt moves the delivery to the dead-letter list",
+			status:        http.StatusInternalServerError,
+			active:        true,
+			attempts:      4,
+			expectedCalls: 1,
+			assertFn: func(t *testing.T, d *domain.Delivery) {
+				assert.Equal(t, domain.DeliveryStatusDead, d.Status)
+				assert.Equal(t, 5, d.Attempts)
+				assert.Contains(t, d.LastError, "unexpected status 500")
+			},
+		},
+		{
+			name:          "inactive subscription",
+			active:        false,
+			expectedCalls: 0,
+			assertFn: func(t *testing.T, d *domain.Delivery) {
+				assert.Equal(t, domain.DeliveryStatusDead, d.Status)
+				assert.Equal(t, errSubscriptionInactive.Error(), d.LastError)
+			},
+		},
+		{
+			name:          "deleted subscription",
+			deleted:       true,
+			expectedCalls: 0,
+			assertFn: func(t *testing.T, d *domain.Delivery) {
+				assert.Equal(t, domain.DeliveryStatusDead, d.Status)
+				assert.Equal(t, errSubscriptionGone.Error(), d.LastError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := &receiver{t: t, secret: "secret", status: tt.status}
+			srv := httptest.NewServer(rc)
+			defer srv.Close()
+
+			var sub *domain.Subscription
+			if !tt.deleted {
+				sub = &domain.Subscription{ID: "sub-1", URL: srv.URL, Secret: "secret", Active: tt.active}
+			}
+
+			var updated *domain.Delivery
+			repo := mocks.NewRepository(t)
+			// the lease covers a whole batch of attempts
+			repo.On("ClaimDueDeliveries", mock.Anything, now, now.Add(10*DefaultTimeout+time.Minute), 10).
+				Return([]domain.Delivery{delivery(tt.attempts)}, nil)
+			repo.On("GetSubscriptionByID", mock.Anything, "sub-1").Return(sub, nil)
+			repo.On("UpdateDelivery", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { updated = args.Get(1).(*domain.Delivery) }).
+				Return(nil)
+
+			uc := New(repo, Config{
+				Client:         srv.Client(),
+				MaxAttempts:    5,
+				InitialBackoff: time.Second,
+				MaxBackoff:     time.Minute,
+				BatchSize:      10,
+			}).(*usecase)
+			uc.now = func() time.Time { return now }
+
+			n, err := uc.DeliverDue(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, 1, n)
+			assert.Equal(t, tt.expectedCalls, rc.calls)
+			tt.assertFn(t, updated)
+		})
+	}
+
+	t.Run("claim error", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		repo.On("ClaimDueDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, errors.New("repository error"))
+
+		n, err := New(repo, Config{}).DeliverDue(context.Background())
+
+		assert.EqualError(t, err, "repository error")
+		assert.Zero(t, n)
+	})
+
+	t.Run("unreachable receiver", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		srv.Close()
+
+		var updated *domain.Delivery
+		repo := mocks.NewRepository(t)
+		repo.On("ClaimDueDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]domain.Delivery{delivery(0)}, nil)
+		repo.On("GetSubscriptionByID", mock.Anything, "sub-1").
+			Return(&domain.Subscription{ID: "sub-1", URL: srv.URL, Secret: "secret", Active: true}, nil)
+		repo.On("UpdateDelivery", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { updated = args.Get(1).(*domain.Delivery) }).
+			Return(nil)
+
+		_, err := New(repo, Config{}).DeliverDue(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DeliveryStatusPending, updated.Status)
+		assert.Equal(t, 1, updated.Attempts)
+		assert.NotEmpty(t, updated.LastError)
+	})
+
+	t.Run("attempt outlasting the timeout", func(t *testing.T) {
+		release := make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer srv.Close()
+		defer close(release)
+
+		var updated *domain.Delivery
+		repo := mocks.NewRepository(t)
+		repo.On("ClaimDueDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]domain.Delivery{delivery(0)}, nil)
+		repo.On("GetSubscriptionByID", mock.Anything, "sub-1").
+			Return(&domain.Subscription{ID: "sub-1", URL: srv.URL, Secret: "secret", Active: true}, nil)
+		repo.On("UpdateDelivery", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { updated = args.Get(1).(*domain.Delivery) }).
+			Return(nil)
+
+		// a client without a timeout of its own is still cut at Timeout
+		_, err := New(repo, Config{Client: srv.Client(), Timeout: 20 * time.Millisecond}).DeliverDue(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DeliveryStatusPending, updated.Status)
+		assert.Contains(t, updated.LastError, "deadline exceeded")
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	uc := New(nil, Config{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}).(*usecase)
+
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 1, expected: time.Second},
+		{attempts: 2, expected: 2 * time.Second},
+		{attempts: 3, expected: 4 * time.Second},
+		{attempts: 4, expected: 8 * time.Second},
+		{attempts: 5, expected: 10 * time.Second},
+		{attempts: 50, expected: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, uc.backoff(tt.attempts), "attempts %d", tt.attempts)
+	}
+}
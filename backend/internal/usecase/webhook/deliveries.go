@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	domain "booklib/internal/domain/webhook"
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	MaxDeliveriesLimit = 1000
+)
+
+var (
+	ErrDeliveryNotDead = errors.New("only dead deliveries can be retried")
+)
+
+type GetDeliveriesInput struct {
+	SubscriptionID string
+	Status         string
+	Limit          int
+}
+
+func (u usecase) GetDeliveries(ctx context.Context, in GetDeliveriesInput) ([]domain.Delivery, error) {
+	switch in.Status {
+	case "", domain.DeliveryStatusPending, domain.DeliveryStatusSucceeded, domain.DeliveryStatusDead:
+	default:
+		return nil, fmt.Errorf("invalid delivery status %q", in.Status)
+	}
+
+	if in.SubscriptionID != "" {
+		// report a missing subscription instead of an empty log
+		if _, err := u.GetSubscription(ctx, in.SubscriptionID); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.repo.GetDeliveries(ctx, domain.DeliveryFilter{
+		SubscriptionID: in.SubscriptionID,
+		Status:         in.Status,
+		Limit:          min(in.Limit, MaxDeliveriesLimit),
+	})
+}
+
+// RetryDelivery moves a dead delivery back to the queue with a fresh set of
+// attempts.
+func (u usecase) RetryDelivery(ctx context.Context, id int64) (*domain.Delivery, error) {
+	d, err := u.repo.GetDeliveryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, domain.ErrDeliveryNotFound
+	}
+	if d.Status != domain.DeliveryStatusDead {
+		return nil, ErrDeliveryNotDead
+	}
+
+	d.Status = domain.DeliveryStatusPending
+	d.Attempts = 0
+	d.NextAttemptAt = u.now()
+	if err = u.repo.UpdateDelivery(ctx, d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
@@ -0,0 +1,135 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "booklib/internal/domain/webhook"
+	"booklib/internal/domain/webhook/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetDeliveries(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       GetDeliveriesInput
+		setupMocks  func(*mocks.Repository)
+		expectedErr string
+	}{
+		{
+			name:  "dead-letter list",
+			input: GetDeliveriesInput{Status: domain.DeliveryStatusDead},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetDeliveries", mock.Anything, domain.DeliveryFilter{Status: domain.DeliveryStatusDead}).
+					Return([]domain.Delivery{{ID: 1, Status: domain.DeliveryStatusDead}}, nil)
+			},
+		},
+		{
+			name:  "log of one subscription with capped limit",
+			input: GetDeliveriesInput{SubscriptionID: "sub-1", Limit: MaxDeliveriesLimit + 1},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetSubscriptionByID", mock.Anything, "sub-1").Return(&domain.Subscription{ID: "sub-1"}, nil)
+				repo.On("GetDeliveries", mock.Anything, domain.DeliveryFilter{SubscriptionID: "sub-1", Limit: MaxDeliveriesLimit}).
+					Return([]domain.Delivery{{ID: 1}}, nil)
+			},
+		},
+		{
+			name:  "unknown subscription",
+			input: GetDeliveriesInput{SubscriptionID: "sub-1"},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetSubscriptionByID", mock.Anything, "sub-1").Return(nil, nil)
+			},
+			expectedErr: domain.ErrNotFound.Error(),
+		},
+		{
+			name:        "invalid status",
+			input:       GetDeliveriesInput{Status: "failed"},
+			setupMocks:  func(repo *mocks.Repository) {},
+			expectedErr: `invalid delivery status "failed"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			tt.setupMocks(repo)
+
+			uc := New(repo, Config{})
+			deliveries, err := uc.GetDeliveries(context.Background(), tt.input)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, deliveries)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, deliveries, 1)
+			}
+		})
+	}
+}
+
+func TestRetryDelivery(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMocks  func(*mocks.Repository)
+		expectedErr string
+	}{
+		{
+			name: "requeues a dead delivery",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetDeliveryByID", mock.Anything, int64(7)).
+					Return(&domain.Delivery{ID: 7, Status: domain.DeliveryStatusDead, Attempts: 8, LastError: "timeout"}, nil)
+				repo.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(d *domain.Delivery) bool {
+					return d.ID == 7 && d.Status == domain.DeliveryStatusPending && d.Attempts == 0 && !d.NextAttemptAt.IsZero()
+				})).Return(nil)
+			},
+		},
+		{
+			name: "delivery not found",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetDeliveryByID", mock.Anything, int64(7)).Return(nil, nil)
+			},
+			expectedErr: domain.ErrDeliveryNotFound.Error(),
+		},
+		{
+			name: "delivery is not dead",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetDeliveryByID", mock.Anything, int64(7)).
+					Return(&domain.Delivery{ID: 7, Status: domain.DeliveryStatusSucceeded}, nil)
+			},
+			expectedErr: ErrDeliveryNotDead.Error(),
+		},
+		{
+			name: "repository error",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetDeliveryByID", mock.Anything, int64(7)).
+					Return(&domain.Delivery{ID: 7, Status: domain.DeliveryStatusDead}, nil)
+				repo.On("UpdateDelivery", mock.Anything, mock.Anything).Return(errors.New("repository error"))
+			},
+			expectedErr: "repository error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			tt.setupMocks(repo)
+
+			uc := New(repo, Config{})
+			d, err := uc.RetryDelivery(context.Background(), 7)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, d)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, domain.DeliveryStatusPending, d.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"booklib/internal/domain/event"
+	domain "booklib/internal/domain/webhook"
+	"context"
+	"encoding/json"
+)
+
+func (u usecase) HandleEvents(ctx context.Context, events []*event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	subs, err := u.repo.GetSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		now        = u.now()
+		deliveries []*domain.Delivery
+	)
+	for _, ev := range events {
+		var payload json.RawMessage
+		for i := range subs {
+			if !subs[i].Matches(ev.Type) {
+				continue
+			}
+			if payload == nil {
+				if payload, err = json.Marshal(ev); err != nil {
+					return err
+				}
+			}
+
+			deliveries = append(deliveries, &domain.Delivery{
+				SubscriptionID: subs[i].ID,
+				EventID:        ev.ID,
+				EventType:      ev.Type,
+				Payload:        payload,
+				Status:         domain.DeliveryStatusPending,
+				NextAttemptAt:  now,
+			})
+		}
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return u.repo.AddDeliveries(ctx, deliveries)
+}
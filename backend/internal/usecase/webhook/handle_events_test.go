@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"booklib/internal/domain/event"
+	domain "booklib/internal/domain/webhook"
+	"booklib/internal/domain/webhook/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandleEvents(t *testing.T) {
+	var (
+		created = &event.Event{ID: 1, Type: event.TypeBookCreated, AggregateType: event.AggregateBook, AggregateID: "id-1"}
+		deleted = &event.Event{ID: 2, Type: event.TypeBookDeleted, AggregateType: event.AggregateBook, AggregateID: "id-1"}
+		subs    = []domain.Subscription{
+			{ID: "all", Active: true, EventTypes: []string{}},
+			{ID: "created-only", Active: true, EventTypes: []string{event.TypeBookCreated}},
+			{ID: "inactive", Active: false, EventTypes: []string{}},
+		}
+	)
+
+	tests := []struct {
+		name        string
+		events      []*event.Event
+		setupMocks  func(*mocks.Repository)
+		expectedErr string
+	}{
+		{
+			name:   "queues a delivery per matching subscription",
+			events: []*event.Event{created, deleted},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetSubscriptions", mock.Anything).Return(subs, nil)
+				repo.On("AddDeliveries", mock.Anything, mock.MatchedBy(func(ds []*domain.Delivery) bool {
+					if len(ds) != 3 {
+						return false
+					}
+					return ds[0].SubscriptionID == "all" && ds[0].EventID == 1 &&
+						ds[1].SubscriptionID == "created-only" && ds[1].EventID == 1 &&
+						ds[2].SubscriptionID == "all" && ds[2].EventID == 2 &&
+						ds[2].Status == domain.DeliveryStatusPending && len(ds[2].Payload) > 0
+				})).Return(nil)
+			},
+		},
+		{
+			name:   "no matching subscription",
+			events: []*event.Event{deleted},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetSubscriptions", mock.Anything).Return([]domain.Subscription{subs[1]}, nil)
+			},
+		},
+		{
+			name:       "no events",
+			events:     nil,
+			setupMocks: func(repo *mocks.Repository) {},
+		},
+		{
+			name:   "repository error",
+			events: []*event.Event{created},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetSubscriptions", mock.Anything).Return(nil, errors.New("repository error"))
+			},
+			expectedErr: "repository error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			tt.setupMocks(repo)
+
+			uc := New(repo, Config{})
+			err := uc.HandleEvents(context.Background(), tt.events)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
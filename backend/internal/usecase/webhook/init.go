@@ -0,0 +1,64 @@
+package webhook
+
+import (
+	"net/http"
+	"time"
+
+	domain "booklib/internal/domain/webhook"
+)
+
+const (
+	DefaultTimeout        = 5 * time.Second
+	DefaultMaxAttempts    = 8
+	DefaultInitialBackoff = 10 * time.Second
+	DefaultMaxBackoff     = time.Hour
+	DefaultBatchSize      = 50
+)
+
+// Config tunes delivery. Zero values fall back to the defaults above.
+type Config struct {
+	// Client sends the deliveries; a client with Timeout is built when nil
+	Client         *http.Client
+	Timeout        time.Duration
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	BatchSize      int
+}
+
+type usecase struct {
+	repo   domain.Repository
+	conf   Config
+	client *http.Client
+	now    func() time.Time
+}
+
+func New(repo domain.Repository, conf Config) UseCase {
+	if conf.Timeout <= 0 {
+		conf.Timeout = DefaultTimeout
+	}
+	if conf.MaxAttempts <= 0 {
+		conf.MaxAttempts = DefaultMaxAttempts
+	}
+	if conf.InitialBackoff <= 0 {
+		conf.InitialBackoff = DefaultInitialBackoff
+	}
+	if conf.MaxBackoff <= 0 {
+		conf.MaxBackoff = DefaultMaxBackoff
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = DefaultBatchSize
+	}
+
+	client := conf.Client
+	if client == nil {
+		client = &http.Client{Timeout: conf.Timeout}
+	}
+
+	return &usecase{
+		repo:   repo,
+		conf:   conf,
+		client: client,
+		now:    time.Now,
+	}
+}
@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	"net/http"
+	"testing"
+
+	"booklib/internal/domain/webhook/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("creates new usecase with defaults", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+
+		uc := New(repo, Config{})
+
+		assert.NotNil(t, uc)
+		assert.Implements(t, (*UseCase)(nil), uc)
+
+		u := uc.(*usecase)
+		assert.Equal(t, DefaultMaxAttempts, u.conf.MaxAttempts)
+		assert.Equal(t, DefaultTimeout, u.client.Timeout)
+	})
+
+	t.Run("uses the given client", func(t *testing.T) {
+		client := &http.Client{}
+
+		uc := New(mocks.NewRepository(t), Config{Client: client})
+
+		assert.Same(t, client, uc.(*usecase).client)
+	})
+}
@@ -0,0 +1,27 @@
+package webhook
+
+import (
+	"context"
+
+	"booklib/internal/domain/event"
+	domain "booklib/internal/domain/webhook"
+)
+
+//go:generate mockery --name=UseCase --output=./mocks
+type UseCase interface {
+	// HandleEvents queues a delivery of every event for each matching
+	// subscription.
+	HandleEvents(ctx context.Context, events []*event.Event) error
+	// DeliverDue sends the deliveries that are due and returns how many were
+	// attempted.
+	DeliverDue(ctx context.Context) (int, error)
+
+	AddSubscription(ctx context.Context, in SubscriptionInput) (*domain.Subscription, error)
+	GetSubscriptions(ctx context.Context) ([]domain.Subscription, error)
+	GetSubscription(ctx context.Context, id string) (*domain.Subscription, error)
+	UpdateSubscription(ctx context.Context, id string, in SubscriptionInput) (*domain.Subscription, error)
+	DeleteSubscription(ctx context.Context, id string) error
+
+	GetDeliveries(ctx context.Context, in GetDeliveriesInput) ([]domain.Delivery, error)
+	RetryDelivery(ctx context.Context, id int64) (*domain.Delivery, error)
+}
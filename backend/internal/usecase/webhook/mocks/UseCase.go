@@ -0,0 +1,276 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	event "booklib/internal/domain/event"
+	domainwebhook "booklib/internal/domain/webhook"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	webhook "booklib/internal/usecase/webhook"
+)
+
+// UseCase is an autogenerated mock type for the UseCase type
+type UseCase struct {
+	mock.Mock
+}
+
+// AddSubscription provides a mock function with given fields: ctx, in
+func (_m *UseCase) AddSubscription(ctx context.Context, in webhook.SubscriptionInput) (*domainwebhook.Subscription, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSubscription")
+	}
+
+	var r0 *domainwebhook.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.SubscriptionInput) (*domainwebhook.Subscription, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.SubscriptionInput) *domainwebhook.Subscription); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domainwebhook.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhook.SubscriptionInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteSubscription provides a mock function with given fields: ctx, id
+func (_m *UseCase) DeleteSubscription(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeliverDue provides a mock function with given fields: ctx
+func (_m *UseCase) DeliverDue(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeliverDue")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, in
+func (_m *UseCase) GetDeliveries(ctx context.Context, in webhook.GetDeliveriesInput) ([]domainwebhook.Delivery, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveries")
+	}
+
+	var r0 []domainwebhook.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.GetDeliveriesInput) ([]domainwebhook.Delivery, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.GetDeliveriesInput) []domainwebhook.Delivery); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domainwebhook.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhook.GetDeliveriesInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubscription provides a mock function with given fields: ctx, id
+func (_m *UseCase) GetSubscription(ctx context.Context, id string) (*domainwebhook.Subscription, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscription")
+	}
+
+	var r0 *domainwebhook.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domainwebhook.Subscription, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domainwebhook.Subscription); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domainwebhook.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubscriptions provides a mock function with given fields: ctx
+func (_m *UseCase) GetSubscriptions(ctx context.Context) ([]domainwebhook.Subscription, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptions")
+	}
+
+	var r0 []domainwebhook.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domainwebhook.Subscription, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domainwebhook.Subscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domainwebhook.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HandleEvents provides a mock function with given fields: ctx, events
+func (_m *UseCase) HandleEvents(ctx context.Context, events []*event.Event) error {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*event.Event) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RetryDelivery provides a mock function with given fields: ctx, id
+func (_m *UseCase) RetryDelivery(ctx context.Context, id int64) (*domainwebhook.Delivery, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryDelivery")
+	}
+
+	var r0 *domainwebhook.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*domainwebhook.Delivery, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *domainwebhook.Delivery); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domainwebhook.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateSubscription provides a mock function with given fields: ctx, id, in
+func (_m *UseCase) UpdateSubscription(ctx context.Context, id string, in webhook.SubscriptionInput) (*domainwebhook.Subscription, error) {
+	ret := _m.Called(ctx, id, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSubscription")
+	}
+
+	var r0 *domainwebhook.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, webhook.SubscriptionInput) (*domainwebhook.Subscription, error)); ok {
+		return rf(ctx, id, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, webhook.SubscriptionInput) *domainwebhook.Subscription); ok {
+		r0 = rf(ctx, id, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domainwebhook.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, webhook.SubscriptionInput) error); ok {
+		r1 = rf(ctx, id, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUseCase creates a new instance of UseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UseCase {
+	mock := &UseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	domain "booklib/internal/domain/webhook"
+	"context"
+)
+
+type SubscriptionInput struct {
+	URL        string
+	EventTypes []string
+	// Secret is required on creation; an empty secret keeps the current one
+	// on update.
+	Secret string
+	Active bool
+}
+
+func (u usecase) AddSubscription(ctx context.Context, in SubscriptionInput) (*domain.Subscription, error) {
+	sub, err := domain.NewSubscription(in.URL, normalizeEventTypes(in.EventTypes), in.Secret, in.Active)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.repo.AddSubscription(ctx, sub)
+}
+
+func (u usecase) GetSubscriptions(ctx context.Context) ([]domain.Subscription, error) {
+	return u.repo.GetSubscriptions(ctx)
+}
+
+func (u usecase) GetSubscription(ctx context.Context, id string) (*domain.Subscription, error) {
+	sub, err := u.repo.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	return sub, nil
+}
+
+func (u usecase) UpdateSubscription(ctx context.Context, id string, in SubscriptionInput) (*domain.Subscription, error) {
+	sub, err := u.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sub.URL = in.URL
+	sub.EventTypes = normalizeEventTypes(in.EventTypes)
+	sub.Active = in.Active
+	if in.Secret != "" {
+		sub.Secret = in.Secret
+	}
+	if err = sub.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := u.repo.UpdateSubscription(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	return res, nil
+}
+
+func (u usecase) DeleteSubscription(ctx context.Context, id string) error {
+	return u.repo.DeleteSubscription(ctx, id)
+}
+
+func normalizeEventTypes(eventTypes []string) []string {
+	if eventTypes == nil {
+		return []string{}
+	}
+	return eventTypes
+}
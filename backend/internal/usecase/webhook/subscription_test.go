@@ -0,0 +1,243 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"booklib/internal/domain/event"
+	domain "booklib/internal/domain/webhook"
+	"booklib/internal/domain/webhook/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddSubscription(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       SubscriptionInput
+		setupMocks  func(*mocks.Repository)
+		expectedErr string
+	}{
+		{
+			name:  "successful add subscription",
+			input: SubscriptionInput{URL: "https://example.com/hook", EventTypes: []string{event.TypeBookCreated}, Secret: "secret", Active: true},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("AddSubscription", mock.Anything, mock.MatchedBy(func(sub *domain.Subscription) bool {
+					return sub.ID != "" && sub.URL == "https://example.com/hook" && sub.Secret == "secret" && sub.Active
+				})).Return(func(_ context.Context, sub *domain.Subscription) (*domain.Subscription, error) {
+					return sub, nil
+				})
+			},
+		},
+		{
+			name:  "nil event types are stored as all events",
+			input: SubscriptionInput{URL: "https://example.com/hook", Secret: "secret"},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("AddSubscription", mock.Anything, mock.MatchedBy(func(sub *domain.Subscription) bool {
+					return sub.EventTypes != nil && len(sub.EventTypes) == 0
+				})).Return(func(_ context.Context, sub *domain.Subscription) (*domain.Subscription, error) {
+					return sub, nil
+				})
+			},
+		},
+		{
+			name:        "invalid url",
+			input:       SubscriptionInput{URL: "ftp://example.com", Secret: "secret"},
+			setupMocks:  func(repo *mocks.Repository) {},
+			expectedErr: "url must be an absolute http or https URL",
+		},
+		{
+			name:        "empty secret",
+			input:       SubscriptionInput{URL: "https://example.com/hook"},
+			setupMocks:  func(repo *mocks.Repository) {},
+			expectedErr: "secret cannot be empty",
+		},
+		{
+			name:        "unknown event type",
+			input:       SubscriptionInput{URL: "https://example.com/hook", Secret: "secret", EventTypes: []string{"book.read"}},
+			setupMocks:  func(repo *mocks.Repository) {},
+			expectedErr: `unknown event type "book.read"`,
+		},
+		{
+			name:  "repository error",
+			input: SubscriptionInput{URL: "https://example.com/hook", Secret: "secret"},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("AddSubscription", mock.Anything, mock.Anything).Return(nil, errors.New("repository error"))
+			},
+			expectedErr: "repository error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			tt.setupMocks(repo)
+
+			uc := New(repo, Config{})
+			sub, err := uc.AddSubscription(context.Background(), tt.input)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, sub)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.input.URL, sub.URL)
+			}
+		})
+	}
+}
+
+func TestGetSubscription(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMocks  func(*mocks.Repository)
+		expectedErr string
+	}{
+		{
+			name: "successful get subscription",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetSubscriptionByID", mock.Anything, "sub-1").Return(&domain.Subscription{ID: "sub-1"}, nil)
+			},
+		},
+		{
+			name: "subscription not found",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetSubscriptionByID", mock.Anything, "sub-1").Return(nil, nil)
+			},
+			expectedErr: domain.ErrNotFound.Error(),
+		},
+		{
+			name: "repository error",
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetSubscriptionByID", mock.Anything, "sub-1").Return(nil, errors.New("repository error"))
+			},
+			expectedErr: "repository error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			tt.setupMocks(repo)
+
+			uc := New(repo, Config{})
+			sub, err := uc.GetSubscription(context.Background(), "sub-1")
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, sub)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "sub-1", sub.ID)
+			}
+		})
+	}
+}
+
+func TestGetSubscriptions(t *testing.T) {
+	repo := mocks.NewRepository(t)
+	repo.On("GetSubscriptions", mock.Anything).Return([]domain.Subscription{{ID: "sub-1"}}, nil)
+
+	subs, err := New(repo, Config{}).GetSubscriptions(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Subscription{{ID: "sub-1"}}, subs)
+}
+
+func TestUpdateSubscription(t *testing.T) {
+	existing := func() *domain.Subscription {
+		return &domain.Subscription{ID: "sub-1", URL: "https://example.com/old", EventTypes: []string{}, Secret: "old-secret", Active: true}
+	}
+
+	tests := []struct {
+		name           string
+		input          SubscriptionInput
+		setupMocks     func(*mocks.Repository)
+		expectedSecret string
+		expectedErr    string
+	}{
+		{
+			name:  "empty secret keeps the current one",
+			input: SubscriptionInput{URL: "https://example.com/new", Active: false},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetSubscriptionByID", mock.Anything, "sub-1").Return(existing(), nil)
+				repo.On("UpdateSubscription", mock.Anything, mock.MatchedBy(func(sub *domain.Subscription) bool {
+					return sub.URL == "https://example.com/new" && !sub.Active
+				})).Return(func(_ context.Context, sub *domain.Subscription) (*domain.Subscription, error) {
+					return sub, nil
+				})
+			},
+			expectedSecret: "old-secret",
+		},
+		{
+			name:  "rotates the secret",
+			input: SubscriptionInput{URL: "https://example.com/new", Secret: "new-secret", Active: true},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetSubscriptionByID", mock.Anything, "sub-1").Return(existing(), nil)
+				repo.On("UpdateSubscription", mock.Anything, mock.Anything).
+					Return(func(_ context.Context, sub *domain.Subscription) (*domain.Subscription, error) {
+						return sub, nil
+					})
+			},
+			expectedSecret: "new-secret",
+		},
+		{
+			name:  "subscription not found",
+			input: SubscriptionInput{URL: "https://example.com/new"},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetSubscriptionByID", mock.Anything, "sub-1").Return(nil, nil)
+			},
+			expectedErr: domain.ErrNotFound.Error(),
+		},
+		{
+			name:  "invalid url",
+			input: SubscriptionInput{URL: "not a url"},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetSubscriptionByID", mock.Anything, "sub-1").Return(existing(), nil)
+			},
+			expectedErr: "url must be an absolute http or https URL",
+		},
+		{
+			name:  "subscription deleted before update",
+			input: SubscriptionInput{URL: "https://example.com/new"},
+			setupMocks: func(repo *mocks.Repository) {
+				repo.On("GetSubscriptionByID", mock.Anything, "sub-1").Return(existing(), nil)
+				repo.On("UpdateSubscription", mock.Anything, mock.Anything).Return(nil, nil)
+			},
+			expectedErr: domain.ErrNotFound.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			tt.setupMocks(repo)
+
+			uc := New(repo, Config{})
+			sub, err := uc.UpdateSubscription(context.Background(), "sub-1", tt.input)
+
+			if tt.expectedErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, sub)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.input.URL, sub.URL)
+				assert.Equal(t, tt.expectedSecret, sub.Secret)
+			}
+		})
+	}
+}
+
+func TestDeleteSubscription(t *testing.T) {
+	repo := mocks.NewRepository(t)
+	repo.On("DeleteSubscription", mock.Anything, "sub-1").Return(errors.New("repository error"))
+
+	err := New(repo, Config{}).DeleteSubscription(context.Background(), "sub-1")
+
+	assert.EqualError(t, err, "repository error")
+}
@@ -0,0 +1,5 @@
+DROP INDEX books_updated_at_idx;
+
+ALTER TABLE books
+    ALTER COLUMN created_at DROP NOT NULL,
+    ALTER COLUMN updated_at DROP NOT NULL;
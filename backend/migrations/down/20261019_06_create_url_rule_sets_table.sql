@@ -0,0 +1 @@
+DROP TABLE url_rule_sets;
@@ -0,0 +1,4 @@
+DROP INDEX events_aggregate_type_xid_id_idx;
+
+ALTER TABLE events
+    DROP COLUMN xid;
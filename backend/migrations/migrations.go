@@ -0,0 +1,29 @@
+// Package migrations embeds the SQL migrations, so the binary can migrate a
+// database without the source tree.
+//
+// Every migration is a pair of files named <version>_<name>.sql, one in up/
+// and one in down/. Versions sort in the order migrations are applied.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed up/*.sql down/*.sql sqlite/up/*.sql sqlite/down/*.sql
+var files embed.FS
+
+var (
+	// Postgres holds the postgres migrations in up/ and down/.
+	Postgres fs.FS = files
+	// SQLite holds the sqlite migrations in up/ and down/.
+	SQLite fs.FS = mustSub(files, "sqlite")
+)
+
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
@@ -0,0 +1,2 @@
+DROP TRIGGER events_enqueue_outbox;
+DROP TABLE outbox;
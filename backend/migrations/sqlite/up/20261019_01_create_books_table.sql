@@ -0,0 +1,11 @@
+CREATE TABLE books
+(
+    id         TEXT PRIMARY KEY,
+    title      TEXT      NOT NULL,
+    author     TEXT      NOT NULL,
+    year       INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX books_updated_at_idx ON books (updated_at);
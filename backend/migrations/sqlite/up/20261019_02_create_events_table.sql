@@ -0,0 +1,12 @@
+CREATE TABLE events
+(
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    type           TEXT      NOT NULL,
+    aggregate_type TEXT      NOT NULL,
+    aggregate_id   TEXT      NOT NULL,
+    before         TEXT,
+    after          TEXT,
+    occurred_at    TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX events_aggregate_type_id_idx ON events (aggregate_type, id);
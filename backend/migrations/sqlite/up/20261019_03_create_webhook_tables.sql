@@ -0,0 +1,30 @@
+CREATE TABLE webhook_subscriptions
+(
+    id          TEXT PRIMARY KEY,
+    url         TEXT      NOT NULL,
+    event_types TEXT      NOT NULL DEFAULT '[]',
+    secret      TEXT      NOT NULL,
+    active      BOOLEAN   NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at  TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE TABLE webhook_deliveries
+(
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    subscription_id TEXT      NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id        INTEGER   NOT NULL,
+    event_type      TEXT      NOT NULL,
+    payload         TEXT      NOT NULL,
+    status          TEXT      NOT NULL,
+    attempts        INTEGER   NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    last_error      TEXT      NOT NULL DEFAULT '',
+    response_status INTEGER   NOT NULL DEFAULT 0,
+    created_at      TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at      TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX webhook_deliveries_subscription_id_idx ON webhook_deliveries (subscription_id, id);
+CREATE INDEX webhook_deliveries_status_idx ON webhook_deliveries (status, id);
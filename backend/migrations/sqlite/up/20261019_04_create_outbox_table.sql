@@ -0,0 +1,24 @@
+CREATE TABLE outbox
+(
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id        INTEGER   NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    aggregate_type  TEXT      NOT NULL,
+    aggregate_id    TEXT      NOT NULL,
+    attempts        INTEGER   NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    last_error      TEXT      NOT NULL DEFAULT '',
+    created_at      TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    published_at    TIMESTAMP
+);
+
+CREATE INDEX outbox_pending_idx ON outbox (next_attempt_at, id) WHERE published_at IS NULL;
+CREATE INDEX outbox_pending_aggregate_idx ON outbox (aggregate_type, aggregate_id, id) WHERE published_at IS NULL;
+
+-- sqlite has no data-modifying CTEs, so events are queued by a trigger instead
+-- of by the statement that records them
+CREATE TRIGGER events_enqueue_outbox
+    AFTER INSERT
+    ON events
+BEGIN
+    INSERT INTO outbox (event_id, aggregate_type, aggregate_id) VALUES (NEW.id, NEW.aggregate_type, NEW.aggregate_id);
+END;
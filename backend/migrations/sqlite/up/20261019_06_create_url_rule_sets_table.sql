@@ -0,0 +1,11 @@
+CREATE TABLE url_rule_sets
+(
+    id         TEXT PRIMARY KEY,
+    name       TEXT      NOT NULL,
+    hosts      TEXT      NOT NULL,
+    priority   INTEGER   NOT NULL DEFAULT 0,
+    operations TEXT      NOT NULL,
+    active     BOOLEAN   NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
@@ -0,0 +1,3 @@
+-- sqlite runs one write transaction at a time, so event ids already follow
+-- commit order; kept so both drivers share the schema version of backups
+SELECT 1;
@@ -0,0 +1,8 @@
+UPDATE books SET created_at = NOW() WHERE created_at IS NULL;
+UPDATE books SET updated_at = created_at WHERE updated_at IS NULL;
+
+ALTER TABLE books
+    ALTER COLUMN created_at SET NOT NULL,
+    ALTER COLUMN updated_at SET NOT NULL;
+
+CREATE INDEX books_updated_at_idx ON books (updated_at);
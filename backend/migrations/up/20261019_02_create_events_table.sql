@@ -0,0 +1,12 @@
+CREATE TABLE events
+(
+    id             BIGSERIAL PRIMARY KEY,
+    type           TEXT        NOT NULL,
+    aggregate_type TEXT        NOT NULL,
+    aggregate_id   TEXT        NOT NULL,
+    before         JSONB,
+    after          JSONB,
+    occurred_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX events_aggregate_type_id_idx ON events (aggregate_type, id);
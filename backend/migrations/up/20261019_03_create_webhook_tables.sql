@@ -0,0 +1,30 @@
+CREATE TABLE webhook_subscriptions
+(
+    id          UUID PRIMARY KEY,
+    url         TEXT        NOT NULL,
+    event_types TEXT[]      NOT NULL DEFAULT '{}',
+    secret      TEXT        NOT NULL,
+    active      BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE webhook_deliveries
+(
+    id              BIGSERIAL PRIMARY KEY,
+    subscription_id UUID        NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id        BIGINT      NOT NULL,
+    event_type      TEXT        NOT NULL,
+    payload         JSONB       NOT NULL,
+    status          TEXT        NOT NULL,
+    attempts        INTEGER     NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error      TEXT        NOT NULL DEFAULT '',
+    response_status INTEGER     NOT NULL DEFAULT 0,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX webhook_deliveries_subscription_id_idx ON webhook_deliveries (subscription_id, id);
+CREATE INDEX webhook_deliveries_status_idx ON webhook_deliveries (status, id);
@@ -0,0 +1,20 @@
+CREATE TABLE links
+(
+    code       TEXT PRIMARY KEY,
+    target_url TEXT        NOT NULL,
+    custom     BOOLEAN     NOT NULL DEFAULT FALSE,
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- generated links that never expire are shared by everyone shortening the
+-- same target
+CREATE UNIQUE INDEX links_shared_target_url_idx ON links (target_url) WHERE NOT custom AND expires_at IS NULL;
+
+CREATE TABLE link_clicks
+(
+    code   TEXT   NOT NULL REFERENCES links (code) ON DELETE CASCADE,
+    day    DATE   NOT NULL,
+    clicks BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (code, day)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE url_rule_sets
+(
+    id         UUID PRIMARY KEY,
+    name       TEXT        NOT NULL,
+    hosts      TEXT[]      NOT NULL,
+    priority   INTEGER     NOT NULL DEFAULT 0,
+    operations JSONB       NOT NULL,
+    active     BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
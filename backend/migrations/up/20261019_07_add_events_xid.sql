@@ -0,0 +1,7 @@
+-- ids are taken when a row is inserted, not when its transaction commits, so a
+-- reader following ids could move past an event committed later with a lower
+-- id; xid lets it stop at the oldest transaction still running
+ALTER TABLE events
+    ADD COLUMN xid XID8 NOT NULL DEFAULT pg_current_xact_id();
+
+CREATE INDEX events_aggregate_type_xid_id_idx ON events (aggregate_type, xid, id);
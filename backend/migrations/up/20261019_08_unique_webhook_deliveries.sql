@@ -0,0 +1,6 @@
+-- a publisher failing after the webhooks handled an event retries the event,
+-- which handles it again; one delivery per subscription and event is kept
+DELETE FROM webhook_deliveries
+WHERE id NOT IN (SELECT MIN(id) FROM webhook_deliveries GROUP BY subscription_id, event_id);
+
+CREATE UNIQUE INDEX webhook_deliveries_subscription_id_event_id_idx ON webhook_deliveries (subscription_id, event_id);
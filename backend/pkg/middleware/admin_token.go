@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminTokenMiddleware lets through only the requests sending the token as
+// "Authorization: Bearer <token>" and answers the others with 401. An empty
+// token lets nothing through.
+func AdminTokenMiddleware(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		got, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			c.Set(fiber.HeaderWWWAuthenticate, "Bearer")
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status": "error",
+				"error":  "missing or invalid admin token",
+			})
+		}
+
+		return c.Next()
+	}
+}
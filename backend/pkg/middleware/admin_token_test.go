@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminTokenMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          string
+		authorization  string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "valid token",
+			token:          "secret",
+			authorization:  "Bearer secret",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"success"}`,
+		},
+		{
+			name:           "missing token",
+			token:          "secret",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"status":"error","error":"missing or invalid admin token"}`,
+		},
+		{
+			name:           "wrong token",
+			token:          "secret",
+			authorization:  "Bearer secreT",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"status":"error","error":"missing or invalid admin token"}`,
+		},
+		{
+			name:           "not a bearer token",
+			token:          "secret",
+			authorization:  "Basic secret",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"status":"error","error":"missing or invalid admin token"}`,
+		},
+		{
+			name:           "empty token lets nothing through",
+			authorization:  "Bearer ",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"status":"error","error":"missing or invalid admin token"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(AdminTokenMiddleware(tt.token))
+			app.Get("/admin", func(c *fiber.Ctx) error {
+				return c.JSON(fiber.Map{"status": "success"})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			if tt.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.JSONEq(t, tt.expectedBody, string(body))
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", resp.Header.Get(fiber.HeaderWWWAuthenticate))
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimitMiddleware answers 413 to requests with a body over limit bytes,
+// unless skip reports true for them. It keeps limit on every route while the
+// server accepts larger bodies for the few routes skip lets through.
+func BodyLimitMiddleware(limit int, skip func(c *fiber.Ctx) bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if skip != nil && skip(c) {
+			return c.Next()
+		}
+
+		if c.Request().Header.ContentLength() > limit || len(c.Body()) > limit {
+			return fiber.ErrRequestEntityTooLarge
+		}
+
+		return c.Next()
+	}
+}
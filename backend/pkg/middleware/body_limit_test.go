@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyLimitMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "body within the limit",
+			path:           "/books",
+			body:           strings.Repeat("a", 8),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "body over the limit",
+			path:           "/books",
+			body:           strings.Repeat("a", 9),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "skipped route takes larger bodies",
+			path:           "/restore",
+			body:           strings.Repeat("a", 64),
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(BodyLimitMiddleware(8, func(c *fiber.Ctx) bool { return c.Path() == "/restore" }))
+			app.Post("/*", func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body)))
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rizanw/go-log"
+)
+
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotencyMiddleware makes POST requests carrying an Idempotency-Key header
+// safe to retry. The first response for a key is stored for ttl and replayed
+// for every retry with the same payload; reusing a key with a different
+// payload is rejected with 422. Requests without the header are untouched.
+// Streamed responses are not stored: reading them would buffer the whole
+// stream, so the key is released and a retry runs the request again.
+func IdempotencyMiddleware(store IdempotencyStore, ttl time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Method() != fiber.MethodPost {
+			return c.Next()
+		}
+
+		key := c.Get(HeaderIdempotencyKey)
+		if key == "" {
+			return c.Next()
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status": "error",
+				"error":  "Idempotency-Key is too long",
+			})
+		}
+
+		var (
+			ctx      = c.UserContext()
+			storeKey = c.Method() + " " + c.Path() + " " + key
+			reqHash  = hashRequest(c)
+		)
+
+		existing, reserved, err := store.Reserve(ctx, storeKey, IdempotencyRecord{
+			RequestHash: reqHash,
+			ExpiresAt:   time.Now().Add(ttl),
+		})
+		if err != nil {
+			log.Error(ctx, err, nil, "failed to reserve idempotency key")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status": "error",
+				"error":  err.Error(),
+			})
+		}
+
+		if !reserved {
+			switch {
+			case existing.RequestHash != reqHash:
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+					"status": "error",
+					"error":  "Idempotency-Key has already been used with a different request",
+				})
+			case !existing.Completed:
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+					"status": "error",
+					"error":  "a request with this Idempotency-Key is still being processed",
+				})
+			}
+
+			c.Set(HeaderIdempotentReplayed, "true")
+			c.Set(fiber.HeaderContentType, existing.ContentType)
+			return c.Status(existing.StatusCode).Send(existing.Body)
+		}
+
+		err = c.Next()
+
+		// only successful and client-error responses are final, anything else
+		// frees the key so the client can retry
+		status := c.Response().StatusCode()
+		if err != nil || status >= fiber.StatusInternalServerError {
+			if releaseErr := store.Release(ctx, storeKey); releaseErr != nil {
+				log.Error(ctx, releaseErr, nil, "failed to release idempotency key")
+			}
+			return err
+		}
+
+		// the stream is written after the handler returns; reading the body
+		// here would drain it before it reaches the client
+		if c.Response().IsBodyStream() {
+			if err = store.Release(ctx, storeKey); err != nil {
+				log.Error(ctx, err, nil, "failed to release idempotency key")
+			}
+			return nil
+		}
+
+		rec := IdempotencyRecord{
+			RequestHash: reqHash,
+			Completed:   true,
+			StatusCode:  status,
+			ContentType: string(c.Response().Header.ContentType()),
+			Body:        append([]byte(nil), c.Response().Body()...),
+			ExpiresAt:   time.Now().Add(ttl),
+		}
+		if err = store.Complete(ctx, storeKey, rec); err != nil {
+			log.Error(ctx, err, nil, "failed to store idempotent response")
+		}
+
+		return nil
+	}
+}
+
+func hashRequest(c *fiber.Ctx) string {
+	h := sha256.New()
+	h.Write([]byte(c.Method()))
+	h.Write([]byte{0})
+	h.Write([]byte(c.OriginalURL()))
+	h.Write([]byte{0})
+	h.Write(c.Body())
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+// IdempotencyRecord is what gets stored for a single Idempotency-Key.
+type IdempotencyRecord struct {
+	RequestHash string
+	Completed   bool
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	ExpiresAt   time.Time
+}
+
+// IdempotencyStore persists idempotency records. Implementations must make
+// Reserve atomic so that two concurrent requests with the same key cannot
+// both be processed.
+type IdempotencyStore interface {
+	// Reserve saves rec under key if there is no live record yet. When a record
+	// already exists it is returned and reserved is false.
+	Reserve(ctx context.Context, key string, rec IdempotencyRecord) (existing *IdempotencyRecord, reserved bool, err error)
+	// Complete replaces the reserved record with the final response.
+	Complete(ctx context.Context, key string, rec IdempotencyRecord) error
+	// Release drops a reservation so the request can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]IdempotencyRecord
+	// expiries orders the records by ExpiresAt, so eviction only looks at
+	// the expired ones
+	expiries expiryHeap
+	now      func() time.Time
+}
+
+// NewMemoryIdempotencyStore returns an in-process IdempotencyStore. Expired
+// records are evicted lazily. Records live in one instance only: replicas
+// behind a load balancer do not see each other's keys.
+func NewMemoryIdempotencyStore() IdempotencyStore {
+	return &memoryIdempotencyStore{
+		records: make(map[string]IdempotencyRecord),
+		now:     time.Now,
+	}
+}
+
+func (s *memoryIdempotencyStore) Reserve(_ context.Context, key string, rec IdempotencyRecord) (*IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evictExpired()
+
+	if existing, ok := s.records[key]; ok {
+		return &existing, false, nil
+	}
+
+	s.put(key, rec)
+	return nil, true, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, key string, rec IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.put(key, rec)
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+func (s *memoryIdempotencyStore) put(key string, rec IdempotencyRecord) {
+	s.records[key] = rec
+	if !rec.ExpiresAt.IsZero() {
+		heap.Push(&s.expiries, expiry{key: key, at: rec.ExpiresAt})
+	}
+}
+
+// evictExpired drops the records past their expiry. Entries of records
+// released or stored again since are stale and just popped.
+func (s *memoryIdempotencyStore) evictExpired() {
+	now := s.now()
+	for len(s.expiries) > 0 && now.After(s.expiries[0].at) {
+		e := heap.Pop(&s.expiries).(expiry)
+		if rec, ok := s.records[e.key]; ok && rec.ExpiresAt.Equal(e.at) {
+			delete(s.records, e.key)
+		}
+	}
+}
+
+type expiry struct {
+	key string
+	at  time.Time
+}
+
+// expiryHeap is a container/heap of expiries, the earliest first.
+type expiryHeap []expiry
+
+func (h expiryHeap) Len() int           { return len(h) }
+func (h expiryHeap) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+func (h expiryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *expiryHeap) Push(x any)        { *h = append(*h, x.(expiry)) }
+func (h *expiryHeap) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
@@ -0,0 +1,275 @@
+package middleware
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newIdempotencyTestApp(store IdempotencyStore, calls *int, status int) *fiber.App {
+	app := fiber.New()
+	app.Use(IdempotencyMiddleware(store, time.Hour))
+	app.Post("/books", func(c *fiber.Ctx) error {
+		*calls++
+		return c.Status(status).JSON(fiber.Map{
+			"status": "success",
+			"call":   *calls,
+		})
+	})
+	app.Get("/books", func(c *fiber.Ctx) error {
+		*calls++
+		return c.SendString("ok")
+	})
+	return app
+}
+
+func doIdempotentRequest(t *testing.T, app *fiber.App, method, key, body string) (*http.Response, string) {
+	req := httptest.NewRequest(method, "/books", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(HeaderIdempotencyKey, key)
+	}
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+
+	respBody, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+
+	return resp, string(respBody)
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		requests       []struct{ method, key, body string }
+		expectedStatus []int
+		expectedCalls  int
+	}{
+		{
+			name:   "replays stored response for same key and body",
+			status: http.StatusCreated,
+			requests: []struct{ method, key, body string }{
+				{http.MethodPost, "key-1", `{"title":"a"}`},
+				{http.MethodPost, "key-1", `{"title":"a"}`},
+			},
+			expectedStatus: []int{http.StatusCreated, http.StatusCreated},
+			expectedCalls:  1,
+		},
+		{
+			name:   "rejects reused key with different body",
+			status: http.StatusCreated,
+			requests: []struct{ method, key, body string }{
+				{http.MethodPost, "key-1", `{"title":"a"}`},
+				{http.MethodPost, "key-1", `{"title":"b"}`},
+			},
+			expectedStatus: []int{http.StatusCreated, http.StatusUnprocessableEntity},
+			expectedCalls:  1,
+		},
+		{
+			name:   "different keys are processed separately",
+			status: http.StatusCreated,
+			requests: []struct{ method, key, body string }{
+				{http.MethodPost, "key-1", `{"title":"a"}`},
+				{http.MethodPost, "key-2", `{"title":"a"}`},
+			},
+			expectedStatus: []int{http.StatusCreated, http.StatusCreated},
+			expectedCalls:  2,
+		},
+		{
+			name:   "requests without key are not deduplicated",
+			status: http.StatusCreated,
+			requests: []struct{ method, key, body string }{
+				{http.MethodPost, "", `{"title":"a"}`},
+				{http.MethodPost, "", `{"title":"a"}`},
+			},
+			expectedStatus: []int{http.StatusCreated, http.StatusCreated},
+			expectedCalls:  2,
+		},
+		{
+			name:   "non POST requests are ignored",
+			status: http.StatusOK,
+			requests: []struct{ method, key, body string }{
+				{http.MethodGet, "key-1", ""},
+				{http.MethodGet, "key-1", ""},
+			},
+			expectedStatus: []int{http.StatusOK, http.StatusOK},
+			expectedCalls:  2,
+		},
+		{
+			name:   "server errors release the key",
+			status: http.StatusInternalServerError,
+			requests: []struct{ method, key, body string }{
+				{http.MethodPost, "key-1", `{"title":"a"}`},
+				{http.MethodPost, "key-1", `{"title":"a"}`},
+			},
+			expectedStatus: []int{http.StatusInternalServerError, http.StatusInternalServerError},
+			expectedCalls:  2,
+		},
+		{
+			name:   "too long key",
+			status: http.StatusCreated,
+			requests: []struct{ method, key, body string }{
+				{http.MethodPost, strings.Repeat("k", maxIdempotencyKeyLength+1), `{"title":"a"}`},
+			},
+			expectedStatus: []int{http.StatusBadRequest},
+			expectedCalls:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			app := newIdempotencyTestApp(NewMemoryIdempotencyStore(), &calls, tt.status)
+
+			for i, r := range tt.requests {
+				resp, _ := doIdempotentRequest(t, app, r.method, r.key, r.body)
+				assert.Equal(t, tt.expectedStatus[i], resp.StatusCode)
+			}
+
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func TestIdempotencyMiddleware_Replay(t *testing.T) {
+	t.Run("replayed response matches the original", func(t *testing.T) {
+		var calls int
+		app := newIdempotencyTestApp(NewMemoryIdempotencyStore(), &calls, http.StatusCreated)
+
+		first, firstBody := doIdempotentRequest(t, app, http.MethodPost, "key-1", `{"title":"a"}`)
+		second, secondBody := doIdempotentRequest(t, app, http.MethodPost, "key-1", `{"title":"a"}`)
+
+		assert.Equal(t, firstBody, secondBody)
+		assert.Equal(t, first.Header.Get("Content-Type"), second.Header.Get("Content-Type"))
+		assert.Empty(t, first.Header.Get(HeaderIdempotentReplayed))
+		assert.Equal(t, "true", second.Header.Get(HeaderIdempotentReplayed))
+	})
+
+	t.Run("in-flight key returns conflict", func(t *testing.T) {
+		var (
+			app         = fiber.New()
+			retryStatus int
+		)
+		app.Use(IdempotencyMiddleware(NewMemoryIdempotencyStore(), time.Hour))
+		app.Post("/books", func(c *fiber.Ctx) error {
+			// retry while the first request is still being handled
+			if c.Get("X-Retry") == "" {
+				req := httptest.NewRequest(http.MethodPost, "/books", strings.NewReader(`{"title":"a"}`))
+				req.Header.Set(HeaderIdempotencyKey, "key-1")
+				req.Header.Set("X-Retry", "1")
+				resp, err := app.Test(req)
+				assert.NoError(t, err)
+				retryStatus = resp.StatusCode
+			}
+			return c.SendStatus(http.StatusCreated)
+		})
+
+		resp, _ := doIdempotentRequest(t, app, http.MethodPost, "key-1", `{"title":"a"}`)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, http.StatusConflict, retryStatus)
+	})
+
+	t.Run("streamed response is not stored", func(t *testing.T) {
+		var (
+			app   = fiber.New()
+			calls int
+		)
+		app.Use(IdempotencyMiddleware(NewMemoryIdempotencyStore(), time.Hour))
+		app.Post("/books", func(c *fiber.Ctx) error {
+			calls++
+			c.Set(fiber.HeaderContentType, "application/x-ndjson")
+			c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+				_, _ = w.WriteString("{\"index\":0}\n")
+				_ = w.Flush()
+				_, _ = w.WriteString("{\"index\":1}\n")
+			})
+			return nil
+		})
+
+		first, firstBody := doIdempotentRequest(t, app, http.MethodPost, "key-1", `["a","b"]`)
+		second, secondBody := doIdempotentRequest(t, app, http.MethodPost, "key-1", `["a","b"]`)
+
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, "{\"index\":0}\n{\"index\":1}\n", firstBody)
+		assert.Equal(t, firstBody, secondBody)
+		assert.Empty(t, first.Header.Get(HeaderIdempotentReplayed))
+		assert.Empty(t, second.Header.Get(HeaderIdempotentReplayed))
+	})
+}
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	t.Run("expired records are evicted", func(t *testing.T) {
+		var (
+			ctx   = context.Background()
+			now   = time.Now()
+			store = &memoryIdempotencyStore{
+				records: make(map[string]IdempotencyRecord),
+				now:     func() time.Time { return now },
+			}
+		)
+
+		_, reserved, err := store.Reserve(ctx, "key", IdempotencyRecord{RequestHash: "a", ExpiresAt: now.Add(time.Minute)})
+		assert.NoError(t, err)
+		assert.True(t, reserved)
+
+		existing, reserved, err := store.Reserve(ctx, "key", IdempotencyRecord{RequestHash: "b", ExpiresAt: now.Add(time.Minute)})
+		assert.NoError(t, err)
+		assert.False(t, reserved)
+		assert.Equal(t, "a", existing.RequestHash)
+
+		now = now.Add(2 * time.Minute)
+		_, reserved, err = store.Reserve(ctx, "key", IdempotencyRecord{RequestHash: "b", ExpiresAt: now.Add(time.Minute)})
+		assert.NoError(t, err)
+		assert.True(t, reserved)
+	})
+
+	t.Run("only records past their latest expiry are evicted", func(t *testing.T) {
+		var (
+			ctx   = context.Background()
+			now   = time.Now()
+			store = &memoryIdempotencyStore{
+				records: make(map[string]IdempotencyRecord),
+				now:     func() time.Time { return now },
+			}
+		)
+
+		_, _, _ = store.Reserve(ctx, "completed", IdempotencyRecord{RequestHash: "a", ExpiresAt: now.Add(time.Minute)})
+		assert.NoError(t, store.Complete(ctx, "completed", IdempotencyRecord{RequestHash: "a", Completed: true, ExpiresAt: now.Add(3 * time.Minute)}))
+		_, _, _ = store.Reserve(ctx, "released", IdempotencyRecord{RequestHash: "a", ExpiresAt: now.Add(time.Minute)})
+		assert.NoError(t, store.Release(ctx, "released"))
+		_, _, _ = store.Reserve(ctx, "released", IdempotencyRecord{RequestHash: "b", ExpiresAt: now.Add(3 * time.Minute)})
+		_, _, _ = store.Reserve(ctx, "expired", IdempotencyRecord{RequestHash: "a", ExpiresAt: now.Add(time.Minute)})
+
+		now = now.Add(2 * time.Minute)
+		_, reserved, _ := store.Reserve(ctx, "other", IdempotencyRecord{RequestHash: "a", ExpiresAt: now.Add(time.Minute)})
+		assert.True(t, reserved)
+
+		assert.Contains(t, store.records, "completed")
+		assert.Equal(t, "b", store.records["released"].RequestHash)
+		assert.NotContains(t, store.records, "expired")
+		assert.Len(t, store.expiries, 3)
+	})
+
+	t.Run("released records can be reserved again", func(t *testing.T) {
+		ctx := context.Background()
+		store := NewMemoryIdempotencyStore()
+
+		_, reserved, _ := store.Reserve(ctx, "key", IdempotencyRecord{RequestHash: "a"})
+		assert.True(t, reserved)
+		assert.NoError(t, store.Release(ctx, "key"))
+
+		_, reserved, _ = store.Reserve(ctx, "key", IdempotencyRecord{RequestHash: "a"})
+		assert.True(t, reserved)
+	})
+}
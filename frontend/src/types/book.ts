@@ -3,4 +3,6 @@ export interface Book {
     title: string
     author: string
     year: number
+    created_at?: string
+    updated_at?: string
 }